@@ -2,20 +2,20 @@
 package actorsfakes
 
 import (
-	"os"
 	"sync"
 
 	"github.com/cloudfoundry/cli/cf/actors"
+	"github.com/cloudfoundry/cli/cf/api/applicationbits"
 	"github.com/cloudfoundry/cli/cf/api/resources"
 	"github.com/cloudfoundry/cli/cf/models"
 )
 
 type FakePushActor struct {
-	UploadAppStub        func(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) error
+	UploadAppStub        func(appGUID string, writeZip applicationbits.ZipWriter, presentFiles []resources.AppFileResource) error
 	uploadAppMutex       sync.RWMutex
 	uploadAppArgsForCall []struct {
 		appGUID      string
-		zipFile      *os.File
+		writeZip     applicationbits.ZipWriter
 		presentFiles []resources.AppFileResource
 	}
 	uploadAppReturns struct {
@@ -30,9 +30,10 @@ type FakePushActor struct {
 	processPathReturns struct {
 		result1 error
 	}
-	GatherFilesStub        func(localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error)
+	GatherFilesStub        func(appGUID string, localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error)
 	gatherFilesMutex       sync.RWMutex
 	gatherFilesArgsForCall []struct {
+		appGUID    string
 		localFiles []models.AppFileFields
 		appDir     string
 		uploadDir  string
@@ -44,16 +45,21 @@ type FakePushActor struct {
 	}
 }
 
-func (fake *FakePushActor) UploadApp(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) error {
+func (fake *FakePushActor) UploadApp(appGUID string, writeZip applicationbits.ZipWriter, presentFiles []resources.AppFileResource) error {
+	var presentFilesCopy []resources.AppFileResource
+	if presentFiles != nil {
+		presentFilesCopy = make([]resources.AppFileResource, len(presentFiles))
+		copy(presentFilesCopy, presentFiles)
+	}
 	fake.uploadAppMutex.Lock()
 	fake.uploadAppArgsForCall = append(fake.uploadAppArgsForCall, struct {
 		appGUID      string
-		zipFile      *os.File
+		writeZip     applicationbits.ZipWriter
 		presentFiles []resources.AppFileResource
-	}{appGUID, zipFile, presentFiles})
+	}{appGUID, writeZip, presentFilesCopy})
 	fake.uploadAppMutex.Unlock()
 	if fake.UploadAppStub != nil {
-		return fake.UploadAppStub(appGUID, zipFile, presentFiles)
+		return fake.UploadAppStub(appGUID, writeZip, presentFiles)
 	} else {
 		return fake.uploadAppReturns.result1
 	}
@@ -65,10 +71,10 @@ func (fake *FakePushActor) UploadAppCallCount() int {
 	return len(fake.uploadAppArgsForCall)
 }
 
-func (fake *FakePushActor) UploadAppArgsForCall(i int) (string, *os.File, []resources.AppFileResource) {
+func (fake *FakePushActor) UploadAppArgsForCall(i int) (string, applicationbits.ZipWriter, []resources.AppFileResource) {
 	fake.uploadAppMutex.RLock()
 	defer fake.uploadAppMutex.RUnlock()
-	return fake.uploadAppArgsForCall[i].appGUID, fake.uploadAppArgsForCall[i].zipFile, fake.uploadAppArgsForCall[i].presentFiles
+	return fake.uploadAppArgsForCall[i].appGUID, fake.uploadAppArgsForCall[i].writeZip, fake.uploadAppArgsForCall[i].presentFiles
 }
 
 func (fake *FakePushActor) UploadAppReturns(result1 error) {
@@ -111,16 +117,22 @@ func (fake *FakePushActor) ProcessPathReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakePushActor) GatherFiles(localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error) {
+func (fake *FakePushActor) GatherFiles(appGUID string, localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error) {
+	var localFilesCopy []models.AppFileFields
+	if localFiles != nil {
+		localFilesCopy = make([]models.AppFileFields, len(localFiles))
+		copy(localFilesCopy, localFiles)
+	}
 	fake.gatherFilesMutex.Lock()
 	fake.gatherFilesArgsForCall = append(fake.gatherFilesArgsForCall, struct {
+		appGUID    string
 		localFiles []models.AppFileFields
 		appDir     string
 		uploadDir  string
-	}{localFiles, appDir, uploadDir})
+	}{appGUID, localFilesCopy, appDir, uploadDir})
 	fake.gatherFilesMutex.Unlock()
 	if fake.GatherFilesStub != nil {
-		return fake.GatherFilesStub(localFiles, appDir, uploadDir)
+		return fake.GatherFilesStub(appGUID, localFiles, appDir, uploadDir)
 	} else {
 		return fake.gatherFilesReturns.result1, fake.gatherFilesReturns.result2, fake.gatherFilesReturns.result3
 	}
@@ -132,10 +144,10 @@ func (fake *FakePushActor) GatherFilesCallCount() int {
 	return len(fake.gatherFilesArgsForCall)
 }
 
-func (fake *FakePushActor) GatherFilesArgsForCall(i int) ([]models.AppFileFields, string, string) {
+func (fake *FakePushActor) GatherFilesArgsForCall(i int) (string, []models.AppFileFields, string, string) {
 	fake.gatherFilesMutex.RLock()
 	defer fake.gatherFilesMutex.RUnlock()
-	return fake.gatherFilesArgsForCall[i].localFiles, fake.gatherFilesArgsForCall[i].appDir, fake.gatherFilesArgsForCall[i].uploadDir
+	return fake.gatherFilesArgsForCall[i].appGUID, fake.gatherFilesArgsForCall[i].localFiles, fake.gatherFilesArgsForCall[i].appDir, fake.gatherFilesArgsForCall[i].uploadDir
 }
 
 func (fake *FakePushActor) GatherFilesReturns(result1 []resources.AppFileResource, result2 bool, result3 error) {
@@ -0,0 +1,41 @@
+package plugininstaller
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// AuditRecord records who installed, updated or rolled back a plugin, which
+// binary it was and where it came from.
+type AuditRecord struct {
+	Time     time.Time
+	Action   string
+	Plugin   string
+	Version  string
+	SHA1     string
+	Source   string
+	Repo     string `json:",omitempty"`
+	SignedBy string `json:",omitempty"`
+	OSUser   string
+	CFUser   string `json:",omitempty"`
+	API      string `json:",omitempty"`
+}
+
+// AppendAuditRecord appends the record to the audit log at path, one JSON
+// object per line.
+func AppendAuditRecord(path string, record AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
@@ -37,25 +37,36 @@ func (downloader *PluginDownloader) downloadFromPath(pluginSourceFilepath string
 }
 
 func (downloader *PluginDownloader) downloadFromPlugin(plugin clipr.Plugin) (string, string) {
+	platform := binaryPlatform()
+	if platform == "" {
+		downloader.binaryNotAvailable()
+		return "", ""
+	}
+
+	return downloader.downloadFromPath(downloader.getBinaryURL(plugin, platform)), downloader.getBinaryChecksum(plugin, platform)
+}
+
+// binaryPlatform is the platform of the plugin repository binaries that run
+// on this machine, or "" if there are none.
+func binaryPlatform() string {
 	arch := runtime.GOARCH
 
 	switch runtime.GOOS {
 	case "darwin":
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "osx")), downloader.getBinaryChecksum(plugin, "osx")
+		return "osx"
 	case "linux":
 		if arch == "386" {
-			return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "linux32")), downloader.getBinaryChecksum(plugin, "linux32")
+			return "linux32"
 		}
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "linux64")), downloader.getBinaryChecksum(plugin, "linux64")
+		return "linux64"
 	case "windows":
 		if arch == "386" {
-			return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "win32")), downloader.getBinaryChecksum(plugin, "win32")
+			return "win32"
 		}
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "win64")), downloader.getBinaryChecksum(plugin, "win64")
+		return "win64"
 	default:
-		downloader.binaryNotAvailable()
+		return ""
 	}
-	return "", ""
 }
 
 func (downloader *PluginDownloader) getBinaryURL(plugin clipr.Plugin, os string) string {
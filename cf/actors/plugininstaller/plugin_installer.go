@@ -12,6 +12,9 @@ import (
 
 type PluginInstaller interface {
 	Install(inputSourceFilepath string) string
+	// Source is the URL or local path of the binary installed by the last
+	// call to Install.
+	Source() string
 }
 
 type Context struct {
@@ -20,6 +20,7 @@ type pluginInstallerWithRepo struct {
 	Checksummer      utils.Sha1Checksum
 	PluginRepo       pluginrepo.PluginRepo
 	GetPluginRepos   pluginReposFetcher
+	source           string
 }
 
 func (installer *pluginInstallerWithRepo) Install(inputSourceFilepath string) string {
@@ -45,6 +46,7 @@ func (installer *pluginInstallerWithRepo) Install(inputSourceFilepath string) st
 		if strings.ToLower(plugin.Name) == targetPluginName {
 			found = true
 			outputSourceFilepath, sha1 = installer.PluginDownloader.downloadFromPlugin(plugin)
+			installer.source = installer.PluginDownloader.getBinaryURL(plugin, binaryPlatform())
 
 			installer.Checksummer.SetFilePath(outputSourceFilepath)
 			if !installer.Checksummer.CheckSha1(sha1) {
@@ -60,6 +62,10 @@ func (installer *pluginInstallerWithRepo) Install(inputSourceFilepath string) st
 	return outputSourceFilepath
 }
 
+func (installer *pluginInstallerWithRepo) Source() string {
+	return installer.source
+}
+
 func (installer *pluginInstallerWithRepo) getRepoFromConfig(repoName string) (models.PluginRepo, error) {
 	targetRepo := strings.ToLower(repoName)
 	list := installer.GetPluginRepos()
@@ -3,7 +3,6 @@ package plugininstaller
 import (
 	"os"
 	"path/filepath"
-	"strings"
 
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/terminal"
@@ -14,6 +13,7 @@ type pluginInstallerWithoutRepo struct {
 	PluginDownloader *PluginDownloader
 	DownloadFromPath downloadFromPath
 	RepoName         string
+	source           string
 }
 
 func (installer *pluginInstallerWithoutRepo) Install(inputSourceFilepath string) (outputSourceFilepath string) {
@@ -22,10 +22,10 @@ func (installer *pluginInstallerWithoutRepo) Install(inputSourceFilepath string)
 	} else {
 		outputSourceFilepath = inputSourceFilepath
 	}
+	installer.source = outputSourceFilepath
 
 	installer.UI.Say("")
-	if strings.HasPrefix(outputSourceFilepath, "https://") || strings.HasPrefix(outputSourceFilepath, "http://") ||
-		strings.HasPrefix(outputSourceFilepath, "ftp://") || strings.HasPrefix(outputSourceFilepath, "ftps://") {
+	if isURL(outputSourceFilepath) {
 		installer.UI.Say(T("Attempting to download binary file from internet address..."))
 		return installer.PluginDownloader.downloadFromPath(outputSourceFilepath)
 	} else if !installer.ensureCandidatePluginBinaryExistsAtGivenPath(outputSourceFilepath) {
@@ -35,6 +35,10 @@ func (installer *pluginInstallerWithoutRepo) Install(inputSourceFilepath string)
 	return outputSourceFilepath
 }
 
+func (installer *pluginInstallerWithoutRepo) Source() string {
+	return installer.source
+}
+
 func (installer *pluginInstallerWithoutRepo) ensureCandidatePluginBinaryExistsAtGivenPath(pluginSourceFilepath string) bool {
 	_, err := os.Stat(pluginSourceFilepath)
 	if err != nil && os.IsNotExist(err) {
@@ -0,0 +1,81 @@
+package plugininstaller
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/models"
+	"golang.org/x/crypto/ed25519"
+)
+
+// SignatureSuffix is appended to the URL or path of a plugin binary to get
+// the one of its detached signature.
+const SignatureSuffix = ".sig"
+
+// FetchSignature reads the detached signature of the plugin binary at
+// source, a URL or a local path.
+func FetchSignature(source string) ([]byte, error) {
+	signatureSource := source + SignatureSuffix
+
+	if !isURL(signatureSource) {
+		return ioutil.ReadFile(signatureSource)
+	}
+
+	resp, err := http.Get(signatureSource)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error downloading file from %s", signatureSource)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// VerifySignature checks that signature, the base64 encoding of an ed25519
+// signature, signs the plugin binary at binaryPath with one of keys, and
+// returns that key.
+func VerifySignature(binaryPath string, signature []byte, keys []models.PluginKey) (models.PluginKey, error) {
+	rawSignature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil || len(rawSignature) != ed25519.SignatureSize {
+		return models.PluginKey{}, errors.New(T("The plugin signature is not a base64 encoded ed25519 signature"))
+	}
+
+	binary, err := ioutil.ReadFile(binaryPath)
+	if err != nil {
+		return models.PluginKey{}, err
+	}
+
+	for _, key := range keys {
+		publicKey, err := ParsePublicKey(key.PublicKey)
+		if err != nil {
+			continue
+		}
+
+		if ed25519.Verify(publicKey, binary, rawSignature) {
+			return key, nil
+		}
+	}
+
+	return models.PluginKey{}, errors.New(T("The plugin is not signed by any of the trusted plugin keys"))
+}
+
+// ParsePublicKey parses the base64 encoding of an ed25519 public key.
+func ParsePublicKey(publicKey string) (ed25519.PublicKey, error) {
+	rawKey, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil || len(rawKey) != ed25519.PublicKeySize {
+		return nil, errors.New(T("The plugin key is not a base64 encoded ed25519 public key"))
+	}
+	return ed25519.PublicKey(rawKey), nil
+}
+
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://") ||
+		strings.HasPrefix(source, "ftp://") || strings.HasPrefix(source, "ftps://")
+}
@@ -16,6 +16,12 @@ type FakePluginInstaller struct {
 	installReturns struct {
 		result1 string
 	}
+	SourceStub        func() string
+	sourceMutex       sync.RWMutex
+	sourceArgsForCall []struct{}
+	sourceReturns     struct {
+		result1 string
+	}
 }
 
 func (fake *FakePluginInstaller) Install(inputSourceFilepath string) string {
@@ -50,4 +56,28 @@ func (fake *FakePluginInstaller) InstallReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakePluginInstaller) Source() string {
+	fake.sourceMutex.Lock()
+	fake.sourceArgsForCall = append(fake.sourceArgsForCall, struct{}{})
+	fake.sourceMutex.Unlock()
+	if fake.SourceStub != nil {
+		return fake.SourceStub()
+	} else {
+		return fake.sourceReturns.result1
+	}
+}
+
+func (fake *FakePluginInstaller) SourceCallCount() int {
+	fake.sourceMutex.RLock()
+	defer fake.sourceMutex.RUnlock()
+	return len(fake.sourceArgsForCall)
+}
+
+func (fake *FakePluginInstaller) SourceReturns(result1 string) {
+	fake.SourceStub = nil
+	fake.sourceReturns = struct {
+		result1 string
+	}{result1}
+}
+
 var _ plugininstaller.PluginInstaller = new(FakePluginInstaller)
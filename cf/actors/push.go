@@ -1,40 +1,68 @@
 package actors
 
 import (
+	"crypto/sha1"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"sync"
 
 	"github.com/cloudfoundry/cli/cf/api/applicationbits"
 	"github.com/cloudfoundry/cli/cf/api/resources"
 	"github.com/cloudfoundry/cli/cf/appfiles"
 	"github.com/cloudfoundry/cli/cf/models"
-	"github.com/cloudfoundry/gofileutils/fileutils"
 )
 
 const windowsPathPrefix = `\\?\`
 
+// ErrAppBitsUnchanged is returned by GatherFiles when the app's files are the
+// same as at its last successful upload, so there is nothing to upload.
+var ErrAppBitsUnchanged = errors.New("app bits unchanged")
+
 //go:generate counterfeiter . PushActor
 
 type PushActor interface {
-	UploadApp(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) error
+	UploadApp(appGUID string, writeZip applicationbits.ZipWriter, presentFiles []resources.AppFileResource) error
 	ProcessPath(dirOrZipFile string, f func(string)) error
-	GatherFiles(localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error)
+	GatherFiles(appGUID string, localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error)
 }
 
 type PushActorImpl struct {
-	appBitsRepo applicationbits.Repository
-	appfiles    appfiles.AppFiles
-	zipper      appfiles.Zipper
+	appBitsRepo   applicationbits.Repository
+	appfiles      appfiles.AppFiles
+	zipper        appfiles.Zipper
+	resourceCache applicationbits.ResourceCache
+
+	// gathered holds what GatherFiles found for each app being pushed until
+	// its upload succeeds.
+	gathered     map[string]gatheredFiles
+	gatheredLock *sync.Mutex
 }
 
-func NewPushActor(appBitsRepo applicationbits.Repository, zipper appfiles.Zipper, appfiles appfiles.AppFiles) PushActor {
+// gatheredFiles is what GatherFiles found for an app, kept so that UploadApp
+// can record the fingerprint, or gather the files again without the
+// resource cache when the upload fails.
+type gatheredFiles struct {
+	fingerprint string
+	localFiles  []models.AppFileFields
+	appDir      string
+	uploadDir   string
+	usedCache   bool
+}
+
+func NewPushActor(appBitsRepo applicationbits.Repository, zipper appfiles.Zipper, appfiles appfiles.AppFiles, resourceCache applicationbits.ResourceCache) PushActor {
 	return PushActorImpl{
-		appBitsRepo: appBitsRepo,
-		appfiles:    appfiles,
-		zipper:      zipper,
+		appBitsRepo:   appBitsRepo,
+		appfiles:      appfiles,
+		zipper:        zipper,
+		resourceCache: resourceCache,
+		gathered:      map[string]gatheredFiles{},
+		gatheredLock:  new(sync.Mutex),
 	}
 }
 
@@ -89,20 +117,72 @@ func (actor PushActorImpl) ProcessPath(dirOrZipFile string, f func(string)) erro
 	return nil
 }
 
-func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error) {
+// GatherFiles works out which of localFiles the Cloud Controller does not
+// already have and copies them to uploadDir. Files the resource cache knows
+// the Cloud Controller has are not matched again, and if the files are the
+// same as at the app's last successful upload it returns
+// ErrAppBitsUnchanged without doing anything.
+func (actor PushActorImpl) GatherFiles(appGUID string, localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error) {
+	fingerprint := appFingerprint(localFiles, appDir)
+	if len(localFiles) > 0 && actor.resourceCache.AppFingerprint(appGUID) == fingerprint {
+		return []resources.AppFileResource{}, false, ErrAppBitsUnchanged
+	}
+
+	remoteFiles, hasFileToUpload, usedCache, err := actor.gatherFiles(localFiles, appDir, uploadDir, true)
+	if err != nil {
+		return []resources.AppFileResource{}, false, err
+	}
+
+	actor.gatheredLock.Lock()
+	actor.gathered[appGUID] = gatheredFiles{
+		fingerprint: fingerprint,
+		localFiles:  localFiles,
+		appDir:      appDir,
+		uploadDir:   uploadDir,
+		usedCache:   usedCache,
+	}
+	actor.gatheredLock.Unlock()
+
+	return remoteFiles, hasFileToUpload, nil
+}
+
+// gatherFiles does the work of GatherFiles, only trusting the resource cache
+// when useCache is set. usedCache is true when the cache saved matching any
+// of the files.
+func (actor PushActorImpl) gatherFiles(localFiles []models.AppFileFields, appDir string, uploadDir string, useCache bool) (remoteFiles []resources.AppFileResource, hasFileToUpload bool, usedCache bool, err error) {
+	cachedFiles := []resources.AppFileResource{}
 	appFileResource := []resources.AppFileResource{}
 	for _, file := range localFiles {
-		appFileResource = append(appFileResource, resources.AppFileResource{
+		resource := resources.AppFileResource{
 			Path: file.Path,
 			Sha1: file.Sha1,
 			Size: file.Size,
-		})
+		}
+
+		if useCache && isCacheable(file) && actor.resourceCache.HasResource(file.Sha1) {
+			cachedFiles = append(cachedFiles, resource)
+		} else {
+			appFileResource = append(appFileResource, resource)
+		}
 	}
 
-	remoteFiles, err := actor.appBitsRepo.GetApplicationFiles(appFileResource)
-	if err != nil {
-		return []resources.AppFileResource{}, false, err
+	remoteFiles = []resources.AppFileResource{}
+	if len(appFileResource) > 0 {
+		remoteFiles, err = actor.appBitsRepo.GetApplicationFiles(appFileResource)
+		if err != nil {
+			return []resources.AppFileResource{}, false, false, err
+		}
+	}
+
+	matchedSha1s := []string{}
+	for _, remoteFile := range remoteFiles {
+		if remoteFile.Sha1 != "" {
+			matchedSha1s = append(matchedSha1s, remoteFile.Sha1)
+		}
 	}
+	actor.resourceCache.AddResources(matchedSha1s)
+
+	remoteFiles = append(remoteFiles, cachedFiles...)
 
 	filesToUpload := make([]models.AppFileFields, len(localFiles), len(localFiles))
 	copy(filesToUpload, localFiles)
@@ -117,21 +197,18 @@ func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir
 
 	err = actor.appfiles.CopyFiles(filesToUpload, appDir, uploadDir)
 	if err != nil {
-		return []resources.AppFileResource{}, false, err
+		return []resources.AppFileResource{}, false, false, err
 	}
 
-	_, err = os.Stat(filepath.Join(appDir, ".cfignore"))
-	if err == nil {
-		err = fileutils.CopyPathToPath(filepath.Join(appDir, ".cfignore"), filepath.Join(uploadDir, ".cfignore"))
-		if err != nil {
-			return []resources.AppFileResource{}, false, err
-		}
+	err = appfiles.CopyIgnoreFiles(appDir, uploadDir)
+	if err != nil {
+		return []resources.AppFileResource{}, false, false, err
 	}
 
 	for i := range remoteFiles {
 		fullPath, err := filepath.Abs(filepath.Join(appDir, remoteFiles[i].Path))
 		if err != nil {
-			return []resources.AppFileResource{}, false, err
+			return []resources.AppFileResource{}, false, false, err
 		}
 
 		if runtime.GOOS == "windows" {
@@ -139,7 +216,7 @@ func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir
 		}
 		fileInfo, err := os.Lstat(fullPath)
 		if err != nil {
-			return []resources.AppFileResource{}, false, err
+			return []resources.AppFileResource{}, false, false, err
 		}
 		fileMode := fileInfo.Mode()
 
@@ -150,9 +227,80 @@ func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir
 		remoteFiles[i].Mode = fmt.Sprintf("%#o", fileMode)
 	}
 
-	return remoteFiles, len(filesToUpload) > 0, nil
+	return remoteFiles, len(filesToUpload) > 0, len(cachedFiles) > 0, nil
 }
 
-func (actor PushActorImpl) UploadApp(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) error {
-	return actor.appBitsRepo.UploadBits(appGUID, zipFile, presentFiles)
+// UploadApp uploads the app's bits and records their fingerprint for the
+// next push. A failed upload clears the resource cache, as the Cloud
+// Controller may have rejected a resource that it no longer has. When the
+// cache was used to gather the files, they are gathered again without it
+// and the upload is tried once more.
+func (actor PushActorImpl) UploadApp(appGUID string, writeZip applicationbits.ZipWriter, presentFiles []resources.AppFileResource) error {
+	err := actor.appBitsRepo.UploadBits(appGUID, writeZip, presentFiles)
+
+	actor.gatheredLock.Lock()
+	gathered, ok := actor.gathered[appGUID]
+	delete(actor.gathered, appGUID)
+	actor.gatheredLock.Unlock()
+
+	if err != nil {
+		actor.resourceCache.ClearResources()
+		if !ok || !gathered.usedCache {
+			return err
+		}
+
+		err = actor.retryUpload(appGUID, writeZip, gathered)
+		if err != nil {
+			return err
+		}
+	}
+
+	if ok {
+		actor.resourceCache.SetAppFingerprint(appGUID, gathered.fingerprint)
+	}
+
+	return nil
+}
+
+func (actor PushActorImpl) retryUpload(appGUID string, writeZip applicationbits.ZipWriter, gathered gatheredFiles) error {
+	presentFiles, hasFileToUpload, _, err := actor.gatherFiles(gathered.localFiles, gathered.appDir, gathered.uploadDir, false)
+	if err != nil {
+		return err
+	}
+
+	if writeZip == nil && hasFileToUpload {
+		writeZip = func(w io.Writer) error {
+			return actor.zipper.WriteZip(gathered.uploadDir, w)
+		}
+	}
+
+	return actor.appBitsRepo.UploadBits(appGUID, writeZip, presentFiles)
+}
+
+// isCacheable is false for directories, which are never resource matched.
+func isCacheable(file models.AppFileFields) bool {
+	return file.Sha1 != "" && file.Sha1 != "0"
+}
+
+// appFingerprint identifies an app's files by their paths, contents and
+// modes, in no particular order.
+func appFingerprint(localFiles []models.AppFileFields, appDir string) string {
+	entries := make([]string, 0, len(localFiles))
+	for _, file := range localFiles {
+		var mode os.FileMode
+		fileInfo, err := os.Lstat(filepath.Join(appDir, file.Path))
+		if err == nil {
+			mode = fileInfo.Mode()
+		}
+
+		entries = append(entries, fmt.Sprintf("%s\x00%s\x00%d\x00%o", file.Path, file.Sha1, file.Size, mode))
+	}
+	sort.Strings(entries)
+
+	hash := sha1.New()
+	for _, entry := range entries {
+		_, _ = io.WriteString(hash, entry+"\n")
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil))
 }
@@ -9,6 +9,7 @@ import (
 	"runtime"
 
 	"github.com/cloudfoundry/cli/cf/actors"
+	"github.com/cloudfoundry/cli/cf/api/applicationbits"
 	"github.com/cloudfoundry/cli/cf/api/applicationbits/applicationbitsfakes"
 	"github.com/cloudfoundry/cli/cf/api/resources"
 	"github.com/cloudfoundry/cli/cf/appfiles"
@@ -20,21 +21,23 @@ import (
 
 var _ = Describe("Push Actor", func() {
 	var (
-		appBitsRepo  *applicationbitsfakes.FakeApplicationBitsRepository
-		appFiles     *appfilesfakes.FakeAppFiles
-		fakezipper   *appfilesfakes.FakeZipper
-		actor        actors.PushActor
-		fixturesDir  string
-		appDir       string
-		allFiles     []models.AppFileFields
-		presentFiles []resources.AppFileResource
+		appBitsRepo   *applicationbitsfakes.FakeApplicationBitsRepository
+		resourceCache *applicationbitsfakes.FakeResourceCache
+		appFiles      *appfilesfakes.FakeAppFiles
+		fakezipper    *appfilesfakes.FakeZipper
+		actor         actors.PushActor
+		fixturesDir   string
+		appDir        string
+		allFiles      []models.AppFileFields
+		presentFiles  []resources.AppFileResource
 	)
 
 	BeforeEach(func() {
 		appBitsRepo = new(applicationbitsfakes.FakeApplicationBitsRepository)
 		appFiles = new(appfilesfakes.FakeAppFiles)
 		fakezipper = new(appfilesfakes.FakeZipper)
-		actor = actors.NewPushActor(appBitsRepo, fakezipper, appFiles)
+		resourceCache = new(applicationbitsfakes.FakeResourceCache)
+		actor = actors.NewPushActor(appBitsRepo, fakezipper, appFiles, resourceCache)
 		fixturesDir = filepath.Join("..", "..", "fixtures", "applications")
 		allFiles = []models.AppFileFields{
 			{Path: "example-app/.cfignore"},
@@ -75,7 +78,7 @@ var _ = Describe("Push Actor", func() {
 			})
 
 			It("returns an error if we cannot reach the cc", func() {
-				_, _, err := actor.GatherFiles(allFiles, appDir, tmpDir)
+				_, _, err := actor.GatherFiles("app-guid", allFiles, appDir, tmpDir)
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(Equal(expectedErr))
 			})
@@ -90,12 +93,88 @@ var _ = Describe("Push Actor", func() {
 			})
 
 			It("returns an error", func() {
-				_, _, err := actor.GatherFiles(allFiles, appDir, tmpDir)
+				_, _, err := actor.GatherFiles("app-guid", allFiles, appDir, tmpDir)
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(Equal(expectedErr))
 			})
 		})
 
+		Context("when the resource cache knows the Cloud Controller has some files", func() {
+			BeforeEach(func() {
+				allFiles[1].Sha1 = "app-rb-sha"
+				allFiles[2].Sha1 = "config-ru-sha"
+				allFiles[5].Sha1 = "ignore-me-sha"
+
+				resourceCache.HasResourceStub = func(sha1 string) bool {
+					return sha1 == "app-rb-sha"
+				}
+				appBitsRepo.GetApplicationFilesReturns([]resources.AppFileResource{
+					{Path: "example-app/ignore-me", Sha1: "ignore-me-sha"},
+				}, nil)
+			})
+
+			It("only asks the Cloud Controller about the other files", func() {
+				actualFiles, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appBitsRepo.GetApplicationFilesCallCount()).To(Equal(1))
+				for _, file := range appBitsRepo.GetApplicationFilesArgsForCall(0) {
+					Expect(file.Path).NotTo(Equal("example-app/app.rb"))
+				}
+
+				paths := []string{}
+				for _, file := range actualFiles {
+					paths = append(paths, file.Path)
+				}
+				Expect(paths).To(ConsistOf("example-app/ignore-me", "example-app/app.rb"))
+			})
+
+			It("caches the files the Cloud Controller matched", func() {
+				_, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(resourceCache.AddResourcesCallCount()).To(Equal(1))
+				Expect(resourceCache.AddResourcesArgsForCall(0)).To(Equal([]string{"ignore-me-sha"}))
+			})
+		})
+
+		Context("when the app files are unchanged since the last upload", func() {
+			BeforeEach(func() {
+				_, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actor.UploadApp("app-guid", nil, nil)).To(Succeed())
+
+				Expect(resourceCache.SetAppFingerprintCallCount()).To(Equal(1))
+				appGUID, fingerprint := resourceCache.SetAppFingerprintArgsForCall(0)
+				Expect(appGUID).To(Equal("app-guid"))
+				resourceCache.AppFingerprintStub = func(guid string) string {
+					if guid == appGUID {
+						return fingerprint
+					}
+					return ""
+				}
+			})
+
+			It("returns ErrAppBitsUnchanged without matching or copying any files", func() {
+				_, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
+				Expect(err).To(Equal(actors.ErrAppBitsUnchanged))
+
+				Expect(appBitsRepo.GetApplicationFilesCallCount()).To(Equal(1))
+				Expect(appFiles.CopyFilesCallCount()).To(Equal(1))
+			})
+
+			It("gathers the files of a different app", func() {
+				_, _, err := actor.GatherFiles("other-app-guid", allFiles, fixturesDir, tmpDir)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("gathers the files when one of them changed", func() {
+				allFiles[1].Sha1 = "new-sha"
+				_, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
 		Context("when using .cfignore", func() {
 			BeforeEach(func() {
 				appDir = filepath.Join(fixturesDir, "exclude-a-default-cfignore")
@@ -104,7 +183,7 @@ var _ = Describe("Push Actor", func() {
 			})
 
 			It("copies the .cfignore file to the upload directory", func() {
-				_, _, err := actor.GatherFiles(allFiles, appDir, tmpDir)
+				_, _, err := actor.GatherFiles("app-guid", allFiles, appDir, tmpDir)
 				Expect(err).NotTo(HaveOccurred())
 
 				_, err = os.Stat(filepath.Join(tmpDir, ".cfignore"))
@@ -122,7 +201,7 @@ var _ = Describe("Push Actor", func() {
 
 			expectedFileMode := fmt.Sprintf("%#o", info.Mode())
 
-			actualFiles, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+			actualFiles, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
 			Expect(err).NotTo(HaveOccurred())
 
 			expectedFiles := []resources.AppFileResource{
@@ -145,7 +224,7 @@ var _ = Describe("Push Actor", func() {
 
 			expectedFileMode := fmt.Sprintf("%#o", info.Mode()|0700)
 
-			actualFiles, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+			actualFiles, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
 			Expect(err).NotTo(HaveOccurred())
 
 			expectedFiles := []resources.AppFileResource{
@@ -164,7 +243,7 @@ var _ = Describe("Push Actor", func() {
 			})
 
 			It("returns true for hasFileToUpload", func() {
-				_, hasFileToUpload, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+				_, hasFileToUpload, err := actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(hasFileToUpload).To(BeTrue())
 			})
@@ -179,7 +258,7 @@ var _ = Describe("Push Actor", func() {
 					{Path: "example-app/ignore-me"},
 					{Path: "example-app/manifest.yml"},
 				}
-				_, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+				_, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(appFiles.CopyFilesCallCount()).To(Equal(1))
@@ -202,7 +281,7 @@ var _ = Describe("Push Actor", func() {
 			})
 
 			It("returns true for hasFileToUpload", func() {
-				_, hasFileToUpload, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+				_, hasFileToUpload, err := actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(hasFileToUpload).To(BeTrue())
 			})
@@ -216,7 +295,7 @@ var _ = Describe("Push Actor", func() {
 					{Path: "example-app/Gemfile.lock"},
 					{Path: "example-app/ignore-me"},
 				}
-				_, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+				_, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(appFiles.CopyFilesCallCount()).To(Equal(1))
@@ -243,13 +322,13 @@ var _ = Describe("Push Actor", func() {
 			})
 
 			It("returns false for hasFileToUpload", func() {
-				_, hasFileToUpload, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+				_, hasFileToUpload, err := actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(hasFileToUpload).To(BeFalse())
 			})
 
 			It("copies nothing to the upload dir", func() {
-				_, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+				_, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(appFiles.CopyFilesCallCount()).To(Equal(1))
@@ -262,7 +341,80 @@ var _ = Describe("Push Actor", func() {
 	})
 
 	Describe(".UploadApp", func() {
-		It("Simply delegates to the UploadApp function on the app bits repo, which is not worth testing", func() {})
+		It("does not record a fingerprint for an app whose files were not gathered", func() {
+			Expect(actor.UploadApp("app-guid", nil, nil)).To(Succeed())
+
+			Expect(appBitsRepo.UploadBitsCallCount()).To(Equal(1))
+			Expect(resourceCache.SetAppFingerprintCallCount()).To(BeZero())
+		})
+
+		It("clears the resource cache when the upload fails", func() {
+			appBitsRepo.UploadBitsReturns(errors.New("upload failed"))
+
+			err := actor.UploadApp("app-guid", nil, nil)
+			Expect(err).To(MatchError("upload failed"))
+
+			Expect(resourceCache.ClearResourcesCallCount()).To(Equal(1))
+			Expect(resourceCache.SetAppFingerprintCallCount()).To(BeZero())
+		})
+
+		Context("when the files were gathered using the resource cache", func() {
+			var tmpDir string
+
+			BeforeEach(func() {
+				var err error
+				tmpDir, err = ioutil.TempDir("", "upload-app")
+				Expect(err).NotTo(HaveOccurred())
+
+				allFiles[1].Sha1 = "app-rb-sha"
+				resourceCache.HasResourceStub = func(sha1 string) bool {
+					return sha1 == "app-rb-sha"
+				}
+				appBitsRepo.GetApplicationFilesReturns([]resources.AppFileResource{}, nil)
+
+				_, _, err = actor.GatherFiles("app-guid", allFiles, fixturesDir, tmpDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(appBitsRepo.GetApplicationFilesCallCount()).To(Equal(1))
+
+				appBitsRepo.UploadBitsStub = func(string, applicationbits.ZipWriter, []resources.AppFileResource) error {
+					if appBitsRepo.UploadBitsCallCount() == 1 {
+						return errors.New("resource not found")
+					}
+					return nil
+				}
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(tmpDir)
+			})
+
+			It("gathers the files again without the cache and retries the upload once", func() {
+				Expect(actor.UploadApp("app-guid", nil, nil)).To(Succeed())
+
+				Expect(resourceCache.ClearResourcesCallCount()).To(Equal(1))
+				Expect(appBitsRepo.GetApplicationFilesCallCount()).To(Equal(2))
+				paths := []string{}
+				for _, file := range appBitsRepo.GetApplicationFilesArgsForCall(1) {
+					paths = append(paths, file.Path)
+				}
+				Expect(paths).To(ContainElement("example-app/app.rb"))
+
+				Expect(appBitsRepo.UploadBitsCallCount()).To(Equal(2))
+				_, writeZip, presentFiles := appBitsRepo.UploadBitsArgsForCall(1)
+				Expect(writeZip).NotTo(BeNil())
+				Expect(presentFiles).To(BeEmpty())
+				Expect(resourceCache.SetAppFingerprintCallCount()).To(Equal(1))
+			})
+
+			It("fails when the retried upload fails", func() {
+				appBitsRepo.UploadBitsReturns(errors.New("upload failed"))
+				appBitsRepo.UploadBitsStub = nil
+
+				Expect(actor.UploadApp("app-guid", nil, nil)).To(MatchError("upload failed"))
+				Expect(appBitsRepo.UploadBitsCallCount()).To(Equal(2))
+				Expect(resourceCache.SetAppFingerprintCallCount()).To(BeZero())
+			})
+		})
 	})
 
 	Describe("ProcessPath", func() {
@@ -273,7 +425,7 @@ var _ = Describe("Push Actor", func() {
 
 		BeforeEach(func() {
 			zipper := &appfiles.ApplicationZipper{}
-			actor = actors.NewPushActor(appBitsRepo, zipper, appFiles)
+			actor = actors.NewPushActor(appBitsRepo, zipper, appFiles, resourceCache)
 		})
 
 		Context("when given a zip file", func() {
@@ -321,7 +473,7 @@ var _ = Describe("Push Actor", func() {
 				e := errors.New("some-error")
 				fakezipper.UnzipReturns(e)
 				fakezipper.IsZipFileReturns(true)
-				actor = actors.NewPushActor(appBitsRepo, fakezipper, appFiles)
+				actor = actors.NewPushActor(appBitsRepo, fakezipper, appFiles, resourceCache)
 
 				f := func(tempDir string) {}
 				err := actor.ProcessPath(zipFile, f)
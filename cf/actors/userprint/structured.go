@@ -0,0 +1,56 @@
+package userprint
+
+import (
+	"sort"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/presenters"
+	"github.com/cloudfoundry/cli/cf/terminal"
+)
+
+// StructuredPrinter prints each user once, with all of their roles, in the
+// UI's structured output format.
+type StructuredPrinter struct {
+	UI         terminal.UI
+	UserLister func(guid string, role models.Role) ([]models.UserFields, error)
+	Roles      []models.Role
+}
+
+func (p *StructuredPrinter) PrintUsers(guid string, username string) {
+	users := map[string]models.UserFields{}
+	userRoles := map[string][]models.Role{}
+	for _, role := range p.Roles {
+		roleUsers, err := p.UserLister(guid, role)
+		if err != nil {
+			p.UI.Failed(T("Failed fetching users for role {{.Role}}.\n{{.Error}}",
+				map[string]interface{}{
+					"Error": err.Error(),
+					"Role":  role.ToString(),
+				}))
+			return
+		}
+
+		for _, user := range roleUsers {
+			users[user.Username] = user
+			userRoles[user.Username] = append(userRoles[user.Username], role)
+		}
+	}
+
+	records := []presenters.User{}
+	for name, user := range users {
+		records = append(records, presenters.NewUser(user, userRoles[name]))
+	}
+	sort.Sort(usersByName(records))
+
+	err := p.UI.PrintStructured(records)
+	if err != nil {
+		p.UI.Failed(err.Error())
+	}
+}
+
+type usersByName []presenters.User
+
+func (u usersByName) Len() int           { return len(u) }
+func (u usersByName) Swap(i, j int)      { u[i], u[j] = u[j], u[i] }
+func (u usersByName) Less(i, j int) bool { return u[i].Username < u[j].Username }
@@ -5,27 +5,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/textproto"
-	"os"
 	"time"
 
 	"github.com/cloudfoundry/cli/cf/api/resources"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/net"
-	"github.com/cloudfoundry/gofileutils/fileutils"
 )
 
 const (
 	DefaultAppUploadBitsTimeout = 15 * time.Minute
 )
 
+// ZipWriter writes an app's zipped bits to w. UploadBits calls it while the
+// upload is in flight, and again if the upload has to be retried.
+type ZipWriter func(w io.Writer) error
+
 //go:generate counterfeiter . Repository
 
 type Repository interface {
 	GetApplicationFiles(appFilesRequest []resources.AppFileResource) ([]resources.AppFileResource, error)
-	UploadBits(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) (apiErr error)
+	UploadBits(appGUID string, writeZip ZipWriter, presentFiles []resources.AppFileResource) (apiErr error)
 }
 
 type CloudControllerApplicationBitsRepository struct {
@@ -39,48 +42,57 @@ func NewCloudControllerApplicationBitsRepository(config coreconfig.Reader, gatew
 	return
 }
 
-func (repo CloudControllerApplicationBitsRepository) UploadBits(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) (apiErr error) {
+// UploadBits streams the multipart upload body straight into the request, so
+// the zip is never written to disk. A nil writeZip uploads only the list of
+// files the Cloud Controller already has.
+func (repo CloudControllerApplicationBitsRepository) UploadBits(appGUID string, writeZip ZipWriter, presentFiles []resources.AppFileResource) error {
 	apiURL := fmt.Sprintf("/v2/apps/%s/bits", appGUID)
-	fileutils.TempFile("requests", func(requestFile *os.File, err error) {
-		if err != nil {
-			apiErr = fmt.Errorf("%s: %s", T("Error creating tmp file: {{.Err}}", map[string]interface{}{"Err": err}), err.Error())
-			return
-		}
 
-		// json.Marshal represents a nil value as "null" instead of an empty slice "[]"
-		if presentFiles == nil {
-			presentFiles = []resources.AppFileResource{}
-		}
+	// json.Marshal represents a nil value as "null" instead of an empty slice "[]"
+	if presentFiles == nil {
+		presentFiles = []resources.AppFileResource{}
+	}
 
-		presentFilesJSON, err := json.Marshal(presentFiles)
-		if err != nil {
-			apiErr = fmt.Errorf("%s: %s", T("Error marshaling JSON"), err.Error())
-			return
-		}
+	presentFilesJSON, err := json.Marshal(presentFiles)
+	if err != nil {
+		return fmt.Errorf("%s: %s", T("Error marshaling JSON"), err.Error())
+	}
 
-		boundary, err := repo.writeUploadBody(zipFile, requestFile, presentFilesJSON)
-		if err != nil {
-			apiErr = fmt.Errorf("%s: %s", T("Error writing to tmp file: {{.Err}}", map[string]interface{}{"Err": err}), err.Error())
-			return
+	boundary := multipart.NewWriter(ioutil.Discard).Boundary()
+
+	// A failure to zip the app surfaces as a broken request; keep the
+	// original error so that it can be reported instead.
+	zipErrs := make(chan error, 1)
+	writeBody := func(body io.Writer) error {
+		err := repo.writeUploadBody(writeZip, body, boundary, presentFilesJSON)
+		if err != nil && err != io.ErrClosedPipe {
+			select {
+			case zipErrs <- err:
+			default:
+			}
 		}
+		return err
+	}
 
-		var request *net.Request
-		request, apiErr = repo.gateway.NewRequestForFile("PUT", repo.config.APIEndpoint()+apiURL, repo.config.AccessToken(), requestFile)
-		if apiErr != nil {
-			return
-		}
+	request, err := repo.gateway.NewRequestForStream("PUT", repo.config.APIEndpoint()+apiURL, repo.config.AccessToken(), writeBody)
+	if err != nil {
+		return err
+	}
 
-		contentType := fmt.Sprintf("multipart/form-data; boundary=%s", boundary)
-		request.HTTPReq.Header.Set("Content-Type", contentType)
+	contentType := fmt.Sprintf("multipart/form-data; boundary=%s", boundary)
+	request.HTTPReq.Header.Set("Content-Type", contentType)
 
-		response := &resources.Resource{}
-		_, apiErr = repo.gateway.PerformPollingRequestForJSONResponse(repo.config.APIEndpoint(), request, response, DefaultAppUploadBitsTimeout)
-		if apiErr != nil {
-			return
+	response := &resources.Resource{}
+	_, err = repo.gateway.PerformPollingRequestForJSONResponse(repo.config.APIEndpoint(), request, response, DefaultAppUploadBitsTimeout)
+	if err != nil {
+		select {
+		case zipErr := <-zipErrs:
+			return zipErr
+		default:
 		}
-	})
+	}
 
-	return
+	return err
 }
 
 func (repo CloudControllerApplicationBitsRepository) GetApplicationFiles(appFilesToCheck []resources.AppFileResource) ([]resources.AppFileResource, error) {
@@ -133,51 +145,42 @@ func appFilesBySha(in []resources.AppFileResource) (out map[string]resources.App
 	return out
 }
 
-func (repo CloudControllerApplicationBitsRepository) writeUploadBody(zipFile *os.File, body *os.File, presentResourcesJSON []byte) (boundary string, err error) {
+func (repo CloudControllerApplicationBitsRepository) writeUploadBody(writeZip ZipWriter, body io.Writer, boundary string, presentResourcesJSON []byte) error {
 	writer := multipart.NewWriter(body)
-	defer writer.Close()
-
-	boundary = writer.Boundary()
+	err := writer.SetBoundary(boundary)
+	if err != nil {
+		return err
+	}
 
 	part, err := writer.CreateFormField("resources")
 	if err != nil {
-		return
+		return err
 	}
 
-	_, err = io.Copy(part, bytes.NewBuffer(presentResourcesJSON))
+	_, err = part.Write(presentResourcesJSON)
 	if err != nil {
-		return
+		return err
 	}
 
-	if zipFile != nil {
-		zipStats, zipErr := zipFile.Stat()
-		if zipErr != nil {
-			return
-		}
-
-		if zipStats.Size() == 0 {
-			return
-		}
-
-		part, zipErr = createZipPartWriter(zipStats, writer)
-		if zipErr != nil {
-			return
+	if writeZip != nil {
+		part, err = createZipPartWriter(writer)
+		if err != nil {
+			return err
 		}
 
-		_, zipErr = io.Copy(part, zipFile)
-		if zipErr != nil {
-			return
+		err = writeZip(part)
+		if err != nil {
+			return err
 		}
 	}
 
-	return
+	return writer.Close()
 }
 
-func createZipPartWriter(zipStats os.FileInfo, writer *multipart.Writer) (io.Writer, error) {
+func createZipPartWriter(writer *multipart.Writer) (io.Writer, error) {
 	h := make(textproto.MIMEHeader)
 	h.Set("Content-Disposition", `form-data; name="application"; filename="application.zip"`)
 	h.Set("Content-Type", "application/zip")
-	h.Set("Content-Length", fmt.Sprintf("%d", zipStats.Size()))
 	h.Set("Content-Transfer-Encoding", "binary")
 	return writer.CreatePart(h)
 }
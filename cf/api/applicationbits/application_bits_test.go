@@ -2,14 +2,16 @@ package applicationbits_test
 
 import (
 	"archive/zip"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
-	"strconv"
 	"strings"
 	"time"
 
@@ -95,7 +97,7 @@ var _ = Describe("CloudControllerApplicationBitsRepository", func() {
 				createProgressEndpoint("finished"),
 			)
 
-			apiErr := repo.UploadBits("my-cool-app-guid", uploadFile, []resources.AppFileResource{file1, file2})
+			apiErr := repo.UploadBits("my-cool-app-guid", copyZip(uploadFile), []resources.AppFileResource{file1, file2})
 			Expect(apiErr).NotTo(HaveOccurred())
 		})
 
@@ -118,11 +120,53 @@ var _ = Describe("CloudControllerApplicationBitsRepository", func() {
 				createProgressEndpoint("running"),
 				createProgressEndpoint("failed"),
 			)
-			apiErr := repo.UploadBits("my-cool-app-guid", uploadFile, []resources.AppFileResource{file1, file2})
+			apiErr := repo.UploadBits("my-cool-app-guid", copyZip(uploadFile), []resources.AppFileResource{file1, file2})
 
 			Expect(apiErr).To(HaveOccurred())
 		})
 
+		It("streams the upload without knowing its length in advance", func() {
+			var transferEncoding []string
+			matcher := uploadBodyMatcher(defaultZipCheck)
+			setupTestServer(testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method: "PUT",
+				Path:   "/v2/apps/my-cool-app-guid/bits",
+				Matcher: func(request *http.Request) {
+					transferEncoding = request.TransferEncoding
+					matcher(request)
+				},
+				Response: testnet.TestResponse{
+					Status: http.StatusCreated,
+					Body: `
+					{
+						"metadata":{
+							"guid": "my-job-guid",
+							"url": "/v2/jobs/my-job-guid"
+						}
+					}`,
+				},
+			}),
+				createProgressEndpoint("finished"),
+			)
+
+			apiErr := repo.UploadBits("my-cool-app-guid", copyZip(uploadFile), []resources.AppFileResource{file1, file2})
+			Expect(apiErr).NotTo(HaveOccurred())
+			Expect(transferEncoding).To(Equal([]string{"chunked"}))
+		})
+
+		It("returns the error from writing the zip when it fails", func() {
+			testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = ioutil.ReadAll(r.Body)
+				w.WriteHeader(http.StatusBadRequest)
+			}))
+			configRepo.SetAPIEndpoint(testServer.URL)
+
+			apiErr := repo.UploadBits("my-cool-app-guid", func(w io.Writer) error {
+				return errors.New("zip failed")
+			}, []resources.AppFileResource{file1, file2})
+			Expect(apiErr).To(MatchError("zip failed"))
+		})
+
 		Context("when there are no files to upload", func() {
 			It("makes a request without a zipfile", func() {
 				setupTestServer(
@@ -373,14 +417,8 @@ func uploadBodyMatcher(zipChecks func(zipReader *zip.Reader)) func(*http.Request
 			return
 		}
 
-		length, err := strconv.ParseInt(applicationFile.Header.Get("content-length"), 10, 64)
-		if err != nil {
-			Fail(fmt.Sprintf("Cannot convert content-length to int %v", err.Error()))
-			return
-		}
-
 		if zipChecks != nil {
-			zipReader, err := zip.NewReader(file, length)
+			zipReader, err := zip.NewReader(file, applicationFile.Size)
 			if err != nil {
 				Fail(fmt.Sprintf("Error reading zip content %v", err.Error()))
 				return
@@ -391,6 +429,13 @@ func uploadBodyMatcher(zipChecks func(zipReader *zip.Reader)) func(*http.Request
 	}
 }
 
+func copyZip(zipFile *os.File) ZipWriter {
+	return func(w io.Writer) error {
+		_, err := io.Copy(w, zipFile)
+		return err
+	}
+}
+
 func createProgressEndpoint(status string) (req testnet.TestRequest) {
 	body := fmt.Sprintf(`
 	{
@@ -0,0 +1,174 @@
+// This file was generated by counterfeiter
+package applicationbitsfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/cli/cf/api/applicationbits"
+)
+
+type FakeResourceCache struct {
+	HasResourceStub        func(sha1 string) bool
+	hasResourceMutex       sync.RWMutex
+	hasResourceArgsForCall []struct {
+		sha1 string
+	}
+	hasResourceReturns struct {
+		result1 bool
+	}
+	AddResourcesStub        func(sha1s []string)
+	addResourcesMutex       sync.RWMutex
+	addResourcesArgsForCall []struct {
+		sha1s []string
+	}
+	ClearResourcesStub        func()
+	clearResourcesMutex       sync.RWMutex
+	clearResourcesArgsForCall []struct{}
+	AppFingerprintStub        func(appGUID string) string
+	appFingerprintMutex       sync.RWMutex
+	appFingerprintArgsForCall []struct {
+		appGUID string
+	}
+	appFingerprintReturns struct {
+		result1 string
+	}
+	SetAppFingerprintStub        func(appGUID string, fingerprint string)
+	setAppFingerprintMutex       sync.RWMutex
+	setAppFingerprintArgsForCall []struct {
+		appGUID     string
+		fingerprint string
+	}
+}
+
+func (fake *FakeResourceCache) HasResource(sha1 string) bool {
+	fake.hasResourceMutex.Lock()
+	fake.hasResourceArgsForCall = append(fake.hasResourceArgsForCall, struct {
+		sha1 string
+	}{sha1})
+	fake.hasResourceMutex.Unlock()
+	if fake.HasResourceStub != nil {
+		return fake.HasResourceStub(sha1)
+	} else {
+		return fake.hasResourceReturns.result1
+	}
+}
+
+func (fake *FakeResourceCache) HasResourceCallCount() int {
+	fake.hasResourceMutex.RLock()
+	defer fake.hasResourceMutex.RUnlock()
+	return len(fake.hasResourceArgsForCall)
+}
+
+func (fake *FakeResourceCache) HasResourceArgsForCall(i int) string {
+	fake.hasResourceMutex.RLock()
+	defer fake.hasResourceMutex.RUnlock()
+	return fake.hasResourceArgsForCall[i].sha1
+}
+
+func (fake *FakeResourceCache) HasResourceReturns(result1 bool) {
+	fake.HasResourceStub = nil
+	fake.hasResourceReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResourceCache) AddResources(sha1s []string) {
+	var sha1sCopy []string
+	if sha1s != nil {
+		sha1sCopy = make([]string, len(sha1s))
+		copy(sha1sCopy, sha1s)
+	}
+	fake.addResourcesMutex.Lock()
+	fake.addResourcesArgsForCall = append(fake.addResourcesArgsForCall, struct {
+		sha1s []string
+	}{sha1sCopy})
+	fake.addResourcesMutex.Unlock()
+	if fake.AddResourcesStub != nil {
+		fake.AddResourcesStub(sha1s)
+	}
+}
+
+func (fake *FakeResourceCache) AddResourcesCallCount() int {
+	fake.addResourcesMutex.RLock()
+	defer fake.addResourcesMutex.RUnlock()
+	return len(fake.addResourcesArgsForCall)
+}
+
+func (fake *FakeResourceCache) AddResourcesArgsForCall(i int) []string {
+	fake.addResourcesMutex.RLock()
+	defer fake.addResourcesMutex.RUnlock()
+	return fake.addResourcesArgsForCall[i].sha1s
+}
+
+func (fake *FakeResourceCache) ClearResources() {
+	fake.clearResourcesMutex.Lock()
+	fake.clearResourcesArgsForCall = append(fake.clearResourcesArgsForCall, struct{}{})
+	fake.clearResourcesMutex.Unlock()
+	if fake.ClearResourcesStub != nil {
+		fake.ClearResourcesStub()
+	}
+}
+
+func (fake *FakeResourceCache) ClearResourcesCallCount() int {
+	fake.clearResourcesMutex.RLock()
+	defer fake.clearResourcesMutex.RUnlock()
+	return len(fake.clearResourcesArgsForCall)
+}
+
+func (fake *FakeResourceCache) AppFingerprint(appGUID string) string {
+	fake.appFingerprintMutex.Lock()
+	fake.appFingerprintArgsForCall = append(fake.appFingerprintArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.appFingerprintMutex.Unlock()
+	if fake.AppFingerprintStub != nil {
+		return fake.AppFingerprintStub(appGUID)
+	} else {
+		return fake.appFingerprintReturns.result1
+	}
+}
+
+func (fake *FakeResourceCache) AppFingerprintCallCount() int {
+	fake.appFingerprintMutex.RLock()
+	defer fake.appFingerprintMutex.RUnlock()
+	return len(fake.appFingerprintArgsForCall)
+}
+
+func (fake *FakeResourceCache) AppFingerprintArgsForCall(i int) string {
+	fake.appFingerprintMutex.RLock()
+	defer fake.appFingerprintMutex.RUnlock()
+	return fake.appFingerprintArgsForCall[i].appGUID
+}
+
+func (fake *FakeResourceCache) AppFingerprintReturns(result1 string) {
+	fake.AppFingerprintStub = nil
+	fake.appFingerprintReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResourceCache) SetAppFingerprint(appGUID string, fingerprint string) {
+	fake.setAppFingerprintMutex.Lock()
+	fake.setAppFingerprintArgsForCall = append(fake.setAppFingerprintArgsForCall, struct {
+		appGUID     string
+		fingerprint string
+	}{appGUID, fingerprint})
+	fake.setAppFingerprintMutex.Unlock()
+	if fake.SetAppFingerprintStub != nil {
+		fake.SetAppFingerprintStub(appGUID, fingerprint)
+	}
+}
+
+func (fake *FakeResourceCache) SetAppFingerprintCallCount() int {
+	fake.setAppFingerprintMutex.RLock()
+	defer fake.setAppFingerprintMutex.RUnlock()
+	return len(fake.setAppFingerprintArgsForCall)
+}
+
+func (fake *FakeResourceCache) SetAppFingerprintArgsForCall(i int) (string, string) {
+	fake.setAppFingerprintMutex.RLock()
+	defer fake.setAppFingerprintMutex.RUnlock()
+	return fake.setAppFingerprintArgsForCall[i].appGUID, fake.setAppFingerprintArgsForCall[i].fingerprint
+}
+
+var _ applicationbits.ResourceCache = new(FakeResourceCache)
@@ -0,0 +1,164 @@
+package applicationbits
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/configuration"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+)
+
+// ResourceCacheExpiry is how long a resource the Cloud Controller reported
+// having is trusted before it is matched again.
+const ResourceCacheExpiry = 7 * 24 * time.Hour
+
+//go:generate counterfeiter . ResourceCache
+
+// ResourceCache remembers, for the targeted API, which files the Cloud
+// Controller already has and the fingerprint of each app's last successful
+// upload. It is only an optimization: failing to read or save it never
+// fails a push.
+type ResourceCache interface {
+	HasResource(sha1 string) bool
+	AddResources(sha1s []string)
+	ClearResources()
+	AppFingerprint(appGUID string) string
+	SetAppFingerprint(appGUID string, fingerprint string)
+}
+
+type ResourceCacheData struct {
+	Targets map[string]*ResourceCacheTarget
+}
+
+type ResourceCacheTarget struct {
+	Resources map[string]time.Time
+	Apps      map[string]string
+}
+
+func NewResourceCacheData() *ResourceCacheData {
+	return &ResourceCacheData{
+		Targets: map[string]*ResourceCacheTarget{},
+	}
+}
+
+func (d *ResourceCacheData) JSONMarshalV3() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+func (d *ResourceCacheData) JSONUnmarshalV3(input []byte) error {
+	return json.Unmarshal(input, d)
+}
+
+type DiskResourceCache struct {
+	mutex     *sync.Mutex
+	initOnce  *sync.Once
+	persistor configuration.Persistor
+	config    coreconfig.Reader
+	data      *ResourceCacheData
+	clock     func() time.Time
+}
+
+func NewDiskResourceCache(path string, config coreconfig.Reader) *DiskResourceCache {
+	return NewResourceCache(configuration.NewDiskPersistor(path), config, time.Now)
+}
+
+func NewResourceCache(persistor configuration.Persistor, config coreconfig.Reader, clock func() time.Time) *DiskResourceCache {
+	return &DiskResourceCache{
+		mutex:     new(sync.Mutex),
+		initOnce:  new(sync.Once),
+		persistor: persistor,
+		config:    config,
+		data:      NewResourceCacheData(),
+		clock:     clock,
+	}
+}
+
+func (c *DiskResourceCache) HasResource(sha1 string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cachedAt, ok := c.target().Resources[sha1]
+	return ok && c.clock().Sub(cachedAt) < ResourceCacheExpiry
+}
+
+func (c *DiskResourceCache) AddResources(sha1s []string) {
+	if len(sha1s) == 0 {
+		return
+	}
+
+	c.write(func(target *ResourceCacheTarget) {
+		now := c.clock()
+		for _, sha1 := range sha1s {
+			target.Resources[sha1] = now
+		}
+	})
+}
+
+// ClearResources forgets every resource cached for the targeted API, for
+// when the Cloud Controller may no longer have one of them.
+func (c *DiskResourceCache) ClearResources() {
+	c.write(func(target *ResourceCacheTarget) {
+		target.Resources = map[string]time.Time{}
+	})
+}
+
+func (c *DiskResourceCache) AppFingerprint(appGUID string) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.target().Apps[appGUID]
+}
+
+func (c *DiskResourceCache) SetAppFingerprint(appGUID string, fingerprint string) {
+	c.write(func(target *ResourceCacheTarget) {
+		target.Apps[appGUID] = fingerprint
+	})
+}
+
+// target returns the targeted API's entries, loading the cache from disk
+// the first time. The caller must hold the mutex.
+func (c *DiskResourceCache) target() *ResourceCacheTarget {
+	c.initOnce.Do(func() {
+		_ = c.persistor.Load(c.data)
+		if c.data.Targets == nil {
+			c.data.Targets = map[string]*ResourceCacheTarget{}
+		}
+	})
+
+	apiEndpoint := c.config.APIEndpoint()
+	target, ok := c.data.Targets[apiEndpoint]
+	if !ok {
+		target = &ResourceCacheTarget{}
+		c.data.Targets[apiEndpoint] = target
+	}
+	if target.Resources == nil {
+		target.Resources = map[string]time.Time{}
+	}
+	if target.Apps == nil {
+		target.Apps = map[string]string{}
+	}
+
+	return target
+}
+
+func (c *DiskResourceCache) write(cb func(*ResourceCacheTarget)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cb(c.target())
+	c.pruneExpired()
+
+	_ = c.persistor.Save(c.data)
+}
+
+func (c *DiskResourceCache) pruneExpired() {
+	now := c.clock()
+	for _, target := range c.data.Targets {
+		for sha1, cachedAt := range target.Resources {
+			if now.Sub(cachedAt) >= ResourceCacheExpiry {
+				delete(target.Resources, sha1)
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package applicationbits_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/configuration"
+	"github.com/cloudfoundry/cli/cf/configuration/configurationfakes"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+
+	. "github.com/cloudfoundry/cli/cf/api/applicationbits"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceCache", func() {
+	var (
+		persistor *configurationfakes.FakePersistor
+		config    coreconfig.Repository
+		now       time.Time
+		cache     ResourceCache
+	)
+
+	BeforeEach(func() {
+		persistor = new(configurationfakes.FakePersistor)
+		config = testconfig.NewRepositoryWithDefaults()
+		config.SetAPIEndpoint("https://api.first.example.com")
+		now = time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+
+		cache = NewResourceCache(persistor, config, func() time.Time { return now })
+	})
+
+	It("remembers the resources the Cloud Controller has", func() {
+		cache.AddResources([]string{"sha-1", "sha-2"})
+
+		Expect(cache.HasResource("sha-1")).To(BeTrue())
+		Expect(cache.HasResource("sha-2")).To(BeTrue())
+		Expect(cache.HasResource("sha-3")).To(BeFalse())
+		Expect(persistor.SaveCallCount()).To(Equal(1))
+	})
+
+	It("keeps the resources of each API apart", func() {
+		cache.AddResources([]string{"sha-1"})
+
+		config.SetAPIEndpoint("https://api.second.example.com")
+		Expect(cache.HasResource("sha-1")).To(BeFalse())
+	})
+
+	It("forgets resources once they expire", func() {
+		cache.AddResources([]string{"sha-1"})
+
+		now = now.Add(ResourceCacheExpiry)
+		Expect(cache.HasResource("sha-1")).To(BeFalse())
+	})
+
+	It("clears the resources of the targeted API", func() {
+		cache.AddResources([]string{"sha-1"})
+		cache.SetAppFingerprint("app-guid", "fingerprint")
+
+		cache.ClearResources()
+
+		Expect(cache.HasResource("sha-1")).To(BeFalse())
+		Expect(cache.AppFingerprint("app-guid")).To(Equal("fingerprint"))
+	})
+
+	It("remembers the fingerprint of each app", func() {
+		cache.SetAppFingerprint("app-guid", "fingerprint")
+
+		Expect(cache.AppFingerprint("app-guid")).To(Equal("fingerprint"))
+		Expect(cache.AppFingerprint("other-app-guid")).To(BeEmpty())
+	})
+
+	It("loads what was saved before", func() {
+		persistor.LoadStub = func(data configuration.DataInterface) error {
+			return data.JSONUnmarshalV3([]byte(`{
+				"Targets": {
+					"https://api.first.example.com": {
+						"Resources": {"sha-1": "2016-06-01T00:00:00Z"},
+						"Apps": {"app-guid": "fingerprint"}
+					}
+				}
+			}`))
+		}
+
+		Expect(cache.HasResource("sha-1")).To(BeTrue())
+		Expect(cache.AppFingerprint("app-guid")).To(Equal("fingerprint"))
+		Expect(persistor.LoadCallCount()).To(Equal(1))
+	})
+})
@@ -1,7 +1,6 @@
 package authentication
 
 import (
-	"crypto/tls"
 	"encoding/base64"
 	"fmt"
 	"net/http"
@@ -49,6 +48,11 @@ func NewUAARepository(gateway net.Gateway, config coreconfig.ReadWriter, dumper
 }
 
 func (uaa UAARepository) Authorize(token string) (string, error) {
+	tlsConfig, err := net.NewTLSConfigFromConfig(uaa.config, nil)
+	if err != nil {
+		return "", err
+	}
+
 	httpClient := &http.Client{
 		CheckRedirect: func(req *http.Request, _ []*http.Request) error {
 			uaa.DumpRequest(req)
@@ -56,10 +60,8 @@ func (uaa UAARepository) Authorize(token string) (string, error) {
 		},
 		Timeout: 30 * time.Second,
 		Transport: &http.Transport{
-			DisableKeepAlives: true,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: uaa.config.IsSSLDisabled(),
-			},
+			DisableKeepAlives:   true,
+			TLSClientConfig:     tlsConfig,
 			Proxy:               http.ProxyFromEnvironment,
 			TLSHandshakeTimeout: 10 * time.Second,
 		},
@@ -119,7 +121,7 @@ func (uaa UAARepository) Authenticate(credentials map[string]string) error {
 		data[key] = []string{val}
 	}
 
-	err := uaa.getAuthToken(data)
+	accessToken, refreshToken, err := uaa.getAuthToken(data)
 	if err != nil {
 		httpError, ok := err.(errors.HTTPError)
 		if ok {
@@ -134,6 +136,9 @@ func (uaa UAARepository) Authenticate(credentials map[string]string) error {
 		return err
 	}
 
+	uaa.config.SetAccessToken(accessToken)
+	uaa.config.SetRefreshToken(refreshToken)
+
 	return nil
 }
 
@@ -187,13 +192,16 @@ func (uaa UAARepository) RefreshAuthToken() (string, error) {
 		"scope":         {""},
 	}
 
-	apiErr := uaa.getAuthToken(data)
+	accessToken, refreshToken, apiErr := uaa.getAuthToken(data)
+	if apiErr == nil {
+		uaa.config.SetRefreshedTokens(accessToken, refreshToken)
+	}
 	updatedToken := uaa.config.AccessToken()
 
 	return updatedToken, apiErr
 }
 
-func (uaa UAARepository) getAuthToken(data url.Values) error {
+func (uaa UAARepository) getAuthToken(data url.Values) (string, string, error) {
 	type uaaErrorResponse struct {
 		Code        string `json:"error"`
 		Description string `json:"error_description"`
@@ -209,7 +217,7 @@ func (uaa UAARepository) getAuthToken(data url.Values) error {
 	path := fmt.Sprintf("%s/oauth/token", uaa.config.AuthenticationEndpoint())
 	request, err := uaa.gateway.NewRequest("POST", path, "Basic "+base64.StdEncoding.EncodeToString([]byte("cf:")), strings.NewReader(data.Encode()))
 	if err != nil {
-		return fmt.Errorf("%s: %s", T("Failed to start oauth request"), err.Error())
+		return "", "", fmt.Errorf("%s: %s", T("Failed to start oauth request"), err.Error())
 	}
 	request.HTTPReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
@@ -219,20 +227,17 @@ func (uaa UAARepository) getAuthToken(data url.Values) error {
 	switch err.(type) {
 	case nil:
 	case errors.HTTPError:
-		return err
+		return "", "", err
 	case *errors.InvalidTokenError:
-		return errors.New(T("Authentication has expired.  Please log back in to re-authenticate.\n\nTIP: Use `cf login -a <endpoint> -u <user> -o <org> -s <space>` to log back in and re-authenticate."))
+		return "", "", errors.New(T("Authentication has expired.  Please log back in to re-authenticate.\n\nTIP: Use `cf login -a <endpoint> -u <user> -o <org> -s <space>` to log back in and re-authenticate."))
 	default:
-		return fmt.Errorf("%s: %s", T("auth request failed"), err.Error())
+		return "", "", fmt.Errorf("%s: %s", T("auth request failed"), err.Error())
 	}
 
 	// TODO: get the actual status code
 	if response.Error.Code != "" {
-		return errors.NewHTTPError(0, response.Error.Code, response.Error.Description)
+		return "", "", errors.NewHTTPError(0, response.Error.Code, response.Error.Description)
 	}
 
-	uaa.config.SetAccessToken(fmt.Sprintf("%s %s", response.TokenType, response.AccessToken))
-	uaa.config.SetRefreshToken(response.RefreshToken)
-
-	return nil
+	return fmt.Sprintf("%s %s", response.TokenType, response.AccessToken), response.RefreshToken, nil
 }
@@ -0,0 +1,93 @@
+package logs
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Filter selects which log messages are shown. The zero value lets every
+// message through.
+type Filter struct {
+	// SourceTypes, such as APP, RTR or STG, are compared without regard to
+	// case. A message from any of them is kept.
+	SourceTypes []string
+	// Instances are source instance indexes, such as "0".
+	Instances []string
+	// Stream is "out" or "err"; the empty string keeps both.
+	Stream string
+	// Pattern must match the message text.
+	Pattern *regexp.Regexp
+	// Since and Until bound the message timestamps. A zero time leaves that
+	// end of the window open.
+	Since time.Time
+	Until time.Time
+}
+
+func (f Filter) Matches(msg Loggable) bool {
+	if len(f.SourceTypes) > 0 && !containsFold(f.SourceTypes, msg.GetSourceName()) {
+		return false
+	}
+
+	if len(f.Instances) > 0 && !containsFold(f.Instances, msg.GetSourceInstance()) {
+		return false
+	}
+
+	switch f.Stream {
+	case "out":
+		if msg.IsError() {
+			return false
+		}
+	case "err":
+		if !msg.IsError() {
+			return false
+		}
+	}
+
+	timestamp := msg.GetTimestamp()
+	if !f.Since.IsZero() && timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && timestamp.After(f.Until) {
+		return false
+	}
+
+	if f.Pattern != nil && !f.Pattern.MatchString(msg.ToSimpleLog()) {
+		return false
+	}
+
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Entry is the JSON form of a log message printed by `cf --output json logs`.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	Source    string    `json:"source" yaml:"source"`
+	Instance  string    `json:"instance" yaml:"instance"`
+	Stream    string    `json:"stream" yaml:"stream"`
+	Message   string    `json:"message" yaml:"message"`
+}
+
+func NewEntry(msg Loggable) Entry {
+	stream := "out"
+	if msg.IsError() {
+		stream = "err"
+	}
+
+	return Entry{
+		Timestamp: msg.GetTimestamp().UTC(),
+		Source:    msg.GetSourceName(),
+		Instance:  msg.GetSourceInstance(),
+		Stream:    stream,
+		Message:   msg.ToSimpleLog(),
+	}
+}
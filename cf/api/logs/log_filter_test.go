@@ -0,0 +1,80 @@
+package logs_test
+
+import (
+	"regexp"
+	"time"
+
+	testlogs "github.com/cloudfoundry/cli/testhelpers/logs"
+
+	. "github.com/cloudfoundry/cli/cf/api/logs"
+	"github.com/cloudfoundry/loggregatorlib/logmessage"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Filter", func() {
+	var (
+		date   time.Time
+		appOut Loggable
+		appErr Loggable
+		rtrOut Loggable
+	)
+
+	BeforeEach(func() {
+		date = time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+		appOut = testlogs.NewLogMessage("GET /health 200", "", "APP", "0", logmessage.LogMessage_OUT, date)
+		appErr = testlogs.NewLogMessage("panic: oops", "", "APP", "1", logmessage.LogMessage_ERR, date.Add(time.Minute))
+		rtrOut = testlogs.NewLogMessage("GET /health", "", "RTR", "0", logmessage.LogMessage_OUT, date.Add(2*time.Minute))
+	})
+
+	It("lets every message through by default", func() {
+		Expect(Filter{}.Matches(appOut)).To(BeTrue())
+		Expect(Filter{}.Matches(appErr)).To(BeTrue())
+		Expect(Filter{}.Matches(rtrOut)).To(BeTrue())
+	})
+
+	It("filters by source type without regard to case", func() {
+		filter := Filter{SourceTypes: []string{"rtr", "STG"}}
+		Expect(filter.Matches(appOut)).To(BeFalse())
+		Expect(filter.Matches(rtrOut)).To(BeTrue())
+	})
+
+	It("filters by instance", func() {
+		filter := Filter{Instances: []string{"1"}}
+		Expect(filter.Matches(appOut)).To(BeFalse())
+		Expect(filter.Matches(appErr)).To(BeTrue())
+	})
+
+	It("filters by stream", func() {
+		Expect(Filter{Stream: "err"}.Matches(appOut)).To(BeFalse())
+		Expect(Filter{Stream: "err"}.Matches(appErr)).To(BeTrue())
+		Expect(Filter{Stream: "out"}.Matches(appErr)).To(BeFalse())
+	})
+
+	It("filters by the message text", func() {
+		filter := Filter{Pattern: regexp.MustCompile(`\b200$`)}
+		Expect(filter.Matches(appOut)).To(BeTrue())
+		Expect(filter.Matches(rtrOut)).To(BeFalse())
+	})
+
+	It("keeps the messages inside the time window", func() {
+		filter := Filter{Since: date.Add(30 * time.Second), Until: date.Add(time.Minute)}
+		Expect(filter.Matches(appOut)).To(BeFalse())
+		Expect(filter.Matches(appErr)).To(BeTrue())
+		Expect(filter.Matches(rtrOut)).To(BeFalse())
+	})
+})
+
+var _ = Describe("NewEntry", func() {
+	It("describes the message", func() {
+		date := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+		msg := testlogs.NewLogMessage("panic: oops\n", "", "APP", "1", logmessage.LogMessage_ERR, date)
+
+		entry := NewEntry(msg)
+		Expect(entry.Timestamp.Equal(date)).To(BeTrue())
+		Expect(entry.Source).To(Equal("APP"))
+		Expect(entry.Instance).To(Equal("1"))
+		Expect(entry.Stream).To(Equal("err"))
+		Expect(entry.Message).To(Equal("panic: oops"))
+	})
+})
@@ -0,0 +1,22 @@
+package logs
+
+// UnavailableLogsRepository is the Repository when logs cannot be read at
+// all, such as when the client certificate to read them with cannot be
+// loaded. Every read fails with its error.
+type UnavailableLogsRepository struct {
+	err error
+}
+
+func NewUnavailableLogsRepository(err error) *UnavailableLogsRepository {
+	return &UnavailableLogsRepository{err: err}
+}
+
+func (repo *UnavailableLogsRepository) RecentLogsFor(appGUID string) ([]Loggable, error) {
+	return nil, repo.err
+}
+
+func (repo *UnavailableLogsRepository) TailLogsFor(appGUID string, onConnect func(), logChan chan<- Loggable, errChan chan<- error) {
+	errChan <- repo.err
+}
+
+func (repo *UnavailableLogsRepository) Close() {}
@@ -0,0 +1,28 @@
+package logs_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/cli/cf/api/logs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UnavailableLogsRepository", func() {
+	var repo *logs.UnavailableLogsRepository
+
+	BeforeEach(func() {
+		repo = logs.NewUnavailableLogsRepository(errors.New("Error loading client certificate"))
+	})
+
+	It("fails to get recent logs", func() {
+		_, err := repo.RecentLogsFor("app-guid")
+		Expect(err).To(MatchError("Error loading client certificate"))
+	})
+
+	It("fails to tail logs", func() {
+		errChan := make(chan error, 1)
+		repo.TailLogsFor("app-guid", func() {}, make(chan logs.Loggable), errChan)
+		Expect(errChan).To(Receive(MatchError("Error loading client certificate")))
+	})
+})
@@ -257,7 +257,7 @@ var _ = Describe("Organization Repository", func() {
 			requestHandler := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
 				Method:   "GET",
 				Path:     "/v2/organizations?q=name%3Aorg1&inline-relations-depth=1",
-				Response: testnet.TestResponse{Status: http.StatusBadGateway, Body: `{"resources": []}`},
+				Response: testnet.TestResponse{Status: http.StatusInternalServerError, Body: `{"resources": []}`},
 			})
 
 			testserver, handler, repo := createOrganizationRepo(requestHandler)
@@ -63,6 +63,7 @@ type RepositoryLocator struct {
 	userRepo                        UserRepository
 	passwordRepo                    password.Repository
 	logsRepo                        logs.Repository
+	newLogsRepo                     func() logs.Repository
 	authTokenRepo                   ServiceAuthTokenRepository
 	serviceBrokerRepo               ServiceBrokerRepository
 	servicePlanRepo                 CloudControllerServicePlanRepository
@@ -105,19 +106,11 @@ func NewRepositoryLocator(config coreconfig.ReadWriter, gatewaysByName map[strin
 	loc.domainRepo = NewCloudControllerDomainRepository(config, cloudControllerGateway, strategy)
 	loc.endpointRepo = NewEndpointRepository(cloudControllerGateway)
 
-	tlsConfig := net.NewTLSConfig([]tls.Certificate{}, config.IsSSLDisabled())
-
-	apiVersion, _ := semver.Make(config.APIVersion())
-
-	if apiVersion.GTE(cf.NoaaMinimumAPIVersion) {
-		consumer := consumer.New(config.DopplerEndpoint(), tlsConfig, http.ProxyFromEnvironment)
-		consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
-		loc.logsRepo = logs.NewNoaaLogsRepository(config, consumer, loc.authRepo)
-	} else {
-		consumer := loggregator_consumer.New(config.LoggregatorEndpoint(), tlsConfig, http.ProxyFromEnvironment)
-		consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
-		loc.logsRepo = logs.NewLoggregatorLogsRepository(config, consumer, loc.authRepo)
+	authRepo := loc.authRepo
+	loc.newLogsRepo = func() logs.Repository {
+		return newLogsRepository(config, logger, authRepo)
 	}
+	loc.logsRepo = loc.newLogsRepo()
 
 	loc.organizationRepo = organizations.NewCloudControllerOrganizationRepository(config, cloudControllerGateway)
 	loc.passwordRepo = password.NewCloudControllerRepository(config, uaaGateway)
@@ -356,6 +349,7 @@ func (locator RepositoryLocator) GetPasswordRepository() password.Repository {
 
 func (locator RepositoryLocator) SetLogsRepository(repo logs.Repository) RepositoryLocator {
 	locator.logsRepo = repo
+	locator.newLogsRepo = nil
 	return locator
 }
 
@@ -363,6 +357,36 @@ func (locator RepositoryLocator) GetLogsRepository() logs.Repository {
 	return locator.logsRepo
 }
 
+// NewLogsRepository returns a logs repository of its own, for tailing the
+// logs of one app while another is tailed through GetLogsRepository. Once a
+// repository has been set with SetLogsRepository, that one is returned.
+func (locator RepositoryLocator) NewLogsRepository() logs.Repository {
+	if locator.newLogsRepo == nil {
+		return locator.logsRepo
+	}
+	return locator.newLogsRepo()
+}
+
+func newLogsRepository(config coreconfig.Reader, logger trace.Printer, tokenRefresher authentication.TokenRefresher) logs.Repository {
+	tlsConfig, err := net.NewTLSConfigFromConfig(config, []tls.Certificate{})
+	if err != nil {
+		// Logs are not read without the client certificate, so the reason
+		// is reported rather than a doppler TLS handshake failure.
+		return logs.NewUnavailableLogsRepository(err)
+	}
+
+	apiVersion, _ := semver.Make(config.APIVersion())
+	if apiVersion.GTE(cf.NoaaMinimumAPIVersion) {
+		consumer := consumer.New(config.DopplerEndpoint(), tlsConfig, http.ProxyFromEnvironment)
+		consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
+		return logs.NewNoaaLogsRepository(config, consumer, tokenRefresher)
+	}
+
+	consumer := loggregator_consumer.New(config.LoggregatorEndpoint(), tlsConfig, http.ProxyFromEnvironment)
+	consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
+	return logs.NewLoggregatorLogsRepository(config, consumer, tokenRefresher)
+}
+
 func (locator RepositoryLocator) SetServiceAuthTokenRepository(repo ServiceAuthTokenRepository) RepositoryLocator {
 	locator.authTokenRepo = repo
 	return locator
@@ -227,6 +227,7 @@ var _ = Describe("ServiceBindingsRepository", func() {
 
 		Context("when the service does not exist", func() {
 			BeforeEach(func() {
+				configRepo.SetRequestRetries(0)
 				server.AppendHandlers(ghttp.CombineHandlers(
 					ghttp.VerifyRequest("GET", "/v2/service_instances/service-instance-guid/service_bindings"),
 					ghttp.RespondWith(http.StatusGatewayTimeout, nil),
@@ -203,6 +203,7 @@ var _ = Describe("UserRepository", func() {
 
 		Context("when CC returns an error", func() {
 			BeforeEach(func() {
+				config.SetRequestRetries(0)
 				ccServer.AppendHandlers(
 					ghttp.CombineHandlers(
 						ghttp.VerifyRequest("GET", "/v2/organizations/org-guid/managers"),
@@ -378,6 +379,7 @@ var _ = Describe("UserRepository", func() {
 
 		Context("when CC returns an error", func() {
 			BeforeEach(func() {
+				config.SetRequestRetries(0)
 				ccServer.AppendHandlers(
 					ghttp.CombineHandlers(
 						ghttp.VerifyRequest("GET", "/v2/organizations/org-guid/managers"),
@@ -612,6 +614,7 @@ var _ = Describe("UserRepository", func() {
 
 		Context("when CC returns an error", func() {
 			BeforeEach(func() {
+				config.SetRequestRetries(0)
 				ccServer.AppendHandlers(
 					ghttp.CombineHandlers(
 						ghttp.VerifyRequest("GET", "/v2/spaces/space-guid/managers"),
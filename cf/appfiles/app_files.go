@@ -6,19 +6,25 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
+	"sync"
 
 	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/gofileutils/fileutils"
 )
 
-const windowsPathPrefix = `\\?\`
+const (
+	windowsPathPrefix = `\\?\`
+	dirPermissions    = 0700
+)
 
 //go:generate counterfeiter . AppFiles
 
 type AppFiles interface {
 	AppFilesInDir(dir string) (appFiles []models.AppFileFields, err error)
+	IgnoredFilesInDir(dir string) (ignoredFiles []IgnoredFile, err error)
 	CopyFiles(appFiles []models.AppFileFields, fromDir, toDir string) (err error)
 	CountFiles(directory string) int64
 	WalkAppFiles(dir string, onEachFile func(string, string) error) (err error)
@@ -26,6 +32,9 @@ type AppFiles interface {
 
 type ApplicationFiles struct{}
 
+// AppFilesInDir lists the files to push from dir. Files are hashed across
+// all CPUs while the directory is still being walked; the list keeps the
+// walk's order.
 func (appfiles ApplicationFiles) AppFilesInDir(dir string) ([]models.AppFileFields, error) {
 	appFiles := []models.AppFileFields{}
 
@@ -34,6 +43,38 @@ func (appfiles ApplicationFiles) AppFilesInDir(dir string) ([]models.AppFileFiel
 		return appFiles, toplevelErr
 	}
 
+	type shaJob struct {
+		index    int
+		fullPath string
+	}
+
+	jobs := make(chan shaJob)
+	shaErrs := make(chan error, runtime.NumCPU())
+	wg := new(sync.WaitGroup)
+	shas := []string{}
+	shasLock := new(sync.Mutex)
+
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				sha, err := appfiles.shaFile(job.fullPath)
+				if err != nil {
+					shaErrs <- err
+					// Keep draining so the walk is never blocked.
+					for range jobs {
+					}
+					return
+				}
+
+				shasLock.Lock()
+				shas[job.index] = sha
+				shasLock.Unlock()
+			}
+		}()
+	}
+
 	toplevelErr = appfiles.WalkAppFiles(fullDirPath, func(fileName string, fullPath string) error {
 		fileInfo, err := os.Lstat(fullPath)
 		if err != nil {
@@ -48,20 +89,44 @@ func (appfiles ApplicationFiles) AppFilesInDir(dir string) ([]models.AppFileFiel
 		if fileInfo.IsDir() {
 			appFile.Sha1 = "0"
 			appFile.Size = 0
-		} else {
-			sha, err := appfiles.shaFile(fullPath)
-			if err != nil {
-				return err
-			}
-			appFile.Sha1 = sha
 		}
 
+		shasLock.Lock()
+		shas = append(shas, "")
+		shasLock.Unlock()
+
 		appFiles = append(appFiles, appFile)
 
+		if !fileInfo.IsDir() {
+			select {
+			case jobs <- shaJob{index: len(appFiles) - 1, fullPath: fullPath}:
+			case err = <-shaErrs:
+				return err
+			}
+		}
+
 		return nil
 	})
 
-	return appFiles, toplevelErr
+	close(jobs)
+	wg.Wait()
+	close(shaErrs)
+
+	if toplevelErr != nil {
+		return appFiles, toplevelErr
+	}
+
+	for err := range shaErrs {
+		return appFiles, err
+	}
+
+	for i := range appFiles {
+		if appFiles[i].Sha1 == "" {
+			appFiles[i].Sha1 = shas[i]
+		}
+	}
+
+	return appFiles, nil
 }
 
 func (appfiles ApplicationFiles) shaFile(fullPath string) (string, error) {
@@ -163,8 +228,67 @@ func (appfiles ApplicationFiles) CountFiles(directory string) int64 {
 }
 
 func (appfiles ApplicationFiles) WalkAppFiles(dir string, onEachFile func(string, string) error) error {
+	return walkAppFiles(dir, onEachFile, nil)
+}
+
+// IgnoredFile is a file or directory that is not pushed, and the .cfignore
+// rule that excluded it. Directory paths end in '/'; nothing inside them is
+// listed.
+type IgnoredFile struct {
+	Path string
+	Rule string
+}
+
+func (appfiles ApplicationFiles) IgnoredFilesInDir(dir string) ([]IgnoredFile, error) {
+	ignoredFiles := []IgnoredFile{}
+	err := walkAppFiles(dir, func(_, _ string) error {
+		return nil
+	}, func(ignoredFile IgnoredFile) {
+		ignoredFiles = append(ignoredFiles, ignoredFile)
+	})
+
+	return ignoredFiles, err
+}
+
+// CopyIgnoreFiles copies every .cfignore in fromDir to the same place in
+// toDir, so that walking toDir ignores the same files.
+func CopyIgnoreFiles(fromDir string, toDir string) error {
+	copyIgnoreFile := func(relativePath string) error {
+		if path.Base(relativePath) != ".cfignore" {
+			return nil
+		}
+
+		toPath := filepath.Join(toDir, filepath.FromSlash(relativePath))
+		err := os.MkdirAll(filepath.Dir(toPath), dirPermissions)
+		if err != nil {
+			return err
+		}
+
+		return fileutils.CopyPathToPath(filepath.Join(fromDir, filepath.FromSlash(relativePath)), toPath)
+	}
+
+	var copyErr error
+	err := walkAppFiles(fromDir, func(fileName string, _ string) error {
+		return copyIgnoreFile(filepath.ToSlash(fileName))
+	}, func(ignoredFile IgnoredFile) {
+		if copyErr == nil {
+			copyErr = copyIgnoreFile(ignoredFile.Path)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return copyErr
+}
+
+func walkAppFiles(dir string, onEachFile func(string, string) error, onIgnored func(IgnoredFile)) error {
 	cfIgnore := loadIgnoreFile(dir)
 	walkFunc := func(fullPath string, f os.FileInfo, err error) error {
+		if fullPath == dir {
+			return err
+		}
+
 		fileRelativePath, _ := filepath.Rel(dir, fullPath)
 		fileRelativeUnixPath := filepath.ToSlash(fileRelativePath)
 
@@ -176,8 +300,17 @@ func (appfiles ApplicationFiles) WalkAppFiles(dir string, onEachFile func(string
 			fullPath = windowsPathPrefix + fullPath
 		}
 
-		if cfIgnore.FileShouldBeIgnored(fileRelativeUnixPath) {
-			if err == nil && f.IsDir() {
+		isDir := err == nil && f.IsDir()
+		if ignored, rule := cfIgnore.match(fileRelativeUnixPath, isDir); ignored {
+			if onIgnored != nil {
+				ignoredFile := IgnoredFile{Path: fileRelativeUnixPath, Rule: rule.String()}
+				if isDir {
+					ignoredFile.Path += "/"
+				}
+				onIgnored(ignoredFile)
+			}
+
+			if isDir {
 				return filepath.SkipDir
 			}
 			return nil
@@ -187,12 +320,12 @@ func (appfiles ApplicationFiles) WalkAppFiles(dir string, onEachFile func(string
 			return err
 		}
 
-		if fullPath == dir {
+		if !f.Mode().IsRegular() && !f.IsDir() {
 			return nil
 		}
 
-		if !f.Mode().IsRegular() && !f.IsDir() {
-			return nil
+		if f.IsDir() {
+			cfIgnore.addNestedFile(fullPath, fileRelativeUnixPath)
 		}
 
 		return onEachFile(fileRelativePath, fullPath)
@@ -201,11 +334,11 @@ func (appfiles ApplicationFiles) WalkAppFiles(dir string, onEachFile func(string
 	return filepath.Walk(dir, walkFunc)
 }
 
-func loadIgnoreFile(dir string) CfIgnore {
+func loadIgnoreFile(dir string) *cfIgnore {
 	fileContents, err := ioutil.ReadFile(filepath.Join(dir, ".cfignore"))
 	if err != nil {
-		return NewCfIgnore("")
+		return newCfIgnore("")
 	}
 
-	return NewCfIgnore(string(fileContents))
+	return newCfIgnore(string(fileContents))
 }
@@ -64,16 +64,12 @@ var _ = Describe("AppFiles", func() {
 				paths = append(paths, file.Path)
 			}
 
+			// As with .gitignore, dir1/child-dir/file3.txt cannot be included
+			// again because dir1/child-dir itself is ignored.
 			Expect(paths).To(Equal([]string{
 				"dir1",
-				"dir1/child-dir",
-				"dir1/child-dir/file3.txt",
 				"dir1/file1.txt",
 				"dir2",
-
-				// TODO: this should be excluded.
-				// .cfignore doesn't handle ** patterns right now
-				"dir2/child-dir2",
 			}))
 		})
 
@@ -100,6 +96,82 @@ var _ = Describe("AppFiles", func() {
 		})
 	})
 
+	Context("with a nested .cfignore", func() {
+		var appDir string
+
+		BeforeEach(func() {
+			var err error
+			appDir, err = ioutil.TempDir("", "nested-cfignore")
+			Expect(err).NotTo(HaveOccurred())
+
+			files := map[string]string{
+				".cfignore":            "*.log\nnode_modules/\n",
+				"app.js":               "",
+				"server.log":           "",
+				"node_modules/dep.js":  "",
+				"logs/.cfignore":       "!keep.log\n/local.js\n",
+				"logs/keep.log":        "",
+				"logs/drop.log":        "",
+				"logs/local.js":        "",
+				"logs/nested/local.js": "",
+			}
+			for name, contents := range files {
+				fullPath := filepath.Join(appDir, filepath.FromSlash(name))
+				Expect(os.MkdirAll(filepath.Dir(fullPath), 0700)).To(Succeed())
+				Expect(ioutil.WriteFile(fullPath, []byte(contents), 0600)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(appDir)
+		})
+
+		It("applies its rules to the files below it", func() {
+			files, err := appFiles.AppFilesInDir(appDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			paths := []string{}
+			for _, file := range files {
+				paths = append(paths, file.Path)
+			}
+			Expect(paths).To(Equal([]string{
+				"app.js",
+				"logs",
+				"logs/keep.log",
+				"logs/nested",
+				"logs/nested/local.js",
+			}))
+		})
+
+		It("lists the ignored files and the rules that ignored them", func() {
+			ignoredFiles, err := appFiles.IgnoredFilesInDir(appDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ignoredFiles).To(Equal([]appfiles.IgnoredFile{
+				{Path: ".cfignore", Rule: "(default) .cfignore"},
+				{Path: "logs/.cfignore", Rule: "(default) .cfignore"},
+				{Path: "logs/drop.log", Rule: ".cfignore:1 *.log"},
+				{Path: "logs/local.js", Rule: "logs/.cfignore:2 /local.js"},
+				{Path: "node_modules/", Rule: ".cfignore:2 node_modules/"},
+				{Path: "server.log", Rule: ".cfignore:1 *.log"},
+			}))
+		})
+
+		It("copies every .cfignore with CopyIgnoreFiles", func() {
+			toDir, err := ioutil.TempDir("", "copied-cfignore")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(toDir)
+
+			Expect(appfiles.CopyIgnoreFiles(appDir, toDir)).To(Succeed())
+
+			contents, err := ioutil.ReadFile(filepath.Join(toDir, "logs", ".cfignore"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("!keep.log\n/local.js\n"))
+			Expect(filepath.Join(toDir, ".cfignore")).To(BeAnExistingFile())
+			Expect(filepath.Join(toDir, "app.js")).NotTo(BeAnExistingFile())
+		})
+	})
+
 	Describe("CopyFiles", func() {
 		It("copies only the files specified", func() {
 			copyDir := filepath.Join(fixturePath, "app-copy-test")
@@ -18,6 +18,15 @@ type FakeAppFiles struct {
 		result1 []models.AppFileFields
 		result2 error
 	}
+	IgnoredFilesInDirStub        func(dir string) (ignoredFiles []appfiles.IgnoredFile, err error)
+	ignoredFilesInDirMutex       sync.RWMutex
+	ignoredFilesInDirArgsForCall []struct {
+		dir string
+	}
+	ignoredFilesInDirReturns struct {
+		result1 []appfiles.IgnoredFile
+		result2 error
+	}
 	CopyFilesStub        func(appFiles []models.AppFileFields, fromDir, toDir string) (err error)
 	copyFilesMutex       sync.RWMutex
 	copyFilesArgsForCall []struct {
@@ -80,13 +89,51 @@ func (fake *FakeAppFiles) AppFilesInDirReturns(result1 []models.AppFileFields, r
 	}{result1, result2}
 }
 
+func (fake *FakeAppFiles) IgnoredFilesInDir(dir string) (ignoredFiles []appfiles.IgnoredFile, err error) {
+	fake.ignoredFilesInDirMutex.Lock()
+	fake.ignoredFilesInDirArgsForCall = append(fake.ignoredFilesInDirArgsForCall, struct {
+		dir string
+	}{dir})
+	fake.ignoredFilesInDirMutex.Unlock()
+	if fake.IgnoredFilesInDirStub != nil {
+		return fake.IgnoredFilesInDirStub(dir)
+	} else {
+		return fake.ignoredFilesInDirReturns.result1, fake.ignoredFilesInDirReturns.result2
+	}
+}
+
+func (fake *FakeAppFiles) IgnoredFilesInDirCallCount() int {
+	fake.ignoredFilesInDirMutex.RLock()
+	defer fake.ignoredFilesInDirMutex.RUnlock()
+	return len(fake.ignoredFilesInDirArgsForCall)
+}
+
+func (fake *FakeAppFiles) IgnoredFilesInDirArgsForCall(i int) string {
+	fake.ignoredFilesInDirMutex.RLock()
+	defer fake.ignoredFilesInDirMutex.RUnlock()
+	return fake.ignoredFilesInDirArgsForCall[i].dir
+}
+
+func (fake *FakeAppFiles) IgnoredFilesInDirReturns(result1 []appfiles.IgnoredFile, result2 error) {
+	fake.IgnoredFilesInDirStub = nil
+	fake.ignoredFilesInDirReturns = struct {
+		result1 []appfiles.IgnoredFile
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAppFiles) CopyFiles(appFiles []models.AppFileFields, fromDir string, toDir string) (err error) {
+	var appFilesCopy []models.AppFileFields
+	if appFiles != nil {
+		appFilesCopy = make([]models.AppFileFields, len(appFiles))
+		copy(appFilesCopy, appFiles)
+	}
 	fake.copyFilesMutex.Lock()
 	fake.copyFilesArgsForCall = append(fake.copyFilesArgsForCall, struct {
 		appFiles []models.AppFileFields
 		fromDir  string
 		toDir    string
-	}{appFiles, fromDir, toDir})
+	}{appFilesCopy, fromDir, toDir})
 	fake.copyFilesMutex.Unlock()
 	if fake.CopyFilesStub != nil {
 		return fake.CopyFilesStub(appFiles, fromDir, toDir)
@@ -2,6 +2,7 @@
 package appfilesfakes
 
 import (
+	"io"
 	"os"
 	"sync"
 
@@ -18,6 +19,15 @@ type FakeZipper struct {
 	zipReturns struct {
 		result1 error
 	}
+	WriteZipStub        func(dirOrZipFile string, w io.Writer) (err error)
+	writeZipMutex       sync.RWMutex
+	writeZipArgsForCall []struct {
+		dirOrZipFile string
+		w            io.Writer
+	}
+	writeZipReturns struct {
+		result1 error
+	}
 	IsZipFileStub        func(path string) bool
 	isZipFileMutex       sync.RWMutex
 	isZipFileArgsForCall []struct {
@@ -79,6 +89,39 @@ func (fake *FakeZipper) ZipReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeZipper) WriteZip(dirOrZipFile string, w io.Writer) (err error) {
+	fake.writeZipMutex.Lock()
+	fake.writeZipArgsForCall = append(fake.writeZipArgsForCall, struct {
+		dirOrZipFile string
+		w            io.Writer
+	}{dirOrZipFile, w})
+	fake.writeZipMutex.Unlock()
+	if fake.WriteZipStub != nil {
+		return fake.WriteZipStub(dirOrZipFile, w)
+	} else {
+		return fake.writeZipReturns.result1
+	}
+}
+
+func (fake *FakeZipper) WriteZipCallCount() int {
+	fake.writeZipMutex.RLock()
+	defer fake.writeZipMutex.RUnlock()
+	return len(fake.writeZipArgsForCall)
+}
+
+func (fake *FakeZipper) WriteZipArgsForCall(i int) (string, io.Writer) {
+	fake.writeZipMutex.RLock()
+	defer fake.writeZipMutex.RUnlock()
+	return fake.writeZipArgsForCall[i].dirOrZipFile, fake.writeZipArgsForCall[i].w
+}
+
+func (fake *FakeZipper) WriteZipReturns(result1 error) {
+	fake.WriteZipStub = nil
+	fake.writeZipReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeZipper) IsZipFile(path string) bool {
 	fake.isZipFileMutex.Lock()
 	fake.isZipFileArgsForCall = append(fake.isZipFileArgsForCall, struct {
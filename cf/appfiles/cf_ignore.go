@@ -1,79 +1,242 @@
 package appfiles
 
 import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
 	"path"
+	"path/filepath"
+	"regexp"
 	"strings"
-
-	"github.com/cloudfoundry/cli/glob"
 )
 
 //go:generate counterfeiter . CfIgnore
 
+// CfIgnore decides which app files are not pushed. Its rules follow
+// .gitignore: the last matching rule wins, rules starting with '!' include
+// files again, rules containing a '/' are relative to the .cfignore they
+// are in, rules ending in '/' only match directories, and nothing inside an
+// ignored directory can be included again.
 type CfIgnore interface {
 	FileShouldBeIgnored(path string) bool
 }
 
+// NewCfIgnore returns the rules of a top-level .cfignore containing text,
+// after the default rules.
 func NewCfIgnore(text string) CfIgnore {
-	patterns := []ignorePattern{}
-	lines := strings.Split(text, "\n")
-	lines = append(defaultIgnoreLines, lines...)
+	return newCfIgnore(text)
+}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
+func newCfIgnore(text string) *cfIgnore {
+	ignore := &cfIgnore{}
+	ignore.addRules("", defaultIgnoreSource, strings.Join(defaultIgnoreLines, "\n"))
+	ignore.addRules("", ".cfignore", text)
+	return ignore
+}
 
-		ignore := true
-		if strings.HasPrefix(line, "!") {
-			line = line[1:]
-			ignore = false
+// FileShouldBeIgnored is true when path, relative to the app directory and
+// using '/' separators, is ignored. A path ending in '/' is a directory.
+func (ignore *cfIgnore) FileShouldBeIgnored(path string) bool {
+	path = strings.TrimPrefix(path, "/")
+	isDir := strings.HasSuffix(path, "/")
+	path = strings.TrimSuffix(path, "/")
+
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if ignored, _ := ignore.match(strings.Join(segments[:i], "/"), true); ignored {
+			return true
 		}
+	}
 
-		for _, p := range globsForPattern(path.Clean(line)) {
-			patterns = append(patterns, ignorePattern{ignore, p})
+	ignored, _ := ignore.match(path, isDir)
+	return ignored
+}
+
+// match applies the rules to path alone, without looking at the
+// directories above it, and returns the rule that decided.
+func (ignore *cfIgnore) match(path string, isDir bool) (bool, *ignoreRule) {
+	for i := len(ignore.rules) - 1; i >= 0; i-- {
+		rule := &ignore.rules[i]
+		if rule.matches(path, isDir) {
+			return !rule.negate, rule
 		}
 	}
 
-	return cfIgnore(patterns)
+	return false, nil
 }
 
-func (ignore cfIgnore) FileShouldBeIgnored(path string) bool {
-	result := false
+// addNestedFile adds the rules of the .cfignore in dir, if it has one. base
+// is dir relative to the app directory.
+func (ignore *cfIgnore) addNestedFile(dir string, base string) {
+	contents, err := ioutil.ReadFile(filepath.Join(dir, ".cfignore"))
+	if err != nil {
+		return
+	}
+
+	ignore.addRules(base, path.Join(base, ".cfignore"), string(contents))
+}
 
-	for _, pattern := range ignore {
-		if strings.HasPrefix(pattern.glob.String(), "/") && !strings.HasPrefix(path, "/") {
-			path = "/" + path
+func (ignore *cfIgnore) addRules(base string, source string, text string) {
+	for i, line := range strings.Split(text, "\n") {
+		rule, ok := parseIgnoreRule(line)
+		if !ok {
+			continue
 		}
 
-		if pattern.glob.Match(path) {
-			result = pattern.exclude
+		rule.base = base
+		rule.source = source
+		rule.line = i + 1
+		ignore.rules = append(ignore.rules, rule)
+	}
+}
+
+type cfIgnore struct {
+	rules []ignoreRule
+}
+
+type ignoreRule struct {
+	source  string
+	line    int
+	pattern string
+	base    string
+	negate  bool
+	dirOnly bool
+	regexp  *regexp.Regexp
+}
+
+func (rule ignoreRule) String() string {
+	if rule.source == defaultIgnoreSource {
+		return fmt.Sprintf("%s %s", rule.source, rule.pattern)
+	}
+	return fmt.Sprintf("%s:%d %s", rule.source, rule.line, rule.pattern)
+}
+
+func (rule ignoreRule) matches(path string, isDir bool) bool {
+	if rule.dirOnly && !isDir {
+		return false
+	}
+
+	if rule.base != "" {
+		if !strings.HasPrefix(path, rule.base+"/") {
+			return false
 		}
+		path = path[len(rule.base)+1:]
 	}
 
-	return result
+	return rule.regexp.MatchString(path)
 }
 
-func globsForPattern(pattern string) (globs []glob.Glob) {
-	globs = append(globs, glob.MustCompileGlob(pattern))
-	globs = append(globs, glob.MustCompileGlob(path.Join(pattern, "*")))
-	globs = append(globs, glob.MustCompileGlob(path.Join(pattern, "**", "*")))
+func parseIgnoreRule(line string) (ignoreRule, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+
+	rule := ignoreRule{pattern: line}
+
+	if line == "" || strings.HasPrefix(line, "#") {
+		return rule, false
+	}
+
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+
+	if line == "" {
+		return rule, false
+	}
+
+	// A leading "./" anchors the rule to the .cfignore's directory, like a
+	// leading "/" does.
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(path.Clean(line), "/")
+	if line == "." {
+		return rule, false
+	}
 
-	if !strings.HasPrefix(pattern, "/") {
-		globs = append(globs, glob.MustCompileGlob(path.Join("**", pattern)))
-		globs = append(globs, glob.MustCompileGlob(path.Join("**", pattern, "*")))
-		globs = append(globs, glob.MustCompileGlob(path.Join("**", pattern, "**", "*")))
+	if !anchored {
+		line = "**/" + line
 	}
 
-	return
+	re, err := regexp.Compile(ignorePatternToRegexp(line))
+	if err != nil {
+		return rule, false
+	}
+	rule.regexp = re
+
+	return rule, true
 }
 
-type ignorePattern struct {
-	exclude bool
-	glob    glob.Glob
+// ignorePatternToRegexp translates a .gitignore style pattern into a regular
+// expression that matches the whole of a '/' separated path.
+func ignorePatternToRegexp(pattern string) string {
+	chars := []rune(pattern)
+	re := new(bytes.Buffer)
+	re.WriteString("^")
+
+	for i := 0; i < len(chars); i++ {
+		switch c := chars[i]; c {
+		case '*':
+			doubleStar := i+1 < len(chars) && chars[i+1] == '*' &&
+				(i == 0 || chars[i-1] == '/') &&
+				(i+2 == len(chars) || chars[i+2] == '/')
+
+			switch {
+			case doubleStar && i+2 == len(chars):
+				re.WriteString(".*")
+				i++
+			case doubleStar:
+				re.WriteString("(?:.*/)?")
+				i += 2
+			default:
+				re.WriteString("[^/]*")
+			}
+		case '?':
+			re.WriteString("[^/]")
+		case '[':
+			end := i + 1
+			if end < len(chars) && (chars[end] == '!' || chars[end] == '^') {
+				end++
+			}
+			if end < len(chars) && chars[end] == ']' {
+				end++
+			}
+			for end < len(chars) && chars[end] != ']' {
+				end++
+			}
+
+			if end >= len(chars) {
+				re.WriteString(`\[`)
+				continue
+			}
+
+			class := string(chars[i+1 : end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			re.WriteString("[" + class + "]")
+			i = end
+		case '\\':
+			if i+1 < len(chars) {
+				i++
+			}
+			re.WriteString(regexp.QuoteMeta(string(chars[i])))
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	re.WriteString("$")
+	return re.String()
 }
 
-type cfIgnore []ignorePattern
+const defaultIgnoreSource = "(default)"
 
 var defaultIgnoreLines = []string{
 	".cfignore",
@@ -67,6 +67,75 @@ stuff/exclude.c`)
 		Expect(ignore.FileShouldBeIgnored(".git/objects")).To(BeFalse())
 	})
 
+	It("anchors patterns that contain a slash to the top of the app", func() {
+		ignore := NewCfIgnore(`
+/build
+logs/today.log`)
+
+		Expect(ignore.FileShouldBeIgnored("build")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("src/build")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("logs/today.log")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("old/logs/today.log")).To(BeFalse())
+	})
+
+	It("cleans patterns before matching them", func() {
+		ignore := NewCfIgnore(`
+./node_modules
+./tmp/
+docs//drafts`)
+
+		Expect(ignore.FileShouldBeIgnored("node_modules/left-pad/index.js")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("src/node_modules/left-pad/index.js")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("tmp/")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("tmp/cache")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("docs/drafts/intro.md")).To(BeTrue())
+	})
+
+	It("matches double-star patterns at any depth", func() {
+		ignore := NewCfIgnore(`
+**/secrets
+docs/**/draft.md
+vendor/**`)
+
+		Expect(ignore.FileShouldBeIgnored("secrets")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("config/prod/secrets")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("docs/draft.md")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("docs/a/b/draft.md")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("vendor/pkg/file.go")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("vendor")).To(BeFalse())
+	})
+
+	It("only matches directories with patterns ending in a slash", func() {
+		ignore := NewCfIgnore(`tmp/`)
+
+		Expect(ignore.FileShouldBeIgnored("tmp/")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("tmp/cache.db")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("tmp")).To(BeFalse())
+	})
+
+	It("does not include files again inside an ignored directory", func() {
+		ignore := NewCfIgnore(`
+node_modules
+!node_modules/common`)
+
+		Expect(ignore.FileShouldBeIgnored("node_modules/common")).To(BeTrue())
+	})
+
+	It("supports comments, escapes and character classes", func() {
+		ignore := NewCfIgnore(`
+# a comment
+\#hash
+\!bang
+file[0-9].txt
+`)
+
+		Expect(ignore.FileShouldBeIgnored("# a comment")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("#hash")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("!bang")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("file1.txt")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("fileA.txt")).To(BeFalse())
+	})
+
 	Describe("files named manifest.yml", func() {
 		var (
 			ignore CfIgnore
@@ -17,6 +17,7 @@ import (
 
 type Zipper interface {
 	Zip(dirToZip string, targetFile *os.File) (err error)
+	WriteZip(dirOrZipFile string, w io.Writer) (err error)
 	IsZipFile(path string) bool
 	Unzip(appDir string, destDir string) (err error)
 	GetZipSize(zipFile *os.File) (int64, error)
@@ -25,6 +26,22 @@ type Zipper interface {
 type ApplicationZipper struct{}
 
 func (zipper ApplicationZipper) Zip(dirOrZipFilePath string, targetFile *os.File) error {
+	err := zipper.WriteZip(dirOrZipFilePath, targetFile)
+	if err != nil {
+		return err
+	}
+
+	_, err = targetFile.Seek(0, os.SEEK_SET)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// WriteZip writes the zipped contents of a directory, or an existing zip
+// file as is, to w as it goes, without buffering the archive.
+func (zipper ApplicationZipper) WriteZip(dirOrZipFilePath string, w io.Writer) error {
 	if zipper.IsZipFile(dirOrZipFilePath) {
 		zipFile, err := os.Open(dirOrZipFilePath)
 		if err != nil {
@@ -32,23 +49,11 @@ func (zipper ApplicationZipper) Zip(dirOrZipFilePath string, targetFile *os.File
 		}
 		defer zipFile.Close()
 
-		_, err = io.Copy(targetFile, zipFile)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := writeZipFile(dirOrZipFilePath, targetFile)
-		if err != nil {
-			return err
-		}
-	}
-
-	_, err := targetFile.Seek(0, os.SEEK_SET)
-	if err != nil {
+		_, err = io.Copy(w, zipFile)
 		return err
 	}
 
-	return nil
+	return writeZipFile(dirOrZipFilePath, w)
 }
 
 func (zipper ApplicationZipper) IsZipFile(name string) bool {
@@ -136,7 +141,7 @@ func (zipper ApplicationZipper) GetZipSize(zipFile *os.File) (int64, error) {
 	return zipFileSize, nil
 }
 
-func writeZipFile(dir string, targetFile *os.File) error {
+func writeZipFile(dir string, targetFile io.Writer) error {
 	isEmpty, err := fileutils.IsDirEmpty(dir)
 	if err != nil {
 		return err
@@ -147,10 +152,9 @@ func writeZipFile(dir string, targetFile *os.File) error {
 	}
 
 	writer := zip.NewWriter(targetFile)
-	defer writer.Close()
 
 	appfiles := ApplicationFiles{}
-	return appfiles.WalkAppFiles(dir, func(fileName string, fullPath string) error {
+	err = appfiles.WalkAppFiles(dir, func(fileName string, fullPath string) error {
 		fileInfo, err := os.Stat(fullPath)
 		if err != nil {
 			return err
@@ -193,6 +197,13 @@ func writeZipFile(dir string, targetFile *os.File) error {
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// Close writes the archive's central directory, which a streamed
+	// upload would otherwise be missing.
+	return writer.Close()
 }
 
 func (zipper ApplicationZipper) zipFileHeaderLocation(name string) (int64, error) {
@@ -229,6 +229,25 @@ var _ = Describe("Zipper", func() {
 		})
 	})
 
+	Describe("WriteZip", func() {
+		It("writes a zip of the source directory to a stream", func() {
+			workingDir, err := os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+
+			zipper := ApplicationZipper{}
+			buffer := new(bytes.Buffer)
+			err = zipper.WriteZip(filepath.Join(workingDir, "../../fixtures/zip/"), buffer)
+			Expect(err).NotTo(HaveOccurred())
+
+			reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			Expect(err).NotTo(HaveOccurred())
+
+			name, contents := readFileInZip(0, reader)
+			Expect(name).To(Equal("foo.txt"))
+			Expect(contents).To(Equal("This is a simple text file."))
+		})
+	})
+
 	Describe("IsZipFile", func() {
 		var (
 			inDir, outDir string
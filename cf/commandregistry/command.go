@@ -24,4 +24,5 @@ type CommandMetadata struct {
 	TotalArgs       int //Optional: number of required arguments to skip for flag verification
 	Hidden          bool
 	Examples        []string
+	OutputFormats   bool //Optional: command honours the global --output option
 }
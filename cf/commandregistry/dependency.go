@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/cloudfoundry/cli/cf/actors"
@@ -12,12 +13,14 @@ import (
 	"github.com/cloudfoundry/cli/cf/actors/pluginrepo"
 	"github.com/cloudfoundry/cli/cf/actors/servicebuilder"
 	"github.com/cloudfoundry/cli/cf/api"
+	"github.com/cloudfoundry/cli/cf/api/applicationbits"
 	"github.com/cloudfoundry/cli/cf/appfiles"
 	"github.com/cloudfoundry/cli/cf/configuration/confighelpers"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
 	"github.com/cloudfoundry/cli/cf/manifest"
 	"github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/cf/trace"
 	"github.com/cloudfoundry/cli/plugin/models"
@@ -46,6 +49,7 @@ type Dependency struct {
 	AppFiles           appfiles.AppFiles
 	PushActor          actors.PushActor
 	ChecksumUtil       utils.Sha1Checksum
+	KnownHosts         knownhosts.KnownHosts
 	WildcardDependency interface{} //use for injecting fakes
 	Logger             trace.Printer
 }
@@ -80,6 +84,9 @@ func NewDependency(writer io.Writer, logger trace.Printer) Dependency {
 		errorHandler(err)
 	}
 	deps.Config = coreconfig.NewRepositoryFromFilepath(configPath, errorHandler)
+	if profile := os.Getenv("CF_PROFILE"); profile != "" {
+		deps.Config.SetSessionProfile(profile)
+	}
 
 	deps.ManifestRepo = manifest.NewDiskRepository()
 	deps.AppManifest = manifest.NewGenerator()
@@ -134,10 +141,13 @@ func NewDependency(writer io.Writer, logger trace.Printer) Dependency {
 	deps.AppZipper = appfiles.ApplicationZipper{}
 	deps.AppFiles = appfiles.ApplicationFiles{}
 
-	deps.PushActor = actors.NewPushActor(deps.RepoLocator.GetApplicationBitsRepository(), deps.AppZipper, deps.AppFiles)
+	resourceCache := applicationbits.NewDiskResourceCache(filepath.Join(filepath.Dir(configPath), "resource_cache.json"), deps.Config)
+	deps.PushActor = actors.NewPushActor(deps.RepoLocator.GetApplicationBitsRepository(), deps.AppZipper, deps.AppFiles, resourceCache)
 
 	deps.ChecksumUtil = utils.NewSha1Checksum("")
 
+	deps.KnownHosts = knownhosts.NewKnownHosts(filepath.Join(filepath.Dir(configPath), "known_hosts"))
+
 	deps.Logger = logger
 
 	return deps
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/cloudfoundry/cli/cf"
@@ -9,6 +10,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/errors"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/net"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -28,12 +30,15 @@ func (cmd API) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["unset"] = &flags.BoolFlag{Name: "unset", Usage: T("Remove all api endpoint targeting")}
 	fs["skip-ssl-validation"] = &flags.BoolFlag{Name: "skip-ssl-validation", Usage: T("Skip verification of the API endpoint. Not recommended!")}
+	fs["ca-cert"] = &flags.StringFlag{Name: "ca-cert", Usage: T("PEM file of the CA certificates to trust, besides the system's, for the API endpoint and its UAA, routing API and doppler")}
+	fs["client-cert"] = &flags.StringFlag{Name: "client-cert", Usage: T("PEM file of the client certificate to present to the API endpoint and its UAA, routing API and doppler; requires --client-key")}
+	fs["client-key"] = &flags.StringFlag{Name: "client-key", Usage: T("PEM file of the private key of the client certificate; requires --client-cert")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "api",
 		Description: T("Set or view target api url"),
 		Usage: []string{
-			T("CF_NAME api [URL]"),
+			T("CF_NAME api [URL] [--skip-ssl-validation] [--ca-cert FILE] [--client-cert FILE --client-key FILE]"),
 		},
 		Flags: fs,
 	}
@@ -71,9 +76,14 @@ func (cmd API) Execute(c flags.FlagContext) error {
 	} else {
 		endpoint := c.Args()[0]
 
+		err := cmd.setCertificateFiles(c.String("ca-cert"), c.String("client-cert"), c.String("client-key"))
+		if err != nil {
+			return err
+		}
+
 		cmd.ui.Say(T("Setting api endpoint to {{.Endpoint}}...",
 			map[string]interface{}{"Endpoint": terminal.EntityNameColor(endpoint)}))
-		err := cmd.setAPIEndpoint(endpoint, c.Bool("skip-ssl-validation"), cmd.MetaData().Name)
+		err = cmd.setAPIEndpoint(endpoint, c.Bool("skip-ssl-validation"), cmd.MetaData().Name)
 		if err != nil {
 			return err
 		}
@@ -102,12 +112,15 @@ func (cmd API) setAPIEndpoint(endpoint string, skipSSL bool, cmdName string) err
 	if err != nil {
 		cmd.config.SetAPIEndpoint("")
 		cmd.config.SetSSLDisabled(false)
+		cmd.config.SetCACertFile("")
+		cmd.config.SetClientCertificate("", "")
 
 		switch typedErr := err.(type) {
 		case *errors.InvalidSSLCert:
 			cfAPICommand := terminal.CommandColor(fmt.Sprintf("%s %s --skip-ssl-validation", cf.Name, cmdName))
-			tipMessage := fmt.Sprintf(T("TIP: Use '{{.APICommand}}' to continue with an insecure API endpoint",
-				map[string]interface{}{"APICommand": cfAPICommand}))
+			caCertCommand := terminal.CommandColor(fmt.Sprintf("%s api %s --ca-cert FILE", cf.Name, endpoint))
+			tipMessage := fmt.Sprintf(T("TIP: Use '{{.CACertCommand}}' to trust the CA certificate of the API endpoint, or '{{.APICommand}}' to continue with an insecure API endpoint",
+				map[string]interface{}{"CACertCommand": caCertCommand, "APICommand": cfAPICommand}))
 			return errors.New(T("Invalid SSL Cert for {{.URL}}\n{{.TipMessage}}",
 				map[string]interface{}{"URL": typedErr.URL, "TipMessage": tipMessage}))
 		default:
@@ -120,3 +133,34 @@ func (cmd API) setAPIEndpoint(endpoint string, skipSSL bool, cmdName string) err
 	}
 	return nil
 }
+
+// setCertificateFiles checks that the certificate files can be loaded and
+// saves their absolute paths, so that later commands run from any directory
+// find them.
+func (cmd API) setCertificateFiles(caCertFile string, clientCertFile string, clientKeyFile string) error {
+	if (clientCertFile == "") != (clientKeyFile == "") {
+		return errors.New(T("Incorrect Usage. --client-cert and --client-key must be used together") + "\n\n" + commandregistry.Commands.CommandUsage("api"))
+	}
+
+	paths := []*string{&caCertFile, &clientCertFile, &clientKeyFile}
+	for _, path := range paths {
+		if *path == "" {
+			continue
+		}
+
+		absPath, err := filepath.Abs(*path)
+		if err != nil {
+			return err
+		}
+		*path = absPath
+	}
+
+	_, err := net.NewTLSConfigWithFiles(nil, false, caCertFile, clientCertFile, clientKeyFile)
+	if err != nil {
+		return err
+	}
+
+	cmd.config.SetCACertFile(caCertFile)
+	cmd.config.SetClientCertificate(clientCertFile, clientKeyFile)
+	return nil
+}
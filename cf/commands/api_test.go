@@ -2,12 +2,16 @@ package commands_test
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/errors"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testnet "github.com/cloudfoundry/cli/testhelpers/net"
 	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
 	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
 	. "github.com/onsi/ginkgo"
@@ -219,5 +223,86 @@ var _ = Describe("Api", func() {
 				Expect(ui.Outputs).To(ContainSubstrings([]string{"Warning"}))
 			})
 		})
+
+		Describe("certificate files", func() {
+			var (
+				certDir  string
+				certFile string
+				keyFile  string
+			)
+
+			BeforeEach(func() {
+				var err error
+				certDir, err = ioutil.TempDir("", "api-certs")
+				Expect(err).NotTo(HaveOccurred())
+
+				certFile, keyFile = testnet.WriteTLSCertFiles(testnet.MakeSelfSignedTLSCert(), certDir)
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(certDir)
+			})
+
+			It("saves the CA certificate file and client certificate in the config", func() {
+				callApi([]string{"https://example.com", "--ca-cert", certFile, "--client-cert", certFile, "--client-key", keyFile})
+
+				Expect(runCLIErr).NotTo(HaveOccurred())
+				Expect(config.CACertFile()).To(Equal(certFile))
+				Expect(config.ClientCertFile()).To(Equal(certFile))
+				Expect(config.ClientKeyFile()).To(Equal(keyFile))
+			})
+
+			It("saves the absolute paths of the files", func() {
+				wd, err := os.Getwd()
+				Expect(err).NotTo(HaveOccurred())
+				defer os.Chdir(wd)
+				Expect(os.Chdir(certDir)).To(Succeed())
+
+				callApi([]string{"https://example.com", "--ca-cert", filepath.Base(certFile)})
+
+				Expect(runCLIErr).NotTo(HaveOccurred())
+				Expect(filepath.IsAbs(config.CACertFile())).To(BeTrue())
+				Expect(filepath.Base(config.CACertFile())).To(Equal(filepath.Base(certFile)))
+			})
+
+			It("clears the files when they are not given", func() {
+				config.SetCACertFile(certFile)
+				config.SetClientCertificate(certFile, keyFile)
+
+				callApi([]string{"https://example.com"})
+
+				Expect(runCLIErr).NotTo(HaveOccurred())
+				Expect(config.CACertFile()).To(BeEmpty())
+				Expect(config.ClientCertFile()).To(BeEmpty())
+				Expect(config.ClientKeyFile()).To(BeEmpty())
+			})
+
+			It("fails with usage when only one of --client-cert and --client-key is given", func() {
+				callApi([]string{"https://example.com", "--client-cert", certFile})
+
+				Expect(runCLIErr).To(HaveOccurred())
+				Expect(runCLIErr.Error()).To(ContainSubstring("Incorrect Usage"))
+				Expect(endpointRepo.GetCCInfoCallCount()).To(Equal(0))
+			})
+
+			It("fails when a file cannot be loaded", func() {
+				callApi([]string{"https://example.com", "--ca-cert", keyFile})
+
+				Expect(runCLIErr).To(HaveOccurred())
+				Expect(runCLIErr.Error()).To(ContainSubstring("No PEM certificates found"))
+				Expect(endpointRepo.GetCCInfoCallCount()).To(Equal(0))
+				Expect(config.CACertFile()).To(BeEmpty())
+			})
+
+			It("clears the files when the endpoint cannot be reached", func() {
+				endpointRepo.GetCCInfoReturns(nil, "", errors.NewInvalidSSLCert("https://example.com", "it don't work"))
+
+				callApi([]string{"https://example.com", "--ca-cert", certFile})
+
+				Expect(runCLIErr).To(HaveOccurred())
+				Expect(runCLIErr.Error()).To(ContainSubstring("--ca-cert FILE"))
+				Expect(config.CACertFile()).To(BeEmpty())
+			})
+		})
 	})
 })
@@ -1,6 +1,8 @@
 package applicationfakes
 
 import (
+	"sync"
+
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/requirements"
@@ -11,9 +13,13 @@ type FakeAppDisplayer struct {
 	AppToDisplay models.Application
 	OrgName      string
 	SpaceName    string
+
+	mutex sync.Mutex
 }
 
 func (displayer *FakeAppDisplayer) ShowApp(app models.Application, orgName, spaceName string) error {
+	displayer.mutex.Lock()
+	defer displayer.mutex.Unlock()
 	displayer.AppToDisplay = app
 	return nil
 }
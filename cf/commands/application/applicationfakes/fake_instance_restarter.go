@@ -0,0 +1,213 @@
+// This file was generated by counterfeiter
+package applicationfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/commands/application"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/flags"
+)
+
+type FakeInstanceRestarter struct {
+	MetaDataStub        func() commandregistry.CommandMetadata
+	metaDataMutex       sync.RWMutex
+	metaDataArgsForCall []struct{}
+	metaDataReturns     struct {
+		result1 commandregistry.CommandMetadata
+	}
+	SetDependencyStub        func(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command
+	setDependencyMutex       sync.RWMutex
+	setDependencyArgsForCall []struct {
+		deps       commandregistry.Dependency
+		pluginCall bool
+	}
+	setDependencyReturns struct {
+		result1 commandregistry.Command
+	}
+	RequirementsStub        func(requirementsFactory requirements.Factory, context flags.FlagContext) []requirements.Requirement
+	requirementsMutex       sync.RWMutex
+	requirementsArgsForCall []struct {
+		requirementsFactory requirements.Factory
+		context             flags.FlagContext
+	}
+	requirementsReturns struct {
+		result1 []requirements.Requirement
+	}
+	ExecuteStub        func(context flags.FlagContext) error
+	executeMutex       sync.RWMutex
+	executeArgsForCall []struct {
+		context flags.FlagContext
+	}
+	executeReturns struct {
+		result1 error
+	}
+	RestartInstanceStub        func(app models.Application, index int) error
+	restartInstanceMutex       sync.RWMutex
+	restartInstanceArgsForCall []struct {
+		app   models.Application
+		index int
+	}
+	restartInstanceReturns struct {
+		result1 error
+	}
+}
+
+func (fake *FakeInstanceRestarter) MetaData() commandregistry.CommandMetadata {
+	fake.metaDataMutex.Lock()
+	fake.metaDataArgsForCall = append(fake.metaDataArgsForCall, struct{}{})
+	fake.metaDataMutex.Unlock()
+	if fake.MetaDataStub != nil {
+		return fake.MetaDataStub()
+	} else {
+		return fake.metaDataReturns.result1
+	}
+}
+
+func (fake *FakeInstanceRestarter) MetaDataCallCount() int {
+	fake.metaDataMutex.RLock()
+	defer fake.metaDataMutex.RUnlock()
+	return len(fake.metaDataArgsForCall)
+}
+
+func (fake *FakeInstanceRestarter) MetaDataReturns(result1 commandregistry.CommandMetadata) {
+	fake.MetaDataStub = nil
+	fake.metaDataReturns = struct {
+		result1 commandregistry.CommandMetadata
+	}{result1}
+}
+
+func (fake *FakeInstanceRestarter) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	fake.setDependencyMutex.Lock()
+	fake.setDependencyArgsForCall = append(fake.setDependencyArgsForCall, struct {
+		deps       commandregistry.Dependency
+		pluginCall bool
+	}{deps, pluginCall})
+	fake.setDependencyMutex.Unlock()
+	if fake.SetDependencyStub != nil {
+		return fake.SetDependencyStub(deps, pluginCall)
+	} else {
+		return fake.setDependencyReturns.result1
+	}
+}
+
+func (fake *FakeInstanceRestarter) SetDependencyCallCount() int {
+	fake.setDependencyMutex.RLock()
+	defer fake.setDependencyMutex.RUnlock()
+	return len(fake.setDependencyArgsForCall)
+}
+
+func (fake *FakeInstanceRestarter) SetDependencyArgsForCall(i int) (commandregistry.Dependency, bool) {
+	fake.setDependencyMutex.RLock()
+	defer fake.setDependencyMutex.RUnlock()
+	return fake.setDependencyArgsForCall[i].deps, fake.setDependencyArgsForCall[i].pluginCall
+}
+
+func (fake *FakeInstanceRestarter) SetDependencyReturns(result1 commandregistry.Command) {
+	fake.SetDependencyStub = nil
+	fake.setDependencyReturns = struct {
+		result1 commandregistry.Command
+	}{result1}
+}
+
+func (fake *FakeInstanceRestarter) Requirements(requirementsFactory requirements.Factory, context flags.FlagContext) []requirements.Requirement {
+	fake.requirementsMutex.Lock()
+	fake.requirementsArgsForCall = append(fake.requirementsArgsForCall, struct {
+		requirementsFactory requirements.Factory
+		context             flags.FlagContext
+	}{requirementsFactory, context})
+	fake.requirementsMutex.Unlock()
+	if fake.RequirementsStub != nil {
+		return fake.RequirementsStub(requirementsFactory, context)
+	} else {
+		return fake.requirementsReturns.result1
+	}
+}
+
+func (fake *FakeInstanceRestarter) RequirementsCallCount() int {
+	fake.requirementsMutex.RLock()
+	defer fake.requirementsMutex.RUnlock()
+	return len(fake.requirementsArgsForCall)
+}
+
+func (fake *FakeInstanceRestarter) RequirementsArgsForCall(i int) (requirements.Factory, flags.FlagContext) {
+	fake.requirementsMutex.RLock()
+	defer fake.requirementsMutex.RUnlock()
+	return fake.requirementsArgsForCall[i].requirementsFactory, fake.requirementsArgsForCall[i].context
+}
+
+func (fake *FakeInstanceRestarter) RequirementsReturns(result1 []requirements.Requirement) {
+	fake.RequirementsStub = nil
+	fake.requirementsReturns = struct {
+		result1 []requirements.Requirement
+	}{result1}
+}
+
+func (fake *FakeInstanceRestarter) Execute(context flags.FlagContext) error {
+	fake.executeMutex.Lock()
+	fake.executeArgsForCall = append(fake.executeArgsForCall, struct {
+		context flags.FlagContext
+	}{context})
+	fake.executeMutex.Unlock()
+	if fake.ExecuteStub != nil {
+		return fake.ExecuteStub(context)
+	} else {
+		return fake.executeReturns.result1
+	}
+}
+
+func (fake *FakeInstanceRestarter) ExecuteCallCount() int {
+	fake.executeMutex.RLock()
+	defer fake.executeMutex.RUnlock()
+	return len(fake.executeArgsForCall)
+}
+
+func (fake *FakeInstanceRestarter) ExecuteArgsForCall(i int) flags.FlagContext {
+	fake.executeMutex.RLock()
+	defer fake.executeMutex.RUnlock()
+	return fake.executeArgsForCall[i].context
+}
+
+func (fake *FakeInstanceRestarter) ExecuteReturns(result1 error) {
+	fake.ExecuteStub = nil
+	fake.executeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInstanceRestarter) RestartInstance(app models.Application, index int) error {
+	fake.restartInstanceMutex.Lock()
+	fake.restartInstanceArgsForCall = append(fake.restartInstanceArgsForCall, struct {
+		app   models.Application
+		index int
+	}{app, index})
+	fake.restartInstanceMutex.Unlock()
+	if fake.RestartInstanceStub != nil {
+		return fake.RestartInstanceStub(app, index)
+	} else {
+		return fake.restartInstanceReturns.result1
+	}
+}
+
+func (fake *FakeInstanceRestarter) RestartInstanceCallCount() int {
+	fake.restartInstanceMutex.RLock()
+	defer fake.restartInstanceMutex.RUnlock()
+	return len(fake.restartInstanceArgsForCall)
+}
+
+func (fake *FakeInstanceRestarter) RestartInstanceArgsForCall(i int) (models.Application, int) {
+	fake.restartInstanceMutex.RLock()
+	defer fake.restartInstanceMutex.RUnlock()
+	return fake.restartInstanceArgsForCall[i].app, fake.restartInstanceArgsForCall[i].index
+}
+
+func (fake *FakeInstanceRestarter) RestartInstanceReturns(result1 error) {
+	fake.RestartInstanceStub = nil
+	fake.restartInstanceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+var _ application.InstanceRestarter = new(FakeInstanceRestarter)
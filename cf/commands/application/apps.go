@@ -13,6 +13,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/formatters"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/cf/uihelpers"
@@ -39,6 +40,7 @@ func (cmd *ListApps) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			"CF_NAME apps",
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -84,6 +86,18 @@ func (cmd *ListApps) Execute(c flags.FlagContext) error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	if cmd.pluginCall {
+		cmd.populatePluginModel(apps)
+	}
+
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.Application{}
+		for _, application := range apps {
+			records = append(records, presenters.NewApplication(application))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	if len(apps) == 0 {
 		cmd.ui.Say(T("No apps found"))
 		return nil
@@ -124,10 +138,6 @@ func (cmd *ListApps) Execute(c flags.FlagContext) error {
 	}
 
 	table.Print()
-
-	if cmd.pluginCall {
-		cmd.populatePluginModel(apps)
-	}
 	return nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/cf/trace/tracefakes"
 	"github.com/cloudfoundry/cli/plugin/models"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
@@ -234,5 +235,48 @@ var _ = Describe("list-apps command", func() {
 				))
 			})
 		})
+
+		Context("when json output is requested", func() {
+			BeforeEach(func() {
+				ui.Format = terminal.JSONOutput
+			})
+
+			It("prints the apps using the documented schema instead of a table", func() {
+				runCommand()
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{`"name": "Application-1"`},
+					[]string{`"guid": "Application-1-guid"`},
+					[]string{`"state": "started"`},
+					[]string{`"instances": 2`},
+					[]string{`"memory_in_mb": 256`},
+					[]string{`"app1.example.com"`},
+				))
+				Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"requested state"}))
+			})
+
+			It("prints an empty list when there are no apps", func() {
+				appSummaryRepo.GetSummariesInCurrentSpaceApps = []models.Application{}
+
+				runCommand()
+
+				Expect(ui.Outputs).To(ContainElement("[]"))
+				Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"No apps found"}))
+			})
+		})
+
+		Context("when yaml output is requested", func() {
+			It("prints the apps as yaml", func() {
+				ui.Format = terminal.YAMLOutput
+
+				runCommand()
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"- name: Application-1"},
+					[]string{"running_instances: 1"},
+					[]string{"- app2.cfapps.io"},
+				))
+			})
+		})
 	})
 })
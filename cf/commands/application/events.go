@@ -7,6 +7,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -31,6 +32,7 @@ func (cmd *Events) MetaData() commandregistry.CommandMetadata {
 			"CF_NAME events ",
 			T("APP_NAME"),
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -67,14 +69,22 @@ func (cmd *Events) Execute(c flags.FlagContext) error {
 			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
 			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
 
-	table := cmd.ui.Table([]string{T("time"), T("event"), T("actor"), T("description")})
-
 	events, err := cmd.eventsRepo.RecentEvents(app.GUID, 50)
 	if err != nil {
 		return errors.New(T("Failed fetching events.\n{{.APIErr}}",
 			map[string]interface{}{"APIErr": err.Error()}))
 	}
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.Event{}
+		for _, event := range events {
+			records = append(records, presenters.NewEvent(event))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
+	table := cmd.ui.Table([]string{T("time"), T("event"), T("actor"), T("description")})
+
 	for _, event := range events {
 		actor := event.ActorName
 		if actor == "" {
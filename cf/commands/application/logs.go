@@ -1,6 +1,10 @@
 package application
 
 import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/cloudfoundry/cli/cf/api/logs"
@@ -19,6 +23,8 @@ type Logs struct {
 	logsRepo logs.Repository
 	config   coreconfig.Reader
 	appReq   requirements.ApplicationRequirement
+
+	filter logs.Filter
 }
 
 func init() {
@@ -28,14 +34,25 @@ func init() {
 func (cmd *Logs) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["recent"] = &flags.BoolFlag{Name: "recent", Usage: T("Dump recent logs instead of tailing")}
+	fs["source"] = &flags.StringSliceFlag{Name: "source", Usage: T("Only show logs from this source type (e.g. APP, RTR, STG, CELL). This flag can be defined more than once.")}
+	fs["instance"] = &flags.StringSliceFlag{Name: "instance", Usage: T("Only show logs from this instance index. This flag can be defined more than once.")}
+	fs["stream"] = &flags.StringFlag{Name: "stream", Usage: T("Only show logs written to this stream: 'out' or 'err'")}
+	fs["grep"] = &flags.StringFlag{Name: "grep", Usage: T("Only show logs whose message matches this regular expression")}
+	fs["since"] = &flags.StringFlag{Name: "since", Usage: T("With --recent, only show logs written at or after this time (e.g. 2016-06-01T12:00:00Z) or this long ago (e.g. 10m)")}
+	fs["until"] = &flags.StringFlag{Name: "until", Usage: T("With --recent, only show logs written at or before this time (e.g. 2016-06-01T12:00:00Z) or this long ago (e.g. 10m)")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "logs",
-		Description: T("Tail or show recent logs for an app"),
+		Description: T("Tail or show recent logs for an app. With --output json, each log message is printed as one JSON object per line"),
 		Usage: []string{
-			T("CF_NAME logs APP_NAME"),
+			T("CF_NAME logs APP_NAME [--recent] [--source SOURCE_TYPE] [--instance INDEX] [--stream out|err] [--grep REGEX] [--since TIME] [--until TIME]"),
+		},
+		Examples: []string{
+			"CF_NAME logs my-app --source APP --stream err",
+			"CF_NAME --output json logs my-app --recent --since 1h | jq .message",
 		},
-		Flags: fs,
+		Flags:         fs,
+		OutputFormats: true,
 	}
 }
 
@@ -65,7 +82,11 @@ func (cmd *Logs) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 func (cmd *Logs) Execute(c flags.FlagContext) error {
 	app := cmd.appReq.GetApplication()
 
-	var err error
+	err := cmd.parseOptions(c, time.Now())
+	if err != nil {
+		return err
+	}
+
 	if c.Bool("recent") {
 		err = cmd.recentLogsFor(app)
 	} else {
@@ -77,13 +98,104 @@ func (cmd *Logs) Execute(c flags.FlagContext) error {
 	return nil
 }
 
+// parseOptions reads the filtering and output flags. now is the time
+// durations given to --since and --until count back from.
+func (cmd *Logs) parseOptions(c flags.FlagContext, now time.Time) error {
+	cmd.filter = logs.Filter{
+		SourceTypes: c.StringSlice("source"),
+		Instances:   c.StringSlice("instance"),
+	}
+
+	switch stream := strings.ToLower(c.String("stream")); stream {
+	case "", "out", "err":
+		cmd.filter.Stream = stream
+	default:
+		return errors.New(T("Invalid stream '{{.Stream}}'. Valid streams are: out, err",
+			map[string]interface{}{"Stream": c.String("stream")}))
+	}
+
+	if c.IsSet("grep") {
+		pattern, err := regexp.Compile(c.String("grep"))
+		if err != nil {
+			return errors.New(T("Invalid regular expression for --grep: {{.Error}}",
+				map[string]interface{}{"Error": err.Error()}))
+		}
+		cmd.filter.Pattern = pattern
+	}
+
+	if (c.IsSet("since") || c.IsSet("until")) && !c.Bool("recent") {
+		return errors.New(T("--since and --until can only be used with --recent"))
+	}
+
+	var err error
+	cmd.filter.Since, err = parseLogTime("since", c.String("since"), now)
+	if err != nil {
+		return err
+	}
+	cmd.filter.Until, err = parseLogTime("until", c.String("until"), now)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// parseLogTime reads a time given either in RFC 3339 or as a duration
+// before now. The empty string is the zero time.
+func parseLogTime(flagName string, value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, errors.New(T("Invalid time '{{.Value}}' for --{{.Flag}}. Use a time such as 2016-06-01T12:00:00Z or a duration such as 10m",
+		map[string]interface{}{"Value": value, "Flag": flagName}))
+}
+
+// printLog prints msg unless the filter drops it. Structured output is
+// written straight to stdout, one JSON object per line or one YAML document
+// per message, so that it can be read while logs are still being tailed.
+func (cmd *Logs) printLog(msg logs.Loggable) error {
+	if !cmd.filter.Matches(msg) {
+		return nil
+	}
+
+	switch cmd.ui.OutputFormat() {
+	case terminal.JSONOutput:
+		encoded, err := json.Marshal(logs.NewEntry(msg))
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.ui.Writer(), "%s\n", encoded)
+		return err
+	case terminal.YAMLOutput:
+		_, err := fmt.Fprintln(cmd.ui.Writer(), "---")
+		if err != nil {
+			return err
+		}
+		return terminal.WriteStructured(cmd.ui.Writer(), terminal.YAMLOutput, logs.NewEntry(msg))
+	}
+
+	cmd.ui.Say("%s", msg.ToLog(time.Local))
+	return nil
+}
+
 func (cmd *Logs) recentLogsFor(app models.Application) error {
-	cmd.ui.Say(T("Connected, dumping recent logs for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
-		map[string]interface{}{
-			"AppName":   terminal.EntityNameColor(app.Name),
-			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+	if !cmd.ui.OutputFormat().IsStructured() {
+		cmd.ui.Say(T("Connected, dumping recent logs for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(app.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+	}
 
 	messages, err := cmd.logsRepo.RecentLogsFor(app.GUID)
 	if err != nil {
@@ -91,13 +203,20 @@ func (cmd *Logs) recentLogsFor(app models.Application) error {
 	}
 
 	for _, msg := range messages {
-		cmd.ui.Say("%s", msg.ToLog(time.Local))
+		err = cmd.printLog(msg)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 func (cmd *Logs) tailLogsFor(app models.Application) error {
 	onConnect := func() {
+		if cmd.ui.OutputFormat().IsStructured() {
+			return
+		}
+
 		cmd.ui.Say(T("Connected, tailing logs for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
 			map[string]interface{}{
 				"AppName":   terminal.EntityNameColor(app.Name),
@@ -117,7 +236,10 @@ func (cmd *Logs) tailLogsFor(app models.Application) error {
 			if !ok {
 				return nil
 			}
-			cmd.ui.Say("%s", msg.ToLog(time.Local))
+			err := cmd.printLog(msg)
+			if err != nil {
+				return err
+			}
 		case err := <-e:
 			return cmd.handleError(err)
 		}
@@ -8,8 +8,10 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	io_helpers "github.com/cloudfoundry/cli/testhelpers/io"
 	testlogs "github.com/cloudfoundry/cli/testhelpers/logs"
 	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
 	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
@@ -143,6 +145,138 @@ var _ = Describe("logs command", func() {
 			))
 		})
 
+		Describe("filtering", func() {
+			BeforeEach(func() {
+				date := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+				logsRepo.RecentLogsForReturns([]logs.Loggable{
+					testlogs.NewLogMessage("app out 0", app.GUID, "APP", "0", logmessage.LogMessage_OUT, date),
+					testlogs.NewLogMessage("app err 1", app.GUID, "APP", "1", logmessage.LogMessage_ERR, date.Add(time.Minute)),
+					testlogs.NewLogMessage("router out 0", app.GUID, "RTR", "0", logmessage.LogMessage_OUT, date.Add(2*time.Minute)),
+				}, nil)
+			})
+
+			It("only shows the requested sources", func() {
+				runCommand("--recent", "--source", "rtr", "my-app")
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"router out 0"}))
+				Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"app out 0"}))
+			})
+
+			It("only shows the requested instances and stream", func() {
+				runCommand("--recent", "--instance", "1", "--stream", "err", "my-app")
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"app err 1"}))
+				Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"out 0"}))
+			})
+
+			It("only shows the messages matching --grep", func() {
+				runCommand("--recent", "--grep", "^app", "my-app")
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"app out 0"}, []string{"app err 1"}))
+				Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"router out 0"}))
+			})
+
+			It("only shows the recent messages inside the --since and --until window", func() {
+				runCommand("--recent", "--since", "2016-06-01T12:00:30Z", "--until", "2016-06-01T12:01:30Z", "my-app")
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"app err 1"}))
+				Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"out 0"}))
+			})
+
+			It("accepts a duration for --since", func() {
+				runCommand("--recent", "--since", "1h", "my-app")
+
+				Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"app out 0"}))
+			})
+
+			It("filters the tailed logs", func() {
+				runCommand("--source", "STG", "my-app")
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"Connected, tailing logs for app"}))
+				Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"Log Line 1"}))
+			})
+
+			It("requires --recent for --since and --until", func() {
+				runCommand("--since", "1h", "my-app")
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"--since and --until can only be used with --recent"}))
+				Expect(logsRepo.TailLogsForCallCount()).To(Equal(0))
+			})
+
+			It("fails with an invalid time", func() {
+				runCommand("--recent", "--until", "yesterday", "my-app")
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"Invalid time 'yesterday' for --until"}))
+			})
+
+			It("fails with an invalid stream", func() {
+				runCommand("--recent", "--stream", "both", "my-app")
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"Invalid stream 'both'"}))
+			})
+
+			It("fails with an invalid regular expression", func() {
+				runCommand("--recent", "--grep", "(", "my-app")
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"Invalid regular expression for --grep"}))
+			})
+		})
+
+		Describe("with --output", func() {
+			BeforeEach(func() {
+				logsRepo.RecentLogsForReturns([]logs.Loggable{
+					testlogs.NewLogMessage("hello\n", app.GUID, "APP", "2", logmessage.LogMessage_ERR, time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)),
+					testlogs.NewLogMessage("world", app.GUID, "RTR", "0", logmessage.LogMessage_OUT, time.Date(2016, 6, 1, 12, 0, 1, 0, time.UTC)),
+				}, nil)
+			})
+
+			It("supports the global --output option", func() {
+				Expect(commandregistry.Commands.FindCommand("logs").MetaData().OutputFormats).To(BeTrue())
+			})
+
+			It("prints one JSON object per line for each log message and nothing else", func() {
+				ui.Format = terminal.JSONOutput
+
+				output := io_helpers.CaptureOutput(func() {
+					runCommand("--recent", "my-app")
+				})
+
+				Expect(output).To(Equal([]string{
+					`{"timestamp":"2016-06-01T12:00:00Z","source":"APP","instance":"2","stream":"err","message":"hello"}`,
+					`{"timestamp":"2016-06-01T12:00:01Z","source":"RTR","instance":"0","stream":"out","message":"world"}`,
+					"",
+				}))
+				Expect(ui.Outputs).To(BeEmpty())
+			})
+
+			It("prints one YAML document for each log message", func() {
+				ui.Format = terminal.YAMLOutput
+
+				output := io_helpers.CaptureOutput(func() {
+					runCommand("--recent", "my-app")
+				})
+
+				Expect(output).To(ContainSubstrings(
+					[]string{"---"},
+					[]string{"source: APP"},
+					[]string{"message: hello"},
+					[]string{"---"},
+					[]string{"message: world"},
+				))
+			})
+
+			It("does not print the connection message when tailing", func() {
+				ui.Format = terminal.JSONOutput
+
+				output := io_helpers.CaptureOutput(func() {
+					runCommand("my-app")
+				})
+
+				Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"Connected"}))
+				Expect(output).To(ContainSubstrings([]string{`"message":"Log Line 1"`}))
+			})
+		})
+
 		Context("when the loggregator server has an invalid cert", func() {
 			Context("when the skip-ssl-validation flag is not set", func() {
 				It("fails and informs the user about the skip-ssl-validation flag", func() {
@@ -2,11 +2,14 @@ package application
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/flags"
@@ -14,8 +17,10 @@ import (
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/actors"
 	"github.com/cloudfoundry/cli/cf/api"
+	"github.com/cloudfoundry/cli/cf/api/applicationbits"
 	"github.com/cloudfoundry/cli/cf/api/applications"
 	"github.com/cloudfoundry/cli/cf/api/authentication"
+	"github.com/cloudfoundry/cli/cf/api/resources"
 	"github.com/cloudfoundry/cli/cf/api/stacks"
 	"github.com/cloudfoundry/cli/cf/appfiles"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
@@ -31,22 +36,25 @@ import (
 )
 
 type Push struct {
-	ui            terminal.UI
-	config        coreconfig.Reader
-	manifestRepo  manifest.Repository
-	appStarter    Starter
-	appStopper    Stopper
-	serviceBinder service.Binder
-	appRepo       applications.Repository
-	domainRepo    api.DomainRepository
-	routeRepo     api.RouteRepository
-	serviceRepo   api.ServiceRepository
-	stackRepo     stacks.StackRepository
-	authRepo      authentication.Repository
-	wordGenerator generator.WordGenerator
-	actor         actors.PushActor
-	zipper        appfiles.Zipper
-	appfiles      appfiles.AppFiles
+	ui             terminal.UI
+	config         coreconfig.Reader
+	manifestRepo   manifest.Repository
+	appStarter     Starter
+	appStopper     Stopper
+	serviceBinder  service.Binder
+	appRepo        applications.Repository
+	appSummaryRepo api.AppSummaryRepository
+	domainRepo     api.DomainRepository
+	routeRepo      api.RouteRepository
+	serviceRepo    api.ServiceRepository
+	stackRepo      stacks.StackRepository
+	authRepo       authentication.Repository
+	wordGenerator  generator.WordGenerator
+	actor          actors.PushActor
+	zipper         appfiles.Zipper
+	appfiles       appfiles.AppFiles
+
+	showIgnored bool
 }
 
 func init() {
@@ -66,6 +74,9 @@ func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Path to app directory or to a zip file of the contents of the app directory")}
 	fs["s"] = &flags.StringFlag{ShortName: "s", Usage: T("Stack to use (a stack is a pre-built file system, including an operating system, that can run apps)")}
 	fs["t"] = &flags.StringFlag{ShortName: "t", Usage: T("Maximum time (in seconds) for CLI to wait for application start, other server side timeouts may apply")}
+	fs["parallel"] = &flags.IntFlag{Name: "parallel", Usage: T("Number of apps from a multi-app manifest to push at the same time (Default: 1). Apps wait for the apps listed in their 'depends-on', and staging logs are not shown")}
+	fs["dry-run"] = &flags.BoolFlag{Name: "dry-run", Usage: T("Show the changes the push would make to each app without making them")}
+	fs["show-ignored"] = &flags.BoolFlag{Name: "show-ignored", Usage: T("List the files .cfignore excludes from the upload and the rule that excludes each")}
 	fs["docker-image"] = &flags.StringFlag{Name: "docker-image", ShortName: "o", Usage: T("Docker-image to be used (e.g. user/docker-image-name)")}
 	fs["health-check-type"] = &flags.StringFlag{Name: "health-check-type", ShortName: "u", Usage: T("Application health check type (e.g. 'port' or 'none')")}
 	fs["no-hostname"] = &flags.BoolFlag{Name: "no-hostname", Usage: T("Map the root domain to this app")}
@@ -74,6 +85,9 @@ func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 	fs["no-start"] = &flags.BoolFlag{Name: "no-start", Usage: T("Do not start an app after pushing")}
 	fs["random-route"] = &flags.BoolFlag{Name: "random-route", Usage: T("Create a random route for this app")}
 	fs["route-path"] = &flags.StringFlag{Name: "route-path", Usage: T("Path for the route")}
+	fs["var"] = &flags.StringSliceFlag{Name: "var", Usage: T("Variable substitution for the manifest as NAME=VALUE. This flag can be defined more than once.")}
+	fs["vars-file"] = &flags.StringSliceFlag{Name: "vars-file", Usage: T("Path to a YAML file of variables to substitute in the manifest. This flag can be defined more than once.")}
+	fs["strategy"] = &flags.StringFlag{Name: "strategy", Usage: T("Deployment strategy for an existing app. 'blue-green' starts the new version alongside the old one and moves the routes over once it is running")}
 	// Hidden:true to hide app-ports for release #117189491
 	fs["app-ports"] = &flags.StringFlag{Name: "app-ports", Usage: T("Comma delimited list of ports the application may listen on"), Hidden: true}
 
@@ -101,14 +115,21 @@ func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 			fmt.Sprintf("[-u %s] ", T("HEALTH_CHECK_TYPE")),
 			fmt.Sprintf("[--route-path %s] ", T("ROUTE_PATH")),
 			"\n   ",
+			fmt.Sprintf("[--strategy %s] ", T("STRATEGY")),
+			fmt.Sprintf("[--vars-file %s] ", T("VARS_FILE_PATH")),
+			fmt.Sprintf("[--var %s]", T("NAME=VALUE")),
+			"\n   ",
 			// Commented to hide app-ports for release #117189491
 			// fmt.Sprintf("[--app-ports %s] ", T("APP_PORTS")),
-			"[--no-hostname] [--no-manifest] [--no-route] [--no-start]\n",
+			"[--no-hostname] [--no-manifest] [--no-route] [--no-start] [--dry-run] [--show-ignored]\n",
 			"\n   ",
 			T("Push multiple apps with a manifest"),
 			":\n   ",
 			"CF_NAME push ",
 			fmt.Sprintf("[-f %s] ", T("MANIFEST_PATH")),
+			fmt.Sprintf("[--vars-file %s] ", T("VARS_FILE_PATH")),
+			fmt.Sprintf("[--var %s] ", T("NAME=VALUE")),
+			fmt.Sprintf("[--parallel %s]", T("NUM")),
 			"\n",
 		},
 		Flags: fs,
@@ -163,6 +184,7 @@ func (cmd *Push) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 	cmd.serviceBinder = appCommand.(service.Binder)
 
 	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.appSummaryRepo = deps.RepoLocator.GetAppSummaryRepository()
 	cmd.domainRepo = deps.RepoLocator.GetDomainRepository()
 	cmd.routeRepo = deps.RepoLocator.GetRouteRepository()
 	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
@@ -192,130 +214,390 @@ func (cmd *Push) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	appSet, err = orderByDependencies(appSet)
+	if err != nil {
+		return err
+	}
+
+	blueGreen, err := cmd.blueGreenRequested(c)
+	if err != nil {
+		return err
+	}
+
+	parallel := 1
+	if c.IsSet("parallel") {
+		parallel = c.Int("parallel")
+		if parallel < 1 {
+			return errors.New(T("Invalid parallel count: {{.Parallel}}\nParallel count must be a positive integer",
+				map[string]interface{}{"Parallel": parallel}))
+		}
+	}
+
 	_, err = cmd.authRepo.RefreshAuthToken()
 	if err != nil {
 		return err
 	}
 
-	routeActor := actors.NewRouteActor(cmd.ui, cmd.routeRepo)
+	cmd.showIgnored = c.Bool("show-ignored")
+
+	if c.Bool("dry-run") {
+		return cmd.dryRun(appSet)
+	}
+
+	if parallel > 1 && len(appSet) > 1 {
+		return cmd.pushInParallel(appSet, parallel, blueGreen, c)
+	}
 
 	for _, appParams := range appSet {
-		if appParams.Name == nil {
-			return errors.New(T("Error: No name found for app"))
+		err = cmd.pushApp(appParams, blueGreen, c)
+		if err != nil {
+			return err
 		}
+	}
+	return nil
+}
+
+func (cmd *Push) pushApp(appParams models.AppParams, blueGreen bool, c flags.FlagContext) error {
+	if appParams.Name == nil {
+		return errors.New(T("Error: No name found for app"))
+	}
+
+	routeActor := actors.NewRouteActor(cmd.ui, cmd.routeRepo)
 
-		err := cmd.fetchStackGUID(&appParams)
+	err := cmd.fetchStackGUID(&appParams)
+	if err != nil {
+		return err
+	}
+
+	if c.IsSet("docker-image") {
+		diego := true
+		appParams.Diego = &diego
+	}
+
+	var app models.Application
+	existingApp, err := cmd.appRepo.Read(*appParams.Name)
+	switch err.(type) {
+	case nil:
+		if blueGreen {
+			return cmd.blueGreenPush(existingApp, appParams, c)
+		}
+
+		cmd.ui.Say(T("Updating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(existingApp.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+		mergeEnvironmentVars(existingApp, appParams)
+
+		app, err = cmd.appRepo.Update(existingApp.GUID, appParams)
 		if err != nil {
 			return err
 		}
+	case *errors.ModelNotFoundError:
+		spaceGUID := cmd.config.SpaceFields().GUID
+		appParams.SpaceGUID = &spaceGUID
 
-		if c.IsSet("docker-image") {
-			diego := true
-			appParams.Diego = &diego
+		cmd.ui.Say(T("Creating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(*appParams.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+		app, err = cmd.appRepo.Create(appParams)
+		if err != nil {
+			return err
 		}
+	default:
+		return err
+	}
 
-		var app models.Application
-		existingApp, err := cmd.appRepo.Read(*appParams.Name)
-		switch err.(type) {
-		case nil:
-			cmd.ui.Say(T("Updating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	err = cmd.updateRoutes(routeActor, app, appParams)
+	if err != nil {
+		return err
+	}
+
+	if c.String("docker-image") == "" {
+		err = cmd.processPath(*appParams.Path, app)
+		if err != nil {
+			return err
+		}
+	}
+
+	if appParams.ServicesToBind != nil {
+		err = cmd.bindAppToServices(*appParams.ServicesToBind, app)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = cmd.restart(app, appParams, c)
+	if err != nil {
+		return errors.New(
+			T("Error restarting application: {{.Error}}",
 				map[string]interface{}{
-					"AppName":   terminal.EntityNameColor(existingApp.Name),
-					"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-					"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-					"Username":  terminal.EntityNameColor(cmd.config.Username())}))
-
-			if appParams.EnvironmentVars != nil {
-				for key, val := range existingApp.EnvironmentVars {
-					if _, ok := (*appParams.EnvironmentVars)[key]; !ok {
-						(*appParams.EnvironmentVars)[key] = val
+					"Error": err.Error(),
+				}),
+		)
+	}
+
+	return nil
+}
+
+// orderByDependencies sorts appSet so that every app comes after the apps
+// listed in its depends-on, keeping the manifest order otherwise. Apps that
+// are not part of this push are ignored as dependencies.
+func orderByDependencies(appSet []models.AppParams) ([]models.AppParams, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	index := map[string]int{}
+	for i, appParams := range appSet {
+		index[*appParams.Name] = i
+	}
+
+	state := make([]int, len(appSet))
+	ordered := make([]models.AppParams, 0, len(appSet))
+
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		name := *appSet[i].Name
+		path = append(path, name)
+
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return errors.New(T("Error: the depends-on entries in the manifest form a cycle: {{.Apps}}",
+				map[string]interface{}{"Apps": strings.Join(path, " -> ")}))
+		}
+
+		state[i] = visiting
+		if appSet[i].DependsOn != nil {
+			for _, dependency := range *appSet[i].DependsOn {
+				if j, ok := index[dependency]; ok {
+					err := visit(j, path)
+					if err != nil {
+						return err
 					}
 				}
 			}
+		}
+		state[i] = visited
 
-			app, err = cmd.appRepo.Update(existingApp.GUID, appParams)
-			if err != nil {
-				return err
-			}
-		case *errors.ModelNotFoundError:
-			spaceGUID := cmd.config.SpaceFields().GUID
-			appParams.SpaceGUID = &spaceGUID
+		ordered = append(ordered, appSet[i])
+		return nil
+	}
 
-			cmd.ui.Say(T("Creating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
-				map[string]interface{}{
-					"AppName":   terminal.EntityNameColor(*appParams.Name),
-					"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-					"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-					"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+	for i := range appSet {
+		err := visit(i, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-			app, err = cmd.appRepo.Create(appParams)
-			if err != nil {
-				return err
+	return ordered, nil
+}
+
+// pushInParallel pushes up to parallel apps at once. An app is not started
+// on until the apps it depends on have been pushed, and is skipped if one of
+// them fails. Each app's output is prefixed with its name and a summary is
+// printed once every app is done.
+func (cmd *Push) pushInParallel(appSet []models.AppParams, parallel int, blueGreen bool, c flags.FlagContext) error {
+	index := map[string]int{}
+	done := make([]chan struct{}, len(appSet))
+	for i, appParams := range appSet {
+		index[*appParams.Name] = i
+		done[i] = make(chan struct{})
+	}
+
+	results := make([]error, len(appSet))
+	slots := make(chan struct{}, parallel)
+	outputLock := new(sync.Mutex)
+
+	wg := new(sync.WaitGroup)
+	for i, appParams := range appSet {
+		wg.Add(1)
+
+		go func(i int, appParams models.AppParams) {
+			defer wg.Done()
+			defer close(done[i])
+
+			if appParams.DependsOn != nil {
+				for _, dependency := range *appParams.DependsOn {
+					j, ok := index[dependency]
+					if !ok {
+						continue
+					}
+
+					<-done[j]
+					if results[j] != nil {
+						results[i] = errors.New(T("Skipped because {{.AppName}} failed",
+							map[string]interface{}{"AppName": dependency}))
+						return
+					}
+				}
 			}
-		default:
-			return err
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			worker := cmd.parallelWorker(*appParams.Name, outputLock)
+			results[i] = worker.pushAppInParallel(appParams, blueGreen, c)
+		}(i, appParams)
+	}
+	wg.Wait()
+
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Push summary:"))
+
+	table := cmd.ui.Table([]string{T("app"), T("result")})
+	failures := 0
+	for i, appParams := range appSet {
+		if results[i] == nil {
+			table.Add(*appParams.Name, terminal.SuccessColor(T("pushed")))
+			continue
 		}
 
-		cmd.ui.Ok()
-		cmd.ui.Say("")
+		failures++
+		table.Add(*appParams.Name, terminal.FailureColor(strings.SplitN(results[i].Error(), "\n", 2)[0]))
+	}
+	table.Print()
 
-		err = cmd.updateRoutes(routeActor, app, appParams)
-		if err != nil {
-			return err
+	if failures > 0 {
+		return errors.New(T("{{.Failures}} of {{.Total}} apps failed to push",
+			map[string]interface{}{"Failures": failures, "Total": len(appSet)}))
+	}
+
+	return nil
+}
+
+// parallelWorker returns a copy of cmd that prefixes its output with
+// appName. The start and stop commands are copied too, as the registered
+// ones all print to the same UI, and the start command tails the staging
+// logs through a logs repository of its own.
+func (cmd *Push) parallelWorker(appName string, outputLock *sync.Mutex) *Push {
+	ui := terminal.NewPrefixedUI(cmd.ui, fmt.Sprintf("[%s] ", appName), outputLock)
+
+	worker := *cmd
+	worker.ui = ui
+
+	if starter, ok := cmd.appStarter.(*Start); ok {
+		starterCopy := *starter
+		starterCopy.ui = ui
+		if starter.newLogRepo != nil {
+			starterCopy.logRepo = starter.newLogRepo()
 		}
 
-		if c.String("docker-image") == "" {
-			err = cmd.actor.ProcessPath(*appParams.Path, cmd.processPathCallback(*appParams.Path, app))
-			if err != nil {
-				return errors.New(
-					T("Error processing app files: {{.Error}}",
-						map[string]interface{}{
-							"Error": err.Error(),
-						}),
-				)
-			}
+		if displayer, ok := starter.appDisplayer.(*ShowApp); ok {
+			displayerCopy := *displayer
+			displayerCopy.ui = ui
+			starterCopy.appDisplayer = &displayerCopy
 		}
 
-		if appParams.ServicesToBind != nil {
-			err := cmd.bindAppToServices(*appParams.ServicesToBind, app)
-			if err != nil {
-				return err
+		worker.appStarter = &starterCopy
+	}
+
+	if stopper, ok := cmd.appStopper.(*Stop); ok {
+		stopperCopy := *stopper
+		stopperCopy.ui = ui
+		worker.appStopper = &stopperCopy
+	}
+
+	return &worker
+}
+
+// pushAppInParallel pushes a single app and reports a failure in the app's
+// own output, so that it is not lost among the other apps' output.
+func (cmd *Push) pushAppInParallel(appParams models.AppParams, blueGreen bool, c flags.FlagContext) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != terminal.QuietPanic {
+				panic(r)
 			}
+			// ui.Failed has already printed the reason.
+			err = errors.New(T("Push failed"))
 		}
+	}()
 
-		err = cmd.restart(app, appParams, c)
-		if err != nil {
-			return errors.New(
-				T("Error restarting application: {{.Error}}",
-					map[string]interface{}{
-						"Error": err.Error(),
-					}),
-			)
+	err = cmd.pushApp(appParams, blueGreen, c)
+	if err != nil {
+		cmd.ui.Say(terminal.FailureColor(T("FAILED")))
+		cmd.ui.Say(err.Error())
+	}
+
+	return err
+}
+
+// mergeEnvironmentVars keeps the variables already set on existingApp that
+// appParams does not mention, since pushing never removes variables.
+func mergeEnvironmentVars(existingApp models.Application, appParams models.AppParams) {
+	if appParams.EnvironmentVars == nil {
+		return
+	}
+
+	for key, val := range existingApp.EnvironmentVars {
+		if _, ok := (*appParams.EnvironmentVars)[key]; !ok {
+			(*appParams.EnvironmentVars)[key] = val
 		}
 	}
-	return nil
 }
 
-func (cmd *Push) processPathCallback(path string, app models.Application) func(string) {
+// processPath uploads the app files at path, which is either a directory or
+// a zip file.
+func (cmd *Push) processPath(path string, app models.Application) error {
+	var uploadErr error
+	err := cmd.actor.ProcessPath(path, cmd.processPathCallback(path, app, &uploadErr))
+	if err != nil {
+		return errors.New(
+			T("Error processing app files: {{.Error}}",
+				map[string]interface{}{
+					"Error": err.Error(),
+				}),
+		)
+	}
+
+	return uploadErr
+}
+
+func (cmd *Push) processPathCallback(path string, app models.Application, uploadErr *error) func(string) {
 	return func(appDir string) {
 		localFiles, err := cmd.appfiles.AppFilesInDir(appDir)
 		if err != nil {
-			cmd.ui.Failed(
+			*uploadErr = errors.New(
 				T("Error processing app files in '{{.Path}}': {{.Error}}",
 					map[string]interface{}{
 						"Path":  path,
 						"Error": err.Error(),
 					}),
 			)
+			return
+		}
+
+		if cmd.showIgnored {
+			err = cmd.printIgnoredFiles(appDir)
+			if err != nil {
+				*uploadErr = err
+				return
+			}
 		}
 
 		if len(localFiles) == 0 {
-			cmd.ui.Failed(
+			*uploadErr = errors.New(
 				T("No app files found in '{{.Path}}'",
 					map[string]interface{}{
 						"Path": path,
 					}),
 			)
+			return
 		}
 
 		cmd.ui.Say(T("Uploading {{.AppName}}...",
@@ -323,14 +605,39 @@ func (cmd *Push) processPathCallback(path string, app models.Application) func(s
 
 		err = cmd.uploadApp(app.GUID, appDir, path, localFiles)
 		if err != nil {
-			cmd.ui.Failed(fmt.Sprintf(T("Error uploading application.\n{{.APIErr}}",
-				map[string]interface{}{"APIErr": err.Error()})))
+			*uploadErr = errors.New(T("Error uploading application.\n{{.APIErr}}",
+				map[string]interface{}{"APIErr": err.Error()}))
 			return
 		}
 		cmd.ui.Ok()
 	}
 }
 
+// printIgnoredFiles lists the files in appDir that are not uploaded and the
+// .cfignore rule that excludes each of them.
+func (cmd *Push) printIgnoredFiles(appDir string) error {
+	ignoredFiles, err := cmd.appfiles.IgnoredFilesInDir(appDir)
+	if err != nil {
+		return errors.New(T("Error listing ignored app files: {{.Error}}",
+			map[string]interface{}{"Error": err.Error()}))
+	}
+
+	if len(ignoredFiles) == 0 {
+		cmd.ui.Say(T("No app files are ignored"))
+		return nil
+	}
+
+	cmd.ui.Say(T("Files not uploaded:"))
+	table := cmd.ui.Table([]string{T("file"), T("rule")})
+	for _, ignoredFile := range ignoredFiles {
+		table.Add(ignoredFile.Path, ignoredFile.Rule)
+	}
+	table.Print()
+	cmd.ui.Say("")
+
+	return nil
+}
+
 func (cmd *Push) updateRoutes(routeActor actors.RouteActor, app models.Application, appParams models.AppParams) error {
 	defaultRouteAcceptable := len(app.Routes) == 0
 	routeDefined := appParams.Domains != nil || !appParams.IsHostEmpty() || appParams.NoHostname
@@ -557,6 +864,528 @@ func (cmd *Push) restart(app models.Application, params models.AppParams, c flag
 	return nil
 }
 
+// blueGreenTempAppSuffix names the app that receives the new bits during a
+// blue-green push until it takes over the live app's name.
+const blueGreenTempAppSuffix = "-new"
+
+func (cmd *Push) blueGreenRequested(c flags.FlagContext) (bool, error) {
+	switch c.String("strategy") {
+	case "":
+		return false, nil
+	case "blue-green":
+	default:
+		return false, errors.New(T("Invalid strategy '{{.Strategy}}'. The only supported strategy is 'blue-green'",
+			map[string]interface{}{"Strategy": c.String("strategy")}))
+	}
+
+	if c.Bool("no-start") {
+		return false, errors.New(T("Incorrect Usage: '--strategy blue-green' cannot be used with '--no-start'"))
+	}
+
+	return true, nil
+}
+
+// blueGreenPush replaces liveApp without downtime. The new bits are pushed to
+// a temporary app which is started next to liveApp and only takes over its
+// routes once an instance is running. liveApp is then deleted and the
+// temporary app renamed. Failures before liveApp is deleted are rolled back,
+// leaving liveApp serving its routes.
+func (cmd *Push) blueGreenPush(liveApp models.Application, appParams models.AppParams, c flags.FlagContext) error {
+	routes := &routeCreationRecorder{RouteRepository: cmd.routeRepo}
+	routeActor := actors.NewRouteActor(cmd.ui, routes)
+
+	orgName := cmd.config.OrganizationFields().Name
+	spaceName := cmd.config.SpaceFields().Name
+	tempAppName := liveApp.Name + blueGreenTempAppSuffix
+
+	_, err := cmd.appRepo.Read(tempAppName)
+	switch err.(type) {
+	case nil:
+		return errors.New(T("App {{.TempAppName}} already exists, possibly left over from an earlier blue-green push. Delete it and try again.",
+			map[string]interface{}{"TempAppName": tempAppName}))
+	case *errors.ModelNotFoundError:
+	default:
+		return err
+	}
+
+	services, err := cmd.blueGreenServices(liveApp, appParams)
+	if err != nil {
+		return err
+	}
+
+	mergeEnvironmentVars(liveApp, appParams)
+
+	spaceGUID := cmd.config.SpaceFields().GUID
+	params := blueGreenAppParams(liveApp)
+	params.Merge(&appParams)
+	if appParams.Diego != nil {
+		params.Diego = appParams.Diego
+	}
+	params.Name = &tempAppName
+	params.SpaceGUID = &spaceGUID
+	params.ServicesToBind = &services
+
+	cmd.ui.Say(T("Creating app {{.TempAppName}} to replace {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"TempAppName": terminal.EntityNameColor(tempAppName),
+			"AppName":     terminal.EntityNameColor(liveApp.Name),
+			"OrgName":     terminal.EntityNameColor(orgName),
+			"SpaceName":   terminal.EntityNameColor(spaceName),
+			"Username":    terminal.EntityNameColor(cmd.config.Username())}))
+
+	newApp, err := cmd.appRepo.Create(params)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	err = cmd.startBlueGreenApp(newApp, params, c)
+	if err != nil {
+		return cmd.rollBackBlueGreenPush(liveApp, newApp, routes.created, err)
+	}
+
+	cmd.ui.Say(T("Moving routes from {{.AppName}} to {{.TempAppName}}...",
+		map[string]interface{}{
+			"AppName":     terminal.EntityNameColor(liveApp.Name),
+			"TempAppName": terminal.EntityNameColor(tempAppName),
+		}))
+
+	for _, route := range liveApp.Routes {
+		err = cmd.routeRepo.Bind(route.GUID, newApp.GUID)
+		if err != nil {
+			return cmd.rollBackBlueGreenPush(liveApp, newApp, routes.created, err)
+		}
+		newApp.Routes = append(newApp.Routes, route)
+	}
+
+	// Routes derived from the app name must use the live name, not the
+	// temporary one.
+	routedApp := newApp
+	routedApp.Name = liveApp.Name
+	err = cmd.updateRoutes(routeActor, routedApp, params)
+	if err != nil {
+		return cmd.rollBackBlueGreenPush(liveApp, newApp, routes.created, err)
+	}
+
+	for _, route := range liveApp.Routes {
+		err = cmd.routeRepo.Unbind(route.GUID, liveApp.GUID)
+		if err != nil {
+			return cmd.rollBackBlueGreenPush(liveApp, newApp, routes.created, err)
+		}
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	cmd.ui.Say(T("Deleting app {{.AppName}}...",
+		map[string]interface{}{"AppName": terminal.EntityNameColor(liveApp.Name)}))
+
+	err = cmd.appRepo.Delete(liveApp.GUID)
+	if err != nil {
+		return errors.New(T("{{.TempAppName}} is serving the routes of {{.AppName}}, but {{.AppName}} could not be deleted: {{.Error}}",
+			map[string]interface{}{"TempAppName": tempAppName, "AppName": liveApp.Name, "Error": err.Error()}))
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	cmd.ui.Say(T("Renaming app {{.TempAppName}} to {{.AppName}}...",
+		map[string]interface{}{
+			"TempAppName": terminal.EntityNameColor(tempAppName),
+			"AppName":     terminal.EntityNameColor(liveApp.Name),
+		}))
+
+	_, err = cmd.appRepo.Update(newApp.GUID, models.AppParams{Name: &liveApp.Name})
+	if err != nil {
+		return errors.New(T("{{.TempAppName}} replaced {{.AppName}} but could not be renamed: {{.Error}}",
+			map[string]interface{}{"TempAppName": tempAppName, "AppName": liveApp.Name, "Error": err.Error()}))
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	return nil
+}
+
+// blueGreenAppParams carries liveApp's settings over to its replacement so
+// that pushing without flags or a manifest keeps the app configured as it is.
+func blueGreenAppParams(liveApp models.Application) models.AppParams {
+	params := models.AppParams{
+		EnvironmentVars: &liveApp.EnvironmentVars,
+		Diego:           &liveApp.Diego,
+		EnableSSH:       &liveApp.EnableSSH,
+	}
+
+	if liveApp.Buildpack != "" {
+		params.BuildpackURL = &liveApp.Buildpack
+	}
+	if liveApp.Command != "" {
+		params.Command = &liveApp.Command
+	}
+	if liveApp.DockerImage != "" {
+		params.DockerImage = &liveApp.DockerImage
+	}
+	if liveApp.HealthCheckType != "" {
+		params.HealthCheckType = &liveApp.HealthCheckType
+	}
+	if liveApp.DiskQuota > 0 {
+		params.DiskQuota = &liveApp.DiskQuota
+	}
+	if liveApp.Memory > 0 {
+		params.Memory = &liveApp.Memory
+	}
+	if liveApp.InstanceCount > 0 {
+		params.InstanceCount = &liveApp.InstanceCount
+	}
+	if liveApp.Stack != nil {
+		params.StackGUID = &liveApp.Stack.GUID
+	}
+
+	return params
+}
+
+// blueGreenServices returns the services the new app must be bound to: those
+// of the live app plus any requested by the manifest.
+func (cmd *Push) blueGreenServices(liveApp models.Application, appParams models.AppParams) ([]string, error) {
+	summary, err := cmd.appSummaryRepo.GetSummary(liveApp.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	services := []string{}
+	seen := map[string]bool{}
+	for _, service := range summary.Services {
+		services = append(services, service.Name)
+		seen[service.Name] = true
+	}
+
+	if appParams.ServicesToBind != nil {
+		for _, name := range *appParams.ServicesToBind {
+			if !seen[name] {
+				services = append(services, name)
+				seen[name] = true
+			}
+		}
+	}
+
+	return services, nil
+}
+
+func (cmd *Push) startBlueGreenApp(app models.Application, params models.AppParams, c flags.FlagContext) error {
+	if c.String("docker-image") == "" {
+		err := cmd.processPath(*params.Path, app)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := cmd.bindAppToServices(*params.ServicesToBind, app)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say("")
+
+	if params.HealthCheckTimeout != nil {
+		cmd.appStarter.SetStartTimeoutInSeconds(*params.HealthCheckTimeout)
+	}
+
+	_, err = cmd.appStarter.ApplicationStart(app, cmd.config.OrganizationFields().Name, cmd.config.SpaceFields().Name)
+	return err
+}
+
+// routeCreationRecorder remembers the routes created through it, so that a
+// failed blue-green push can delete the routes it added for the new app.
+type routeCreationRecorder struct {
+	api.RouteRepository
+	created []models.Route
+}
+
+func (r *routeCreationRecorder) Create(host string, domain models.DomainFields, path string, useRandomPort bool) (models.Route, error) {
+	route, err := r.RouteRepository.Create(host, domain, path, useRandomPort)
+	if err == nil {
+		r.created = append(r.created, route)
+	}
+	return route, err
+}
+
+// rollBackBlueGreenPush returns liveApp's routes to it, deletes the routes
+// created for newApp and deletes newApp. Binding a route that is still bound
+// is harmless, so every route is bound again regardless of how far the push
+// got.
+func (cmd *Push) rollBackBlueGreenPush(liveApp, newApp models.Application, createdRoutes []models.Route, cause error) error {
+	cmd.ui.Say("")
+	cmd.ui.Warn(T("Blue-green push of {{.AppName}} failed, rolling back...",
+		map[string]interface{}{"AppName": liveApp.Name}))
+
+	for _, route := range liveApp.Routes {
+		_ = cmd.routeRepo.Bind(route.GUID, liveApp.GUID)
+	}
+
+	for _, route := range createdRoutes {
+		err := cmd.routeRepo.Delete(route.GUID)
+		if err != nil {
+			cmd.ui.Warn(T("Could not delete route {{.URL}} while rolling back: {{.Error}}",
+				map[string]interface{}{"URL": route.URL(), "Error": err.Error()}))
+		}
+	}
+
+	err := cmd.appRepo.Delete(newApp.GUID)
+	if err != nil {
+		return errors.New(T("{{.Error}}\nCould not delete app {{.TempAppName}} while rolling back: {{.DeleteError}}",
+			map[string]interface{}{"Error": cause.Error(), "TempAppName": newApp.Name, "DeleteError": err.Error()}))
+	}
+
+	return errors.New(T("{{.Error}}\nRolled back: {{.AppName}} is unchanged and {{.TempAppName}} was deleted",
+		map[string]interface{}{"Error": cause.Error(), "AppName": liveApp.Name, "TempAppName": newApp.Name}))
+}
+
+// pushChange is one line of the diff printed by push --dry-run. An empty
+// from means the setting is added; an empty to means it is removed.
+type pushChange struct {
+	setting string
+	from    string
+	to      string
+}
+
+// dryRun prints what pushing appSet would change without creating or
+// updating anything. It only reads from the Cloud Controller.
+func (cmd *Push) dryRun(appSet []models.AppParams) error {
+	cmd.ui.Say(T("Showing changes for push to org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}. Nothing will be pushed.",
+		map[string]interface{}{
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username()),
+		}))
+	cmd.ui.Say("")
+
+	for _, appParams := range appSet {
+		if appParams.Name == nil {
+			return errors.New(T("Error: No name found for app"))
+		}
+
+		var changes []pushChange
+		existingApp, err := cmd.appRepo.Read(*appParams.Name)
+		switch err.(type) {
+		case nil:
+			summary, err := cmd.appSummaryRepo.GetSummary(existingApp.GUID)
+			if err != nil {
+				return err
+			}
+			existingApp.Services = summary.Services
+
+			mergeEnvironmentVars(existingApp, appParams)
+
+			changes, err = cmd.appChanges(existingApp, appParams)
+			if err != nil {
+				return err
+			}
+
+			if len(changes) == 0 {
+				cmd.ui.Say(T("App {{.AppName}} is unchanged",
+					map[string]interface{}{"AppName": terminal.EntityNameColor(existingApp.Name)}))
+				cmd.ui.Say("")
+				continue
+			}
+
+			cmd.ui.Say(T("App {{.AppName}} will be updated:",
+				map[string]interface{}{"AppName": terminal.EntityNameColor(existingApp.Name)}))
+		case *errors.ModelNotFoundError:
+			changes, err = cmd.appChanges(models.Application{}, appParams)
+			if err != nil {
+				return err
+			}
+
+			cmd.ui.Say(T("App {{.AppName}} will be created:",
+				map[string]interface{}{"AppName": terminal.EntityNameColor(*appParams.Name)}))
+		default:
+			return err
+		}
+
+		for _, change := range changes {
+			if change.from != "" {
+				cmd.ui.Say(terminal.FailureColor(fmt.Sprintf("-   %s: %s", change.setting, change.from)))
+			}
+			if change.to != "" {
+				cmd.ui.Say(terminal.SuccessColor(fmt.Sprintf("+   %s: %s", change.setting, change.to)))
+			}
+		}
+		cmd.ui.Say("")
+	}
+
+	return nil
+}
+
+// appChanges compares the settings of app with those push would give it.
+// Settings that appParams leaves unset keep their current values and are
+// not reported.
+func (cmd *Push) appChanges(app models.Application, appParams models.AppParams) ([]pushChange, error) {
+	var changes []pushChange
+	compare := func(setting, from string, to *string) {
+		if to != nil && *to != from {
+			changes = append(changes, pushChange{setting: setting, from: from, to: *to})
+		}
+	}
+
+	compare("buildpack", app.Buildpack, appParams.BuildpackURL)
+	compare("command", app.Command, appParams.Command)
+	compare("docker-image", app.DockerImage, appParams.DockerImage)
+	compare("health-check-type", app.HealthCheckType, appParams.HealthCheckType)
+
+	if appParams.Memory != nil {
+		compare("memory", megabytesOrEmpty(app.Memory), stringPointer(megabytesOrEmpty(*appParams.Memory)))
+	}
+	if appParams.DiskQuota != nil {
+		compare("disk_quota", megabytesOrEmpty(app.DiskQuota), stringPointer(megabytesOrEmpty(*appParams.DiskQuota)))
+	}
+	if appParams.InstanceCount != nil {
+		var instances string
+		if app.GUID != "" {
+			instances = strconv.Itoa(app.InstanceCount)
+		}
+		compare("instances", instances, stringPointer(strconv.Itoa(*appParams.InstanceCount)))
+	}
+	if appParams.StackName != nil {
+		var stackName string
+		if app.Stack != nil {
+			stackName = app.Stack.Name
+		}
+		compare("stack", stackName, appParams.StackName)
+	}
+
+	if appParams.EnvironmentVars != nil {
+		keys := []string{}
+		for key := range *appParams.EnvironmentVars {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			var from string
+			if value, ok := app.EnvironmentVars[key]; ok {
+				from = fmt.Sprintf("%v", value)
+			}
+			compare("env."+key, from, stringPointer(fmt.Sprintf("%v", (*appParams.EnvironmentVars)[key])))
+		}
+	}
+
+	routeChanges, err := cmd.routeChanges(app, appParams)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, routeChanges...)
+
+	if appParams.ServicesToBind != nil {
+		for _, serviceName := range *appParams.ServicesToBind {
+			if !appHasService(app, serviceName) {
+				changes = append(changes, pushChange{setting: "service", to: serviceName})
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// routeChanges mirrors updateRoutes: routes are only ever added, unless
+// --no-route removes them all.
+func (cmd *Push) routeChanges(app models.Application, appParams models.AppParams) ([]pushChange, error) {
+	var changes []pushChange
+
+	if appParams.NoRoute {
+		for _, route := range app.Routes {
+			changes = append(changes, pushChange{setting: "route", from: route.URL()})
+		}
+		return changes, nil
+	}
+
+	routeDefined := appParams.Domains != nil || !appParams.IsHostEmpty() || appParams.NoHostname
+	if !routeDefined && len(app.Routes) > 0 {
+		return nil, nil
+	}
+
+	var domains []models.DomainFields
+	if appParams.Domains == nil {
+		domain, err := cmd.findDomain(nil)
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, domain)
+	} else {
+		for _, name := range *appParams.Domains {
+			domainName := name
+			domain, err := cmd.findDomain(&domainName)
+			if err != nil {
+				return nil, err
+			}
+			domains = append(domains, domain)
+		}
+	}
+
+	hosts := []string{hostNameForString(*appParams.Name)}
+	switch {
+	case appParams.NoHostname:
+		hosts = []string{""}
+	case !appParams.IsHostEmpty():
+		hosts = *appParams.Hosts
+	}
+
+	var routePath string
+	if appParams.RoutePath != nil {
+		routePath = *appParams.RoutePath
+	}
+
+	for _, domain := range domains {
+		if isTCP(domain) || appParams.UseRandomRoute {
+			changes = append(changes, pushChange{
+				setting: "route",
+				to: T("random route on {{.DomainName}}",
+					map[string]interface{}{"DomainName": domain.Name}),
+			})
+			continue
+		}
+
+		for _, host := range hosts {
+			url := (&models.RoutePresenter{Host: host, Domain: domain.Name, Path: routePath}).URL()
+			if !appHasRoute(app, url) {
+				changes = append(changes, pushChange{setting: "route", to: url})
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+func appHasRoute(app models.Application, url string) bool {
+	for _, route := range app.Routes {
+		if route.URL() == url {
+			return true
+		}
+	}
+	return false
+}
+
+func appHasService(app models.Application, serviceName string) bool {
+	for _, service := range app.Services {
+		if service.Name == serviceName {
+			return true
+		}
+	}
+	return false
+}
+
+func megabytesOrEmpty(megabytes int64) string {
+	if megabytes == 0 {
+		return ""
+	}
+	return formatters.ByteSize(megabytes * formatters.MEGABYTE)
+}
+
+func stringPointer(s string) *string {
+	return &s
+}
+
 func (cmd *Push) getAppParamsFromManifest(c flags.FlagContext) ([]models.AppParams, error) {
 	if c.Bool("no-manifest") {
 		return []models.AppParams{}, nil
@@ -573,7 +1402,12 @@ func (cmd *Push) getAppParamsFromManifest(c flags.FlagContext) ([]models.AppPara
 		}
 	}
 
-	m, err := cmd.manifestRepo.ReadManifest(path)
+	vars, err := manifestVars(c)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := cmd.manifestRepo.ReadManifest(path, vars)
 
 	if err != nil {
 		if m.Path == "" && c.String("f") == "" {
@@ -592,6 +1426,29 @@ func (cmd *Push) getAppParamsFromManifest(c flags.FlagContext) ([]models.AppPara
 	return apps, nil
 }
 
+// manifestVars collects the manifest variables from --vars-file and --var.
+// Files are applied in order and --var assignments override them all.
+func manifestVars(c flags.FlagContext) (manifest.Vars, error) {
+	vars := manifest.Vars{}
+
+	for _, path := range c.StringSlice("vars-file") {
+		err := vars.LoadFile(path)
+		if err != nil {
+			return nil, errors.New(T("Error reading vars file {{.Path}}:\n{{.Err}}",
+				map[string]interface{}{"Path": path, "Err": err.Error()}))
+		}
+	}
+
+	for _, assignment := range c.StringSlice("var") {
+		err := vars.Set(assignment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vars, nil
+}
+
 func (cmd *Push) createAppSetFromContextAndManifest(contextApp models.AppParams, manifestApps []models.AppParams) ([]models.AppParams, error) {
 	var err error
 	var apps []models.AppParams
@@ -787,50 +1644,60 @@ func (cmd *Push) uploadApp(appGUID, appDir, appDirOrZipFile string, localFiles [
 	if err != nil {
 		return err
 	}
+	defer os.RemoveAll(uploadDir)
 
-	remoteFiles, hasFileToUpload, err := cmd.actor.GatherFiles(localFiles, appDir, uploadDir)
-	if err != nil {
-		return err
+	remoteFiles, hasFileToUpload, err := cmd.actor.GatherFiles(appGUID, localFiles, appDir, uploadDir)
+	if err == actors.ErrAppBitsUnchanged {
+		cmd.ui.Say(T("App files from {{.Path}} are unchanged since the last push, skipping upload",
+			map[string]interface{}{"Path": appDir}))
+		return nil
 	}
-
-	zipFile, err := ioutil.TempFile("", "uploads")
 	if err != nil {
 		return err
 	}
-	defer func() {
-		zipFile.Close()
-		os.Remove(zipFile.Name())
-	}()
 
+	var writeZip applicationbits.ZipWriter
 	if hasFileToUpload {
-		err = cmd.zipper.Zip(uploadDir, zipFile)
-		if err != nil {
-			if emptyDirErr, ok := err.(*errors.EmptyDirError); ok {
-				return emptyDirErr
-			}
-			return fmt.Errorf("%s: %s", T("Error zipping application"), err.Error())
-		}
-
-		var zipFileSize int64
-		zipFileSize, err = cmd.zipper.GetZipSize(zipFile)
-		if err != nil {
-			return err
-		}
-
-		zipFileCount := cmd.appfiles.CountFiles(uploadDir)
-		if zipFileCount > 0 {
+		uploadFileCount := cmd.appfiles.CountFiles(uploadDir)
+		if uploadFileCount > 0 {
 			cmd.ui.Say(T("Uploading app files from: {{.Path}}", map[string]interface{}{"Path": appDir}))
-			cmd.ui.Say(T("Uploading {{.ZipFileBytes}}, {{.FileCount}} files",
+			// The zip is only made while it is uploaded, so its size is
+			// not known yet.
+			cmd.ui.Say(T("Uploading {{.FileCount}} files, {{.FileBytes}} before compression",
 				map[string]interface{}{
-					"ZipFileBytes": formatters.ByteSize(zipFileSize),
-					"FileCount":    zipFileCount}))
+					"FileCount": uploadFileCount,
+					"FileBytes": formatters.ByteSize(uploadSize(localFiles, remoteFiles))}))
+		}
+
+		// The zip is written straight into the upload request.
+		writeZip = func(w io.Writer) error {
+			err := cmd.zipper.WriteZip(uploadDir, w)
+			if err != nil {
+				if emptyDirErr, ok := err.(*errors.EmptyDirError); ok {
+					return emptyDirErr
+				}
+				return fmt.Errorf("%s: %s", T("Error zipping application"), err.Error())
+			}
+			return nil
 		}
 	}
 
-	err = os.RemoveAll(uploadDir)
-	if err != nil {
-		return err
+	return cmd.actor.UploadApp(appGUID, writeZip, remoteFiles)
+}
+
+// uploadSize is the total size of the local files the Cloud Controller does
+// not already have, before compression.
+func uploadSize(localFiles []models.AppFileFields, remoteFiles []resources.AppFileResource) int64 {
+	present := map[string]bool{}
+	for _, remoteFile := range remoteFiles {
+		present[remoteFile.Path] = true
 	}
 
-	return cmd.actor.UploadApp(appGUID, zipFile, remoteFiles)
+	var size int64
+	for _, localFile := range localFiles {
+		if !present[localFile.Path] {
+			size += localFile.Size
+		}
+	}
+	return size
 }
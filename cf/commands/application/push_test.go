@@ -2,16 +2,26 @@ package application_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/cloudfoundry/cli/cf/actors"
 	"github.com/cloudfoundry/cli/cf/actors/actorsfakes"
 	"github.com/cloudfoundry/cli/cf/api/apifakes"
+	"github.com/cloudfoundry/cli/cf/api/appinstances/appinstancesfakes"
+	"github.com/cloudfoundry/cli/cf/api/applicationbits"
 	"github.com/cloudfoundry/cli/cf/api/applications/applicationsfakes"
 	"github.com/cloudfoundry/cli/cf/api/authentication/authenticationfakes"
+	"github.com/cloudfoundry/cli/cf/api/logs"
+	"github.com/cloudfoundry/cli/cf/api/logs/logsfakes"
 	"github.com/cloudfoundry/cli/cf/api/resources"
 	"github.com/cloudfoundry/cli/cf/api/stacks/stacksfakes"
+	cfappfiles "github.com/cloudfoundry/cli/cf/appfiles"
 	"github.com/cloudfoundry/cli/cf/appfiles/appfilesfakes"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/commands/application"
@@ -24,6 +34,7 @@ import (
 	"github.com/cloudfoundry/cli/generic"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testlogs "github.com/cloudfoundry/cli/testhelpers/logs"
 	"github.com/cloudfoundry/cli/testhelpers/maker"
 	testmanifest "github.com/cloudfoundry/cli/testhelpers/manifest"
 	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
@@ -33,6 +44,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/blang/semver"
+	"github.com/cloudfoundry/loggregatorlib/logmessage"
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/flags"
 	. "github.com/cloudfoundry/cli/testhelpers/matchers"
@@ -44,6 +56,7 @@ var _ = Describe("Push Command", func() {
 		configRepo                 coreconfig.Repository
 		manifestRepo               *testmanifest.FakeManifestRepository
 		starter                    *applicationfakes.FakeStarter
+		startCommand               commandregistry.Command
 		stopper                    *applicationfakes.FakeStopper
 		serviceBinder              *servicefakes.OldFakeAppBinder
 		appRepo                    *applicationsfakes.FakeRepository
@@ -79,7 +92,7 @@ var _ = Describe("Push Command", func() {
 		deps.AppFiles = appfiles
 
 		//inject fake commands dependencies into registry
-		commandregistry.Register(starter)
+		commandregistry.Register(startCommand)
 		commandregistry.Register(stopper)
 		commandregistry.Register(serviceBinder)
 
@@ -98,6 +111,7 @@ var _ = Describe("Push Command", func() {
 			return starter
 		}
 		starter.MetaDataReturns(commandregistry.CommandMetadata{Name: "start"})
+		startCommand = starter
 
 		stopper.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
 			return stopper
@@ -842,7 +856,7 @@ var _ = Describe("Push Command", func() {
 				appfiles.AppFilesInDirReturns(expectedLocalFiles, nil)
 				callPush("-p", "../some/path-to/an-app/file.zip", "app-with-path")
 
-				actualLocalFiles, _, _ := actor.GatherFilesArgsForCall(0)
+				_, actualLocalFiles, _, _ := actor.GatherFilesArgsForCall(0)
 				Expect(actualLocalFiles).To(Equal(expectedLocalFiles))
 			})
 
@@ -864,10 +878,50 @@ var _ = Describe("Push Command", func() {
 				))
 			})
 
+			Context("with --show-ignored", func() {
+				It("lists the ignored files and the rule that ignores each", func() {
+					appfiles.IgnoredFilesInDirReturns([]cfappfiles.IgnoredFile{
+						{Path: "logs/", Rule: ".cfignore:1 logs/"},
+						{Path: "lib/debug.log", Rule: "lib/.cfignore:2 *.log"},
+					}, nil)
+					callPush("-p", "../some/path-to/an-app/file.zip", "--show-ignored", "app-with-path")
+
+					Expect(appfiles.IgnoredFilesInDirCallCount()).To(Equal(1))
+					Expect(ui.Outputs).To(ContainSubstrings(
+						[]string{"Files not uploaded:"},
+						[]string{"file", "rule"},
+						[]string{"logs/", ".cfignore:1 logs/"},
+						[]string{"lib/debug.log", "lib/.cfignore:2 *.log"},
+					))
+				})
+
+				It("says when nothing is ignored", func() {
+					callPush("-p", "../some/path-to/an-app/file.zip", "--show-ignored", "app-with-path")
+
+					Expect(ui.Outputs).To(ContainSubstrings([]string{"No app files are ignored"}))
+				})
+
+				It("fails when the ignored files cannot be listed", func() {
+					appfiles.IgnoredFilesInDirReturns(nil, errors.New("some error"))
+					callPush("-p", "../some/path-to/an-app/file.zip", "--show-ignored", "app-with-path")
+
+					Expect(ui.Outputs).To(ContainSubstrings(
+						[]string{"Error listing ignored app files: some error"},
+					))
+					Expect(actor.GatherFilesCallCount()).To(Equal(0))
+				})
+			})
+
+			It("does not list the ignored files by default", func() {
+				callPush("-p", "../some/path-to/an-app/file.zip", "app-with-path")
+
+				Expect(appfiles.IgnoredFilesInDirCallCount()).To(Equal(0))
+			})
+
 			It("pushes the contents of the app directory or zip file specified using the -p flag", func() {
 				callPush("-p", "../some/path-to/an-app/file.zip", "app-with-path")
 
-				_, appDir, _ := actor.GatherFilesArgsForCall(0)
+				_, _, appDir, _ := actor.GatherFilesArgsForCall(0)
 				Expect(appDir).To(Equal("../some/path-to/an-app/file.zip"))
 			})
 
@@ -875,7 +929,7 @@ var _ = Describe("Push Command", func() {
 				callPush("app-with-default-path")
 				dir, _ := os.Getwd()
 
-				_, appDir, _ := actor.GatherFilesArgsForCall(0)
+				_, _, appDir, _ := actor.GatherFilesArgsForCall(0)
 				Expect(appDir).To(Equal(dir))
 			})
 
@@ -932,6 +986,33 @@ var _ = Describe("Push Command", func() {
 				Expect(*params.Name).To(Equal("app-name"))
 			})
 
+			It("passes the variables from --vars-file and --var to the manifest", func() {
+				varsFile, err := ioutil.TempFile("", "vars")
+				Expect(err).NotTo(HaveOccurred())
+				defer os.Remove(varsFile.Name())
+				_, err = varsFile.WriteString("instances: 2\nstage: dev\n")
+				Expect(err).NotTo(HaveOccurred())
+				varsFile.Close()
+
+				callPush("--vars-file", varsFile.Name(), "--var", "stage=prod", "--var", "host=my-host", "app-name")
+
+				Expect(manifestRepo.ReadManifestArgs.Vars).To(Equal(manifest.Vars{
+					"instances": 2,
+					"stage":     "prod",
+					"host":      "my-host",
+				}))
+			})
+
+			It("fails when given a malformed --var", func() {
+				callPush("--var", "no-equals-sign", "app-name")
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Invalid variable assignment 'no-equals-sign'"},
+				))
+				Expect(appRepo.CreateCallCount()).To(BeZero())
+			})
+
 			It("pushes an app when provided a manifest with one app defined", func() {
 				domainRepo.FindByNameInOrgReturns(models.DomainFields{
 					Name: "manifest-example.com",
@@ -1375,6 +1456,502 @@ var _ = Describe("Push Command", func() {
 		})
 	})
 
+	Describe("re-pushing an existing app with --strategy blue-green", func() {
+		var (
+			liveApp        models.Application
+			appSummaryRepo *apifakes.FakeAppSummaryRepository
+		)
+
+		BeforeEach(func() {
+			appSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+			deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
+
+			liveApp = models.Application{}
+			liveApp.Name = "existing-app"
+			liveApp.GUID = "existing-app-guid"
+			liveApp.State = "started"
+			liveApp.Memory = 256
+			liveApp.InstanceCount = 2
+			liveApp.EnvironmentVars = map[string]interface{}{"crazy": "pants"}
+			liveApp.Routes = []models.RouteSummary{{GUID: "existing-route-guid", Host: "existing-app"}}
+
+			appRepo.ReadStub = func(name string) (models.Application, error) {
+				if name == "existing-app" {
+					return liveApp, nil
+				}
+				return models.Application{}, errors.NewModelNotFoundError("App", name)
+			}
+			appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+				return models.Application{
+					ApplicationFields: models.ApplicationFields{Name: *params.Name, GUID: "new-app-guid", State: "stopped"},
+				}, nil
+			}
+
+			serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+				return maker.NewServiceInstance(name), nil
+			}
+			appSummaryRepo.GetSummaryReturns(models.Application{
+				Services: []models.ServicePlanSummary{{Name: "live-service"}},
+			}, nil)
+		})
+
+		It("fails when given an unknown strategy", func() {
+			callPush("--strategy", "rolling", "existing-app")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Invalid strategy 'rolling'"},
+			))
+			Expect(appRepo.CreateCallCount()).To(BeZero())
+		})
+
+		It("fails when combined with --no-start", func() {
+			callPush("--strategy", "blue-green", "--no-start", "existing-app")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"cannot be used with '--no-start'"},
+			))
+		})
+
+		It("fails when the temporary app already exists", func() {
+			appRepo.ReadReturns(liveApp, nil)
+			appRepo.ReadStub = nil
+
+			callPush("--strategy", "blue-green", "existing-app")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"existing-app-new already exists"},
+			))
+			Expect(appRepo.CreateCallCount()).To(BeZero())
+		})
+
+		It("starts a copy of the app without stopping the live one", func() {
+			callPush("--strategy", "blue-green", "-m", "1G", "existing-app")
+
+			Expect(stopper.ApplicationStopCallCount()).To(BeZero())
+			Expect(appRepo.UpdateCallCount()).To(Equal(1))
+
+			params := appRepo.CreateArgsForCall(0)
+			Expect(*params.Name).To(Equal("existing-app-new"))
+			Expect(*params.SpaceGUID).To(Equal(configRepo.SpaceFields().GUID))
+			Expect(*params.Memory).To(Equal(int64(1024)))
+			Expect(*params.InstanceCount).To(Equal(2))
+			Expect((*params.EnvironmentVars)["crazy"]).To(Equal("pants"))
+
+			appGUID, _, _ := actor.UploadAppArgsForCall(0)
+			Expect(appGUID).To(Equal("new-app-guid"))
+
+			Expect(serviceBinder.AppsToBind[0].GUID).To(Equal("new-app-guid"))
+			Expect(serviceBinder.InstancesToBindTo[0].Name).To(Equal("live-service"))
+
+			startedApp, _, _ := starter.ApplicationStartArgsForCall(0)
+			Expect(startedApp.GUID).To(Equal("new-app-guid"))
+		})
+
+		It("moves the routes, deletes the live app and takes over its name", func() {
+			callPush("--strategy", "blue-green", "existing-app")
+
+			routeGUID, appGUID := routeRepo.BindArgsForCall(0)
+			Expect(routeGUID).To(Equal("existing-route-guid"))
+			Expect(appGUID).To(Equal("new-app-guid"))
+
+			routeGUID, appGUID = routeRepo.UnbindArgsForCall(0)
+			Expect(routeGUID).To(Equal("existing-route-guid"))
+			Expect(appGUID).To(Equal("existing-app-guid"))
+
+			Expect(appRepo.DeleteCallCount()).To(Equal(1))
+			Expect(appRepo.DeleteArgsForCall(0)).To(Equal("existing-app-guid"))
+
+			appGUID, params := appRepo.UpdateArgsForCall(0)
+			Expect(appGUID).To(Equal("new-app-guid"))
+			Expect(*params.Name).To(Equal("existing-app"))
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Creating app", "existing-app-new", "to replace", "existing-app"},
+				[]string{"Moving routes from", "existing-app", "existing-app-new"},
+				[]string{"Deleting app", "existing-app"},
+				[]string{"Renaming app", "existing-app-new", "existing-app"},
+			))
+		})
+
+		Context("when the new app fails to start", func() {
+			BeforeEach(func() {
+				starter.ApplicationStartReturns(models.Application{}, errors.New("Start unsuccessful"))
+			})
+
+			It("deletes the new app and leaves the live app serving its routes", func() {
+				callPush("--strategy", "blue-green", "existing-app")
+
+				Expect(appRepo.DeleteCallCount()).To(Equal(1))
+				Expect(appRepo.DeleteArgsForCall(0)).To(Equal("new-app-guid"))
+				Expect(routeRepo.UnbindCallCount()).To(BeZero())
+				Expect(appRepo.UpdateCallCount()).To(BeZero())
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Start unsuccessful"},
+					[]string{"Rolled back", "existing-app is unchanged", "existing-app-new was deleted"},
+				))
+			})
+		})
+
+		Context("when a route cannot be moved", func() {
+			BeforeEach(func() {
+				routeRepo.BindStub = func(routeGUID, appGUID string) error {
+					if appGUID == "new-app-guid" {
+						return errors.New("route bind failed")
+					}
+					return nil
+				}
+			})
+
+			It("returns the routes to the live app and deletes the new app", func() {
+				callPush("--strategy", "blue-green", "existing-app")
+
+				routeGUID, appGUID := routeRepo.BindArgsForCall(1)
+				Expect(routeGUID).To(Equal("existing-route-guid"))
+				Expect(appGUID).To(Equal("existing-app-guid"))
+
+				Expect(appRepo.DeleteArgsForCall(0)).To(Equal("new-app-guid"))
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"route bind failed"},
+				))
+			})
+		})
+
+		Context("when the push fails after creating routes for the new app", func() {
+			BeforeEach(func() {
+				routeRepo.FindReturns(models.Route{}, errors.NewModelNotFoundError("Route", "new-host"))
+				routeRepo.UnbindReturns(errors.New("route unbind failed"))
+			})
+
+			It("deletes the routes it created along with the new app", func() {
+				callPush("--strategy", "blue-green", "-n", "new-host", "existing-app")
+
+				Expect(routeRepo.CreateCallCount()).To(Equal(1))
+				Expect(routeRepo.DeleteCallCount()).To(Equal(1))
+				Expect(routeRepo.DeleteArgsForCall(0)).To(Equal("new-host-route-guid"))
+
+				routeGUID, appGUID := routeRepo.BindArgsForCall(routeRepo.BindCallCount() - 1)
+				Expect(routeGUID).To(Equal("existing-route-guid"))
+				Expect(appGUID).To(Equal("existing-app-guid"))
+
+				Expect(appRepo.DeleteArgsForCall(0)).To(Equal("new-app-guid"))
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"route unbind failed"},
+				))
+			})
+		})
+	})
+
+	Describe("with --dry-run", func() {
+		var appSummaryRepo *apifakes.FakeAppSummaryRepository
+
+		BeforeEach(func() {
+			appSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+			deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
+		})
+
+		Context("when the app does not exist", func() {
+			BeforeEach(func() {
+				appRepo.ReadReturns(models.Application{}, errors.NewModelNotFoundError("App", "new-app"))
+			})
+
+			It("shows the settings and route of the new app without creating it", func() {
+				callPush("--dry-run", "-m", "1G", "-i", "2", "new-app")
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"Nothing will be pushed"},
+					[]string{"App new-app will be created:"},
+					[]string{"+   memory: 1G"},
+					[]string{"+   instances: 2"},
+					[]string{"+   route: new-app.foo.cf-app.com"},
+				))
+				Expect(appRepo.CreateCallCount()).To(BeZero())
+				Expect(routeRepo.CreateCallCount()).To(BeZero())
+				Expect(actor.ProcessPathCallCount()).To(BeZero())
+				Expect(starter.ApplicationStartCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the app exists", func() {
+			BeforeEach(func() {
+				existingApp := models.Application{}
+				existingApp.Name = "existing-app"
+				existingApp.GUID = "existing-app-guid"
+				existingApp.Memory = 256
+				existingApp.InstanceCount = 2
+				existingApp.EnvironmentVars = map[string]interface{}{"FOO": "bar"}
+				existingApp.Routes = []models.RouteSummary{{
+					Host:   "existing-app",
+					Domain: models.DomainFields{Name: "foo.cf-app.com"},
+				}}
+				appRepo.ReadReturns(existingApp, nil)
+
+				appSummaryRepo.GetSummaryReturns(models.Application{
+					Services: []models.ServicePlanSummary{{Name: "bound-service"}},
+				}, nil)
+			})
+
+			It("shows only the settings that change without updating the app", func() {
+				manifestRepo.ReadManifestReturns.Manifest = &manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{
+								"name":      "existing-app",
+								"memory":    "512M",
+								"instances": 2,
+								"services":  []interface{}{"bound-service", "new-service"},
+								"env": generic.NewMap(map[interface{}]interface{}{
+									"FOO": "baz",
+								}),
+							}),
+						},
+					}),
+				}
+
+				callPush("--dry-run")
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"App existing-app will be updated:"},
+					[]string{"-   memory: 256M"},
+					[]string{"+   memory: 512M"},
+					[]string{"-   env.FOO: bar"},
+					[]string{"+   env.FOO: baz"},
+					[]string{"+   service: new-service"},
+				))
+				Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"instances"}))
+				Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"route"}))
+				Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"bound-service"}))
+
+				Expect(appRepo.UpdateCallCount()).To(BeZero())
+				Expect(stopper.ApplicationStopCallCount()).To(BeZero())
+				Expect(actor.ProcessPathCallCount()).To(BeZero())
+			})
+
+			It("shows the routes that --no-route would remove", func() {
+				callPush("--dry-run", "--no-route", "existing-app")
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"-   route: existing-app.foo.cf-app.com"},
+				))
+				Expect(routeRepo.UnbindCallCount()).To(BeZero())
+			})
+
+			It("says so when nothing would change", func() {
+				callPush("--dry-run", "-m", "256M", "existing-app")
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"App existing-app is unchanged"},
+				))
+			})
+		})
+	})
+
+	Describe("with --parallel", func() {
+		var dependentAppsManifest = func() *manifest.Manifest {
+			return &manifest.Manifest{
+				Path: "manifest.yml",
+				Data: generic.NewMap(map[interface{}]interface{}{
+					"applications": []interface{}{
+						generic.NewMap(map[interface{}]interface{}{
+							"name":       "frontend",
+							"depends-on": []interface{}{"backend"},
+						}),
+						generic.NewMap(map[interface{}]interface{}{
+							"name": "backend",
+						}),
+						generic.NewMap(map[interface{}]interface{}{
+							"name": "worker",
+						}),
+					},
+				}),
+			}
+		}
+
+		BeforeEach(func() {
+			appRepo.ReadReturns(models.Application{}, errors.NewModelNotFoundError("App", "the-app"))
+			appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+				app := models.Application{}
+				app.Name = *params.Name
+				app.GUID = *params.Name + "-guid"
+				return app, nil
+			}
+			stopper.ApplicationStopStub = func(app models.Application, _ string, _ string) (models.Application, error) {
+				return app, nil
+			}
+			manifestRepo.ReadManifestReturns.Manifest = dependentAppsManifest()
+		})
+
+		It("pushes every app with its output prefixed by the app name", func() {
+			callPush("--parallel", "2")
+
+			Expect(appRepo.CreateCallCount()).To(Equal(3))
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"[frontend]", "Creating app", "frontend"},
+				[]string{"[backend]", "Creating app", "backend"},
+				[]string{"[worker]", "Creating app", "worker"},
+			))
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Push summary:"},
+				[]string{"frontend", "pushed"},
+				[]string{"backend", "pushed"},
+				[]string{"worker", "pushed"},
+			))
+			Expect(starter.ApplicationStartCallCount()).To(Equal(3))
+		})
+
+		It("pushes an app only after the apps it depends on", func() {
+			var (
+				eventsLock sync.Mutex
+				events     []string
+			)
+			record := func(event string) {
+				eventsLock.Lock()
+				defer eventsLock.Unlock()
+				events = append(events, event)
+			}
+
+			appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+				record("create " + *params.Name)
+				app := models.Application{}
+				app.Name = *params.Name
+				app.GUID = *params.Name + "-guid"
+				return app, nil
+			}
+			starter.ApplicationStartStub = func(app models.Application, _ string, _ string) (models.Application, error) {
+				record("start " + app.GUID)
+				return app, nil
+			}
+
+			callPush("--parallel", "3")
+
+			Expect(events).To(ConsistOf(
+				"create frontend", "start frontend-guid",
+				"create backend", "start backend-guid",
+				"create worker", "start worker-guid",
+			))
+			startedBackend, createdFrontend := -1, -1
+			for i, event := range events {
+				switch event {
+				case "start backend-guid":
+					startedBackend = i
+				case "create frontend":
+					createdFrontend = i
+				}
+			}
+			Expect(startedBackend).To(BeNumerically("<", createdFrontend))
+		})
+
+		It("skips apps whose dependencies failed and reports the failures", func() {
+			appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+				if *params.Name == "backend" {
+					return models.Application{}, errors.New("backend is broken")
+				}
+				app := models.Application{}
+				app.Name = *params.Name
+				app.GUID = *params.Name + "-guid"
+				return app, nil
+			}
+
+			Expect(callPush("--parallel", "2")).To(BeFalse())
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"[backend]", "FAILED"},
+				[]string{"[backend]", "backend is broken"},
+			))
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"frontend", "Skipped because backend failed"},
+				[]string{"backend", "backend is broken"},
+				[]string{"worker", "pushed"},
+			))
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"2 of 3 apps failed to push"},
+			))
+			Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"Creating app", "frontend"}))
+		})
+
+		It("fails when the depends-on entries form a cycle", func() {
+			m := dependentAppsManifest()
+			apps := m.Data.Get("applications").([]interface{})
+			apps[1].(generic.Map).Set("depends-on", []interface{}{"frontend"})
+
+			manifestRepo.ReadManifestReturns.Manifest = m
+			callPush("--parallel", "2")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"cycle", "frontend -> backend -> frontend"},
+			))
+			Expect(appRepo.CreateCallCount()).To(BeZero())
+		})
+
+		Context("with the start command", func() {
+			var originalAppCommand commandregistry.Command
+
+			BeforeEach(func() {
+				originalAppCommand = commandregistry.Commands.FindCommand("app")
+				commandregistry.Register(new(applicationfakes.FakeAppDisplayer))
+
+				appRepo.UpdateStub = func(appGUID string, params models.AppParams) (models.Application, error) {
+					app := models.Application{}
+					app.GUID = appGUID
+					app.Name = strings.TrimSuffix(appGUID, "-guid")
+					app.PackageState = "STAGED"
+					return app, nil
+				}
+				appRepo.GetAppStub = func(appGUID string) (models.Application, error) {
+					return appRepo.Update(appGUID, models.AppParams{})
+				}
+
+				appInstancesRepo := new(appinstancesfakes.FakeAppInstancesRepository)
+				appInstancesRepo.GetInstancesReturns([]models.AppInstanceFields{{State: models.InstanceRunning}}, nil)
+				deps.RepoLocator = deps.RepoLocator.SetAppInstancesRepository(appInstancesRepo)
+
+				logRepo := new(logsfakes.FakeRepository)
+				logRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+					onConnect()
+					go func() {
+						logChan <- testlogs.NewLogMessage("staging "+appGUID, appGUID, application.LogMessageTypeStaging, "0", logmessage.LogMessage_OUT, time.Now())
+						close(logChan)
+					}()
+				}
+				deps.RepoLocator = deps.RepoLocator.SetLogsRepository(logRepo)
+
+				startCommand = &application.Start{}
+			})
+
+			AfterEach(func() {
+				commandregistry.Register(originalAppCommand)
+			})
+
+			It("shows each app's staging logs prefixed with its name", func() {
+				Expect(callPush("--parallel", "2")).To(BeTrue())
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"[frontend]", "staging frontend-guid"},
+					[]string{"[backend]", "staging backend-guid"},
+					[]string{"[worker]", "staging worker-guid"},
+				))
+			})
+		})
+
+		It("fails when the parallel count is not positive", func() {
+			callPush("--parallel", "0")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Invalid parallel count: 0"},
+			))
+			Expect(appRepo.CreateCallCount()).To(BeZero())
+		})
+	})
+
 	Describe("service instances", func() {
 		BeforeEach(func() {
 			serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
@@ -1472,8 +2049,10 @@ var _ = Describe("Push Command", func() {
 	Describe("displaying information about files being uploaded", func() {
 		It("displays information about the files being uploaded", func() {
 			appfiles.CountFilesReturns(11)
-			zipper.ZipReturns(nil)
-			zipper.GetZipSizeReturns(6100000, nil)
+			appfiles.AppFilesInDirReturns([]models.AppFileFields{
+				{Path: "path/to/app", Size: 1000},
+				{Path: "foo", Size: 6100000},
+			}, nil)
 			actor.GatherFilesReturns([]resources.AppFileResource{{Path: "path/to/app"}, {Path: "bar"}}, true, nil)
 
 			curDir, err := os.Getwd()
@@ -1482,8 +2061,42 @@ var _ = Describe("Push Command", func() {
 			callPush("appName")
 			Expect(ui.Outputs).To(ContainSubstrings(
 				[]string{"Uploading", curDir},
-				[]string{"5.8M", "11 files"},
+				[]string{"11 files", "5.8M before compression"},
+			))
+		})
+
+		It("streams the zipped files into the upload", func() {
+			actor.GatherFilesReturns([]resources.AppFileResource{{Path: "bar"}}, true, nil)
+			actor.UploadAppStub = func(_ string, writeZip applicationbits.ZipWriter, _ []resources.AppFileResource) error {
+				return writeZip(ioutil.Discard)
+			}
+
+			callPush("appName")
+
+			Expect(zipper.WriteZipCallCount()).To(Equal(1))
+			Expect(zipper.ZipCallCount()).To(BeZero())
+		})
+
+		It("skips the upload when the app files are unchanged since the last push", func() {
+			actor.GatherFilesReturns(nil, false, actors.ErrAppBitsUnchanged)
+
+			callPush("appName")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"unchanged since the last push, skipping upload"},
 			))
+			Expect(actor.UploadAppCallCount()).To(BeZero())
+			Expect(starter.ApplicationStartCallCount()).To(Equal(1))
+		})
+
+		It("uploads no zip when the Cloud Controller already has every file", func() {
+			actor.GatherFilesReturns([]resources.AppFileResource{{Path: "some-path"}}, false, nil)
+
+			callPush("appName")
+
+			Expect(actor.UploadAppCallCount()).To(Equal(1))
+			_, writeZip, _ := actor.UploadAppArgsForCall(0)
+			Expect(writeZip).To(BeNil())
 		})
 	})
 
@@ -1,6 +1,12 @@
 package application
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf"
+	"github.com/cloudfoundry/cli/cf/api/appinstances"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
@@ -18,11 +24,16 @@ type Restarter interface {
 }
 
 type Restart struct {
-	ui      terminal.UI
-	config  coreconfig.Reader
-	starter Starter
-	stopper Stopper
-	appReq  requirements.ApplicationRequirement
+	ui                terminal.UI
+	config            coreconfig.Reader
+	starter           Starter
+	stopper           Stopper
+	instanceRestarter InstanceRestarter
+	appInstancesRepo  appinstances.Repository
+	appReq            requirements.ApplicationRequirement
+
+	StartupTimeout time.Duration
+	PingerThrottle time.Duration
 }
 
 func init() {
@@ -30,13 +41,18 @@ func init() {
 }
 
 func (cmd *Restart) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["rolling"] = &flags.BoolFlag{Name: "rolling", Usage: T("Restart instances a batch at a time, waiting for each batch to be running again before continuing")}
+	fs["batch-size"] = &flags.IntFlag{Name: "batch-size", Usage: T("Number of instances to restart at a time with --rolling (Default: 1)")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "restart",
 		ShortName:   "rs",
 		Description: T("Restart an app"),
 		Usage: []string{
-			T("CF_NAME restart APP_NAME"),
+			T("CF_NAME restart APP_NAME [--rolling [--batch-size NUM]]"),
 		},
+		Flags: fs,
 	}
 }
 
@@ -59,6 +75,9 @@ func (cmd *Restart) Requirements(requirementsFactory requirements.Factory, fc fl
 func (cmd *Restart) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
+	cmd.appInstancesRepo = deps.RepoLocator.GetAppInstancesRepository()
+	cmd.StartupTimeout = DefaultStartupTimeout
+	cmd.PingerThrottle = DefaultPingerThrottle
 
 	//get start for dependency
 	starter := commandregistry.Commands.FindCommand("start")
@@ -70,11 +89,33 @@ func (cmd *Restart) SetDependency(deps commandregistry.Dependency, pluginCall bo
 	stopper = stopper.SetDependency(deps, false)
 	cmd.stopper = stopper.(Stopper)
 
+	//get restart-app-instance for dependency
+	instanceRestarter := commandregistry.Commands.FindCommand("restart-app-instance")
+	instanceRestarter = instanceRestarter.SetDependency(deps, false)
+	cmd.instanceRestarter = instanceRestarter.(InstanceRestarter)
+
 	return cmd
 }
 
 func (cmd *Restart) Execute(c flags.FlagContext) error {
 	app := cmd.appReq.GetApplication()
+
+	if c.IsSet("batch-size") && !c.Bool("rolling") {
+		return errors.New(T("Incorrect Usage: '--batch-size' can only be used with '--rolling'"))
+	}
+
+	if c.Bool("rolling") {
+		batchSize := 1
+		if c.IsSet("batch-size") {
+			batchSize = c.Int("batch-size")
+			if batchSize < 1 {
+				return errors.New(T("Invalid batch size: {{.BatchSize}}\nBatch size must be a positive integer",
+					map[string]interface{}{"BatchSize": batchSize}))
+			}
+		}
+		return cmd.RollingRestart(app, batchSize)
+	}
+
 	return cmd.ApplicationRestart(app, cmd.config.OrganizationFields().Name, cmd.config.SpaceFields().Name)
 }
 
@@ -92,3 +133,102 @@ func (cmd *Restart) ApplicationRestart(app models.Application, orgName, spaceNam
 	}
 	return nil
 }
+
+// RollingRestart restarts the instances of a started app batchSize at a
+// time. Each batch must be running again before the next one is restarted,
+// and the restart stops at the first batch with a crashed instance so that
+// the remaining instances keep serving.
+func (cmd *Restart) RollingRestart(app models.Application, batchSize int) error {
+	if app.State != models.ApplicationStateStarted {
+		return errors.New(T("App {{.AppName}} must be started to restart it with --rolling",
+			map[string]interface{}{"AppName": app.Name}))
+	}
+
+	cmd.ui.Say(T("Restarting app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}, {{.BatchSize}} instance(s) at a time...",
+		map[string]interface{}{
+			"AppName":   terminal.EntityNameColor(app.Name),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username()),
+			"BatchSize": batchSize,
+		}))
+	cmd.ui.Say("")
+
+	instances, err := cmd.appInstancesRepo.GetInstances(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	for first := 0; first < len(instances); first += batchSize {
+		last := first + batchSize
+		if last > len(instances) {
+			last = len(instances)
+		}
+
+		for index := first; index < last; index++ {
+			err = cmd.instanceRestarter.RestartInstance(app, index)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = cmd.waitForReplacements(app, instances, first, last)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.ui.Say(terminal.HeaderColor(T("\nApp restarted\n")))
+	cmd.ui.Ok()
+	return nil
+}
+
+// waitForReplacements waits until the instances from first up to (but not
+// including) last are running again. An instance counts as replaced once it
+// is running with a different start time than before it was restarted.
+func (cmd *Restart) waitForReplacements(app models.Application, previous []models.AppInstanceFields, first, last int) error {
+	timer := time.NewTimer(cmd.StartupTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			return errors.New(T("Timed out waiting for instances of {{.AppName}} to restart. Instances after them were not restarted.",
+				map[string]interface{}{"AppName": app.Name}))
+		default:
+		}
+
+		time.Sleep(cmd.PingerThrottle)
+
+		current, err := cmd.appInstancesRepo.GetInstances(app.GUID)
+		if err != nil {
+			cmd.ui.Warn("Could not fetch instance count: %s", err.Error())
+			continue
+		}
+
+		replaced := 0
+		for index := first; index < last && index < len(current); index++ {
+			switch current[index].State {
+			case models.InstanceCrashed, models.InstanceFlapping:
+				return errors.New(T("Instance {{.Index}} of {{.AppName}} crashed after restarting. Instances after it were not restarted.\n\nTIP: use '{{.Command}}' for more information",
+					map[string]interface{}{
+						"Index":   index,
+						"AppName": app.Name,
+						"Command": terminal.CommandColor(fmt.Sprintf("%s logs %s --recent", cf.Name, app.Name)),
+					}))
+			case models.InstanceRunning:
+				if !current[index].Since.Equal(previous[index].Since) {
+					replaced++
+				}
+			}
+		}
+
+		cmd.ui.Say(T("{{.Replaced}} of {{.Total}} restarted instances running",
+			map[string]interface{}{"Replaced": replaced, "Total": last - first}))
+
+		if replaced == last-first {
+			cmd.ui.Say("")
+			return nil
+		}
+	}
+}
@@ -8,11 +8,19 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
 )
 
+//go:generate counterfeiter . InstanceRestarter
+
+type InstanceRestarter interface {
+	commandregistry.Command
+	RestartInstance(app models.Application, index int) error
+}
+
 type RestartAppInstance struct {
 	ui               terminal.UI
 	config           coreconfig.Reader
@@ -69,6 +77,10 @@ func (cmd *RestartAppInstance) Execute(fc flags.FlagContext) error {
 		return errors.New(T("Instance must be a non-negative integer"))
 	}
 
+	return cmd.RestartInstance(app, instance)
+}
+
+func (cmd *RestartAppInstance) RestartInstance(app models.Application, instance int) error {
 	cmd.ui.Say(T("Restarting instance {{.Instance}} of application {{.AppName}} as {{.Username}}",
 		map[string]interface{}{
 			"Instance": instance,
@@ -76,7 +88,7 @@ func (cmd *RestartAppInstance) Execute(fc flags.FlagContext) error {
 			"Username": terminal.EntityNameColor(cmd.config.Username()),
 		}))
 
-	err = cmd.appInstancesRepo.DeleteInstance(app.GUID, instance)
+	err := cmd.appInstancesRepo.DeleteInstance(app.GUID, instance)
 	if err != nil {
 		return err
 	}
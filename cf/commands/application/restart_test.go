@@ -2,7 +2,10 @@ package application_test
 
 import (
 	"os"
+	"time"
 
+	"github.com/cloudfoundry/cli/cf/api/appinstances/appinstancesfakes"
+	"github.com/cloudfoundry/cli/cf/commands/application"
 	"github.com/cloudfoundry/cli/cf/commands/application/applicationfakes"
 	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/trace/tracefakes"
@@ -24,20 +27,25 @@ var _ = Describe("restart command", func() {
 		requirementsFactory *testreq.FakeReqFactory
 		starter             *applicationfakes.FakeStarter
 		stopper             *applicationfakes.FakeStopper
+		instanceRestarter   *applicationfakes.FakeInstanceRestarter
+		appInstancesRepo    *appinstancesfakes.FakeAppInstancesRepository
 		config              coreconfig.Repository
 		app                 models.Application
 		originalStop        commandregistry.Command
 		originalStart       commandregistry.Command
+		originalRestartApp  commandregistry.Command
 		deps                commandregistry.Dependency
 	)
 
 	updateCommandDependency := func(pluginCall bool) {
 		deps.UI = ui
 		deps.Config = config
+		deps.RepoLocator = deps.RepoLocator.SetAppInstancesRepository(appInstancesRepo)
 
-		//inject fake 'stopper and starter' into registry
+		//inject fake 'stopper, starter and instance restarter' into registry
 		commandregistry.Register(starter)
 		commandregistry.Register(stopper)
+		commandregistry.Register(instanceRestarter)
 
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("restart").SetDependency(deps, pluginCall))
 	}
@@ -52,6 +60,8 @@ var _ = Describe("restart command", func() {
 		requirementsFactory = &testreq.FakeReqFactory{}
 		starter = new(applicationfakes.FakeStarter)
 		stopper = new(applicationfakes.FakeStopper)
+		instanceRestarter = new(applicationfakes.FakeInstanceRestarter)
+		appInstancesRepo = new(appinstancesfakes.FakeAppInstancesRepository)
 		config = testconfig.NewRepositoryWithDefaults()
 
 		app = models.Application{}
@@ -61,6 +71,7 @@ var _ = Describe("restart command", func() {
 		//save original command and restore later
 		originalStart = commandregistry.Commands.FindCommand("start")
 		originalStop = commandregistry.Commands.FindCommand("stop")
+		originalRestartApp = commandregistry.Commands.FindCommand("restart-app-instance")
 
 		//setup fakes to correctly interact with commandregistry
 		starter.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
@@ -72,11 +83,17 @@ var _ = Describe("restart command", func() {
 			return stopper
 		}
 		stopper.MetaDataReturns(commandregistry.CommandMetadata{Name: "stop"})
+
+		instanceRestarter.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return instanceRestarter
+		}
+		instanceRestarter.MetaDataReturns(commandregistry.CommandMetadata{Name: "restart-app-instance"})
 	})
 
 	AfterEach(func() {
 		commandregistry.Register(originalStart)
 		commandregistry.Register(originalStop)
+		commandregistry.Register(originalRestartApp)
 	})
 
 	Describe("requirements", func() {
@@ -127,5 +144,118 @@ var _ = Describe("restart command", func() {
 
 			Expect(requirementsFactory.ApplicationName).To(Equal("my-app"))
 		})
+
+		Context("with --rolling", func() {
+			var restarted map[int]bool
+
+			runRollingCommand := func(args ...string) bool {
+				updateCommandDependency(false)
+				cmd := commandregistry.Commands.FindCommand("restart").(*application.Restart)
+				cmd.PingerThrottle = time.Millisecond
+				cmd.StartupTimeout = time.Second
+				commandregistry.Register(cmd)
+				return testcmd.RunCLICommandWithoutDependency("restart", args, requirementsFactory, ui)
+			}
+
+			BeforeEach(func() {
+				app.State = "started"
+				requirementsFactory.Application = app
+
+				restarted = map[int]bool{}
+				instanceRestarter.RestartInstanceStub = func(_ models.Application, index int) error {
+					restarted[index] = true
+					return nil
+				}
+
+				before := time.Unix(1000, 0)
+				after := time.Unix(2000, 0)
+				appInstancesRepo.GetInstancesStub = func(string) ([]models.AppInstanceFields, error) {
+					instances := make([]models.AppInstanceFields, 3)
+					for index := range instances {
+						instances[index] = models.AppInstanceFields{State: models.InstanceRunning, Since: before}
+						if restarted[index] {
+							instances[index].Since = after
+						}
+					}
+					return instances, nil
+				}
+			})
+
+			It("restarts the instances one at a time without stopping the app", func() {
+				Expect(runRollingCommand("--rolling", "my-app")).To(BeTrue())
+
+				Expect(stopper.ApplicationStopCallCount()).To(BeZero())
+				Expect(instanceRestarter.RestartInstanceCallCount()).To(Equal(3))
+				for i := 0; i < 3; i++ {
+					restartedApp, index := instanceRestarter.RestartInstanceArgsForCall(i)
+					Expect(restartedApp.GUID).To(Equal("my-app-guid"))
+					Expect(index).To(Equal(i))
+				}
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"Restarting app", "my-app", "1 instance(s) at a time"},
+					[]string{"1 of 1 restarted instances running"},
+					[]string{"1 of 1 restarted instances running"},
+					[]string{"1 of 1 restarted instances running"},
+					[]string{"App restarted"},
+					[]string{"OK"},
+				))
+			})
+
+			It("restarts a batch of instances at a time when given --batch-size", func() {
+				Expect(runRollingCommand("--rolling", "--batch-size", "2", "my-app")).To(BeTrue())
+
+				Expect(instanceRestarter.RestartInstanceCallCount()).To(Equal(3))
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"2 of 2 restarted instances running"},
+					[]string{"1 of 1 restarted instances running"},
+				))
+			})
+
+			It("stops restarting instances when a restarted instance crashes", func() {
+				appInstancesRepo.GetInstancesStub = func(string) ([]models.AppInstanceFields, error) {
+					instances := []models.AppInstanceFields{
+						{State: models.InstanceRunning},
+						{State: models.InstanceRunning},
+						{State: models.InstanceRunning},
+					}
+					if restarted[0] {
+						instances[0].State = models.InstanceCrashed
+					}
+					return instances, nil
+				}
+
+				Expect(runRollingCommand("--rolling", "my-app")).To(BeFalse())
+
+				Expect(instanceRestarter.RestartInstanceCallCount()).To(Equal(1))
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Instance 0 of my-app crashed after restarting"},
+				))
+			})
+
+			It("fails when the app is not started", func() {
+				app.State = "stopped"
+				requirementsFactory.Application = app
+
+				Expect(runRollingCommand("--rolling", "my-app")).To(BeFalse())
+
+				Expect(instanceRestarter.RestartInstanceCallCount()).To(BeZero())
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"my-app must be started"},
+				))
+			})
+
+			It("fails when --batch-size is given without --rolling", func() {
+				Expect(runCommand("--batch-size", "2", "my-app")).To(BeFalse())
+
+				Expect(stopper.ApplicationStopCallCount()).To(BeZero())
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"'--batch-size' can only be used with '--rolling'"},
+				))
+			})
+		})
 	})
 })
@@ -0,0 +1,158 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/commands"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/formatters"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	sshCmd "github.com/cloudfoundry/cli/cf/ssh"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
+	"github.com/cloudfoundry/cli/cf/ssh/options"
+	"github.com/cloudfoundry/cli/cf/ssh/terminal"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+)
+
+type SCP struct {
+	ui            terminal.UI
+	config        coreconfig.Reader
+	gateway       net.Gateway
+	appReq        requirements.ApplicationRequirement
+	sshCodeGetter commands.SSHCodeGetter
+	knownHosts    knownhosts.KnownHosts
+	opts          *options.SCPOptions
+	secureShell   sshCmd.SecureShell
+}
+
+func init() {
+	commandregistry.Register(&SCP{})
+}
+
+func (cmd *SCP) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["r"] = &flags.BoolFlag{ShortName: "r", Usage: T("Recursively copy directories")}
+	fs["skip-host-validation"] = &flags.BoolFlag{Name: "skip-host-validation", ShortName: "k", Usage: T("Skip host key validation")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "scp",
+		Description: T("Copy files to or from an application container instance"),
+		Usage: []string{
+			T("CF_NAME scp [-r] [--skip-host-validation] APP_NAME[/INDEX]:REMOTE_PATH LOCAL_PATH\n   CF_NAME scp [-r] [--skip-host-validation] LOCAL_PATH APP_NAME[/INDEX]:REMOTE_PATH"),
+		},
+		Examples: []string{
+			"CF_NAME scp my-app/1:/home/vcap/app/heap.hprof .",
+			"CF_NAME scp -r ./config my-app:app/config",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *SCP) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires SOURCE and TARGET as arguments") + "\n\n" + commandregistry.Commands.CommandUsage("scp"))
+	}
+
+	var err error
+	cmd.opts, err = options.NewSCPOptions(fc)
+	if err != nil {
+		cmd.ui.Failed(fmt.Sprintf(T("Incorrect Usage:")+" %s\n\n%s", err.Error(), commandregistry.Commands.CommandUsage("scp")))
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(cmd.opts.AppName)
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+
+	return reqs
+}
+
+func (cmd *SCP) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.gateway = deps.Gateways["cloud-controller"]
+	cmd.knownHosts = deps.KnownHosts
+
+	if deps.WildcardDependency != nil {
+		cmd.secureShell = deps.WildcardDependency.(sshCmd.SecureShell)
+	}
+
+	sshCodeGetter := commandregistry.Commands.FindCommand("ssh-code")
+	sshCodeGetter = sshCodeGetter.SetDependency(deps, false)
+	cmd.sshCodeGetter = sshCodeGetter.(commands.SSHCodeGetter)
+
+	return cmd
+}
+
+func (cmd *SCP) Execute(fc flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+	info, err := getSSHEndpointInfo(cmd.gateway, cmd.config)
+	if err != nil {
+		return errors.New(T("Error getting SSH info:") + err.Error())
+	}
+
+	sshAuthCode, err := cmd.sshCodeGetter.Get()
+	if err != nil {
+		return errors.New(T("Error getting one time auth code: ") + err.Error())
+	}
+
+	//init secureShell if it is not already set by SetDependency() with fakes
+	if cmd.secureShell == nil {
+		cmd.secureShell = sshCmd.NewSecureShell(
+			sshCmd.DefaultSecureDialer(),
+			sshTerminal.DefaultHelper(),
+			sshCmd.DefaultListenerFactory(),
+			30*time.Second,
+			app,
+			info.SSHEndpointFingerprint,
+			info.SSHEndpoint,
+			sshAuthCode,
+			cmd.config.APIEndpoint(),
+			cmd.knownHosts,
+		)
+	}
+
+	err = cmd.secureShell.Connect(&cmd.opts.SSHOptions)
+	if err != nil {
+		return errors.New(T("Error opening SSH connection: ") + err.Error())
+	}
+	defer cmd.secureShell.Close()
+
+	if cmd.opts.Download {
+		err = cmd.secureShell.Download(cmd.opts.RemotePath, cmd.opts.LocalPath, cmd.opts.Recursive, cmd.progress)
+	} else {
+		err = cmd.secureShell.Upload(cmd.opts.LocalPath, cmd.opts.RemotePath, cmd.opts.Recursive, cmd.progress)
+	}
+	if err != nil {
+		return errors.New(T("Error copying files: ") + err.Error())
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
+
+func (cmd *SCP) progress(name string, size int64, file io.ReadSeeker) io.Reader {
+	cmd.ui.Say(T("Copying {{.Path}} ({{.Size}})...",
+		map[string]interface{}{
+			"Path": terminal.EntityNameColor(filepath.ToSlash(name)),
+			"Size": formatters.ByteSize(size),
+		}))
+
+	progressReader := net.NewProgressReader(file, cmd.ui, 5*time.Second)
+	progressReader.SetTotalSize(size)
+	if cmd.opts.Download {
+		progressReader.SetDownloading()
+	}
+	return progressReader
+}
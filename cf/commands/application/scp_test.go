@@ -0,0 +1,196 @@
+package application_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry/cli/cf/api/apifakes"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/commands/commandsfakes"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/net"
+	sshCmd "github.com/cloudfoundry/cli/cf/ssh"
+	"github.com/cloudfoundry/cli/cf/ssh/sshfakes"
+	"github.com/cloudfoundry/cli/testhelpers/cloudcontrollergateway"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testnet "github.com/cloudfoundry/cli/testhelpers/net"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("scp command", func() {
+	var (
+		ui *testterm.FakeUI
+
+		sshCodeGetter         *commandsfakes.FakeSSHCodeGetter
+		originalSSHCodeGetter commandregistry.Command
+
+		requirementsFactory *testreq.FakeReqFactory
+		configRepo          coreconfig.Repository
+		deps                commandregistry.Dependency
+
+		fakeSecureShell *sshfakes.FakeSecureShell
+		testServer      *httptest.Server
+	)
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = &testreq.FakeReqFactory{}
+		deps.Gateways = make(map[string]net.Gateway)
+
+		originalSSHCodeGetter = commandregistry.Commands.FindCommand("ssh-code")
+
+		sshCodeGetter = new(commandsfakes.FakeSSHCodeGetter)
+		sshCodeGetter.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return sshCodeGetter
+		}
+		sshCodeGetter.MetaDataReturns(commandregistry.CommandMetadata{Name: "ssh-code"})
+
+		fakeSecureShell = new(sshfakes.FakeSecureShell)
+		deps.WildcardDependency = fakeSecureShell
+
+		getRequest := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+			Method: "GET",
+			Path:   "/v2/info",
+			Response: testnet.TestResponse{
+				Status: http.StatusOK,
+				Body:   getInfoResponseBody,
+			},
+		})
+
+		testServer, _ = testnet.NewServer([]testnet.TestRequest{getRequest})
+		configRepo.SetAPIEndpoint(testServer.URL)
+		deps.Gateways["cloud-controller"] = cloudcontrollergateway.NewTestCloudControllerGateway(configRepo)
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		requirementsFactory.Application = app
+	})
+
+	AfterEach(func() {
+		commandregistry.Register(originalSSHCodeGetter)
+		testServer.Close()
+	})
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = configRepo
+
+		commandregistry.Register(sshCodeGetter)
+
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("scp").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("scp", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails with usage when not provided two args", func() {
+			requirementsFactory.LoginSuccess = true
+
+			runCommand("my-app:file")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires SOURCE and TARGET"},
+			))
+		})
+
+		It("fails with usage when neither path is in an app", func() {
+			requirementsFactory.LoginSuccess = true
+
+			Expect(runCommand("here", "there")).To(BeFalse())
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Exactly one of SOURCE and TARGET must be in an app"},
+			))
+		})
+
+		It("fails requirements when not logged in", func() {
+			Expect(runCommand("my-app:file", ".")).To(BeFalse())
+		})
+
+		It("fails if a space is not targeted", func() {
+			requirementsFactory.LoginSuccess = true
+			requirementsFactory.TargetedSpaceSuccess = false
+			Expect(runCommand("my-app:file", ".")).To(BeFalse())
+		})
+	})
+
+	Context("when logged in and targeting a space", func() {
+		BeforeEach(func() {
+			requirementsFactory.LoginSuccess = true
+			requirementsFactory.TargetedSpaceSuccess = true
+		})
+
+		It("downloads from the app instance", func() {
+			runCommand("-r", "my-app/2:/home/vcap/logs", "local-dir")
+
+			Expect(requirementsFactory.ApplicationName).To(Equal("my-app"))
+			Expect(fakeSecureShell.ConnectCallCount()).To(Equal(1))
+			Expect(fakeSecureShell.ConnectArgsForCall(0).Index).To(Equal(uint(2)))
+			Expect(fakeSecureShell.DownloadCallCount()).To(Equal(1))
+
+			remotePath, localPath, recursive, _ := fakeSecureShell.DownloadArgsForCall(0)
+			Expect(remotePath).To(Equal("/home/vcap/logs"))
+			Expect(localPath).To(Equal("local-dir"))
+			Expect(recursive).To(BeTrue())
+			Expect(fakeSecureShell.CloseCallCount()).To(Equal(1))
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"OK"}))
+		})
+
+		It("uploads to the app instance", func() {
+			runCommand("local-file", "my-app:app/file")
+
+			Expect(fakeSecureShell.ConnectArgsForCall(0).Index).To(Equal(uint(0)))
+			Expect(fakeSecureShell.UploadCallCount()).To(Equal(1))
+
+			localPath, remotePath, recursive, _ := fakeSecureShell.UploadArgsForCall(0)
+			Expect(localPath).To(Equal("local-file"))
+			Expect(remotePath).To(Equal("app/file"))
+			Expect(recursive).To(BeFalse())
+		})
+
+		It("reports the progress of each file", func() {
+			fakeSecureShell.UploadStub = func(localPath string, remotePath string, recursive bool, progress sshCmd.CopyProgress) error {
+				_, err := io.Copy(ioutil.Discard, progress("local-file", 5, bytes.NewReader([]byte("hello"))))
+				return err
+			}
+
+			runCommand("local-file", "my-app:app/file")
+
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"Copying", "local-file", "5B"}))
+		})
+
+		It("notifies users when connecting fails", func() {
+			fakeSecureShell.ConnectReturns(errors.New("dial error"))
+
+			runCommand("my-app:file", ".")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Error opening SSH connection", "dial error"},
+			))
+			Expect(fakeSecureShell.DownloadCallCount()).To(Equal(0))
+		})
+
+		It("notifies users when copying fails", func() {
+			fakeSecureShell.DownloadReturns(errors.New("file does not exist"))
+
+			runCommand("my-app:file", ".")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Error copying files", "file does not exist"},
+			))
+		})
+	})
+})
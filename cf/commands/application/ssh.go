@@ -1,20 +1,28 @@
 package application
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"golang.org/x/crypto/ssh"
 
+	"github.com/cloudfoundry/cli/cf/api/appinstances"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/commands"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	cferrors "github.com/cloudfoundry/cli/cf/errors"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/net"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	sshCmd "github.com/cloudfoundry/cli/cf/ssh"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
 	"github.com/cloudfoundry/cli/cf/ssh/options"
 	"github.com/cloudfoundry/cli/cf/ssh/terminal"
 	"github.com/cloudfoundry/cli/cf/terminal"
@@ -22,13 +30,15 @@ import (
 )
 
 type SSH struct {
-	ui            terminal.UI
-	config        coreconfig.Reader
-	gateway       net.Gateway
-	appReq        requirements.ApplicationRequirement
-	sshCodeGetter commands.SSHCodeGetter
-	opts          *options.SSHOptions
-	secureShell   sshCmd.SecureShell
+	ui               terminal.UI
+	config           coreconfig.Reader
+	gateway          net.Gateway
+	appReq           requirements.ApplicationRequirement
+	appInstancesRepo appinstances.Repository
+	sshCodeGetter    commands.SSHCodeGetter
+	knownHosts       knownhosts.KnownHosts
+	opts             *options.SSHOptions
+	secureShell      sshCmd.SecureShell
 }
 
 type sshInfo struct {
@@ -43,6 +53,8 @@ func init() {
 func (cmd *SSH) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["L"] = &flags.StringSliceFlag{ShortName: "L", Usage: T("Local port forward specification. This flag can be defined more than once.")}
+	fs["R"] = &flags.StringSliceFlag{ShortName: "R", Usage: T("Remote port forward specification: listen in the app container and connect from this machine. This flag can be defined more than once.")}
+	fs["D"] = &flags.StringSliceFlag{ShortName: "D", Usage: T("Dynamic port forward specification: run a local SOCKS5 proxy that connects from the app container. This flag can be defined more than once.")}
 	fs["command"] = &flags.StringSliceFlag{Name: "command", ShortName: "c", Usage: T("Command to run. This flag can be defined more than once.")}
 	fs["app-instance-index"] = &flags.IntFlag{Name: "app-instance-index", ShortName: "i", Usage: T("Application instance index")}
 	fs["skip-host-validation"] = &flags.BoolFlag{Name: "skip-host-validation", ShortName: "k", Usage: T("Skip host key validation")}
@@ -50,12 +62,15 @@ func (cmd *SSH) MetaData() commandregistry.CommandMetadata {
 	fs["request-pseudo-tty"] = &flags.BoolFlag{Name: "request-pseudo-tty", ShortName: "t", Usage: T("Request pseudo-tty allocation")}
 	fs["force-pseudo-tty"] = &flags.BoolFlag{Name: "force-pseudo-tty", ShortName: "tt", Usage: T("Force pseudo-tty allocation")}
 	fs["disable-pseudo-tty"] = &flags.BoolFlag{Name: "disable-pseudo-tty", ShortName: "T", Usage: T("Disable pseudo-tty allocation")}
+	fs["all-instances"] = &flags.BoolFlag{Name: "all-instances", Usage: T("Run the command on every running instance at the same time, prefixing each line of output with the instance index")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "ssh",
 		Description: T("SSH to an application container instance"),
 		Usage: []string{
-			T("CF_NAME ssh APP_NAME [-i app-instance-index] [-c command] [-L [bind_address:]port:host:hostport] [--skip-host-validation] [--skip-remote-execution] [--request-pseudo-tty] [--force-pseudo-tty] [--disable-pseudo-tty]"),
+			T("CF_NAME ssh APP_NAME [-i app-instance-index] [-c command] [-L [bind_address:]port:host:hostport] [-R [bind_address:]port:host:hostport] [-D [bind_address:]port] [--skip-host-validation] [--skip-remote-execution] [--request-pseudo-tty] [--force-pseudo-tty] [--disable-pseudo-tty]"),
+			"\n   ",
+			T("CF_NAME ssh APP_NAME --all-instances -c command [--skip-host-validation]"),
 		},
 		Flags: fs,
 	}
@@ -92,6 +107,8 @@ func (cmd *SSH) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.gateway = deps.Gateways["cloud-controller"]
+	cmd.knownHosts = deps.KnownHosts
+	cmd.appInstancesRepo = deps.RepoLocator.GetAppInstancesRepository()
 
 	if deps.WildcardDependency != nil {
 		cmd.secureShell = deps.WildcardDependency.(sshCmd.SecureShell)
@@ -107,11 +124,15 @@ func (cmd *SSH) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 
 func (cmd *SSH) Execute(fc flags.FlagContext) error {
 	app := cmd.appReq.GetApplication()
-	info, err := cmd.getSSHEndpointInfo()
+	info, err := getSSHEndpointInfo(cmd.gateway, cmd.config)
 	if err != nil {
 		return errors.New(T("Error getting SSH info:") + err.Error())
 	}
 
+	if cmd.opts.AllInstances {
+		return cmd.runOnAllInstances(app, info)
+	}
+
 	sshAuthCode, err := cmd.sshCodeGetter.Get()
 	if err != nil {
 		return errors.New(T("Error getting one time auth code: ") + err.Error())
@@ -128,6 +149,8 @@ func (cmd *SSH) Execute(fc flags.FlagContext) error {
 			info.SSHEndpointFingerprint,
 			info.SSHEndpoint,
 			sshAuthCode,
+			cmd.config.APIEndpoint(),
+			cmd.knownHosts,
 		)
 	}
 
@@ -142,6 +165,16 @@ func (cmd *SSH) Execute(fc flags.FlagContext) error {
 		return errors.New(T("Error forwarding port: ") + err.Error())
 	}
 
+	err = cmd.secureShell.RemotePortForward()
+	if err != nil {
+		return errors.New(T("Error forwarding remote port: ") + err.Error())
+	}
+
+	err = cmd.secureShell.DynamicPortForward()
+	if err != nil {
+		return errors.New(T("Error starting SOCKS proxy: ") + err.Error())
+	}
+
 	if cmd.opts.SkipRemoteExecution {
 		err = cmd.secureShell.Wait()
 	} else {
@@ -162,8 +195,201 @@ func (cmd *SSH) Execute(fc flags.FlagContext) error {
 	return nil
 }
 
-func (cmd *SSH) getSSHEndpointInfo() (sshInfo, error) {
+func getSSHEndpointInfo(gateway net.Gateway, config coreconfig.Reader) (sshInfo, error) {
 	info := sshInfo{}
-	err := cmd.gateway.GetResource(cmd.config.APIEndpoint()+"/v2/info", &info)
+	err := gateway.GetResource(config.APIEndpoint()+"/v2/info", &info)
 	return info, err
 }
+
+// instanceResult is how the command went on one instance.
+type instanceResult struct {
+	index int
+	err   error
+}
+
+// maxConcurrentInstances is how many instances runOnAllInstances runs the
+// command on at once.
+const maxConcurrentInstances = 10
+
+// runOnAllInstances runs the command on every running instance, on up to
+// maxConcurrentInstances at once. Each instance gets its own connection, and
+// its own one time auth code. A code is fetched only once the instance has a
+// slot, right before connecting, so that it is not left to expire. The codes
+// are fetched one at a time, as fetching one can refresh the auth token and
+// write the config. The remote stderr of each instance goes to stderr.
+func (cmd *SSH) runOnAllInstances(app models.Application, info sshInfo) error {
+	instances, err := cmd.appInstancesRepo.GetInstances(app.GUID)
+	if err != nil {
+		return errors.New(T("Error getting app instances: ") + err.Error())
+	}
+
+	indexes := []int{}
+	for index, instance := range instances {
+		if instance.State == models.InstanceRunning {
+			indexes = append(indexes, index)
+		}
+	}
+
+	if len(indexes) == 0 {
+		return errors.New(T("App {{.AppName}} has no running instances",
+			map[string]interface{}{"AppName": app.Name}))
+	}
+
+	outputLock := &sync.Mutex{}
+	say := func(line string) {
+		outputLock.Lock()
+		defer outputLock.Unlock()
+		cmd.ui.Say("%s", line)
+	}
+	errorWriter := os.Stderr
+	sayError := func(line string) {
+		outputLock.Lock()
+		defer outputLock.Unlock()
+		fmt.Fprintln(errorWriter, line)
+	}
+
+	results := make([]instanceResult, len(indexes))
+	connectLock := &sync.Mutex{}
+	slots := make(chan struct{}, maxConcurrentInstances)
+	wg := &sync.WaitGroup{}
+	for i, index := range indexes {
+		results[i] = instanceResult{index: index}
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, index int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			prefix := fmt.Sprintf("[%d] ", index)
+			stdout := &instanceOutput{prefix: prefix, say: say}
+			stderr := &instanceOutput{prefix: prefix, say: sayError}
+
+			err := cmd.runOnInstance(app, info, index, connectLock, stdout, stderr)
+			stdout.Flush()
+			stderr.Flush()
+
+			results[i].err = err
+		}(i, index)
+	}
+	wg.Wait()
+
+	return cmd.summarizeInstances(results)
+}
+
+// runOnInstance runs the command on one instance. connectLock is held from
+// fetching the one time auth code until the connection is open.
+func (cmd *SSH) runOnInstance(app models.Application, info sshInfo, index int, connectLock *sync.Mutex, stdout io.Writer, stderr io.Writer) error {
+	connectLock.Lock()
+	secureShell, err := cmd.connectToInstance(app, info, index)
+	connectLock.Unlock()
+	if err != nil {
+		return err
+	}
+	defer secureShell.Close()
+
+	return secureShell.ExecuteCommand(stdout, stderr)
+}
+
+func (cmd *SSH) connectToInstance(app models.Application, info sshInfo, index int) (sshCmd.SecureShell, error) {
+	sshAuthCode, err := cmd.sshCodeGetter.Get()
+	if err != nil {
+		return nil, errors.New(T("Error getting one time auth code: ") + err.Error())
+	}
+
+	secureShell := cmd.secureShell
+	if secureShell == nil {
+		secureShell = sshCmd.NewSecureShell(
+			sshCmd.DefaultSecureDialer(),
+			sshTerminal.DefaultHelper(),
+			sshCmd.DefaultListenerFactory(),
+			30*time.Second,
+			app,
+			info.SSHEndpointFingerprint,
+			info.SSHEndpoint,
+			sshAuthCode,
+			cmd.config.APIEndpoint(),
+			cmd.knownHosts,
+		)
+	}
+
+	opts := *cmd.opts
+	opts.Index = uint(index)
+
+	err = secureShell.Connect(&opts)
+	if err != nil {
+		return nil, errors.New(T("Error opening SSH connection: ") + err.Error())
+	}
+
+	return secureShell, nil
+}
+
+// summarizeInstances prints the exit status of each instance. When any
+// instance failed, cf exits with the highest exit status, or 255 when an
+// instance could not run the command at all.
+func (cmd *SSH) summarizeInstances(results []instanceResult) error {
+	cmd.ui.Say("")
+	table := cmd.ui.Table([]string{T("instance"), T("result")})
+
+	highestStatus := 0
+	failures := 0
+	for _, result := range results {
+		status := 0
+		description := terminal.SuccessColor(T("exited 0"))
+
+		switch err := result.err.(type) {
+		case nil:
+		case *ssh.ExitError:
+			status = err.ExitStatus()
+			description = terminal.FailureColor(T("exited {{.Status}}", map[string]interface{}{"Status": status}))
+		default:
+			status = 255
+			description = terminal.FailureColor(err.Error())
+		}
+
+		if status != 0 {
+			failures++
+		}
+		if status > highestStatus {
+			highestStatus = status
+		}
+
+		table.Add(strconv.Itoa(result.index), description)
+	}
+	table.Print()
+
+	if failures == 0 {
+		return nil
+	}
+
+	return cferrors.NewExitStatusError(T("{{.Failures}} of {{.Total}} instances failed",
+		map[string]interface{}{"Failures": failures, "Total": len(results)}), highestStatus)
+}
+
+// instanceOutput passes each line written to it to say, after the prefix.
+type instanceOutput struct {
+	prefix  string
+	say     func(string)
+	pending []byte
+}
+
+func (o *instanceOutput) Write(p []byte) (int, error) {
+	o.pending = append(o.pending, p...)
+	for {
+		newline := bytes.IndexByte(o.pending, '\n')
+		if newline < 0 {
+			break
+		}
+		o.say(o.prefix + string(bytes.TrimSuffix(o.pending[:newline], []byte("\r"))))
+		o.pending = o.pending[newline+1:]
+	}
+	return len(p), nil
+}
+
+// Flush passes on the last line when it did not end in a newline.
+func (o *instanceOutput) Flush() {
+	if len(o.pending) > 0 {
+		o.say(o.prefix + string(o.pending))
+		o.pending = nil
+	}
+}
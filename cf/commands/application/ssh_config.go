@@ -0,0 +1,246 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	gonet "net"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/commands"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	sshCmd "github.com/cloudfoundry/cli/cf/ssh"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
+	"github.com/cloudfoundry/cli/cf/ssh/options"
+	"github.com/cloudfoundry/cli/cf/ssh/terminal"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+	"golang.org/x/crypto/ssh"
+)
+
+type SSHConfig struct {
+	ui            terminal.UI
+	config        coreconfig.Reader
+	gateway       net.Gateway
+	appReq        requirements.ApplicationRequirement
+	sshCodeGetter commands.SSHCodeGetter
+	knownHosts    knownhosts.KnownHosts
+	secureShell   sshCmd.SecureShell
+}
+
+func init() {
+	commandregistry.Register(&SSHConfig{})
+}
+
+func (cmd *SSHConfig) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["app-instance-index"] = &flags.IntFlag{Name: "app-instance-index", ShortName: "i", Usage: T("Application instance index")}
+	fs["host"] = &flags.StringFlag{Name: "host", Usage: T("Host name to use with ssh (Default: cf-APP_NAME)")}
+	fs["skip-host-validation"] = &flags.BoolFlag{Name: "skip-host-validation", ShortName: "k", Usage: T("Skip host key validation")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "ssh-config",
+		Description: T("Print an OpenSSH config Host entry to reach an application container instance with ssh, scp, rsync and other tools"),
+		Usage: []string{
+			T("CF_NAME ssh-config APP_NAME [-i app-instance-index] [--host HOST] [--skip-host-validation]"),
+		},
+		Examples: []string{
+			"CF_NAME ssh-config my-app >> ~/.ssh/config",
+			"ssh cf-my-app",
+			"rsync -av ./assets cf-my-app:app/assets",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *SSHConfig) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires APP_NAME as argument") + "\n\n" + commandregistry.Commands.CommandUsage("ssh-config"))
+	}
+
+	if fc.IsSet("i") && fc.Int("i") < 0 {
+		cmd.ui.Failed(fmt.Sprintf(T("Incorrect Usage:")+" %s\n\n%s", T("Value for flag 'app-instance-index' cannot be negative"), commandregistry.Commands.CommandUsage("ssh-config")))
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+
+	return reqs
+}
+
+func (cmd *SSHConfig) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.gateway = deps.Gateways["cloud-controller"]
+	cmd.knownHosts = deps.KnownHosts
+
+	if deps.WildcardDependency != nil {
+		cmd.secureShell = deps.WildcardDependency.(sshCmd.SecureShell)
+	}
+
+	sshCodeGetter := commandregistry.Commands.FindCommand("ssh-code")
+	sshCodeGetter = sshCodeGetter.SetDependency(deps, false)
+	cmd.sshCodeGetter = sshCodeGetter.(commands.SSHCodeGetter)
+
+	return cmd
+}
+
+func (cmd *SSHConfig) Execute(fc flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+	index := fc.Int("i")
+
+	info, err := getSSHEndpointInfo(cmd.gateway, cmd.config)
+	if err != nil {
+		return errors.New(T("Error getting SSH info:") + err.Error())
+	}
+
+	sshHost, sshPort, err := gonet.SplitHostPort(info.SSHEndpoint)
+	if err != nil {
+		return errors.New(T("Error getting SSH info:") + err.Error())
+	}
+
+	var endpointKey ssh.PublicKey
+	if !fc.Bool("k") {
+		endpointKey, err = cmd.endpointHostKey(app, info, index)
+		if err != nil {
+			return errors.New(T("Error getting the host key of the SSH endpoint: ") + err.Error())
+		}
+	}
+
+	knownHostsPath, err := cmd.writeKnownHosts(sshHost, sshPort, endpointKey)
+	if err != nil {
+		return errors.New(T("Error saving the ssh-proxy host key: ") + err.Error())
+	}
+
+	host := "cf-" + app.Name
+	if fc.IsSet("i") {
+		host = fmt.Sprintf("%s-%d", host, index)
+	}
+	if fc.IsSet("host") {
+		host = fc.String("host")
+	}
+
+	// The app is passed by GUID together with the API endpoint and profile,
+	// so the entry keeps reaching this app after cf targets something else.
+	proxyArgs := []string{cf.Name}
+	if profile := cmd.config.CurrentProfile(); profile != "" {
+		proxyArgs = append(proxyArgs, "--profile", quoteProxyCommandArg(profile))
+	}
+	proxyArgs = append(proxyArgs,
+		"ssh-proxy",
+		"--api", quoteProxyCommandArg(cmd.config.APIEndpoint()),
+		quoteProxyCommandArg(app.GUID),
+		"-i", fmt.Sprintf("%d", index),
+	)
+	if fc.Bool("k") {
+		proxyArgs = append(proxyArgs, "--skip-host-validation")
+	}
+
+	cmd.ui.Say("Host %s", host)
+	cmd.ui.Say("    HostName %s", sshHost)
+	cmd.ui.Say("    Port %s", sshPort)
+	cmd.ui.Say("    User cf:%s/%d", app.GUID, index)
+	cmd.ui.Say("    ProxyCommand %s", strings.Join(proxyArgs, " "))
+	cmd.ui.Say("    HostKeyAlias %s", sshProxyHostKeyAlias)
+	cmd.ui.Say("    UserKnownHostsFile %s", quoteSSHConfigValue(strings.Replace(knownHostsPath, "%", "%%", -1)))
+
+	return nil
+}
+
+// endpointHostKey returns the host key cf trusts for the SSH endpoint. When
+// cf has not connected to the endpoint before, it connects once so that the
+// key is verified and recorded the way cf ssh does it.
+func (cmd *SSHConfig) endpointHostKey(app models.Application, info sshInfo, index int) (ssh.PublicKey, error) {
+	key, err := cmd.knownHosts.Lookup(cmd.config.APIEndpoint(), info.SSHEndpoint)
+	if err != nil || key != nil {
+		return key, err
+	}
+
+	sshAuthCode, err := cmd.sshCodeGetter.Get()
+	if err != nil {
+		return nil, errors.New(T("Error getting one time auth code: ") + err.Error())
+	}
+
+	secureShell := cmd.secureShell
+	if secureShell == nil {
+		secureShell = sshCmd.NewSecureShell(
+			sshCmd.DefaultSecureDialer(),
+			sshTerminal.DefaultHelper(),
+			sshCmd.DefaultListenerFactory(),
+			30*time.Second,
+			app,
+			info.SSHEndpointFingerprint,
+			info.SSHEndpoint,
+			sshAuthCode,
+			cmd.config.APIEndpoint(),
+			cmd.knownHosts,
+		)
+	}
+
+	err = secureShell.Connect(&options.SSHOptions{AppName: app.Name, Index: uint(index)})
+	if err != nil {
+		return nil, err
+	}
+	secureShell.Close()
+
+	return cmd.knownHosts.Lookup(cmd.config.APIEndpoint(), info.SSHEndpoint)
+}
+
+// writeKnownHosts writes the known hosts file that lets ssh verify
+// cf ssh-proxy, and returns its path. The file also holds the host key of
+// the SSH endpoint, when known, for use without the ProxyCommand.
+func (cmd *SSHConfig) writeKnownHosts(sshHost string, sshPort string, endpointKey ssh.PublicKey) (string, error) {
+	hostKeyPath, err := sshProxyFilePath("ssh_proxy_host_key")
+	if err != nil {
+		return "", err
+	}
+
+	hostKey, err := knownhosts.LoadOrCreateHostKey(hostKeyPath)
+	if err != nil {
+		return "", err
+	}
+
+	knownHostsPath := filepath.Join(filepath.Dir(hostKeyPath), "ssh_proxy_known_hosts")
+	lines := sshProxyHostKeyAlias + " " + string(ssh.MarshalAuthorizedKey(hostKey.PublicKey()))
+	if endpointKey != nil {
+		knownHost := sshHost
+		if sshPort != "22" {
+			knownHost = fmt.Sprintf("[%s]:%s", sshHost, sshPort)
+		}
+		lines += knownHost + " " + string(ssh.MarshalAuthorizedKey(endpointKey))
+	}
+
+	err = ioutil.WriteFile(knownHostsPath, []byte(lines), 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return knownHostsPath, nil
+}
+
+// quoteProxyCommandArg quotes an argument of the ProxyCommand, which ssh
+// expands % tokens in and then runs with the shell.
+func quoteProxyCommandArg(arg string) string {
+	quoted := "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+	return strings.Replace(quoted, "%", "%%", -1)
+}
+
+func quoteSSHConfigValue(value string) string {
+	if strings.ContainsAny(value, " \t") {
+		return `"` + value + `"`
+	}
+	return value
+}
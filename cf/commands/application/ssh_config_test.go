@@ -0,0 +1,253 @@
+package application_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/api/apifakes"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/commands/commandsfakes"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts/knownhostsfakes"
+	"github.com/cloudfoundry/cli/cf/ssh/sshfakes"
+	"github.com/cloudfoundry/cli/testhelpers/cloudcontrollergateway"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testnet "github.com/cloudfoundry/cli/testhelpers/net"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ssh-config command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *testreq.FakeReqFactory
+		configRepo          coreconfig.Repository
+		deps                commandregistry.Dependency
+		testServer          *httptest.Server
+
+		sshCodeGetter         *commandsfakes.FakeSSHCodeGetter
+		originalSSHCodeGetter commandregistry.Command
+		fakeSecureShell       *sshfakes.FakeSecureShell
+		fakeKnownHosts        *knownhostsfakes.FakeKnownHosts
+		endpointKey           ssh.PublicKey
+
+		cfHome         string
+		originalCFHome string
+	)
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = &testreq.FakeReqFactory{}
+		deps.Gateways = make(map[string]net.Gateway)
+
+		var err error
+		cfHome, err = ioutil.TempDir("", "ssh-config")
+		Expect(err).NotTo(HaveOccurred())
+		originalCFHome = os.Getenv("CF_HOME")
+		os.Setenv("CF_HOME", cfHome)
+
+		getRequest := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+			Method: "GET",
+			Path:   "/v2/info",
+			Response: testnet.TestResponse{
+				Status: http.StatusOK,
+				Body:   getInfoResponseBody,
+			},
+		})
+
+		testServer, _ = testnet.NewServer([]testnet.TestRequest{getRequest})
+		configRepo.SetAPIEndpoint(testServer.URL)
+		deps.Gateways["cloud-controller"] = cloudcontrollergateway.NewTestCloudControllerGateway(configRepo)
+
+		originalSSHCodeGetter = commandregistry.Commands.FindCommand("ssh-code")
+
+		sshCodeGetter = new(commandsfakes.FakeSSHCodeGetter)
+		sshCodeGetter.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return sshCodeGetter
+		}
+		sshCodeGetter.MetaDataReturns(commandregistry.CommandMetadata{Name: "ssh-code"})
+
+		fakeSecureShell = new(sshfakes.FakeSecureShell)
+		deps.WildcardDependency = fakeSecureShell
+
+		endpointHostKey, err := knownhosts.LoadOrCreateHostKey(filepath.Join(cfHome, "endpoint_host_key"))
+		Expect(err).NotTo(HaveOccurred())
+		endpointKey = endpointHostKey.PublicKey()
+
+		fakeKnownHosts = new(knownhostsfakes.FakeKnownHosts)
+		fakeKnownHosts.LookupReturns(endpointKey, nil)
+		deps.KnownHosts = fakeKnownHosts
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		requirementsFactory.Application = app
+	})
+
+	AfterEach(func() {
+		commandregistry.Register(originalSSHCodeGetter)
+		testServer.Close()
+		os.Setenv("CF_HOME", originalCFHome)
+		os.RemoveAll(cfHome)
+	})
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = configRepo
+
+		commandregistry.Register(sshCodeGetter)
+
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("ssh-config").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("ssh-config", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails with usage when not provided exactly one arg", func() {
+			requirementsFactory.LoginSuccess = true
+
+			runCommand()
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires APP_NAME as argument"},
+			))
+		})
+
+		It("fails requirements when not logged in", func() {
+			Expect(runCommand("my-app")).To(BeFalse())
+		})
+
+		It("fails if a space is not targeted", func() {
+			requirementsFactory.LoginSuccess = true
+			requirementsFactory.TargetedSpaceSuccess = false
+			Expect(runCommand("my-app")).To(BeFalse())
+		})
+	})
+
+	Context("when logged in and targeting a space", func() {
+		BeforeEach(func() {
+			requirementsFactory.LoginSuccess = true
+			requirementsFactory.TargetedSpaceSuccess = true
+		})
+
+		It("prints a Host entry that connects through ssh-proxy", func() {
+			Expect(runCommand("my-app")).To(BeTrue())
+
+			knownHostsPath := filepath.Join(cfHome, ".cf", "ssh_proxy_known_hosts")
+			Expect(ui.Outputs).To(Equal([]string{
+				"Host cf-my-app",
+				"    HostName ssh.run.pivotal.io",
+				"    Port 2222",
+				"    User cf:my-app-guid/0",
+				"    ProxyCommand cf ssh-proxy --api '" + testServer.URL + "' 'my-app-guid' -i 0",
+				"    HostKeyAlias cf-ssh-proxy",
+				"    UserKnownHostsFile " + knownHostsPath,
+			}))
+		})
+
+		It("trusts the ssh-proxy and SSH endpoint host keys in the known hosts file it points to", func() {
+			runCommand("my-app")
+
+			Expect(fakeKnownHosts.LookupCallCount()).To(Equal(1))
+			apiEndpoint, sshEndpoint := fakeKnownHosts.LookupArgsForCall(0)
+			Expect(apiEndpoint).To(Equal(testServer.URL))
+			Expect(sshEndpoint).To(Equal("ssh.run.pivotal.io:2222"))
+
+			knownHosts, err := ioutil.ReadFile(filepath.Join(cfHome, ".cf", "ssh_proxy_known_hosts"))
+			Expect(err).NotTo(HaveOccurred())
+			lines := strings.Split(strings.TrimSpace(string(knownHosts)), "\n")
+			Expect(lines).To(HaveLen(2))
+			Expect(strings.HasPrefix(lines[0], "cf-ssh-proxy ecdsa-sha2-nistp256 ")).To(BeTrue())
+			Expect(lines[1]).To(Equal("[ssh.run.pivotal.io]:2222 " + strings.TrimSpace(string(ssh.MarshalAuthorizedKey(endpointKey)))))
+
+			_, err = os.Stat(filepath.Join(cfHome, ".cf", "ssh_proxy_host_key"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSecureShell.ConnectCallCount()).To(Equal(0))
+		})
+
+		Context("when cf has not connected to the SSH endpoint before", func() {
+			BeforeEach(func() {
+				lookups := 0
+				fakeKnownHosts.LookupStub = func(string, string) (ssh.PublicKey, error) {
+					lookups++
+					if lookups == 1 {
+						return nil, nil
+					}
+					return endpointKey, nil
+				}
+			})
+
+			It("connects once to record the host key", func() {
+				Expect(runCommand("my-app", "-i", "1")).To(BeTrue())
+
+				Expect(sshCodeGetter.GetCallCount()).To(Equal(1))
+				Expect(fakeSecureShell.ConnectCallCount()).To(Equal(1))
+				Expect(fakeSecureShell.ConnectArgsForCall(0).Index).To(Equal(uint(1)))
+				Expect(fakeSecureShell.CloseCallCount()).To(Equal(1))
+
+				knownHosts, err := ioutil.ReadFile(filepath.Join(cfHome, ".cf", "ssh_proxy_known_hosts"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(knownHosts)).To(ContainSubstring("[ssh.run.pivotal.io]:2222 " + string(ssh.MarshalAuthorizedKey(endpointKey))))
+			})
+
+			It("fails when connecting fails", func() {
+				fakeSecureShell.ConnectReturns(errors.New("Host key verification failed"))
+
+				Expect(runCommand("my-app")).To(BeFalse())
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"Error getting the host key of the SSH endpoint", "Host key verification failed"},
+				))
+			})
+		})
+
+		It("names the instance and passes on the flags", func() {
+			runCommand("my-app", "-i", "2", "-k")
+
+			Expect(ui.Outputs).To(ContainElement("Host cf-my-app-2"))
+			Expect(ui.Outputs).To(ContainElement("    User cf:my-app-guid/2"))
+			Expect(ui.Outputs).To(ContainElement("    ProxyCommand cf ssh-proxy --api '" + testServer.URL + "' 'my-app-guid' -i 2 --skip-host-validation"))
+			Expect(fakeKnownHosts.LookupCallCount()).To(Equal(0))
+		})
+
+		It("pins the targeted profile", func() {
+			configRepo.SetSessionProfile("it's 100%")
+			runCommand("my-app")
+
+			Expect(ui.Outputs).To(ContainElement("    ProxyCommand cf --profile 'it'\\''s 100%%' ssh-proxy --api '" + testServer.URL + "' 'my-app-guid' -i 0"))
+		})
+
+		It("quotes the ProxyCommand arguments for the shell and for ssh", func() {
+			app := models.Application{}
+			app.Name = "my-app"
+			app.GUID = `a;b$(rm -rf x)` + "`id`" + `"c'%h`
+			requirementsFactory.Application = app
+
+			runCommand("my-app")
+
+			Expect(ui.Outputs).To(ContainElement("    ProxyCommand cf ssh-proxy --api '" + testServer.URL + "' " + `'a;b$(rm -rf x)` + "`id`" + `"c'\''%%h' -i 0`))
+		})
+
+		It("uses the host name given with --host", func() {
+			runCommand("my-app", "--host", "web")
+
+			Expect(ui.Outputs).To(ContainElement("Host web"))
+		})
+	})
+})
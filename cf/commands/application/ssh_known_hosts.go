@@ -0,0 +1,118 @@
+package application
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+)
+
+type SSHKnownHosts struct {
+	ui         terminal.UI
+	config     coreconfig.Reader
+	knownHosts knownhosts.KnownHosts
+}
+
+func init() {
+	commandregistry.Register(&SSHKnownHosts{})
+}
+
+func (cmd *SSHKnownHosts) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["api"] = &flags.StringFlag{Name: "api", Usage: T("API endpoint the SSH endpoint belongs to (Default: the targeted API endpoint)")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "ssh-known-hosts",
+		Description: T("List or remove the trusted host keys of SSH endpoints"),
+		Usage: []string{
+			T("CF_NAME ssh-known-hosts list\n"),
+			T("   CF_NAME ssh-known-hosts remove SSH_ENDPOINT [--api API_URL]"),
+		},
+		Examples: []string{
+			"CF_NAME ssh-known-hosts list",
+			"CF_NAME ssh-known-hosts remove ssh.example.com:2222",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *SSHKnownHosts) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	args := fc.Args()
+
+	switch {
+	case len(args) == 1 && args[0] == "list":
+	case len(args) == 2 && args[0] == "remove":
+	default:
+		cmd.ui.Failed(T("Incorrect Usage. Requires 'list', or 'remove' and SSH_ENDPOINT as arguments\n\n") + commandregistry.Commands.CommandUsage("ssh-known-hosts"))
+	}
+
+	return []requirements.Requirement{}
+}
+
+func (cmd *SSHKnownHosts) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.knownHosts = deps.KnownHosts
+	return cmd
+}
+
+func (cmd *SSHKnownHosts) Execute(fc flags.FlagContext) error {
+	if fc.Args()[0] == "list" {
+		return cmd.list()
+	}
+
+	apiEndpoint := cmd.config.APIEndpoint()
+	if fc.IsSet("api") {
+		apiEndpoint = fc.String("api")
+	}
+	if apiEndpoint == "" {
+		return errors.New(T("No API endpoint set. Use '--api' to name the API endpoint the SSH endpoint belongs to."))
+	}
+
+	return cmd.remove(apiEndpoint, fc.Args()[1])
+}
+
+func (cmd *SSHKnownHosts) list() error {
+	entries, err := cmd.knownHosts.List()
+	if err != nil {
+		return errors.New(T("Error reading known hosts: ") + err.Error())
+	}
+
+	if len(entries) == 0 {
+		cmd.ui.Say(T("No known SSH hosts."))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("api endpoint"), T("ssh endpoint"), T("key type"), T("fingerprint")})
+	for _, entry := range entries {
+		table.Add(entry.APIEndpoint, entry.SSHEndpoint, entry.Key.Type(), knownhosts.Fingerprint(entry.Key))
+	}
+	table.Print()
+
+	return nil
+}
+
+func (cmd *SSHKnownHosts) remove(apiEndpoint string, sshEndpoint string) error {
+	cmd.ui.Say(T("Removing the host key of {{.SSHEndpoint}} for {{.APIEndpoint}}...",
+		map[string]interface{}{
+			"SSHEndpoint": terminal.EntityNameColor(sshEndpoint),
+			"APIEndpoint": terminal.EntityNameColor(apiEndpoint),
+		}))
+
+	removed, err := cmd.knownHosts.Remove(apiEndpoint, sshEndpoint)
+	if err != nil {
+		return errors.New(T("Error removing known host: ") + err.Error())
+	}
+
+	cmd.ui.Ok()
+	if removed == 0 {
+		cmd.ui.Warn(T("No host key of {{.SSHEndpoint}} is known.", map[string]interface{}{"SSHEndpoint": sshEndpoint}))
+	}
+
+	return nil
+}
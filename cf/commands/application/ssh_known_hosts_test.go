@@ -0,0 +1,146 @@
+package application_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts/knownhostsfakes"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ssh-known-hosts command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *testreq.FakeReqFactory
+		configRepo          coreconfig.Repository
+		deps                commandregistry.Dependency
+		knownHosts          *knownhostsfakes.FakeKnownHosts
+	)
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAPIEndpoint("https://api.example.com")
+		requirementsFactory = &testreq.FakeReqFactory{}
+		knownHosts = new(knownhostsfakes.FakeKnownHosts)
+	})
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = configRepo
+		deps.KnownHosts = knownHosts
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("ssh-known-hosts").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("ssh-known-hosts", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails with usage when called without a subcommand", func() {
+			runCommand()
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires 'list', or 'remove' and SSH_ENDPOINT"},
+			))
+		})
+
+		It("fails with usage when remove is called without an SSH endpoint", func() {
+			runCommand("remove")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+		})
+
+		It("does not require logging in", func() {
+			Expect(runCommand("list")).To(BeTrue())
+		})
+	})
+
+	Describe("list", func() {
+		It("lists the trusted keys", func() {
+			privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			key, err := ssh.NewPublicKey(&privateKey.PublicKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			knownHosts.ListReturns([]knownhosts.Entry{
+				{APIEndpoint: "https://api.example.com", SSHEndpoint: "ssh.example.com:2222", Key: key},
+			}, nil)
+
+			runCommand("list")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"api endpoint", "ssh endpoint", "key type", "fingerprint"},
+				[]string{"https://api.example.com", "ssh.example.com:2222", "ecdsa-sha2-nistp256", knownhosts.Fingerprint(key)},
+			))
+		})
+
+		It("says when no keys are trusted", func() {
+			runCommand("list")
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"No known SSH hosts."}))
+		})
+
+		It("fails when the known hosts cannot be read", func() {
+			knownHosts.ListReturns(nil, errors.New("permission denied"))
+
+			runCommand("list")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Error reading known hosts", "permission denied"},
+			))
+		})
+	})
+
+	Describe("remove", func() {
+		It("removes the key of the SSH endpoint of the targeted API", func() {
+			knownHosts.RemoveReturns(1, nil)
+
+			runCommand("remove", "ssh.example.com:2222")
+
+			Expect(knownHosts.RemoveCallCount()).To(Equal(1))
+			apiEndpoint, sshEndpoint := knownHosts.RemoveArgsForCall(0)
+			Expect(apiEndpoint).To(Equal("https://api.example.com"))
+			Expect(sshEndpoint).To(Equal("ssh.example.com:2222"))
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Removing the host key of", "ssh.example.com:2222", "https://api.example.com"},
+				[]string{"OK"},
+			))
+		})
+
+		It("removes the key for the API given with --api", func() {
+			runCommand("remove", "ssh.other.com:2222", "--api", "https://api.other.com")
+
+			apiEndpoint, _ := knownHosts.RemoveArgsForCall(0)
+			Expect(apiEndpoint).To(Equal("https://api.other.com"))
+		})
+
+		It("warns when the key is not known", func() {
+			knownHosts.RemoveReturns(0, nil)
+
+			runCommand("remove", "ssh.example.com:2222")
+			Expect(ui.WarnOutputs).To(ContainSubstrings([]string{"No host key of ssh.example.com:2222 is known."}))
+		})
+
+		It("fails when no API is targeted or given", func() {
+			configRepo.SetAPIEndpoint("")
+
+			runCommand("remove", "ssh.example.com:2222")
+			Expect(knownHosts.RemoveCallCount()).To(Equal(0))
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"No API endpoint set"}))
+		})
+	})
+})
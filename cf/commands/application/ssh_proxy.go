@@ -0,0 +1,197 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/api/applications"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/commands"
+	"github.com/cloudfoundry/cli/cf/configuration/confighelpers"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	sshCmd "github.com/cloudfoundry/cli/cf/ssh"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
+	"github.com/cloudfoundry/cli/cf/ssh/options"
+	"github.com/cloudfoundry/cli/cf/ssh/terminal"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+)
+
+// sshProxyHostKeyAlias is the name the host key of cf ssh-proxy is known by
+// in the known hosts file cf ssh-config points ssh at.
+const sshProxyHostKeyAlias = "cf-ssh-proxy"
+
+type SSHProxy struct {
+	ui            terminal.UI
+	config        coreconfig.Reader
+	gateway       net.Gateway
+	appRepo       applications.Repository
+	sshCodeGetter commands.SSHCodeGetter
+	knownHosts    knownhosts.KnownHosts
+	opts          *options.SSHOptions
+	secureShell   sshCmd.SecureShell
+	stdin         io.Reader
+	stdout        io.WriteCloser
+	stderr        io.Writer
+}
+
+func init() {
+	commandregistry.Register(&SSHProxy{})
+}
+
+func (cmd *SSHProxy) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["app-instance-index"] = &flags.IntFlag{Name: "app-instance-index", ShortName: "i", Usage: T("Application instance index")}
+	fs["skip-host-validation"] = &flags.BoolFlag{Name: "skip-host-validation", ShortName: "k", Usage: T("Skip host key validation")}
+	fs["api"] = &flags.StringFlag{Name: "api", Usage: T("Fail unless cf targets this API endpoint")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "ssh-proxy",
+		Description: T("Connect ssh to an application container instance when used as its ProxyCommand, see ssh-config"),
+		Usage: []string{
+			T("CF_NAME ssh-proxy APP_GUID [-i app-instance-index] [--api API_URL] [--skip-host-validation]"),
+		},
+		Flags:  fs,
+		Hidden: true,
+	}
+}
+
+func (cmd *SSHProxy) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires APP_GUID as argument") + "\n\n" + commandregistry.Commands.CommandUsage("ssh-proxy"))
+	}
+
+	if fc.IsSet("i") && fc.Int("i") < 0 {
+		cmd.ui.Failed(fmt.Sprintf(T("Incorrect Usage:")+" %s\n\n%s", T("Value for flag 'app-instance-index' cannot be negative"), commandregistry.Commands.CommandUsage("ssh-proxy")))
+	}
+
+	var err error
+	cmd.opts, err = options.NewSSHOptions(fc)
+	if err != nil {
+		cmd.ui.Failed(fmt.Sprintf(T("Incorrect Usage:")+" %s\n\n%s", err.Error(), commandregistry.Commands.CommandUsage("ssh-proxy")))
+	}
+
+	// The app is found by its GUID rather than by name in the targeted
+	// space, so that an ssh config entry keeps reaching the same app after
+	// cf targets another org or space.
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+	}
+
+	return reqs
+}
+
+func (cmd *SSHProxy) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.gateway = deps.Gateways["cloud-controller"]
+	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.knownHosts = deps.KnownHosts
+	cmd.stdin = os.Stdin
+	cmd.stdout = os.Stdout
+	cmd.stderr = os.Stderr
+
+	if deps.WildcardDependency != nil {
+		cmd.secureShell = deps.WildcardDependency.(sshCmd.SecureShell)
+	}
+
+	sshCodeGetter := commandregistry.Commands.FindCommand("ssh-code")
+	sshCodeGetter = sshCodeGetter.SetDependency(deps, false)
+	cmd.sshCodeGetter = sshCodeGetter.(commands.SSHCodeGetter)
+
+	return cmd
+}
+
+func (cmd *SSHProxy) Execute(fc flags.FlagContext) error {
+	err := cmd.proxy(fc.String("api"))
+	if err != nil {
+		// ssh reads standard output as the connection, so the error would
+		// not be seen there.
+		fmt.Fprintln(cmd.stderr, err.Error())
+	}
+	return err
+}
+
+// proxy connects to the instance and relays ssh's connection to it. When
+// apiEndpoint is given, it refuses to connect unless cf targets it.
+func (cmd *SSHProxy) proxy(apiEndpoint string) error {
+	if apiEndpoint != "" && apiEndpoint != cmd.config.APIEndpoint() {
+		return errors.New(T("This ssh config entry is for {{.Expected}}, but cf targets {{.Actual}}. Target {{.Expected}} again, or run ssh-config again.",
+			map[string]interface{}{"Expected": apiEndpoint, "Actual": cmd.config.APIEndpoint()}))
+	}
+
+	app, err := cmd.appRepo.GetApp(cmd.opts.AppName)
+	if err != nil {
+		return errors.New(T("Error getting app: ") + err.Error())
+	}
+	cmd.opts.AppName = app.Name
+
+	info, err := getSSHEndpointInfo(cmd.gateway, cmd.config)
+	if err != nil {
+		return errors.New(T("Error getting SSH info:") + err.Error())
+	}
+
+	hostKeyPath, err := sshProxyFilePath("ssh_proxy_host_key")
+	if err != nil {
+		return err
+	}
+
+	hostKey, err := knownhosts.LoadOrCreateHostKey(hostKeyPath)
+	if err != nil {
+		return errors.New(T("Error loading the ssh-proxy host key: ") + err.Error())
+	}
+
+	// Every connection ssh makes runs its own ssh-proxy, and so gets its own
+	// one time auth code.
+	sshAuthCode, err := cmd.sshCodeGetter.Get()
+	if err != nil {
+		return errors.New(T("Error getting one time auth code: ") + err.Error())
+	}
+
+	//init secureShell if it is not already set by SetDependency() with fakes
+	if cmd.secureShell == nil {
+		cmd.secureShell = sshCmd.NewSecureShell(
+			sshCmd.DefaultSecureDialer(),
+			sshTerminal.DefaultHelper(),
+			sshCmd.DefaultListenerFactory(),
+			30*time.Second,
+			app,
+			info.SSHEndpointFingerprint,
+			info.SSHEndpoint,
+			sshAuthCode,
+			cmd.config.APIEndpoint(),
+			cmd.knownHosts,
+		)
+	}
+
+	err = cmd.secureShell.Connect(cmd.opts)
+	if err != nil {
+		return errors.New(T("Error opening SSH connection: ") + err.Error())
+	}
+	defer cmd.secureShell.Close()
+
+	err = cmd.secureShell.Proxy(cmd.stdin, cmd.stdout, hostKey)
+	if err != nil {
+		return errors.New(T("Error proxying SSH connection: ") + err.Error())
+	}
+
+	return nil
+}
+
+// sshProxyFilePath is the path of a file cf ssh-proxy and cf ssh-config
+// keep next to the cf config.
+func sshProxyFilePath(name string) (string, error) {
+	configPath, err := confighelpers.DefaultFilePath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(configPath), name), nil
+}
@@ -0,0 +1,212 @@
+package application_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/api"
+	"github.com/cloudfoundry/cli/cf/api/apifakes"
+	"github.com/cloudfoundry/cli/cf/api/applications/applicationsfakes"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/commands/commandsfakes"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
+	"github.com/cloudfoundry/cli/cf/ssh/sshfakes"
+	"github.com/cloudfoundry/cli/testhelpers/cloudcontrollergateway"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testnet "github.com/cloudfoundry/cli/testhelpers/net"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ssh-proxy command", func() {
+	var (
+		ui *testterm.FakeUI
+
+		sshCodeGetter         *commandsfakes.FakeSSHCodeGetter
+		originalSSHCodeGetter commandregistry.Command
+
+		requirementsFactory *testreq.FakeReqFactory
+		configRepo          coreconfig.Repository
+		deps                commandregistry.Dependency
+
+		fakeSecureShell *sshfakes.FakeSecureShell
+		appRepo         *applicationsfakes.FakeRepository
+		testServer      *httptest.Server
+
+		cfHome         string
+		originalCFHome string
+	)
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = &testreq.FakeReqFactory{}
+		deps.Gateways = make(map[string]net.Gateway)
+
+		var err error
+		cfHome, err = ioutil.TempDir("", "ssh-proxy")
+		Expect(err).NotTo(HaveOccurred())
+		originalCFHome = os.Getenv("CF_HOME")
+		os.Setenv("CF_HOME", cfHome)
+
+		originalSSHCodeGetter = commandregistry.Commands.FindCommand("ssh-code")
+
+		sshCodeGetter = new(commandsfakes.FakeSSHCodeGetter)
+		sshCodeGetter.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return sshCodeGetter
+		}
+		sshCodeGetter.MetaDataReturns(commandregistry.CommandMetadata{Name: "ssh-code"})
+
+		fakeSecureShell = new(sshfakes.FakeSecureShell)
+		deps.WildcardDependency = fakeSecureShell
+
+		getRequest := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+			Method: "GET",
+			Path:   "/v2/info",
+			Response: testnet.TestResponse{
+				Status: http.StatusOK,
+				Body:   getInfoResponseBody,
+			},
+		})
+
+		testServer, _ = testnet.NewServer([]testnet.TestRequest{getRequest})
+		configRepo.SetAPIEndpoint(testServer.URL)
+		deps.Gateways["cloud-controller"] = cloudcontrollergateway.NewTestCloudControllerGateway(configRepo)
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		appRepo = new(applicationsfakes.FakeRepository)
+		appRepo.GetAppReturns(app, nil)
+		deps.RepoLocator = api.RepositoryLocator{}.SetApplicationRepository(appRepo)
+	})
+
+	AfterEach(func() {
+		commandregistry.Register(originalSSHCodeGetter)
+		testServer.Close()
+		os.Setenv("CF_HOME", originalCFHome)
+		os.RemoveAll(cfHome)
+	})
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = configRepo
+
+		commandregistry.Register(sshCodeGetter)
+
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("ssh-proxy").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("ssh-proxy", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails with usage when not provided exactly one arg", func() {
+			requirementsFactory.LoginSuccess = true
+
+			runCommand()
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires APP_GUID as argument"},
+			))
+		})
+
+		It("fails requirements when not logged in", func() {
+			Expect(runCommand("my-app-guid")).To(BeFalse())
+		})
+
+		It("does not need a targeted space", func() {
+			requirementsFactory.LoginSuccess = true
+			requirementsFactory.TargetedSpaceSuccess = false
+			Expect(runCommand("my-app-guid")).To(BeTrue())
+		})
+	})
+
+	Context("when logged in", func() {
+		BeforeEach(func() {
+			requirementsFactory.LoginSuccess = true
+		})
+
+		It("connects to the instance with a fresh one time code and proxies standard input and output", func() {
+			Expect(runCommand("my-app-guid", "-i", "1")).To(BeTrue())
+
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			Expect(appRepo.GetAppArgsForCall(0)).To(Equal("my-app-guid"))
+			Expect(sshCodeGetter.GetCallCount()).To(Equal(1))
+			Expect(fakeSecureShell.ConnectCallCount()).To(Equal(1))
+			Expect(fakeSecureShell.ConnectArgsForCall(0).Index).To(Equal(uint(1)))
+			Expect(fakeSecureShell.ProxyCallCount()).To(Equal(1))
+
+			in, out, hostKey := fakeSecureShell.ProxyArgsForCall(0)
+			Expect(in).To(Equal(os.Stdin))
+			Expect(out).To(Equal(os.Stdout))
+			Expect(hostKey).NotTo(BeNil())
+			Expect(fakeSecureShell.CloseCallCount()).To(Equal(1))
+		})
+
+		It("presents the host key it keeps next to the cf config", func() {
+			runCommand("my-app-guid")
+
+			savedKey, err := knownhosts.LoadOrCreateHostKey(filepath.Join(cfHome, ".cf", "ssh_proxy_host_key"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, hostKey := fakeSecureShell.ProxyArgsForCall(0)
+			Expect(hostKey.PublicKey().Marshal()).To(Equal(savedKey.PublicKey().Marshal()))
+		})
+
+		It("fails when connecting fails", func() {
+			fakeSecureShell.ConnectReturns(errors.New("dial error"))
+
+			Expect(runCommand("my-app-guid")).To(BeFalse())
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Error opening SSH connection", "dial error"},
+			))
+			Expect(fakeSecureShell.ProxyCallCount()).To(Equal(0))
+		})
+
+		It("fails when proxying fails", func() {
+			fakeSecureShell.ProxyReturns(errors.New("handshake failed"))
+
+			Expect(runCommand("my-app-guid")).To(BeFalse())
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Error proxying SSH connection", "handshake failed"},
+			))
+		})
+
+		It("fails when the app cannot be found", func() {
+			appRepo.GetAppReturns(models.Application{}, errors.New("app not found"))
+
+			Expect(runCommand("my-app-guid")).To(BeFalse())
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Error getting app", "app not found"},
+			))
+			Expect(fakeSecureShell.ConnectCallCount()).To(Equal(0))
+		})
+
+		It("connects when cf targets the API endpoint given with --api", func() {
+			Expect(runCommand("--api", testServer.URL, "my-app-guid")).To(BeTrue())
+			Expect(fakeSecureShell.ProxyCallCount()).To(Equal(1))
+		})
+
+		It("fails when cf targets another API endpoint than the one given with --api", func() {
+			Expect(runCommand("--api", "https://api.other.example.com", "my-app-guid")).To(BeFalse())
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"This ssh config entry is for https://api.other.example.com", "cf targets " + testServer.URL},
+			))
+			Expect(appRepo.GetAppCallCount()).To(Equal(0))
+			Expect(fakeSecureShell.ConnectCallCount()).To(Equal(0))
+		})
+	})
+})
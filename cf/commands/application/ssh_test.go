@@ -2,15 +2,21 @@ package application_test
 
 import (
 	"errors"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"sync"
 
 	"github.com/cloudfoundry/cli/cf/api/apifakes"
+	"github.com/cloudfoundry/cli/cf/api/appinstances/appinstancesfakes"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/commands/commandsfakes"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/ssh/options"
 	"github.com/cloudfoundry/cli/cf/ssh/sshfakes"
 	"github.com/cloudfoundry/cli/testhelpers/cloudcontrollergateway"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
@@ -289,6 +295,46 @@ var _ = Describe("SSH command", func() {
 				})
 			})
 
+			Context("Error remote port forwarding when -R is provided", func() {
+				It("notifies users", func() {
+					fakeSecureShell.RemotePortForwardReturns(errors.New("tcpip-forward request denied"))
+
+					runCommand("my-app", "-R", "9229:localhost:9229")
+
+					Expect(ui.Outputs).To(ContainSubstrings(
+						[]string{"Error forwarding remote port", "tcpip-forward request denied"},
+					))
+					Expect(fakeSecureShell.InteractiveSessionCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("Error starting the SOCKS proxy when -D is provided", func() {
+				It("notifies users", func() {
+					fakeSecureShell.DynamicPortForwardReturns(errors.New("address in use"))
+
+					runCommand("my-app", "-D", "1080")
+
+					Expect(ui.Outputs).To(ContainSubstrings(
+						[]string{"Error starting SOCKS proxy", "address in use"},
+					))
+				})
+			})
+
+			Context("when -R and -D are provided with -N", func() {
+				It("sets up every forward and then waits", func() {
+					runCommand("my-app", "-N", "-L", "8000:localhost:8000", "-R", "9229:localhost:9229", "-D", "1080")
+
+					Expect(fakeSecureShell.LocalPortForwardCallCount()).To(Equal(1))
+					Expect(fakeSecureShell.RemotePortForwardCallCount()).To(Equal(1))
+					Expect(fakeSecureShell.DynamicPortForwardCallCount()).To(Equal(1))
+					Expect(fakeSecureShell.WaitCallCount()).To(Equal(1))
+
+					opts := fakeSecureShell.ConnectArgsForCall(0)
+					Expect(opts.RemoteForwardSpecs).To(HaveLen(1))
+					Expect(opts.DynamicForwardAddresses).To(Equal([]string{"localhost:1080"}))
+				})
+			})
+
 			Context("when -N is provided", func() {
 				It("calls secureShell.Wait()", func() {
 					fakeSecureShell.ConnectReturns(nil)
@@ -326,6 +372,144 @@ var _ = Describe("SSH command", func() {
 
 				})
 			})
+
+			Context("when --all-instances is provided", func() {
+				var appInstancesRepo *appinstancesfakes.FakeRepository
+
+				BeforeEach(func() {
+					appInstancesRepo = new(appinstancesfakes.FakeRepository)
+					appInstancesRepo.GetInstancesReturns([]models.AppInstanceFields{
+						{State: models.InstanceRunning},
+						{State: models.InstanceCrashed},
+						{State: models.InstanceRunning},
+					}, nil)
+					deps.RepoLocator = deps.RepoLocator.SetAppInstancesRepository(appInstancesRepo)
+
+					fakeSecureShell.ExecuteCommandStub = func(stdout io.Writer, stderr io.Writer) error {
+						_, _ = io.WriteString(stdout, "uptime\npartial")
+						return nil
+					}
+				})
+
+				It("runs the command on every running instance", func() {
+					Expect(runCommand("my-app", "--all-instances", "-c", "uptime", "-k")).To(BeTrue())
+
+					Expect(sshCodeGetter.GetCallCount()).To(Equal(2))
+					Expect(fakeSecureShell.ConnectCallCount()).To(Equal(2))
+					Expect(fakeSecureShell.ExecuteCommandCallCount()).To(Equal(2))
+					Expect(fakeSecureShell.CloseCallCount()).To(Equal(2))
+
+					indexes := []uint{fakeSecureShell.ConnectArgsForCall(0).Index, fakeSecureShell.ConnectArgsForCall(1).Index}
+					Expect(indexes).To(ConsistOf(uint(0), uint(2)))
+					Expect(fakeSecureShell.InteractiveSessionCallCount()).To(Equal(0))
+				})
+
+				It("gets each one time auth code right before connecting, after the previous instance has connected", func() {
+					var (
+						eventsLock sync.Mutex
+						events     []string
+					)
+					record := func(event string) {
+						eventsLock.Lock()
+						defer eventsLock.Unlock()
+						events = append(events, event)
+					}
+
+					sshCodeGetter.GetStub = func() (string, error) {
+						record("get")
+						return "code", nil
+					}
+					fakeSecureShell.ConnectStub = func(opts *options.SSHOptions) error {
+						record("connect")
+						return nil
+					}
+
+					Expect(runCommand("my-app", "--all-instances", "-c", "uptime", "-k")).To(BeTrue())
+					Expect(events).To(Equal([]string{"get", "connect", "get", "connect"}))
+				})
+
+				It("does not connect to an instance it could not get an auth code for", func() {
+					sshCodeGetter.GetStub = func() (string, error) {
+						if sshCodeGetter.GetCallCount() == 2 {
+							return "", errors.New("auth api error")
+						}
+						return "code", nil
+					}
+
+					Expect(runCommand("my-app", "--all-instances", "-c", "uptime", "-k")).To(BeFalse())
+					Expect(fakeSecureShell.ConnectCallCount()).To(Equal(1))
+					Expect(ui.Outputs).To(ContainSubstrings(
+						[]string{"Error getting one time auth code", "auth api error"},
+						[]string{"1 of 2 instances failed"},
+					))
+				})
+
+				It("writes the remote stderr of each instance to stderr", func() {
+					fakeSecureShell.ExecuteCommandStub = func(stdout io.Writer, stderr io.Writer) error {
+						_, _ = io.WriteString(stdout, "out line\n")
+						_, _ = io.WriteString(stderr, "err line\n")
+						return nil
+					}
+
+					errFile, err := ioutil.TempFile("", "ssh-stderr")
+					Expect(err).NotTo(HaveOccurred())
+					defer os.Remove(errFile.Name())
+
+					originalStderr := os.Stderr
+					os.Stderr = errFile
+					runCommand("my-app", "--all-instances", "-c", "uptime", "-k")
+					os.Stderr = originalStderr
+					errFile.Close()
+
+					stderr, err := ioutil.ReadFile(errFile.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(stderr)).To(ContainSubstring("[0] err line\n"))
+					Expect(string(stderr)).To(ContainSubstring("[2] err line\n"))
+					Expect(string(stderr)).NotTo(ContainSubstring("out line"))
+
+					Expect(ui.Outputs).To(ContainElement("[0] out line"))
+					Expect(ui.Outputs).NotTo(ContainElement("[0] err line"))
+				})
+
+				It("prefixes each line of output with the instance index", func() {
+					runCommand("my-app", "--all-instances", "-c", "uptime", "-k")
+
+					Expect(ui.Outputs).To(ContainElement("[0] uptime"))
+					Expect(ui.Outputs).To(ContainElement("[0] partial"))
+					Expect(ui.Outputs).To(ContainElement("[2] uptime"))
+					Expect(ui.Outputs).NotTo(ContainElement("[1] uptime"))
+				})
+
+				It("fails with a summary when an instance fails", func() {
+					fakeSecureShell.ConnectStub = func(opts *options.SSHOptions) error {
+						if opts.Index == 2 {
+							return errors.New("dial error")
+						}
+						return nil
+					}
+
+					Expect(runCommand("my-app", "--all-instances", "-c", "uptime", "-k")).To(BeFalse())
+					Expect(ui.Outputs).To(ContainSubstrings(
+						[]string{"0", "exited 0"},
+						[]string{"2", "Error opening SSH connection", "dial error"},
+						[]string{"FAILED"},
+						[]string{"1 of 2 instances failed"},
+					))
+				})
+
+				It("fails when no instance is running", func() {
+					appInstancesRepo.GetInstancesReturns([]models.AppInstanceFields{
+						{State: models.InstanceCrashed},
+					}, nil)
+
+					runCommand("my-app", "--all-instances", "-c", "uptime", "-k")
+					Expect(ui.Outputs).To(ContainSubstrings(
+						[]string{"FAILED"},
+						[]string{"App my-app has no running instances"},
+					))
+					Expect(fakeSecureShell.ConnectCallCount()).To(Equal(0))
+				})
+			})
 		})
 	})
 })
@@ -55,6 +55,11 @@ type Start struct {
 	logRepo          logs.Repository
 	appInstancesRepo appinstances.Repository
 
+	// newLogRepo returns a logs repository of its own. A logs repository
+	// tails one app at a time, so each app pushed in parallel tails its
+	// staging logs through its own.
+	newLogRepo func() logs.Repository
+
 	LogServerConnectionTimeout time.Duration
 	StartupTimeout             time.Duration
 	StagingTimeout             time.Duration
@@ -98,6 +103,7 @@ func (cmd *Start) SetDependency(deps commandregistry.Dependency, pluginCall bool
 	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
 	cmd.appInstancesRepo = deps.RepoLocator.GetAppInstancesRepository()
 	cmd.logRepo = deps.RepoLocator.GetLogsRepository()
+	cmd.newLogRepo = deps.RepoLocator.NewLogsRepository
 	cmd.LogServerConnectionTimeout = 20 * time.Second
 	cmd.PingerThrottle = DefaultPingerThrottle
 
@@ -10,6 +10,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 )
@@ -30,6 +31,7 @@ func (cmd *ListBuildpacks) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME buildpacks"),
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -60,6 +62,7 @@ func (cmd *ListBuildpacks) Execute(c flags.FlagContext) error {
 
 	table := cmd.ui.Table([]string{"buildpack", T("position"), T("enabled"), T("locked"), T("filename")})
 	noBuildpacks := true
+	records := []presenters.Buildpack{}
 
 	apiErr := cmd.buildpackRepo.ListBuildpacks(func(buildpack models.Buildpack) bool {
 		position := ""
@@ -81,9 +84,15 @@ func (cmd *ListBuildpacks) Execute(c flags.FlagContext) error {
 			locked,
 			buildpack.Filename,
 		)
+		records = append(records, presenters.NewBuildpack(buildpack))
 		noBuildpacks = false
 		return true
 	})
+
+	if cmd.ui.OutputFormat().IsStructured() && apiErr == nil {
+		return cmd.ui.PrintStructured(records)
+	}
+
 	table.Print()
 
 	if apiErr != nil {
@@ -2,10 +2,13 @@ package commands
 
 import (
 	"errors"
+	"path/filepath"
 	"sort"
 
 	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/confighelpers"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/configuration/credentials"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -25,15 +28,19 @@ func init() {
 func (cmd *ConfigCommands) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["async-timeout"] = &flags.IntFlag{Name: "async-timeout", Usage: T("Timeout for async HTTP requests")}
+	fs["retries"] = &flags.IntFlag{Name: "retries", Usage: T("Times to retry API requests that fail for a passing reason, such as a router or API restart (Default: 2)")}
+	fs["plugin-grace-period"] = &flags.IntFlag{Name: "plugin-grace-period", Usage: T("Seconds a plugin has to exit after it is passed an interrupt or termination signal, before it is killed (Default: 10)")}
 	fs["trace"] = &flags.StringFlag{Name: "trace", Usage: T("Trace HTTP requests")}
 	fs["color"] = &flags.StringFlag{Name: "color", Usage: T("Enable or disable color")}
 	fs["locale"] = &flags.StringFlag{Name: "locale", Usage: T("Set default locale. If LOCALE is 'CLEAR', previous locale is deleted.")}
+	fs["plugin-install-policy"] = &flags.StringFlag{Name: "plugin-install-policy", Usage: T("Which plugins can be installed: 'any', 'signed' for plugins signed by a trusted plugin key, or 'signed-repo' for signed plugins from a registered repository (Default: any)")}
+	fs["credential-store"] = &flags.StringFlag{Name: "credential-store", Usage: T("Where to keep access and refresh tokens: 'config' for the config file, 'encrypted' for a file encrypted with the passphrase in CF_CREDENTIALS_PASSPHRASE, or the NAME of a credential helper, run as cf-credential-NAME")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "config",
 		Description: T("Write default values to the config"),
 		Usage: []string{
-			T("CF_NAME config [--async-timeout TIMEOUT_IN_MINUTES] [--trace (true | false | path/to/file)] [--color (true | false)] [--locale (LOCALE | CLEAR)]"),
+			T("CF_NAME config [--async-timeout TIMEOUT_IN_MINUTES] [--retries RETRIES] [--plugin-grace-period SECONDS] [--trace (true | false | path/to/file)] [--color (true | false)] [--locale (LOCALE | CLEAR)] [--plugin-install-policy (any | signed | signed-repo)] [--credential-store (config | encrypted | HELPER_NAME)]"),
 		},
 		Flags: fs,
 	}
@@ -51,7 +58,7 @@ func (cmd *ConfigCommands) SetDependency(deps commandregistry.Dependency, plugin
 }
 
 func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
-	if !context.IsSet("trace") && !context.IsSet("async-timeout") && !context.IsSet("color") && !context.IsSet("locale") {
+	if !context.IsSet("trace") && !context.IsSet("async-timeout") && !context.IsSet("retries") && !context.IsSet("plugin-grace-period") && !context.IsSet("color") && !context.IsSet("locale") && !context.IsSet("plugin-install-policy") && !context.IsSet("credential-store") {
 		return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
 	}
 
@@ -64,6 +71,24 @@ func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
 		cmd.config.SetAsyncTimeout(uint(asyncTimeout))
 	}
 
+	if context.IsSet("retries") {
+		retries := context.Int("retries")
+		if retries < 0 {
+			return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+		}
+
+		cmd.config.SetRequestRetries(uint(retries))
+	}
+
+	if context.IsSet("plugin-grace-period") {
+		gracePeriod := context.Int("plugin-grace-period")
+		if gracePeriod < 0 {
+			return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+		}
+
+		cmd.config.SetPluginGracePeriod(uint(gracePeriod))
+	}
+
 	if context.IsSet("trace") {
 		cmd.config.SetTrace(context.String("trace"))
 	}
@@ -80,6 +105,23 @@ func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
 		}
 	}
 
+	if context.IsSet("plugin-install-policy") {
+		policy := context.String("plugin-install-policy")
+		switch policy {
+		case coreconfig.PluginInstallPolicyAny, coreconfig.PluginInstallPolicySigned, coreconfig.PluginInstallPolicySignedRepo:
+			cmd.config.SetPluginInstallPolicy(policy)
+		default:
+			return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+		}
+	}
+
+	if context.IsSet("credential-store") {
+		err := cmd.setCredentialStore(context.String("credential-store"))
+		if err != nil {
+			return err
+		}
+	}
+
 	if context.IsSet("locale") {
 		locale := context.String("locale")
 
@@ -106,3 +148,32 @@ func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
 	}
 	return nil
 }
+
+func (cmd *ConfigCommands) setCredentialStore(name string) error {
+	if name == "config" {
+		cmd.config.SetCredentialStore("")
+		return nil
+	}
+
+	if name == "" {
+		return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+	}
+
+	configPath, err := confighelpers.DefaultFilePath()
+	if err != nil {
+		return err
+	}
+
+	// The store is checked up front, as the tokens cannot be moved to a
+	// store that cannot be used.
+	_, err = credentials.NewStore(name, filepath.Dir(configPath))
+	if err != nil {
+		return errors.New(T("Credential store {{.Name}} cannot be used: {{.Err}}", map[string]interface{}{
+			"Name": name,
+			"Err":  err.Error(),
+		}))
+	}
+
+	cmd.config.SetCredentialStore(name)
+	return nil
+}
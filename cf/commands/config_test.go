@@ -1,6 +1,8 @@
 package commands_test
 
 import (
+	"os"
+
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
@@ -66,6 +68,62 @@ var _ = Describe("config command", func() {
 		})
 	})
 
+	Context("--retries flag", func() {
+		It("stores the number of retries", func() {
+			runCommand("--retries", "5")
+			Expect(configRepo.RequestRetries()).To(Equal(uint(5)))
+
+			runCommand("--retries", "0")
+			Expect(configRepo.RequestRetries()).To(Equal(uint(0)))
+		})
+
+		It("fails with usage when a negative number is passed", func() {
+			runCommand("--retries", "-1")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+			Expect(configRepo.RequestRetries()).To(Equal(uint(coreconfig.DefaultRequestRetries)))
+		})
+	})
+
+	Context("--plugin-grace-period flag", func() {
+		It("stores the grace period", func() {
+			runCommand("--plugin-grace-period", "30")
+			Expect(configRepo.PluginGracePeriod()).To(Equal(uint(30)))
+
+			runCommand("--plugin-grace-period", "0")
+			Expect(configRepo.PluginGracePeriod()).To(Equal(uint(0)))
+		})
+
+		It("fails with usage when a negative number is passed", func() {
+			runCommand("--plugin-grace-period", "-1")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+			Expect(configRepo.PluginGracePeriod()).To(Equal(uint(coreconfig.DefaultPluginGracePeriod)))
+		})
+	})
+
+	Context("--plugin-install-policy flag", func() {
+		It("stores the policy", func() {
+			Expect(configRepo.PluginInstallPolicy()).To(Equal(coreconfig.PluginInstallPolicyAny))
+
+			runCommand("--plugin-install-policy", "signed-repo")
+			Expect(configRepo.PluginInstallPolicy()).To(Equal(coreconfig.PluginInstallPolicySignedRepo))
+
+			runCommand("--plugin-install-policy", "signed")
+			Expect(configRepo.PluginInstallPolicy()).To(Equal(coreconfig.PluginInstallPolicySigned))
+		})
+
+		It("fails with usage when an unknown policy is passed", func() {
+			runCommand("--plugin-install-policy", "some-policy")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+			Expect(configRepo.PluginInstallPolicy()).To(Equal(coreconfig.PluginInstallPolicyAny))
+		})
+	})
+
 	Context("--trace flag", func() {
 		It("stores the trace value when --trace flag is provided", func() {
 			runCommand("--trace", "true")
@@ -125,4 +183,48 @@ var _ = Describe("config command", func() {
 			})
 		})
 	})
+
+	Context("--credential-store flag", func() {
+		var originalPassphrase string
+
+		BeforeEach(func() {
+			originalPassphrase = os.Getenv("CF_CREDENTIALS_PASSPHRASE")
+		})
+
+		AfterEach(func() {
+			os.Setenv("CF_CREDENTIALS_PASSPHRASE", originalPassphrase)
+		})
+
+		It("stores the credential store when it can be used", func() {
+			os.Setenv("CF_CREDENTIALS_PASSPHRASE", "my-passphrase")
+
+			runCommand("--credential-store", "encrypted")
+			Expect(configRepo.CredentialStore()).To(Equal("encrypted"))
+		})
+
+		It("moves the tokens back to the config file with 'config'", func() {
+			configRepo.SetCredentialStore("encrypted")
+
+			runCommand("--credential-store", "config")
+			Expect(configRepo.CredentialStore()).To(BeEmpty())
+		})
+
+		It("fails when the credential store cannot be used", func() {
+			os.Setenv("CF_CREDENTIALS_PASSPHRASE", "")
+
+			runCommand("--credential-store", "encrypted")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Credential store encrypted cannot be used", "CF_CREDENTIALS_PASSPHRASE must be set"},
+			))
+			Expect(configRepo.CredentialStore()).To(BeEmpty())
+		})
+
+		It("fails for a credential helper that cannot be found", func() {
+			runCommand("--credential-store", "no-such-keyring")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Credential store no-such-keyring cannot be used", "cf-credential-no-such-keyring"},
+			))
+		})
+	})
 })
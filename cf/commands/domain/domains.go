@@ -8,6 +8,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -31,6 +32,7 @@ func (cmd *ListDomains) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			"CF_NAME domains",
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -73,6 +75,14 @@ func (cmd *ListDomains) Execute(c flags.FlagContext) error {
 		return errors.New(T("Failed fetching domains.\n{{.Error}}", map[string]interface{}{"Error": err.Error()}))
 	}
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.Domain{}
+		for _, domain := range domains {
+			records = append(records, presenters.NewDomain(domain))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	table := cmd.ui.Table([]string{T("name"), T("status"), T("type")})
 
 	for _, domain := range domains {
@@ -5,6 +5,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -28,6 +29,7 @@ func (cmd *StagingEnvironmentVariableGroup) MetaData() commandregistry.CommandMe
 		Usage: []string{
 			T("CF_NAME staging-environment-variable-group"),
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -64,6 +66,14 @@ func (cmd *StagingEnvironmentVariableGroup) Execute(c flags.FlagContext) error {
 
 	cmd.ui.Ok()
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.EnvironmentVariable{}
+		for _, envVar := range stagingEnvVars {
+			records = append(records, presenters.NewEnvironmentVariable(envVar))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	table := cmd.ui.Table([]string{T("Variable Name"), T("Assigned Value")})
 	for _, envVar := range stagingEnvVars {
 		table.Add(envVar.Name, envVar.Value)
@@ -5,6 +5,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -27,6 +28,7 @@ func (cmd *ShowFeatureFlag) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME feature-flag FEATURE_NAME"),
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -64,6 +66,10 @@ func (cmd *ShowFeatureFlag) Execute(c flags.FlagContext) error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		return cmd.ui.PrintStructured(presenters.NewFeatureFlag(flag))
+	}
+
 	table := cmd.ui.Table([]string{T("Features"), T("State")})
 	table.Add(flag.Name, cmd.flagBoolToString(flag.Enabled))
 
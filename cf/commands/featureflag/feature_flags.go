@@ -5,6 +5,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -27,6 +28,7 @@ func (cmd *ListFeatureFlags) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME feature-flags"),
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -65,6 +67,14 @@ func (cmd *ListFeatureFlags) Execute(c flags.FlagContext) error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.FeatureFlag{}
+		for _, flag := range flags {
+			records = append(records, presenters.NewFeatureFlag(flag))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	table := cmd.ui.Table([]string{T("Features"), T("State")})
 
 	for _, flag := range flags {
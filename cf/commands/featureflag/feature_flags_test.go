@@ -8,6 +8,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commands/featureflag"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
@@ -115,6 +116,19 @@ var _ = Describe("feature-flags command", func() {
 			))
 		})
 
+		It("lists the feature flags as yaml when yaml output is requested", func() {
+			ui.Format = terminal.YAMLOutput
+
+			runCommand()
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"- name: user_org_creation"},
+				[]string{"enabled: true"},
+				[]string{"- name: private_domain_creation"},
+				[]string{"enabled: false"},
+			))
+			Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"error"}))
+		})
+
 		Context("when an error occurs", func() {
 			BeforeEach(func() {
 				flagRepo.ListReturns(nil, errors.New("An error occurred."))
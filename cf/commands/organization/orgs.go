@@ -8,6 +8,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -36,6 +37,7 @@ func (cmd *ListOrgs) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			"CF_NAME orgs",
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -80,6 +82,18 @@ func (cmd ListOrgs) Execute(fc flags.FlagContext) error {
 		noOrgs = false
 	}
 
+	if cmd.pluginCall {
+		cmd.populatePluginModel(orgs)
+	}
+
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.Organization{}
+		for _, org := range orgs {
+			records = append(records, presenters.NewOrganization(org))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	table.Print()
 
 	if err != nil {
@@ -90,10 +104,6 @@ func (cmd ListOrgs) Execute(fc flags.FlagContext) error {
 	if noOrgs {
 		cmd.ui.Say(T("No orgs found"))
 	}
-
-	if cmd.pluginCall {
-		cmd.populatePluginModel(orgs)
-	}
 	return nil
 }
 
@@ -0,0 +1,83 @@
+package plugin
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/actors/plugininstaller"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+)
+
+type AddPluginKey struct {
+	ui     terminal.UI
+	config coreconfig.ReadWriter
+}
+
+func init() {
+	commandregistry.Register(&AddPluginKey{})
+}
+
+func (cmd *AddPluginKey) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "add-plugin-key",
+		Description: T("Trust a public key to sign plugins"),
+		Usage: []string{
+			T(`CF_NAME add-plugin-key KEY_NAME PUBLIC_KEY
+
+   PUBLIC_KEY is a base64 encoded ed25519 public key. A plugin is signed with the base64 encoded ed25519 signature of its binary, put next to it with a '.sig' suffix.
+   Use 'CF_NAME config --plugin-install-policy' to only allow installing signed plugins.`),
+		},
+		Examples: []string{
+			"CF_NAME add-plugin-key MyCompany 11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo=",
+		},
+		TotalArgs: 2,
+	}
+}
+
+func (cmd *AddPluginKey) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires KEY_NAME and PUBLIC_KEY as arguments\n\n") + commandregistry.Commands.CommandUsage("add-plugin-key"))
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs
+}
+
+func (cmd *AddPluginKey) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *AddPluginKey) Execute(c flags.FlagContext) error {
+	cmd.ui.Say("")
+	keyName := strings.Trim(c.Args()[0], " ")
+	publicKey := strings.TrimSpace(c.Args()[1])
+
+	for _, key := range cmd.config.PluginKeys() {
+		if strings.ToLower(key.Name) == strings.ToLower(keyName) {
+			return errors.New(T(`Plugin key named "{{.KeyName}}" already exists, please use another name.`, map[string]interface{}{"KeyName": keyName}))
+		}
+	}
+
+	_, err := plugininstaller.ParsePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	cmd.config.SetPluginKey(models.PluginKey{
+		Name:      keyName,
+		PublicKey: publicKey,
+	})
+
+	cmd.ui.Ok()
+	cmd.ui.Say(T("Plugin key {{.KeyName}} added to the trusted keys", map[string]interface{}{"KeyName": keyName}))
+	cmd.ui.Say("")
+	return nil
+}
@@ -0,0 +1,75 @@
+package plugin_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("add-plugin-key", func() {
+	const publicKey = "11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo="
+
+	var (
+		ui                  *testterm.FakeUI
+		config              coreconfig.Repository
+		requirementsFactory *testreq.FakeReqFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("add-plugin-key").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = &testreq.FakeReqFactory{}
+		config = testconfig.NewRepositoryWithDefaults()
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("add-plugin-key", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("fails with usage when not provided a key name and a public key", func() {
+		runCommand("key1")
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"Incorrect Usage", "Requires KEY_NAME and PUBLIC_KEY as arguments"}))
+	})
+
+	It("adds the key to the trusted keys", func() {
+		runCommand("key1", publicKey)
+
+		Expect(config.PluginKeys()).To(Equal([]models.PluginKey{{Name: "key1", PublicKey: publicKey}}))
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"OK"},
+			[]string{"Plugin key key1 added to the trusted keys"},
+		))
+	})
+
+	It("fails when the public key is not an ed25519 public key", func() {
+		runCommand("key1", "c2hvcnQ=")
+
+		Expect(config.PluginKeys()).To(BeEmpty())
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"not a base64 encoded ed25519 public key"},
+		))
+	})
+
+	It("fails when a key with the same name exists", func() {
+		config.SetPluginKey(models.PluginKey{Name: "Key1", PublicKey: publicKey})
+		runCommand("key1", publicKey)
+
+		Expect(config.PluginKeys()).To(HaveLen(1))
+		Expect(ui.Outputs).To(ContainSubstrings([]string{`Plugin key named "key1" already exists`}))
+	})
+})
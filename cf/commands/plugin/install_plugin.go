@@ -6,7 +6,9 @@ import (
 	"net/rpc"
 	"os"
 	"os/exec"
+	"os/user"
 	"path/filepath"
+	"time"
 
 	"github.com/cloudfoundry/cli/cf/actors/plugininstaller"
 	"github.com/cloudfoundry/cli/cf/actors/pluginrepo"
@@ -81,7 +83,7 @@ func (cmd *PluginInstall) SetDependency(deps commandregistry.Dependency, pluginC
 	//each service can only be registered once
 	rpc.DefaultServer = rpc.NewServer()
 
-	rpcService, err := pluginRPCService.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, pluginRPCService.NewCommandRunner(), deps.Logger, cmd.ui.Writer())
+	rpcService, err := pluginRPCService.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, pluginRPCService.NewCommandRunner(), deps.Logger, cmd.ui.Writer(), rpc.DefaultServer)
 	if err != nil {
 		cmd.ui.Failed("Error initializing RPC service: " + err.Error())
 	}
@@ -92,6 +94,11 @@ func (cmd *PluginInstall) SetDependency(deps commandregistry.Dependency, pluginC
 }
 
 func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
+	err := ensureInstallPolicyAllowsSource(cmd.config, c.String("r"))
+	if err != nil {
+		return err
+	}
+
 	if !cmd.confirmWithUser(
 		c,
 		T("**Attention: Plugins are binaries written by potentially untrusted authors. Install and use plugins at your own risk.**\n\nDo you want to install the plugin {{.Plugin}}? (y or n)", map[string]interface{}{"Plugin": c.Args()[0]}),
@@ -120,18 +127,23 @@ func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
 	installer := plugininstaller.NewPluginInstaller(deps)
 	pluginSourceFilepath := installer.Install(c.Args()[0])
 
+	signedBy, err := verifyPluginSignature(cmd.ui, cmd.config, installer.Source(), pluginSourceFilepath)
+	if err != nil {
+		return err
+	}
+
 	_, pluginExecutableName := filepath.Split(pluginSourceFilepath)
 
 	cmd.ui.Say(fmt.Sprintf(T("Installing plugin {{.PluginPath}}...", map[string]interface{}{"PluginPath": pluginExecutableName})))
 
 	pluginDestinationFilepath := filepath.Join(cmd.pluginConfig.GetPluginPath(), pluginExecutableName)
 
-	err := cmd.ensurePluginBinaryWithSameFileNameDoesNotAlreadyExist(pluginDestinationFilepath, pluginExecutableName)
+	err = cmd.ensurePluginBinaryWithSameFileNameDoesNotAlreadyExist(pluginDestinationFilepath, pluginExecutableName)
 	if err != nil {
 		return err
 	}
 
-	pluginMetadata, err := cmd.runBinaryAndObtainPluginMetadata(pluginSourceFilepath)
+	pluginMetadata, err := runBinaryAndObtainPluginMetadata(cmd.rpcService, pluginSourceFilepath)
 	if err != nil {
 		return err
 	}
@@ -146,11 +158,77 @@ func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	recordPluginAudit(cmd.ui, cmd.config, cmd.pluginConfig, plugininstaller.AuditRecord{
+		Action:   "install",
+		Plugin:   pluginMetadata.Name,
+		Version:  formatVersion(pluginMetadata.Version),
+		Source:   installer.Source(),
+		Repo:     c.String("r"),
+		SignedBy: signedBy,
+	}, pluginDestinationFilepath)
+
 	cmd.ui.Ok()
 	cmd.ui.Say(fmt.Sprintf(T("Plugin {{.PluginName}} v{{.Version}} successfully installed.", map[string]interface{}{"PluginName": pluginMetadata.Name, "Version": fmt.Sprintf("%d.%d.%d", pluginMetadata.Version.Major, pluginMetadata.Version.Minor, pluginMetadata.Version.Build)})))
 	return nil
 }
 
+// ensureInstallPolicyAllowsSource checks that the plugin install policy of
+// config allows installing plugins from the repo repoName, or from a path or
+// URL when repoName is "".
+func ensureInstallPolicyAllowsSource(config coreconfig.Reader, repoName string) error {
+	if config.PluginInstallPolicy() == coreconfig.PluginInstallPolicySignedRepo && repoName == "" {
+		return errors.New(T("The plugin install policy only allows installing plugins from a registered repository, with '-r REPO_NAME'"))
+	}
+	return nil
+}
+
+// verifyPluginSignature checks, when the plugin install policy of config
+// requires it, that the plugin binary at pluginSourceFilepath, which came
+// from source, has a detached signature by a trusted plugin key. It returns
+// the name of that key, or "" if the signature was not checked.
+func verifyPluginSignature(ui terminal.UI, config coreconfig.Reader, source string, pluginSourceFilepath string) (string, error) {
+	if config.PluginInstallPolicy() == coreconfig.PluginInstallPolicyAny {
+		return "", nil
+	}
+
+	signature, err := plugininstaller.FetchSignature(source)
+	if err != nil {
+		return "", errors.New(T("The plugin install policy only allows installing signed plugins, and the signature {{.Signature}} could not be read:\n{{.Error}}", map[string]interface{}{"Signature": source + plugininstaller.SignatureSuffix, "Error": err.Error()}))
+	}
+
+	key, err := plugininstaller.VerifySignature(pluginSourceFilepath, signature, config.PluginKeys())
+	if err != nil {
+		return "", errors.New(T("The plugin install policy only allows installing signed plugins:\n{{.Error}}", map[string]interface{}{"Error": err.Error()}))
+	}
+
+	ui.Say(T("Plugin signature verified with trusted key {{.KeyName}}", map[string]interface{}{"KeyName": key.Name}))
+	return key.Name, nil
+}
+
+// recordPluginAudit fills in who changed the plugin, when, and the SHA1 of
+// its binary at location, and appends the record to the plugin audit log.
+// Failing to do so is only warned about, as the plugin is installed by then.
+func recordPluginAudit(ui terminal.UI, config coreconfig.Reader, pluginConfig pluginconfig.PluginConfiguration, record plugininstaller.AuditRecord, location string) {
+	record.Time = time.Now().UTC()
+	record.CFUser = config.Username()
+	record.API = config.APIEndpoint()
+
+	record.OSUser = os.Getenv("USER")
+	if currentUser, err := user.Current(); err == nil {
+		record.OSUser = currentUser.Username
+	}
+
+	sha1, err := utils.NewSha1Checksum(location).ComputeFileSha1()
+	if err == nil {
+		record.SHA1 = fmt.Sprintf("%x", sha1)
+	}
+
+	err = plugininstaller.AppendAuditRecord(filepath.Join(pluginConfig.GetPluginPath(), "audit.log"), record)
+	if err != nil {
+		ui.Warn(T("Could not record the plugin change in the audit log: {{.Error}}", map[string]interface{}{"Error": err.Error()}))
+	}
+}
+
 func (cmd *PluginInstall) confirmWithUser(c flags.FlagContext, prompt string) bool {
 	return c.Bool("f") || cmd.ui.Confirm(prompt)
 }
@@ -178,6 +256,13 @@ func (cmd *PluginInstall) ensurePluginIsSafeForInstallation(pluginMetadata *plug
 		return errors.New(fmt.Sprintf(T("Plugin name {{.PluginName}} is already taken", map[string]interface{}{"PluginName": pluginMetadata.Name})))
 	}
 
+	return ensurePluginCommandsDoNotConflict(pluginMetadata, plugins, pluginSourceFilepath)
+}
+
+// ensurePluginCommandsDoNotConflict checks that none of the commands or
+// aliases of the plugin in pluginSourceFilepath is a core command or alias,
+// or a command or alias of one of the installed plugins.
+func ensurePluginCommandsDoNotConflict(pluginMetadata *plugin.PluginMetadata, plugins map[string]pluginconfig.PluginMetadata, pluginSourceFilepath string) error {
 	if pluginMetadata.Commands == nil {
 		return errors.New(fmt.Sprintf(T("Error getting command list from plugin {{.FilePath}}", map[string]interface{}{"FilePath": pluginSourceFilepath})))
 	}
@@ -226,28 +311,29 @@ func (cmd *PluginInstall) installPlugin(pluginMetadata *plugin.PluginMetadata, p
 		Location: pluginDestinationFilepath,
 		Version:  pluginMetadata.Version,
 		Commands: pluginMetadata.Commands,
+		Hooks:    pluginMetadata.Hooks,
 	}
 
 	cmd.pluginConfig.SetPlugin(pluginMetadata.Name, configMetadata)
 	return nil
 }
 
-func (cmd *PluginInstall) runBinaryAndObtainPluginMetadata(pluginSourceFilepath string) (*plugin.PluginMetadata, error) {
-	err := cmd.rpcService.Start()
+func runBinaryAndObtainPluginMetadata(rpcService *pluginRPCService.CliRpcService, pluginSourceFilepath string) (*plugin.PluginMetadata, error) {
+	err := rpcService.Start()
 	if err != nil {
 		return nil, err
 	}
-	defer cmd.rpcService.Stop()
+	defer rpcService.Stop()
 
-	err = cmd.runPluginBinary(pluginSourceFilepath, cmd.rpcService.Port())
+	err = runPluginBinary(pluginSourceFilepath, rpcService.Port())
 	if err != nil {
 		return nil, err
 	}
 
-	return cmd.rpcService.RpcCmd.PluginMetadata, nil
+	return rpcService.RpcCmd.PluginMetadata, nil
 }
 
-func (cmd *PluginInstall) runPluginBinary(location string, servicePort string) error {
+func runPluginBinary(location string, servicePort string) error {
 	pluginInvocation := exec.Command(location, servicePort, "SendMetadata")
 
 	err := pluginInvocation.Run()
@@ -1,6 +1,8 @@
 package plugin_test
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -9,6 +11,7 @@ import (
 	"path/filepath"
 	"runtime"
 
+	"github.com/cloudfoundry/cli/cf/actors/plugininstaller"
 	"github.com/cloudfoundry/cli/cf/actors/pluginrepo/pluginrepofakes"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/commandregistry/commandregistryfakes"
@@ -24,6 +27,8 @@ import (
 	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
 	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
 	"github.com/cloudfoundry/cli/utils/utilsfakes"
+	"github.com/cloudfoundry/gofileutils/fileutils"
+	"golang.org/x/crypto/ed25519"
 
 	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/models"
 
@@ -606,6 +611,130 @@ var _ = Describe("Install", func() {
 			Expect(runCommand(test_1, "-f")).To(Equal(true))
 			Expect(runCommand(test_2, "-f")).To(Equal(true))
 		})
+
+		It("records who installed the plugin and from where in the audit log", func() {
+			runCommand(test_1, "-f")
+
+			auditLog, err := ioutil.ReadFile(filepath.Join(pluginDir, "audit.log"))
+			Expect(err).ToNot(HaveOccurred())
+
+			var record plugininstaller.AuditRecord
+			err = json.Unmarshal(auditLog, &record)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(record.Action).To(Equal("install"))
+			Expect(record.Plugin).To(Equal("Test1"))
+			Expect(record.Version).To(Equal("1.2.4"))
+			Expect(record.Source).To(Equal(test_1))
+			Expect(record.SHA1).To(HaveLen(40))
+			Expect(record.CFUser).To(Equal(config.Username()))
+			Expect(record.API).To(Equal(config.APIEndpoint()))
+		})
+	})
+
+	Describe("install policy", func() {
+		var (
+			signedPlugin string
+			privateKey   ed25519.PrivateKey
+		)
+
+		BeforeEach(func() {
+			err := os.MkdirAll(pluginDir, 0700)
+			Expect(err).ToNot(HaveOccurred())
+
+			var publicKey ed25519.PublicKey
+			publicKey, privateKey, err = ed25519.GenerateKey(nil)
+			Expect(err).ToNot(HaveOccurred())
+			config.SetPluginKey(models.PluginKey{Name: "other-key", PublicKey: base64.StdEncoding.EncodeToString(make([]byte, ed25519.PublicKeySize))})
+			config.SetPluginKey(models.PluginKey{Name: "my-key", PublicKey: base64.StdEncoding.EncodeToString(publicKey)})
+
+			signedPlugin = filepath.Join(homeDir, "test_1.exe")
+			err = fileutils.CopyPathToPath(test_1, signedPlugin)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		sign := func(key ed25519.PrivateKey) {
+			binary, err := ioutil.ReadFile(signedPlugin)
+			Expect(err).ToNot(HaveOccurred())
+
+			signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, binary))
+			err = ioutil.WriteFile(signedPlugin+".sig", []byte(signature+"\n"), 0600)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		It("does not check signatures by default", func() {
+			runCommand(signedPlugin, "-f")
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"Plugin", "Test1", "successfully installed"}))
+		})
+
+		Context("when signed plugins are required", func() {
+			BeforeEach(func() {
+				config.SetPluginInstallPolicy(coreconfig.PluginInstallPolicySigned)
+			})
+
+			It("installs a plugin signed by a trusted key", func() {
+				sign(privateKey)
+				runCommand(signedPlugin, "-f")
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"Plugin signature verified with trusted key my-key"},
+					[]string{"Plugin", "Test1", "successfully installed"},
+				))
+
+				auditLog, err := ioutil.ReadFile(filepath.Join(pluginDir, "audit.log"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(auditLog)).To(ContainSubstring(`"SignedBy":"my-key"`))
+			})
+
+			It("fails when the plugin is not signed", func() {
+				runCommand(signedPlugin, "-f")
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"only allows installing signed plugins", signedPlugin + ".sig", "could not be read"},
+				))
+				Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+			})
+
+			It("fails when the plugin is signed by an untrusted key", func() {
+				_, untrustedKey, err := ed25519.GenerateKey(nil)
+				Expect(err).ToNot(HaveOccurred())
+				sign(untrustedKey)
+
+				runCommand(signedPlugin, "-f")
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"not signed by any of the trusted plugin keys"},
+				))
+				Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+			})
+
+			It("fails when the signature is malformed", func() {
+				err := ioutil.WriteFile(signedPlugin+".sig", []byte("not a signature"), 0600)
+				Expect(err).ToNot(HaveOccurred())
+
+				runCommand(signedPlugin, "-f")
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"not a base64 encoded ed25519 signature"}))
+			})
+		})
+
+		Context("when signed plugins from a repository are required", func() {
+			BeforeEach(func() {
+				config.SetPluginInstallPolicy(coreconfig.PluginInstallPolicySignedRepo)
+			})
+
+			It("fails to install a plugin from a path, even if it is signed", func() {
+				sign(privateKey)
+				runCommand(signedPlugin, "-f")
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"only allows installing plugins from a registered repository"},
+				))
+				Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+			})
+		})
 	})
 })
 
@@ -0,0 +1,77 @@
+package plugin
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/presenters"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+)
+
+type ListPluginKeys struct {
+	ui     terminal.UI
+	config coreconfig.Reader
+}
+
+func init() {
+	commandregistry.Register(&ListPluginKeys{})
+}
+
+func (cmd *ListPluginKeys) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "list-plugin-keys",
+		Description: T("List the public keys trusted to sign plugins, and the plugin install policy"),
+		Usage: []string{
+			T("CF_NAME list-plugin-keys"),
+		},
+		OutputFormats: true,
+	}
+}
+
+func (cmd *ListPluginKeys) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	usageReq := requirements.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("No argument required"),
+		func() bool {
+			return len(fc.Args()) != 0
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+	}
+	return reqs
+}
+
+func (cmd *ListPluginKeys) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *ListPluginKeys) Execute(c flags.FlagContext) error {
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.PluginKey{}
+		for _, key := range cmd.config.PluginKeys() {
+			records = append(records, presenters.NewPluginKey(key))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
+	table := cmd.ui.Table([]string{T("Key Name"), T("Public Key")})
+
+	for _, key := range cmd.config.PluginKeys() {
+		table.Add(key.Name, key.PublicKey)
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Plugin install policy: {{.Policy}}", map[string]interface{}{"Policy": terminal.EntityNameColor(cmd.config.PluginInstallPolicy())}))
+	cmd.ui.Say("")
+
+	table.Print()
+
+	cmd.ui.Say("")
+	return nil
+}
@@ -0,0 +1,61 @@
+package plugin_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("list-plugin-keys", func() {
+	var (
+		ui                  *testterm.FakeUI
+		config              coreconfig.Repository
+		requirementsFactory *testreq.FakeReqFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("list-plugin-keys").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = &testreq.FakeReqFactory{}
+		config = testconfig.NewRepositoryWithDefaults()
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("list-plugin-keys", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("lists the trusted keys and the install policy", func() {
+		config.SetPluginKey(models.PluginKey{Name: "key1", PublicKey: "a2V5MQ=="})
+		config.SetPluginKey(models.PluginKey{Name: "key2", PublicKey: "a2V5Mg=="})
+		config.SetPluginInstallPolicy(coreconfig.PluginInstallPolicySigned)
+
+		runCommand()
+
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"OK"},
+			[]string{"Plugin install policy:", "signed"},
+			[]string{"Key Name", "Public Key"},
+			[]string{"key1", "a2V5MQ=="},
+			[]string{"key2", "a2V5Mg=="},
+		))
+	})
+
+	It("shows the default install policy", func() {
+		runCommand()
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"Plugin install policy:", "any"}))
+	})
+})
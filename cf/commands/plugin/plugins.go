@@ -1,11 +1,17 @@
 package plugin
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
+	"github.com/cloudfoundry/cli/cf"
+	"github.com/cloudfoundry/cli/cf/actors/pluginrepo"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -13,8 +19,10 @@ import (
 )
 
 type Plugins struct {
-	ui     terminal.UI
-	config pluginconfig.PluginConfiguration
+	ui         terminal.UI
+	config     pluginconfig.PluginConfiguration
+	coreConfig coreconfig.Reader
+	pluginRepo pluginrepo.PluginRepo
 }
 
 func init() {
@@ -24,14 +32,16 @@ func init() {
 func (cmd *Plugins) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["checksum"] = &flags.BoolFlag{Name: "checksum", Usage: T("Compute and show the sha1 value of the plugin binary file")}
+	fs["outdated"] = &flags.BoolFlag{Name: "outdated", Usage: T("Search the registered repositories for newer versions of installed plugins")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "plugins",
 		Description: T("List all available plugin commands"),
 		Usage: []string{
-			T("CF_NAME plugins"),
+			T("CF_NAME plugins [--checksum | --outdated]"),
 		},
-		Flags: fs,
+		Flags:         fs,
+		OutputFormats: true,
 	}
 }
 
@@ -52,16 +62,26 @@ func (cmd *Plugins) Requirements(requirementsFactory requirements.Factory, fc fl
 func (cmd *Plugins) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
 	cmd.ui = deps.UI
 	cmd.config = deps.PluginConfig
+	cmd.coreConfig = deps.Config
+	cmd.pluginRepo = deps.PluginRepo
 	return cmd
 }
 
 func (cmd *Plugins) Execute(c flags.FlagContext) error {
+	if c.Bool("outdated") {
+		return cmd.listOutdated()
+	}
+
 	var version string
 
 	cmd.ui.Say(T("Listing Installed Plugins..."))
 
 	plugins := cmd.config.Plugins()
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		return cmd.printStructured(plugins, c.Bool("checksum"))
+	}
+
 	var table *terminal.UITable
 	if c.Bool("checksum") {
 		cmd.ui.Say(T("Computing sha1 for installed plugins, this may take a while ..."))
@@ -107,3 +127,71 @@ func (cmd *Plugins) Execute(c flags.FlagContext) error {
 	table.Print()
 	return nil
 }
+
+// printStructured prints the installed plugins sorted by name, with the sha1
+// of each binary if withChecksum is set.
+func (cmd *Plugins) printStructured(plugins map[string]pluginconfig.PluginMetadata, withChecksum bool) error {
+	names := []string{}
+	for pluginName := range plugins {
+		names = append(names, pluginName)
+	}
+	sort.Strings(names)
+
+	records := []presenters.Plugin{}
+	for _, pluginName := range names {
+		metadata := plugins[pluginName]
+
+		var checksum string
+		if withChecksum {
+			sha1, err := utils.NewSha1Checksum(metadata.Location).ComputeFileSha1()
+			if err == nil {
+				checksum = fmt.Sprintf("%x", sha1)
+			}
+		}
+
+		records = append(records, presenters.NewPlugin(pluginName, metadata, checksum))
+	}
+	return cmd.ui.PrintStructured(records)
+}
+
+func (cmd *Plugins) listOutdated() error {
+	repos := cmd.coreConfig.PluginRepos()
+	if len(repos) == 0 {
+		return errors.New(T("No plugin repositories are registered.\nTip: use `add-plugin-repo` command to add repos."))
+	}
+
+	cmd.ui.Say(T("Searching registered repositories for newer versions of installed plugins..."))
+
+	repoPlugins, repoErrors := cmd.pluginRepo.GetPlugins(repos)
+	for _, repoError := range repoErrors {
+		cmd.ui.Warn(repoError)
+	}
+
+	updates := findPluginUpdates(cmd.config.Plugins(), repoPlugins)
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.PluginUpdate{}
+		for _, update := range updates {
+			records = append(records, presenters.NewPluginUpdate(update.Name, update.Installed.Version, update.LatestVersion, update.RepoName))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
+	if len(updates) == 0 {
+		cmd.ui.Say(T("All plugins are up to date."))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("Plugin Name"), T("Version"), T("Latest Version"), T("Repository")})
+	for _, update := range updates {
+		table.Add(update.Name, formatVersion(update.Installed.Version), formatVersion(update.LatestVersion), update.RepoName)
+	}
+	table.Print()
+
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Use '{{.Command}}' to update all outdated plugins.", map[string]interface{}{"Command": terminal.CommandColor(cf.Name + " update-plugin --all")}))
+	return nil
+}
@@ -3,17 +3,24 @@ package plugin_test
 import (
 	"net/rpc"
 
+	"github.com/cloudfoundry/cli/cf/actors/pluginrepo/pluginrepofakes"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	plugincmd "github.com/cloudfoundry/cli/cf/commands/plugin"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig/pluginconfigfakes"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
 	"github.com/cloudfoundry/cli/plugin"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
 	. "github.com/cloudfoundry/cli/testhelpers/matchers"
 	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
 	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
 
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/models"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -23,12 +30,16 @@ var _ = Describe("Plugins", func() {
 		ui                  *testterm.FakeUI
 		requirementsFactory *testreq.FakeReqFactory
 		config              *pluginconfigfakes.FakePluginConfiguration
+		coreConfig          coreconfig.Repository
+		fakePluginRepo      *pluginrepofakes.FakePluginRepo
 		deps                commandregistry.Dependency
 	)
 
 	updateCommandDependency := func(pluginCall bool) {
 		deps.UI = ui
 		deps.PluginConfig = config
+		deps.Config = coreConfig
+		deps.PluginRepo = fakePluginRepo
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("plugins").SetDependency(deps, pluginCall))
 	}
 
@@ -36,6 +47,8 @@ var _ = Describe("Plugins", func() {
 		ui = &testterm.FakeUI{}
 		requirementsFactory = &testreq.FakeReqFactory{}
 		config = new(pluginconfigfakes.FakePluginConfiguration)
+		coreConfig = testconfig.NewRepositoryWithDefaults()
+		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
 
 		rpc.DefaultServer = rpc.NewServer()
 	})
@@ -64,6 +77,47 @@ var _ = Describe("Plugins", func() {
 		})
 	})
 
+	Context("If --outdated flag is provided", func() {
+		BeforeEach(func() {
+			coreConfig.SetPluginRepo(models.PluginRepo{Name: "repo1", URL: "http://repo1.example.com"})
+			config.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+				"Test1": {Location: "path/to/plugin1", Version: plugin.VersionType{Major: 1, Minor: 2, Build: 3}},
+				"Test2": {Location: "path/to/plugin2", Version: plugin.VersionType{Major: 2}},
+				"Test3": {Location: "path/to/plugin3"},
+			})
+		})
+
+		It("lists the plugins that have a newer version in a registered repository", func() {
+			fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+				"repo1": {
+					{Name: "Test1", Version: "1.10.0"},
+					{Name: "Test2", Version: "2.0.0"},
+					{Name: "Test3", Version: "1.0.0"},
+				},
+			}, []string{"repo error1"})
+
+			runCommand("--outdated")
+
+			Expect(fakePluginRepo.GetPluginsArgsForCall(0)).To(Equal([]models.PluginRepo{{Name: "repo1", URL: "http://repo1.example.com"}}))
+			Expect(ui.WarnOutputs).To(ContainSubstrings([]string{"repo error1"}))
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Plugin Name", "Version", "Latest Version", "Repository"},
+				[]string{"Test1", "1.2.3", "1.10.0", "repo1"},
+				[]string{"update-plugin --all"},
+			))
+			Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"Test2"}))
+			Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"Test3"}))
+		})
+
+		It("says all plugins are up to date when there is no newer version", func() {
+			fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{}, nil)
+
+			runCommand("--outdated")
+
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"All plugins are up to date."}))
+		})
+	})
+
 	Context("when arguments are provided", func() {
 		var cmd commandregistry.Command
 		var flagContext flags.FlagContext
@@ -128,6 +182,37 @@ var _ = Describe("Plugins", func() {
 		))
 	})
 
+	It("lists the plugins sorted by name as json when json output is requested", func() {
+		config.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+			"Test2": {
+				Location: "path/to/plugin2",
+				Commands: []plugin.Command{{Name: "test_2_cmd1"}},
+			},
+			"Test1": {
+				Location: "path/to/plugin1",
+				Version:  plugin.VersionType{Major: 1, Minor: 2, Build: 3},
+				Commands: []plugin.Command{
+					{Name: "test_1_cmd1", Alias: "t1", HelpText: "help text for test_1_cmd1"},
+				},
+			},
+		})
+		ui.Format = terminal.JSONOutput
+
+		runCommand()
+
+		Expect(ui.Outputs).To(BeInDisplayOrder(
+			[]string{`"name": "Test1"`},
+			[]string{`"version": "1.2.3"`},
+			[]string{`"location": "path/to/plugin1"`},
+			[]string{`"name": "test_1_cmd1"`},
+			[]string{`"alias": "t1"`},
+			[]string{`"help_text": "help text for test_1_cmd1"`},
+			[]string{`"name": "Test2"`},
+			[]string{`"version": ""`},
+		))
+		Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"Plugin Name", "Command Name"}))
+	})
+
 	It("lists 'N/A' as version when plugin does not provide a version", func() {
 		config.PluginsReturns(map[string]pluginconfig.PluginMetadata{
 			"Test1": {
@@ -0,0 +1,68 @@
+package plugin
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+)
+
+type RemovePluginKey struct {
+	ui     terminal.UI
+	config coreconfig.ReadWriter
+}
+
+func init() {
+	commandregistry.Register(&RemovePluginKey{})
+}
+
+func (cmd *RemovePluginKey) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "remove-plugin-key",
+		Description: T("Stop trusting a public key to sign plugins"),
+		Usage: []string{
+			T("CF_NAME remove-plugin-key KEY_NAME"),
+		},
+		Examples: []string{
+			"CF_NAME remove-plugin-key MyCompany",
+		},
+		TotalArgs: 1,
+	}
+}
+
+func (cmd *RemovePluginKey) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("remove-plugin-key"))
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs
+}
+
+func (cmd *RemovePluginKey) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *RemovePluginKey) Execute(c flags.FlagContext) error {
+	cmd.ui.Say("")
+	keyName := strings.Trim(c.Args()[0], " ")
+
+	for i, key := range cmd.config.PluginKeys() {
+		if strings.ToLower(key.Name) == strings.ToLower(keyName) {
+			cmd.config.UnSetPluginKey(i)
+			cmd.ui.Ok()
+			cmd.ui.Say(T("Plugin key {{.KeyName}} removed from the trusted keys", map[string]interface{}{"KeyName": keyName}))
+			cmd.ui.Say("")
+			return nil
+		}
+	}
+
+	return errors.New(T("Plugin key {{.KeyName}} does not exist", map[string]interface{}{"KeyName": keyName}))
+}
@@ -0,0 +1,63 @@
+package plugin_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("remove-plugin-key", func() {
+	var (
+		ui                  *testterm.FakeUI
+		config              coreconfig.Repository
+		requirementsFactory *testreq.FakeReqFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("remove-plugin-key").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = &testreq.FakeReqFactory{}
+		config = testconfig.NewRepositoryWithDefaults()
+
+		config.SetPluginKey(models.PluginKey{Name: "key1", PublicKey: "a2V5MQ=="})
+		config.SetPluginKey(models.PluginKey{Name: "key2", PublicKey: "a2V5Mg=="})
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("remove-plugin-key", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("removes the key from the trusted keys", func() {
+		runCommand("KEY1")
+
+		Expect(config.PluginKeys()).To(Equal([]models.PluginKey{{Name: "key2", PublicKey: "a2V5Mg=="}}))
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"OK"},
+			[]string{"Plugin key KEY1 removed from the trusted keys"},
+		))
+	})
+
+	It("fails when the key does not exist", func() {
+		runCommand("key3")
+
+		Expect(config.PluginKeys()).To(HaveLen(2))
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Plugin key key3 does not exist"},
+		))
+	})
+})
@@ -54,7 +54,7 @@ func (cmd *PluginUninstall) SetDependency(deps commandregistry.Dependency, plugi
 	//each service can only be registered once
 	rpc.DefaultServer = rpc.NewServer()
 
-	RPCService, err := rpcService.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, rpcService.NewCommandRunner(), deps.Logger, cmd.ui.Writer())
+	RPCService, err := rpcService.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, rpcService.NewCommandRunner(), deps.Logger, cmd.ui.Writer(), rpc.DefaultServer)
 	if err != nil {
 		cmd.ui.Failed("Error initializing RPC service: " + err.Error())
 	}
@@ -93,6 +93,13 @@ func (cmd *PluginUninstall) Execute(c flags.FlagContext) error {
 		cmd.ui.Warn("Error removing plugin binary: " + err.Error())
 	}
 
+	if pluginMetadata.Previous != nil {
+		err = os.Remove(pluginMetadata.Previous.Location)
+		if err != nil && !os.IsNotExist(err) {
+			cmd.ui.Warn("Error removing previous plugin binary: " + err.Error())
+		}
+	}
+
 	cmd.config.RemovePlugin(pluginName)
 
 	cmd.ui.Ok()
@@ -0,0 +1,444 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"net/rpc"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf"
+	"github.com/cloudfoundry/cli/cf/actors/plugininstaller"
+	"github.com/cloudfoundry/cli/cf/actors/pluginrepo"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/downloader"
+	"github.com/cloudfoundry/cli/flags"
+	"github.com/cloudfoundry/cli/plugin"
+	"github.com/cloudfoundry/cli/utils"
+	"github.com/cloudfoundry/gofileutils/fileutils"
+
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/models"
+
+	pluginRPCService "github.com/cloudfoundry/cli/plugin/rpc"
+)
+
+type PluginUpdate struct {
+	ui           terminal.UI
+	config       coreconfig.Reader
+	pluginConfig pluginconfig.PluginConfiguration
+	pluginRepo   pluginrepo.PluginRepo
+	checksum     utils.Sha1Checksum
+	rpcService   *pluginRPCService.CliRpcService
+}
+
+func init() {
+	commandregistry.Register(&PluginUpdate{})
+}
+
+func (cmd *PluginUpdate) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["all"] = &flags.BoolFlag{Name: "all", Usage: T("Update all installed plugins that have a newer version in a registered repository")}
+	fs["r"] = &flags.StringFlag{ShortName: "r", Usage: T("Name of a registered repository to look for newer versions in")}
+	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force update of plugins without confirmation")}
+	fs["rollback"] = &flags.BoolFlag{Name: "rollback", Usage: T("Go back to the version of the plugin installed before its last update")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "update-plugin",
+		Description: T("Update CLI plugins to the newest version in the registered repositories"),
+		Usage: []string{
+			T(`CF_NAME update-plugin (PLUGIN_NAME | --all) [-r REPO_NAME] [-f]
+   CF_NAME update-plugin PLUGIN_NAME --rollback
+
+   Prompts for confirmation unless '-f' is provided. The binary of the previous version is kept until the next update, so that '--rollback' can restore it.`),
+		},
+		Examples: []string{
+			"CF_NAME update-plugin plugin-echo",
+			"CF_NAME update-plugin --all -r My-Repo",
+			"CF_NAME update-plugin plugin-echo --rollback",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *PluginUpdate) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if fc.Bool("all") {
+		if len(fc.Args()) != 0 || fc.Bool("rollback") {
+			cmd.ui.Failed(T("Incorrect Usage. '--all' cannot be used with a plugin name or '--rollback'\n\n") + commandregistry.Commands.CommandUsage("update-plugin"))
+		}
+	} else if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires a plugin name or '--all'\n\n") + commandregistry.Commands.CommandUsage("update-plugin"))
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs
+}
+
+func (cmd *PluginUpdate) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.pluginConfig = deps.PluginConfig
+	cmd.pluginRepo = deps.PluginRepo
+	cmd.checksum = deps.ChecksumUtil
+
+	//reset rpc registration in case there is other running instance,
+	//each service can only be registered once
+	rpc.DefaultServer = rpc.NewServer()
+
+	rpcService, err := pluginRPCService.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, pluginRPCService.NewCommandRunner(), deps.Logger, cmd.ui.Writer(), rpc.DefaultServer)
+	if err != nil {
+		cmd.ui.Failed("Error initializing RPC service: " + err.Error())
+	}
+
+	cmd.rpcService = rpcService
+
+	return cmd
+}
+
+func (cmd *PluginUpdate) Execute(c flags.FlagContext) error {
+	plugins := cmd.pluginConfig.Plugins()
+
+	if !c.Bool("all") {
+		pluginName := c.Args()[0]
+		metadata, ok := plugins[pluginName]
+		if !ok {
+			return errors.New(T("Plugin name {{.PluginName}} does not exist", map[string]interface{}{"PluginName": pluginName}))
+		}
+
+		if c.Bool("rollback") {
+			return cmd.rollback(pluginName, metadata)
+		}
+
+		plugins = map[string]pluginconfig.PluginMetadata{pluginName: metadata}
+	}
+
+	repos, err := cmd.findRepos(c.String("r"))
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Checking registered repositories for plugin updates..."))
+
+	repoPlugins, repoErrors := cmd.pluginRepo.GetPlugins(repos)
+	for _, repoError := range repoErrors {
+		cmd.ui.Warn(repoError)
+	}
+
+	updates := findPluginUpdates(plugins, repoPlugins)
+
+	cmd.ui.Say("")
+	if len(updates) == 0 {
+		if c.Bool("all") {
+			cmd.ui.Say(T("All plugins are up to date."))
+		} else {
+			cmd.ui.Say(T("Plugin {{.PluginName}} is up to date.", map[string]interface{}{"PluginName": c.Args()[0]}))
+		}
+		return nil
+	}
+
+	cmd.ui.Say(T("Updates available:"))
+	printPluginUpdates(cmd.ui, updates)
+	cmd.ui.Say("")
+
+	if !c.Bool("f") && !cmd.ui.Confirm(T("**Attention: Plugins are binaries written by potentially untrusted authors. Install and use plugins at your own risk.**\n\nDo you want to update the plugins listed above? (y or n)")) {
+		return errors.New(T("Plugin update cancelled"))
+	}
+
+	for _, update := range updates {
+		err = cmd.update(update)
+		if err != nil {
+			return err
+		}
+
+		cmd.ui.Ok()
+		cmd.ui.Say(T("Plugin {{.PluginName}} successfully updated to v{{.Version}}.", map[string]interface{}{"PluginName": update.Name, "Version": formatVersion(update.LatestVersion)}))
+		cmd.ui.Say("")
+	}
+
+	return nil
+}
+
+func (cmd *PluginUpdate) findRepos(repoName string) ([]models.PluginRepo, error) {
+	repos := cmd.config.PluginRepos()
+	if len(repos) == 0 {
+		return nil, errors.New(T("No plugin repositories are registered.\nTip: use `add-plugin-repo` command to add repos."))
+	}
+
+	if repoName == "" {
+		return repos, nil
+	}
+
+	for _, repo := range repos {
+		if strings.ToLower(repo.Name) == strings.ToLower(repoName) {
+			return []models.PluginRepo{repo}, nil
+		}
+	}
+
+	return nil, errors.New(repoName + T(" does not exist as an available plugin repo."+"\nTip: use `add-plugin-repo` command to add repos."))
+}
+
+// update installs the new version of the plugin over the old one. The new
+// binary is first copied next to the old one, which is then renamed aside and
+// kept for rollback, so that the plugin is at no point missing or partially
+// written.
+func (cmd *PluginUpdate) update(update pluginUpdate) error {
+	cmd.ui.Say(T("Updating plugin {{.PluginName}}...", map[string]interface{}{"PluginName": update.Name}))
+
+	fileDownloader := downloader.NewDownloader(os.TempDir())
+
+	removeTmpFile := func() {
+		err := fileDownloader.RemoveFile()
+		if err != nil {
+			cmd.ui.Say(T("Problem removing downloaded binary in temp directory: ") + err.Error())
+		}
+	}
+	defer removeTmpFile()
+
+	installer := plugininstaller.NewPluginInstaller(&plugininstaller.Context{
+		Checksummer:    cmd.checksum,
+		GetPluginRepos: cmd.config.PluginRepos,
+		FileDownloader: fileDownloader,
+		PluginRepo:     cmd.pluginRepo,
+		RepoName:       update.RepoName,
+		UI:             cmd.ui,
+	})
+	pluginSourceFilepath := installer.Install(update.Latest.Name)
+
+	signedBy, err := verifyPluginSignature(cmd.ui, cmd.config, installer.Source(), pluginSourceFilepath)
+	if err != nil {
+		return err
+	}
+
+	pluginMetadata, err := runBinaryAndObtainPluginMetadata(cmd.rpcService, pluginSourceFilepath)
+	if err != nil {
+		return err
+	}
+
+	if pluginMetadata.Name != update.Name {
+		return errors.New(T("The binary from repository '{{.RepoName}}' is plugin {{.OtherPluginName}}, not {{.PluginName}}", map[string]interface{}{"RepoName": update.RepoName, "OtherPluginName": pluginMetadata.Name, "PluginName": update.Name}))
+	}
+
+	otherPlugins := map[string]pluginconfig.PluginMetadata{}
+	for name, metadata := range cmd.pluginConfig.Plugins() {
+		if name != update.Name {
+			otherPlugins[name] = metadata
+		}
+	}
+	err = ensurePluginCommandsDoNotConflict(pluginMetadata, otherPlugins, pluginSourceFilepath)
+	if err != nil {
+		return err
+	}
+
+	location := update.Installed.Location
+	newLocation := location + ".new"
+	oldLocation := location + ".old"
+
+	err = fileutils.CopyPathToPath(pluginSourceFilepath, newLocation)
+	if err != nil {
+		return errors.New(T("Could not copy plugin binary: \n{{.Error}}", map[string]interface{}{"Error": err.Error()}))
+	}
+
+	err = os.Rename(location, oldLocation)
+	if err != nil {
+		os.Remove(newLocation)
+		return errors.New(T("Could not keep the previous plugin binary: \n{{.Error}}", map[string]interface{}{"Error": err.Error()}))
+	}
+
+	err = os.Rename(newLocation, location)
+	if err != nil {
+		os.Rename(oldLocation, location)
+		os.Remove(newLocation)
+		return errors.New(T("Could not copy plugin binary: \n{{.Error}}", map[string]interface{}{"Error": err.Error()}))
+	}
+
+	previous := update.Installed
+	previous.Location = oldLocation
+	previous.Previous = nil
+
+	cmd.pluginConfig.SetPlugin(update.Name, pluginconfig.PluginMetadata{
+		Location: location,
+		Version:  pluginMetadata.Version,
+		Commands: pluginMetadata.Commands,
+		Hooks:    pluginMetadata.Hooks,
+		Previous: &previous,
+	})
+
+	recordPluginAudit(cmd.ui, cmd.config, cmd.pluginConfig, plugininstaller.AuditRecord{
+		Action:   "update",
+		Plugin:   update.Name,
+		Version:  formatVersion(pluginMetadata.Version),
+		Source:   installer.Source(),
+		Repo:     update.RepoName,
+		SignedBy: signedBy,
+	}, location)
+	return nil
+}
+
+// rollback puts back the binary the last update replaced. Its signature is
+// not kept, so it cannot be checked again, and rolling back is refused when
+// the plugin install policy requires signed plugins.
+func (cmd *PluginUpdate) rollback(pluginName string, metadata pluginconfig.PluginMetadata) error {
+	if metadata.Previous == nil {
+		return errors.New(T("Plugin {{.PluginName}} has not been updated, there is no previous version to roll back to", map[string]interface{}{"PluginName": pluginName}))
+	}
+
+	if policy := cmd.config.PluginInstallPolicy(); policy != coreconfig.PluginInstallPolicyAny {
+		return errors.New(T("The plugin install policy '{{.Policy}}' only allows installing signed plugins, and the signature of the previous version of plugin {{.PluginName}} cannot be checked. Install the version you want with '{{.Command}}' instead.", map[string]interface{}{"Policy": policy, "PluginName": pluginName, "Command": cf.Name + " install-plugin"}))
+	}
+
+	cmd.ui.Say(T("Rolling back plugin {{.PluginName}}...", map[string]interface{}{"PluginName": pluginName}))
+
+	err := os.Rename(metadata.Previous.Location, metadata.Location)
+	if err != nil {
+		return errors.New(T("Could not restore the previous plugin binary: \n{{.Error}}", map[string]interface{}{"Error": err.Error()}))
+	}
+
+	restored := *metadata.Previous
+	restored.Location = metadata.Location
+	cmd.pluginConfig.SetPlugin(pluginName, restored)
+
+	recordPluginAudit(cmd.ui, cmd.config, cmd.pluginConfig, plugininstaller.AuditRecord{
+		Action:  "rollback",
+		Plugin:  pluginName,
+		Version: formatVersion(restored.Version),
+		Source:  metadata.Previous.Location,
+	}, restored.Location)
+
+	cmd.ui.Ok()
+	cmd.ui.Say(T("Plugin {{.PluginName}} rolled back to v{{.Version}}.", map[string]interface{}{"PluginName": pluginName, "Version": formatVersion(restored.Version)}))
+	return nil
+}
+
+type pluginUpdate struct {
+	Name          string
+	Installed     pluginconfig.PluginMetadata
+	RepoName      string
+	Latest        clipr.Plugin
+	LatestVersion plugin.VersionType
+}
+
+// findPluginUpdates returns, sorted by plugin name, the installed plugins for
+// which a repository in repoPlugins lists a newer version, along with the
+// newest version listed. Plugins that do not report a version, and repository
+// versions that cannot be parsed, are left out, as they cannot be compared.
+func findPluginUpdates(installed map[string]pluginconfig.PluginMetadata, repoPlugins map[string][]clipr.Plugin) []pluginUpdate {
+	repoNames := []string{}
+	for repoName := range repoPlugins {
+		repoNames = append(repoNames, repoName)
+	}
+	sort.Strings(repoNames)
+
+	updates := []pluginUpdate{}
+	for name, metadata := range installed {
+		if !versionKnown(metadata.Version) {
+			continue
+		}
+
+		var update *pluginUpdate
+		for _, repoName := range repoNames {
+			for _, repoPlugin := range repoPlugins[repoName] {
+				if strings.ToLower(repoPlugin.Name) != strings.ToLower(name) {
+					continue
+				}
+
+				version, ok := parseVersion(repoPlugin.Version)
+				if !ok || !versionLess(metadata.Version, version) {
+					continue
+				}
+
+				if update == nil || versionLess(update.LatestVersion, version) {
+					update = &pluginUpdate{
+						Name:          name,
+						Installed:     metadata,
+						RepoName:      repoName,
+						Latest:        repoPlugin,
+						LatestVersion: version,
+					}
+				}
+			}
+		}
+
+		if update != nil {
+			updates = append(updates, *update)
+		}
+	}
+
+	sort.Sort(pluginUpdatesByName(updates))
+	return updates
+}
+
+type pluginUpdatesByName []pluginUpdate
+
+func (u pluginUpdatesByName) Len() int           { return len(u) }
+func (u pluginUpdatesByName) Swap(i, j int)      { u[i], u[j] = u[j], u[i] }
+func (u pluginUpdatesByName) Less(i, j int) bool { return u[i].Name < u[j].Name }
+
+// parseVersion parses a plugin repository version such as "1.2.3" or
+// "v1.2". Anything after the build number, such as "-beta", is ignored.
+func parseVersion(version string) (plugin.VersionType, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".", 3)
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		digits := part
+		if end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' }); end != -1 {
+			digits = part[:end]
+		}
+
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			return plugin.VersionType{}, false
+		}
+		numbers[i] = n
+
+		if digits != part {
+			break
+		}
+	}
+
+	return plugin.VersionType{Major: numbers[0], Minor: numbers[1], Build: numbers[2]}, true
+}
+
+func versionLess(a, b plugin.VersionType) bool {
+	if a.Major != b.Major {
+		return a.Major < b.Major
+	}
+	if a.Minor != b.Minor {
+		return a.Minor < b.Minor
+	}
+	return a.Build < b.Build
+}
+
+func versionKnown(version plugin.VersionType) bool {
+	return version.Major != 0 || version.Minor != 0 || version.Build != 0
+}
+
+func formatVersion(version plugin.VersionType) string {
+	return fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Build)
+}
+
+func printPluginUpdates(ui terminal.UI, updates []pluginUpdate) {
+	for _, update := range updates {
+		details := T("repository: {{.RepoName}}", map[string]interface{}{"RepoName": update.RepoName})
+		if !update.Latest.Updated.IsZero() {
+			details += ", " + T("updated {{.Date}}", map[string]interface{}{"Date": update.Latest.Updated.Format("2006-01-02")})
+		}
+
+		ui.Say(fmt.Sprintf("%s %s -> %s (%s)",
+			terminal.EntityNameColor(update.Name),
+			formatVersion(update.Installed.Version),
+			formatVersion(update.LatestVersion),
+			details,
+		))
+		if update.Latest.Description != "" {
+			ui.Say("   " + update.Latest.Description)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package plugin_test
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/actors/pluginrepo/pluginrepofakes"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/confighelpers"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/plugin"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+	"github.com/cloudfoundry/cli/utils/utilsfakes"
+	"golang.org/x/crypto/ed25519"
+
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/models"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("update-plugin", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *testreq.FakeReqFactory
+		config              coreconfig.Repository
+		pluginConfig        *pluginconfig.PluginConfig
+		fakePluginRepo      *pluginrepofakes.FakePluginRepo
+		fakeChecksum        *utilsfakes.FakeSha1Checksum
+		deps                commandregistry.Dependency
+
+		homeDir        string
+		pluginLocation string
+		testServer     *httptest.Server
+		signature      []byte
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.PluginConfig = pluginConfig
+		deps.PluginRepo = fakePluginRepo
+		deps.ChecksumUtil = fakeChecksum
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("update-plugin").SetDependency(deps, pluginCall))
+	}
+
+	repoPlugin := func(name, version string) clipr.Plugin {
+		binaries := []clipr.Binary{}
+		for _, platform := range []string{"osx", "linux32", "linux64", "win32", "win64"} {
+			binaries = append(binaries, clipr.Binary{Platform: platform, Url: testServer.URL + "/test_1.exe"})
+		}
+
+		return clipr.Plugin{Name: name, Version: version, Description: "the " + version + " release", Binaries: binaries}
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = &testreq.FakeReqFactory{}
+		config = testconfig.NewRepositoryWithDefaults()
+		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
+		fakeChecksum = new(utilsfakes.FakeSha1Checksum)
+		fakeChecksum.CheckSha1Returns(true)
+
+		var err error
+		homeDir, err = ioutil.TempDir("", "plugins")
+		Expect(err).ToNot(HaveOccurred())
+
+		pluginDir := filepath.Join(homeDir, ".cf", "plugins")
+		err = os.MkdirAll(pluginDir, 0700)
+		Expect(err).NotTo(HaveOccurred())
+
+		pluginLocation = filepath.Join(pluginDir, "test_1")
+		err = ioutil.WriteFile(pluginLocation, []byte("old binary"), 0700)
+		Expect(err).NotTo(HaveOccurred())
+
+		confighelpers.PluginRepoDir = func() string {
+			return homeDir
+		}
+
+		pluginConfig = pluginconfig.NewPluginConfig(func(err error) { Expect(err).ToNot(HaveOccurred()) })
+		pluginConfig.SetPlugin("Test1", pluginconfig.PluginMetadata{
+			Location: pluginLocation,
+			Version:  plugin.VersionType{Major: 1, Minor: 2, Build: 3},
+			Commands: []plugin.Command{{Name: "test_1_cmd1"}},
+		})
+
+		signature = nil
+		testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, ".sig") {
+				if signature == nil {
+					http.NotFound(w, r)
+					return
+				}
+				w.Write(signature)
+				return
+			}
+			http.ServeFile(w, r, filepath.Join("..", "..", "..", "fixtures", "plugins", "test_1.exe"))
+		}))
+
+		config.SetPluginRepo(models.PluginRepo{Name: "repo1", URL: testServer.URL})
+	})
+
+	AfterEach(func() {
+		testServer.Close()
+		os.RemoveAll(homeDir)
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("update-plugin", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails with usage when not provided a plugin name or --all", func() {
+			runCommand()
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"Incorrect Usage", "Requires a plugin name or '--all'"}))
+		})
+
+		It("fails with usage when provided both a plugin name and --all", func() {
+			runCommand("Test1", "--all")
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"Incorrect Usage", "'--all' cannot be used"}))
+		})
+	})
+
+	It("fails when the plugin is not installed", func() {
+		runCommand("not-a-plugin", "-f")
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Plugin name not-a-plugin does not exist"},
+		))
+	})
+
+	It("fails when no plugin repository is registered", func() {
+		config.UnSetPluginRepo(0)
+		runCommand("Test1", "-f")
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"No plugin repositories are registered"}))
+	})
+
+	It("says the plugin is up to date when no repository lists a newer version", func() {
+		fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+			"repo1": {repoPlugin("Test1", "1.2.3"), repoPlugin("Test2", "9.0.0")},
+		}, nil)
+
+		runCommand("Test1", "-f")
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"Plugin Test1 is up to date."}))
+		Expect(pluginConfig.Plugins()["Test1"].Previous).To(BeNil())
+	})
+
+	It("warns about repositories that could not be read", func() {
+		fakePluginRepo.GetPluginsReturns(nil, []string{"repo error1"})
+
+		runCommand("--all", "-f")
+		Expect(ui.WarnOutputs).To(ContainSubstrings([]string{"repo error1"}))
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"All plugins are up to date."}))
+	})
+
+	Context("when a repository lists a newer version", func() {
+		BeforeEach(func() {
+			fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+				"repo1": {repoPlugin("test1", "v1.2.4")},
+				"repo2": {repoPlugin("Test1", "1.2.3-beta")},
+			}, nil)
+		})
+
+		It("shows the update and asks for confirmation", func() {
+			ui.Inputs = []string{"n"}
+			runCommand("Test1")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Updates available:"},
+				[]string{"Test1", "1.2.3 -> 1.2.4", "repository: repo1"},
+				[]string{"the v1.2.4 release"},
+				[]string{"Plugin update cancelled"},
+			))
+			Expect(ioutil.ReadFile(pluginLocation)).To(Equal([]byte("old binary")))
+		})
+
+		It("replaces the binary and keeps the old one for rollback", func() {
+			runCommand("--all", "-f")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Updating plugin Test1..."},
+				[]string{"OK"},
+				[]string{"Plugin Test1 successfully updated to v1.2.4."},
+			))
+			Expect(fakeChecksum.CheckSha1CallCount()).To(Equal(1))
+
+			newBinary, err := ioutil.ReadFile(pluginLocation)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(newBinary).NotTo(Equal([]byte("old binary")))
+			Expect(ioutil.ReadFile(pluginLocation + ".old")).To(Equal([]byte("old binary")))
+			Expect(pluginLocation + ".new").NotTo(BeAnExistingFile())
+
+			metadata := pluginConfig.Plugins()["Test1"]
+			Expect(metadata.Location).To(Equal(pluginLocation))
+			Expect(metadata.Version).To(Equal(plugin.VersionType{Major: 1, Minor: 2, Build: 4}))
+			Expect(metadata.Commands).To(ContainElement(plugin.Command{Name: "test_1_cmd2", HelpText: "help text for test_1_cmd2"}))
+			Expect(metadata.Previous).To(Equal(&pluginconfig.PluginMetadata{
+				Location: pluginLocation + ".old",
+				Version:  plugin.VersionType{Major: 1, Minor: 2, Build: 3},
+				Commands: []plugin.Command{{Name: "test_1_cmd1"}},
+			}))
+		})
+
+		It("rolls the update back with --rollback", func() {
+			runCommand("Test1", "-f")
+			runCommand("Test1", "--rollback")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Rolling back plugin Test1..."},
+				[]string{"Plugin Test1 rolled back to v1.2.3."},
+			))
+			Expect(ioutil.ReadFile(pluginLocation)).To(Equal([]byte("old binary")))
+			Expect(pluginLocation + ".old").NotTo(BeAnExistingFile())
+			Expect(pluginConfig.Plugins()["Test1"]).To(Equal(pluginconfig.PluginMetadata{
+				Location: pluginLocation,
+				Version:  plugin.VersionType{Major: 1, Minor: 2, Build: 3},
+				Commands: []plugin.Command{{Name: "test_1_cmd1"}},
+			}))
+		})
+
+		Context("when signed plugins are required", func() {
+			var privateKey ed25519.PrivateKey
+
+			BeforeEach(func() {
+				var publicKey ed25519.PublicKey
+				var err error
+				publicKey, privateKey, err = ed25519.GenerateKey(nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				config.SetPluginKey(models.PluginKey{Name: "my-key", PublicKey: base64.StdEncoding.EncodeToString(publicKey)})
+				config.SetPluginInstallPolicy(coreconfig.PluginInstallPolicySignedRepo)
+			})
+
+			It("updates a plugin signed by a trusted key and records the update in the audit log", func() {
+				binary, err := ioutil.ReadFile(filepath.Join("..", "..", "..", "fixtures", "plugins", "test_1.exe"))
+				Expect(err).NotTo(HaveOccurred())
+				signature = []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, binary)))
+
+				runCommand("Test1", "-f")
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"Plugin signature verified with trusted key my-key"},
+					[]string{"Plugin Test1 successfully updated to v1.2.4."},
+				))
+
+				auditLog, err := ioutil.ReadFile(filepath.Join(homeDir, ".cf", "plugins", "audit.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(auditLog)).To(ContainSubstring(`"Action":"update","Plugin":"Test1","Version":"1.2.4"`))
+				Expect(string(auditLog)).To(ContainSubstring(`"Source":"` + testServer.URL + `/test_1.exe","Repo":"repo1","SignedBy":"my-key"`))
+			})
+
+			It("does not roll back, as the previous version's signature cannot be checked", func() {
+				config.SetPluginInstallPolicy(coreconfig.PluginInstallPolicyAny)
+				runCommand("Test1", "-f")
+				config.SetPluginInstallPolicy(coreconfig.PluginInstallPolicySigned)
+
+				runCommand("Test1", "--rollback")
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"The plugin install policy 'signed' only allows installing signed plugins", "cannot be checked"},
+				))
+				Expect(pluginLocation + ".old").To(BeAnExistingFile())
+				Expect(pluginConfig.Plugins()["Test1"].Version).To(Equal(plugin.VersionType{Major: 1, Minor: 2, Build: 4}))
+			})
+
+			It("does not update a plugin that is not signed", func() {
+				runCommand("Test1", "-f")
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"only allows installing signed plugins", testServer.URL + "/test_1.exe.sig"},
+				))
+				Expect(ioutil.ReadFile(pluginLocation)).To(Equal([]byte("old binary")))
+				Expect(pluginConfig.Plugins()["Test1"].Previous).To(BeNil())
+			})
+		})
+
+		It("does not replace the binary when it is for another plugin", func() {
+			pluginConfig.SetPlugin("Other", pluginconfig.PluginMetadata{
+				Location: pluginLocation,
+				Version:  plugin.VersionType{Major: 1},
+			})
+			fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+				"repo1": {repoPlugin("Other", "2.0.0")},
+			}, nil)
+
+			runCommand("Other", "-f")
+
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"The binary from repository 'repo1' is plugin Test1, not Other"}))
+			Expect(ioutil.ReadFile(pluginLocation)).To(Equal([]byte("old binary")))
+			Expect(pluginLocation + ".old").NotTo(BeAnExistingFile())
+		})
+	})
+
+	It("fails to roll back a plugin that has not been updated", func() {
+		runCommand("Test1", "--rollback")
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"there is no previous version to roll back to"}))
+	})
+})
@@ -3,6 +3,7 @@ package pluginrepo
 import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -26,6 +27,7 @@ func (cmd *ListPluginRepos) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME list-plugin-repos"),
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -52,6 +54,14 @@ func (cmd *ListPluginRepos) SetDependency(deps commandregistry.Dependency, plugi
 func (cmd *ListPluginRepos) Execute(c flags.FlagContext) error {
 	repos := cmd.config.PluginRepos()
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.PluginRepo{}
+		for _, repo := range repos {
+			records = append(records, presenters.NewPluginRepo(repo))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	table := cmd.ui.Table([]string{T("Repo Name"), T("URL")})
 
 	for _, repo := range repos {
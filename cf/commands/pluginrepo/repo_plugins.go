@@ -8,6 +8,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -40,7 +41,8 @@ func (cmd *RepoPlugins) MetaData() commandregistry.CommandMetadata {
 		Examples: []string{
 			"CF_NAME repo-plugins -r PrivateRepo",
 		},
-		Flags: fs,
+		Flags:         fs,
+		OutputFormats: true,
 	}
 }
 
@@ -83,6 +85,13 @@ func (cmd *RepoPlugins) Execute(c flags.FlagContext) error {
 
 	repoPlugins, repoError := cmd.pluginRepo.GetPlugins(repos)
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		for _, e := range repoError {
+			cmd.ui.Warn(e)
+		}
+		return cmd.printStructured(repos, repoPlugins)
+	}
+
 	cmd.printTable(repoPlugins)
 
 	cmd.printErrors(repoError)
@@ -101,6 +110,18 @@ func (cmd RepoPlugins) printTable(repoPlugins map[string][]clipr.Plugin) {
 	}
 }
 
+// printStructured prints the plugins of each repo, in the order the repos
+// were added.
+func (cmd RepoPlugins) printStructured(repos []models.PluginRepo, repoPlugins map[string][]clipr.Plugin) error {
+	records := []presenters.RepoPlugin{}
+	for _, repo := range repos {
+		for _, p := range repoPlugins[repo.Name] {
+			records = append(records, presenters.NewRepoPlugin(repo.Name, p))
+		}
+	}
+	return cmd.ui.PrintStructured(records)
+}
+
 func (cmd RepoPlugins) printErrors(repoError []string) {
 	if len(repoError) > 0 {
 		cmd.ui.Say(terminal.ColorizeBold(T("Logged errors:"), 31))
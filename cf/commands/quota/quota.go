@@ -12,6 +12,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/formatters"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 )
@@ -32,7 +33,8 @@ func (cmd *showQuota) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME quota QUOTA"),
 		},
-		Description: T("Show quota info"),
+		Description:   T("Show quota info"),
+		OutputFormats: true,
 	}
 }
 
@@ -66,6 +68,10 @@ func (cmd *showQuota) Execute(c flags.FlagContext) error {
 
 	cmd.ui.Ok()
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		return cmd.ui.PrintStructured(presenters.NewQuota(quota))
+	}
+
 	var megabytes string
 	if quota.InstanceMemoryLimit == -1 {
 		megabytes = T("unlimited")
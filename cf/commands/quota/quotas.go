@@ -12,6 +12,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/formatters"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 )
@@ -33,6 +34,7 @@ func (cmd *ListQuotas) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME quotas"),
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -70,6 +72,14 @@ func (cmd *ListQuotas) Execute(c flags.FlagContext) error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.Quota{}
+		for _, quota := range quotas {
+			records = append(records, presenters.NewQuota(quota))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	table := cmd.ui.Table([]string{
 		T("name"),
 		T("total memory"),
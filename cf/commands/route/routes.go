@@ -12,6 +12,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 )
@@ -38,7 +39,8 @@ func (cmd *ListRoutes) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			"CF_NAME routes [--orglevel]",
 		},
-		Flags: fs,
+		Flags:         fs,
+		OutputFormats: true,
 	}
 }
 
@@ -97,6 +99,7 @@ func (cmd *ListRoutes) Execute(c flags.FlagContext) error {
 	}
 
 	var routesFound bool
+	records := []presenters.Route{}
 	cb := func(route models.Route) bool {
 		routesFound = true
 		appNames := []string{}
@@ -110,6 +113,7 @@ func (cmd *ListRoutes) Execute(c flags.FlagContext) error {
 		}
 
 		domain := d[route.Domain.GUID]
+		records = append(records, presenters.NewRoute(route, domain))
 
 		table.Add(
 			route.Space.Name,
@@ -130,6 +134,10 @@ func (cmd *ListRoutes) Execute(c flags.FlagContext) error {
 		err = cmd.routeRepo.ListRoutes(cb)
 	}
 
+	if cmd.ui.OutputFormat().IsStructured() && err == nil {
+		return cmd.ui.PrintStructured(records)
+	}
+
 	table.Print()
 	if err != nil {
 		return errors.New(T("Failed fetching routes.\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
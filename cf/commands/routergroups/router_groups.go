@@ -8,6 +8,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -30,6 +31,7 @@ func (cmd *RouterGroups) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			"CF_NAME router-groups",
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -58,12 +60,9 @@ func (cmd *RouterGroups) Execute(c flags.FlagContext) error {
 	cmd.ui.Say(T("Getting router groups as {{.Username}} ...\n",
 		map[string]interface{}{"Username": terminal.EntityNameColor(cmd.config.Username())}))
 
-	table := cmd.ui.Table([]string{T("name"), T("type")})
-
-	noRouterGroups := true
+	routerGroups := []models.RouterGroup{}
 	cb := func(group models.RouterGroup) bool {
-		noRouterGroups = false
-		table.Add(group.Name, group.Type)
+		routerGroups = append(routerGroups, group)
 		return true
 	}
 
@@ -72,7 +71,20 @@ func (cmd *RouterGroups) Execute(c flags.FlagContext) error {
 		return errors.New(T("Failed fetching router groups.\n{{.Err}}", map[string]interface{}{"Err": apiErr.Error()}))
 	}
 
-	if noRouterGroups {
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.RouterGroup{}
+		for _, group := range routerGroups {
+			records = append(records, presenters.NewRouterGroup(group))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
+	table := cmd.ui.Table([]string{T("name"), T("type")})
+	for _, group := range routerGroups {
+		table.Add(group.Name, group.Type)
+	}
+
+	if len(routerGroups) == 0 {
 		cmd.ui.Say(T("No router groups found"))
 	}
 
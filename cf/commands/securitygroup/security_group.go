@@ -10,6 +10,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/api/securitygroups"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 )
@@ -31,6 +32,7 @@ func (cmd *ShowSecurityGroup) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME security-group SECURITY_GROUP"),
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -67,6 +69,10 @@ func (cmd *ShowSecurityGroup) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		return cmd.ui.PrintStructured(presenters.NewSecurityGroup(securityGroup))
+	}
+
 	jsonEncodedBytes, err := json.MarshalIndent(securityGroup.Rules, "\t", "\t")
 	if err != nil {
 		return err
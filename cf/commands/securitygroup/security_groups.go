@@ -10,6 +10,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 )
@@ -31,6 +32,7 @@ func (cmd *SecurityGroups) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			"CF_NAME security-groups",
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -70,6 +72,14 @@ func (cmd *SecurityGroups) Execute(c flags.FlagContext) error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.SecurityGroup{}
+		for _, securityGroup := range securityGroups {
+			records = append(records, presenters.NewSecurityGroup(securityGroup))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	if len(securityGroups) == 0 {
 		cmd.ui.Say(T("No security groups"))
 		return nil
@@ -6,6 +6,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
@@ -150,6 +151,20 @@ var _ = Describe("list-security-groups command", func() {
 						[]string{"#0", "my-group", "org-2", "space-2"},
 					))
 				})
+
+				It("lists every space the group is bound to when json output is requested", func() {
+					ui.Format = terminal.JSONOutput
+
+					runCommand()
+					Expect(ui.Outputs).To(BeInDisplayOrder(
+						[]string{`"name": "my-group"`},
+						[]string{`"name": "space-1"`},
+						[]string{`"organization": "org-1"`},
+						[]string{`"name": "space-2"`},
+						[]string{`"organization": "org-2"`},
+					))
+					Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"#0"}))
+				})
 			})
 
 			Describe("Where there are no spaces assigned", func() {
@@ -13,6 +13,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 )
@@ -39,7 +40,8 @@ func (cmd *MarketplaceServices) MetaData() commandregistry.CommandMetadata {
 			"CF_NAME marketplace ",
 			fmt.Sprintf("[-s %s] ", T("SERVICE")),
 		},
-		Flags: fs,
+		Flags:         fs,
+		OutputFormats: true,
 	}
 }
 
@@ -108,10 +110,17 @@ func (cmd MarketplaceServices) marketplaceByService(serviceName string) error {
 	cmd.ui.Say("")
 
 	if serviceOffering.GUID == "" {
+		if cmd.ui.OutputFormat().IsStructured() {
+			return errors.New(T("Service offering not found"))
+		}
 		cmd.ui.Say(T("Service offering not found"))
 		return nil
 	}
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		return cmd.ui.PrintStructured(presenters.NewServiceOffering(serviceOffering))
+	}
+
 	table := cmd.ui.Table([]string{T("service plan"), T("description"), T("free or paid")})
 	for _, plan := range serviceOffering.Plans {
 		var freeOrPaid string
@@ -152,6 +161,16 @@ func (cmd MarketplaceServices) marketplace() error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	sort.Sort(serviceOfferings)
+
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.ServiceOffering{}
+		for _, offering := range serviceOfferings {
+			records = append(records, presenters.NewServiceOffering(offering))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	if len(serviceOfferings) == 0 {
 		cmd.ui.Say(T("No service offerings found"))
 		return nil
@@ -159,7 +178,6 @@ func (cmd MarketplaceServices) marketplace() error {
 
 	table := cmd.ui.Table([]string{T("service"), T("plans"), T("description")})
 
-	sort.Sort(serviceOfferings)
 	var paidPlanExists bool
 	for _, offering := range serviceOfferings {
 		planNames := ""
@@ -10,6 +10,7 @@ import (
 
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 )
@@ -34,6 +35,7 @@ func (cmd *ListServices) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			"CF_NAME services",
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -80,6 +82,14 @@ func (cmd *ListServices) Execute(fc flags.FlagContext) error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.ServiceInstance{}
+		for _, instance := range serviceInstances {
+			records = append(records, presenters.NewServiceInstance(instance))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	if len(serviceInstances) == 0 {
 		cmd.ui.Say(T("No services found"))
 		return nil
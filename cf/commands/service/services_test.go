@@ -15,6 +15,7 @@ import (
 
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
 
 	. "github.com/cloudfoundry/cli/testhelpers/matchers"
 
@@ -167,6 +168,33 @@ var _ = Describe("services", func() {
 		))
 	})
 
+	It("lists services as json when json output is requested", func() {
+		serviceInstance := models.ServiceInstance{}
+		serviceInstance.Name = "my-service-1"
+		serviceInstance.GUID = "my-service-1-guid"
+		serviceInstance.LastOperation.Type = "create"
+		serviceInstance.LastOperation.State = "succeeded"
+		serviceInstance.ServicePlan = models.ServicePlanFields{Name: "spark", GUID: "spark-guid"}
+		serviceInstance.ServiceOffering = models.ServiceOfferingFields{Label: "cleardb"}
+		serviceInstance.ApplicationNames = []string{"cli1"}
+
+		serviceSummaryRepo.GetSummariesInCurrentSpaceInstances = []models.ServiceInstance{serviceInstance}
+		ui.Format = terminal.JSONOutput
+
+		runCommand()
+
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{`"name": "my-service-1"`},
+			[]string{`"service": "cleardb"`},
+			[]string{`"plan": "spark"`},
+			[]string{`"state": "succeeded"`},
+			[]string{`"user_provided": false`},
+		))
+		Expect(ui.Outputs).ToNot(ContainSubstrings(
+			[]string{"name", "service", "plan", "bound apps", "last operation"},
+		))
+	})
+
 	Describe("when invoked by a plugin", func() {
 
 		var (
@@ -10,6 +10,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -38,7 +39,8 @@ func (cmd *ServiceAccess) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			"CF_NAME service-access [-b BROKER] [-e SERVICE] [-o ORG]",
 		},
-		Flags: fs,
+		Flags:         fs,
+		OutputFormats: true,
 	}
 }
 
@@ -118,6 +120,15 @@ func (cmd *ServiceAccess) Execute(c flags.FlagContext) error {
 	if err != nil {
 		return err
 	}
+
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.BrokerAccess{}
+		for _, serviceBroker := range brokers {
+			records = append(records, presenters.NewBrokerAccess(serviceBroker))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	cmd.printTable(brokers)
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -28,6 +29,7 @@ func (cmd *ListServiceAuthTokens) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME service-auth-tokens"),
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -72,6 +74,14 @@ func (cmd *ListServiceAuthTokens) Execute(c flags.FlagContext) error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.ServiceAuthToken{}
+		for _, authToken := range authTokens {
+			records = append(records, presenters.NewServiceAuthToken(authToken))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	table := cmd.ui.Table([]string{T("label"), T("provider")})
 
 	for _, authToken := range authTokens {
@@ -8,6 +8,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -19,12 +20,7 @@ type ListServiceBrokers struct {
 	repo   api.ServiceBrokerRepository
 }
 
-type serviceBrokerTable []serviceBrokerRow
-
-type serviceBrokerRow struct {
-	name string
-	url  string
-}
+type serviceBrokerTable []models.ServiceBroker
 
 func init() {
 	commandregistry.Register(&ListServiceBrokers{})
@@ -37,6 +33,7 @@ func (cmd *ListServiceBrokers) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			"CF_NAME service-brokers",
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -71,21 +68,30 @@ func (cmd *ListServiceBrokers) Execute(c flags.FlagContext) error {
 			"Username": terminal.EntityNameColor(cmd.config.Username()),
 		}))
 
-	table := cmd.ui.Table([]string{T("name"), T("url")})
 	foundBrokers := false
 	err := cmd.repo.ListServiceBrokers(func(serviceBroker models.ServiceBroker) bool {
-		sbTable = append(sbTable, serviceBrokerRow{
-			name: serviceBroker.Name,
-			url:  serviceBroker.URL,
-		})
+		sbTable = append(sbTable, serviceBroker)
 		foundBrokers = true
 		return true
 	})
 
 	sort.Sort(sbTable)
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		if err != nil {
+			return err
+		}
+
+		records := []presenters.ServiceBroker{}
+		for _, sb := range sbTable {
+			records = append(records, presenters.NewServiceBroker(sb))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
+	table := cmd.ui.Table([]string{T("name"), T("url")})
 	for _, sb := range sbTable {
-		table.Add(sb.name, sb.url)
+		table.Add(sb.Name, sb.URL)
 	}
 
 	table.Print()
@@ -102,4 +108,4 @@ func (cmd *ListServiceBrokers) Execute(c flags.FlagContext) error {
 
 func (a serviceBrokerTable) Len() int           { return len(a) }
 func (a serviceBrokerTable) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a serviceBrokerTable) Less(i, j int) bool { return a[i].name < a[j].name }
+func (a serviceBrokerTable) Less(i, j int) bool { return a[i].Name < a[j].Name }
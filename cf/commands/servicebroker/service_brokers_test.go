@@ -7,6 +7,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
@@ -155,6 +156,25 @@ var _ = Describe("service-brokers command", func() {
 		))
 	})
 
+	It("lists service brokers as json when json output is requested", func() {
+		repo.ListServiceBrokersStub = func(callback func(models.ServiceBroker) bool) error {
+			callback(models.ServiceBroker{Name: "z-broker", GUID: "z-broker-guid", URL: "http://z-url.com"})
+			callback(models.ServiceBroker{Name: "a-broker", GUID: "a-broker-guid", URL: "http://a-url.com"})
+			return nil
+		}
+		ui.Format = terminal.JSONOutput
+
+		testcmd.RunCLICommand("service-brokers", []string{}, requirementsFactory, updateCommandDependency, false, ui)
+
+		Expect(ui.Outputs).To(BeInDisplayOrder(
+			[]string{`"name": "a-broker"`},
+			[]string{`"guid": "a-broker-guid"`},
+			[]string{`"url": "http://a-url.com"`},
+			[]string{`"name": "z-broker"`},
+		))
+		Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"No service brokers found"}))
+	})
+
 	It("says when no service brokers were found", func() {
 		testcmd.RunCLICommand("service-brokers", []string{}, requirementsFactory, updateCommandDependency, false, ui)
 
@@ -4,6 +4,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -34,6 +35,7 @@ func (cmd *ServiceKeys) MetaData() commandregistry.CommandMetadata {
 		Examples: []string{
 			"CF_NAME service-keys mydb",
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -73,6 +75,14 @@ func (cmd *ServiceKeys) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.ServiceKey{}
+		for _, serviceKey := range serviceKeys {
+			records = append(records, presenters.NewServiceKey(serviceKey))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	table := cmd.ui.Table([]string{T("name")})
 
 	for _, serviceKey := range serviceKeys {
@@ -4,6 +4,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
 
 	"github.com/cloudfoundry/cli/cf/api/apifakes"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
@@ -100,6 +101,34 @@ var _ = Describe("service-keys command", func() {
 			Expect(serviceKeyRepo.ListServiceKeysMethod.InstanceGUID).To(Equal("fake-instance-guid"))
 		})
 
+		It("lists service keys as json when json output is requested", func() {
+			serviceKeyRepo.ListServiceKeysMethod.ServiceKeys = []models.ServiceKey{
+				{
+					Fields: models.ServiceKeyFields{
+						Name: "fake-service-key-1",
+						GUID: "fake-service-key-1-guid",
+					},
+					Credentials: map[string]interface{}{"password": "secret"},
+				},
+			}
+			ui.Format = terminal.JSONOutput
+
+			callListServiceKeys([]string{"fake-service-instance"})
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{`"name": "fake-service-key-1"`},
+				[]string{`"guid": "fake-service-key-1-guid"`},
+			))
+			Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"secret"}))
+		})
+
+		It("prints an empty list as json when there are no service keys", func() {
+			ui.Format = terminal.JSONOutput
+
+			callListServiceKeys([]string{"fake-service-instance"})
+			Expect(ui.Outputs).To(ContainElement("[]"))
+			Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"No service key"}))
+		})
+
 		It("does not list service keys when none are returned", func() {
 			callListServiceKeys([]string{"fake-service-instance"})
 			Expect(ui.Outputs).To(ContainSubstrings(
@@ -8,6 +8,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -34,6 +35,7 @@ func (cmd *ListSpaces) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME spaces"),
 		},
+		OutputFormats: true,
 	}
 
 }
@@ -73,8 +75,10 @@ func (cmd *ListSpaces) Execute(c flags.FlagContext) error {
 
 	foundSpaces := false
 	table := cmd.ui.Table([]string{T("name")})
+	records := []presenters.Space{}
 	err := cmd.spaceRepo.ListSpaces(func(space models.Space) bool {
 		table.Add(space.Name)
+		records = append(records, presenters.NewSpace(space))
 		foundSpaces = true
 
 		if cmd.pluginCall {
@@ -86,6 +90,11 @@ func (cmd *ListSpaces) Execute(c flags.FlagContext) error {
 
 		return true
 	})
+
+	if cmd.ui.OutputFormat().IsStructured() && err == nil {
+		return cmd.ui.PrintStructured(records)
+	}
+
 	table.Print()
 
 	if err != nil {
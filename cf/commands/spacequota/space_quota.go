@@ -8,6 +8,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/formatters"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -30,6 +31,7 @@ func (cmd *SpaceQuota) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME space-quota SPACE_QUOTA_NAME"),
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -70,6 +72,11 @@ func (cmd *SpaceQuota) Execute(c flags.FlagContext) error {
 
 	cmd.ui.Ok()
 	cmd.ui.Say("")
+
+	if cmd.ui.OutputFormat().IsStructured() {
+		return cmd.ui.PrintStructured(presenters.NewSpaceQuota(spaceQuota))
+	}
+
 	var megabytes string
 
 	table := cmd.ui.Table([]string{"", ""})
@@ -9,6 +9,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/formatters"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -31,6 +32,7 @@ func (cmd *ListSpaceQuotas) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME space-quotas"),
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -70,6 +72,14 @@ func (cmd *ListSpaceQuotas) Execute(c flags.FlagContext) error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.Quota{}
+		for _, quota := range quotas {
+			records = append(records, presenters.NewSpaceQuota(quota))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	table := cmd.ui.Table([]string{
 		T("name"),
 		T("total memory"),
@@ -6,6 +6,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
@@ -137,6 +138,25 @@ var _ = Describe("quotas command", func() {
 				))
 			})
 
+			Context("when json output is requested", func() {
+				BeforeEach(func() {
+					ui.Format = terminal.JSONOutput
+				})
+
+				It("lists quotas in megabytes with -1 for unlimited", func() {
+					Expect(ui.Outputs).To(BeInDisplayOrder(
+						[]string{`"name": "quota-name"`},
+						[]string{`"total_memory_in_mb": 1024`},
+						[]string{`"instance_memory_in_mb": 512`},
+						[]string{`"reserved_route_ports": 6`},
+						[]string{`"name": "quota-non-basic-not-allowed"`},
+						[]string{`"instance_memory_in_mb": -1`},
+						[]string{`"paid_service_plans": false`},
+					))
+					Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"unlimited"}))
+				})
+			})
+
 			Context("when services are unlimited", func() {
 				BeforeEach(func() {
 					quotaRepo.FindByOrgReturns([]models.SpaceQuota{
@@ -5,6 +5,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -30,8 +31,9 @@ func (cmd *ListStack) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME stack STACK_NAME"),
 		},
-		Flags:     fs,
-		TotalArgs: 1,
+		Flags:         fs,
+		TotalArgs:     1,
+		OutputFormats: true,
 	}
 }
 
@@ -66,6 +68,10 @@ func (cmd *ListStack) Execute(c flags.FlagContext) error {
 			return err
 		}
 
+		if cmd.ui.OutputFormat().IsStructured() {
+			return cmd.ui.PrintStructured(presenters.NewStack(stack))
+		}
+
 		cmd.ui.Say(T("Getting stack '{{.Stack}}' in org {{.OrganizationName}} / space {{.SpaceName}} as {{.Username}}...",
 			map[string]interface{}{"Stack": stackName,
 				"OrganizationName": terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
@@ -5,6 +5,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/presenters"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -27,6 +28,7 @@ func (cmd *ListStacks) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME stacks"),
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -67,6 +69,14 @@ func (cmd *ListStacks) Execute(c flags.FlagContext) error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		records := []presenters.Stack{}
+		for _, stack := range stacks {
+			records = append(records, presenters.NewStack(stack))
+		}
+		return cmd.ui.PrintStructured(records)
+	}
+
 	table := cmd.ui.Table([]string{T("name"), T("description")})
 
 	for _, stack := range stacks {
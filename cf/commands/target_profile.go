@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+)
+
+type TargetProfile struct {
+	ui     terminal.UI
+	config coreconfig.ReadWriter
+}
+
+func init() {
+	commandregistry.Register(&TargetProfile{})
+}
+
+func (cmd *TargetProfile) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "target-profile",
+		ShortName:   "tp",
+		Description: T("Save the current API endpoint, login and targeted org and space as a named profile, or switch between profiles"),
+		Usage: []string{
+			T("CF_NAME target-profile list\n"),
+			T("   CF_NAME target-profile save PROFILE\n"),
+			T("   CF_NAME target-profile use PROFILE\n"),
+			T("   CF_NAME target-profile delete PROFILE\n\n"),
+			T("   Use a profile for a single command, without switching to it:\n"),
+			T("   CF_NAME --profile PROFILE COMMAND"),
+		},
+		Examples: []string{
+			"CF_NAME target-profile save prod",
+			"CF_NAME target-profile use dev",
+			"CF_NAME --profile prod apps",
+		},
+	}
+}
+
+func (cmd *TargetProfile) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	args := fc.Args()
+
+	switch {
+	case len(args) == 1 && args[0] == "list":
+	case len(args) == 2 && (args[0] == "save" || args[0] == "use" || args[0] == "delete"):
+	default:
+		cmd.ui.Failed(T("Incorrect Usage. Requires 'list', or 'save', 'use' or 'delete' and PROFILE as arguments\n\n") + commandregistry.Commands.CommandUsage("target-profile"))
+	}
+
+	return []requirements.Requirement{}
+}
+
+func (cmd *TargetProfile) SetDependency(deps commandregistry.Dependency, _ bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *TargetProfile) Execute(fc flags.FlagContext) error {
+	args := fc.Args()
+
+	switch args[0] {
+	case "save":
+		return cmd.save(args[1])
+	case "use":
+		return cmd.use(args[1])
+	case "delete":
+		cmd.delete(args[1])
+		return nil
+	default:
+		cmd.list()
+		return nil
+	}
+}
+
+func (cmd *TargetProfile) list() {
+	profiles := cmd.config.Profiles()
+	if len(profiles) == 0 {
+		cmd.ui.Say(T("No target profiles saved. Use 'cf target-profile save PROFILE' to save the current target."))
+		return
+	}
+
+	current := cmd.config.CurrentProfile()
+
+	table := cmd.ui.Table([]string{"", T("name"), T("api endpoint"), T("user"), T("org"), T("space")})
+	for _, profile := range profiles {
+		marker := ""
+		if profile.Name == current {
+			marker = "*"
+		}
+
+		table.Add(
+			marker,
+			profile.Name,
+			profile.Target,
+			coreconfig.NewTokenInfo(profile.AccessToken).Username,
+			profile.OrganizationFields.Name,
+			profile.SpaceFields.Name,
+		)
+	}
+	table.Print()
+}
+
+func (cmd *TargetProfile) save(name string) error {
+	if cmd.config.APIEndpoint() == "" {
+		return errors.New(T("No API endpoint set. Use '{{.LoginTip}}' or '{{.APITip}}' to target an endpoint.",
+			map[string]interface{}{
+				"LoginTip": terminal.CommandColor("cf login"),
+				"APITip":   terminal.CommandColor("cf api"),
+			}))
+	}
+
+	cmd.ui.Say(T("Saving the current target as profile {{.Name}}...",
+		map[string]interface{}{"Name": terminal.EntityNameColor(name)}))
+
+	cmd.config.SaveProfile(name)
+
+	cmd.ui.Ok()
+	return nil
+}
+
+func (cmd *TargetProfile) use(name string) error {
+	if !cmd.hasProfile(name) {
+		return errors.New(T("Target profile {{.Name}} not found", map[string]interface{}{"Name": name}))
+	}
+
+	cmd.ui.Say(T("Switching to target profile {{.Name}}...",
+		map[string]interface{}{"Name": terminal.EntityNameColor(name)}))
+
+	cmd.config.UseProfile(name)
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	cmd.ui.ShowConfiguration(cmd.config)
+	return nil
+}
+
+func (cmd *TargetProfile) delete(name string) {
+	cmd.ui.Say(T("Deleting target profile {{.Name}}...",
+		map[string]interface{}{"Name": terminal.EntityNameColor(name)}))
+
+	if !cmd.hasProfile(name) {
+		cmd.ui.Ok()
+		cmd.ui.Warn(T("Target profile {{.Name}} does not exist.", map[string]interface{}{"Name": name}))
+		return
+	}
+
+	cmd.config.DeleteProfile(name)
+
+	cmd.ui.Ok()
+}
+
+func (cmd *TargetProfile) hasProfile(name string) bool {
+	for _, profile := range cmd.config.Profiles() {
+		if profile.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package commands_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("target-profile command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		configRepo          coreconfig.Repository
+		requirementsFactory *testreq.FakeReqFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = configRepo
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("target-profile").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAPIEndpoint("https://api.prod.example.com")
+		requirementsFactory = &testreq.FakeReqFactory{}
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("target-profile", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails with usage when not given a subcommand", func() {
+			runCommand()
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires 'list', or 'save', 'use' or 'delete' and PROFILE as arguments"},
+			))
+		})
+
+		It("fails with usage when a subcommand is not given a profile", func() {
+			runCommand("use")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+		})
+	})
+
+	Describe("save", func() {
+		It("saves the current target as the named profile and makes it current", func() {
+			Expect(runCommand("save", "prod")).To(BeTrue())
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Saving the current target as profile", "prod"},
+				[]string{"OK"},
+			))
+
+			profiles := configRepo.Profiles()
+			Expect(profiles).To(HaveLen(1))
+			Expect(profiles[0].Name).To(Equal("prod"))
+			Expect(profiles[0].Target).To(Equal("https://api.prod.example.com"))
+			Expect(profiles[0].SpaceFields.Name).To(Equal("my-space"))
+			Expect(configRepo.CurrentProfile()).To(Equal("prod"))
+		})
+
+		It("fails when no API endpoint is targeted", func() {
+			configRepo.SetAPIEndpoint("")
+
+			Expect(runCommand("save", "prod")).To(BeFalse())
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"No API endpoint set"},
+			))
+			Expect(configRepo.Profiles()).To(BeEmpty())
+		})
+	})
+
+	Describe("use", func() {
+		BeforeEach(func() {
+			configRepo.SaveProfile("prod")
+
+			configRepo.SetAPIEndpoint("https://api.dev.example.com")
+			configRepo.SetSpaceFields(models.SpaceFields{Name: "dev-space", GUID: "dev-space-guid"})
+			configRepo.SaveProfile("dev")
+		})
+
+		It("switches the current target to the profile", func() {
+			Expect(runCommand("use", "prod")).To(BeTrue())
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Switching to target profile", "prod"},
+				[]string{"OK"},
+			))
+			Expect(ui.ShowConfigurationCalled).To(BeTrue())
+
+			Expect(configRepo.CurrentProfile()).To(Equal("prod"))
+			Expect(configRepo.APIEndpoint()).To(Equal("https://api.prod.example.com"))
+			Expect(configRepo.SpaceFields().Name).To(Equal("my-space"))
+		})
+
+		It("fails when the profile does not exist", func() {
+			Expect(runCommand("use", "staging")).To(BeFalse())
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Target profile staging not found"},
+			))
+			Expect(configRepo.CurrentProfile()).To(Equal("dev"))
+		})
+	})
+
+	Describe("list", func() {
+		It("lists the profiles and marks the current one", func() {
+			configRepo.SaveProfile("prod")
+			configRepo.SetAPIEndpoint("https://api.dev.example.com")
+			configRepo.SaveProfile("dev")
+			configRepo.UseProfile("prod")
+
+			Expect(runCommand("list")).To(BeTrue())
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"name", "api endpoint", "user", "org", "space"},
+				[]string{"dev", "https://api.dev.example.com", "my-user", "my-org", "my-space"},
+				[]string{"*", "prod", "https://api.prod.example.com", "my-user", "my-org", "my-space"},
+			))
+		})
+
+		It("says when there are no profiles", func() {
+			runCommand("list")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"No target profiles saved"},
+			))
+		})
+	})
+
+	Describe("delete", func() {
+		It("deletes the profile", func() {
+			configRepo.SaveProfile("prod")
+
+			Expect(runCommand("delete", "prod")).To(BeTrue())
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Deleting target profile", "prod"},
+				[]string{"OK"},
+			))
+			Expect(configRepo.Profiles()).To(BeEmpty())
+			Expect(configRepo.CurrentProfile()).To(BeEmpty())
+			Expect(configRepo.APIEndpoint()).To(Equal("https://api.prod.example.com"))
+		})
+
+		It("warns when the profile does not exist", func() {
+			Expect(runCommand("delete", "prod")).To(BeTrue())
+
+			Expect(ui.WarnOutputs).To(ContainSubstrings(
+				[]string{"Target profile prod does not exist."},
+			))
+		})
+	})
+})
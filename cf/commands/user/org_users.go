@@ -37,7 +37,8 @@ func (cmd *OrgUsers) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME org-users ORG"),
 		},
-		Flags: fs,
+		Flags:         fs,
+		OutputFormats: true,
 	}
 }
 
@@ -94,6 +95,13 @@ func (cmd *OrgUsers) printer(c flags.FlagContext) userprint.UserPrinter {
 			roles,
 		)
 	}
+	if cmd.ui.OutputFormat().IsStructured() {
+		return &userprint.StructuredPrinter{
+			UI:         cmd.ui,
+			UserLister: cmd.userLister(),
+			Roles:      roles,
+		}
+	}
 	return &userprint.OrgUsersUIPrinter{
 		UI:         cmd.ui,
 		UserLister: cmd.userLister(),
@@ -5,6 +5,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/cf/trace/tracefakes"
 	"github.com/cloudfoundry/cli/plugin/models"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
@@ -218,6 +219,23 @@ var _ = Describe("org-users command", func() {
 			))
 		})
 
+		It("lists the users with their roles as yaml when yaml output is requested", func() {
+			ui.Format = terminal.YAMLOutput
+
+			runCommand("the-org")
+
+			Expect(ui.Outputs).To(BeInDisplayOrder(
+				[]string{"- username: user1"},
+				[]string{"- OrgManager"},
+				[]string{"- username: user2"},
+				[]string{"- username: user3"},
+				[]string{"- OrgAuditor"},
+				[]string{"- username: user4"},
+				[]string{"- BillingManager"},
+			))
+			Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"ORG MANAGER"}))
+		})
+
 		Context("when the -a flag is provided", func() {
 			BeforeEach(func() {
 				user := models.UserFields{Username: "user1"}
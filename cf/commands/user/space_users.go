@@ -36,6 +36,7 @@ func (cmd *SpaceUsers) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME space-users ORG SPACE"),
 		},
+		OutputFormats: true,
 	}
 }
 
@@ -97,6 +98,14 @@ func (cmd *SpaceUsers) printer(org models.Organization, space models.Space, user
 			"CurrentUser": terminal.EntityNameColor(username),
 		}))
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		return &userprint.StructuredPrinter{
+			UI:         cmd.ui,
+			UserLister: cmd.userLister(),
+			Roles:      roles,
+		}
+	}
+
 	return &userprint.SpaceUsersUIPrinter{
 		UI:         cmd.ui,
 		UserLister: cmd.userLister(),
@@ -7,6 +7,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/cf/trace/tracefakes"
 	"github.com/cloudfoundry/cli/plugin/models"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
@@ -132,6 +133,28 @@ var _ = Describe("space-users command", func() {
 			))
 		})
 
+		It("lists each user once with all of their roles when json output is requested", func() {
+			userRepo.ListUsersInSpaceForRoleStub = func(_ string, roleName models.Role) ([]models.UserFields, error) {
+				return map[models.Role][]models.UserFields{
+					models.RoleSpaceManager:   {{Username: "user2", GUID: "user2-guid"}, {Username: "user1", GUID: "user1-guid"}},
+					models.RoleSpaceDeveloper: {{Username: "user2", GUID: "user2-guid"}},
+				}[roleName], nil
+			}
+			ui.Format = terminal.JSONOutput
+
+			runCommand("my-org", "my-space")
+
+			Expect(ui.Outputs).To(BeInDisplayOrder(
+				[]string{`"username": "user1"`},
+				[]string{`"guid": "user1-guid"`},
+				[]string{`"SpaceManager"`},
+				[]string{`"username": "user2"`},
+				[]string{`"SpaceManager"`},
+				[]string{`"SpaceDeveloper"`},
+			))
+			Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"SPACE MANAGER"}))
+		})
+
 		Context("when cc api verson is >= 2.21.0", func() {
 			BeforeEach(func() {
 				configRepo.SetAPIVersion("2.22.0")
@@ -1,8 +1,12 @@
 package configuration
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 )
 
 const (
@@ -17,6 +21,7 @@ type Persistor interface {
 	Exists() bool
 	Load(DataInterface) error
 	Save(DataInterface) error
+	Update(DataInterface, func()) error
 }
 
 //go:generate counterfeiter . DataInterface
@@ -26,6 +31,26 @@ type DataInterface interface {
 	JSONUnmarshalV3([]byte) error
 }
 
+// CorruptFileError is returned when a file cannot be parsed, for instance
+// because it was cut short. The file has been moved aside to MovedTo and
+// replaced by its previous version when Restored, or else by defaults.
+type CorruptFileError struct {
+	Path     string
+	MovedTo  string
+	Restored bool
+	Err      error
+}
+
+func (e *CorruptFileError) Error() string {
+	if e.Restored {
+		return fmt.Sprintf("%s could not be read (%s). It was moved to %s and its previous version was restored; please try again.", e.Path, e.Err, e.MovedTo)
+	}
+	return fmt.Sprintf("%s could not be read (%s). It was moved to %s and a new one was created; please try again.", e.Path, e.Err, e.MovedTo)
+}
+
+// DiskPersistor keeps data in a JSON file. Processes sharing the file take
+// turns through a lock file next to it, and each save replaces the file in
+// one rename, so that a crash cannot leave it half written.
 type DiskPersistor struct {
 	filePath string
 }
@@ -49,42 +74,146 @@ func (dp DiskPersistor) Delete() {
 }
 
 func (dp DiskPersistor) Load(data DataInterface) error {
-	err := dp.read(data)
+	return dp.withLock(func() error {
+		return dp.load(data)
+	})
+}
+
+func (dp DiskPersistor) Save(data DataInterface) error {
+	return dp.withLock(func() error {
+		return dp.write(data)
+	})
+}
+
+// Update loads data as it is saved, changes it and saves it, keeping other
+// processes from saving in between so that their changes are not lost.
+func (dp DiskPersistor) Update(data DataInterface, change func()) error {
+	return dp.withLock(func() error {
+		err := dp.load(data)
+		if err != nil {
+			return err
+		}
+
+		change()
+		return dp.write(data)
+	})
+}
+
+func (dp DiskPersistor) withLock(f func() error) error {
+	err := dp.makeDirectory()
+	if err != nil {
+		return err
+	}
+
+	lock, err := os.OpenFile(dp.filePath+".lock", os.O_RDWR|os.O_CREATE, filePermissions)
 	if os.IsPermission(err) {
+		// Nothing can be saved here either, so reading needs no lock.
+		return f()
+	}
+	if err != nil {
 		return err
 	}
+	defer lock.Close()
 
+	err = lockFile(lock)
 	if err != nil {
-		err = dp.write(data)
+		return err
 	}
-	return err
+	defer unlockFile(lock)
+
+	return f()
 }
 
-func (dp DiskPersistor) Save(data DataInterface) error {
+func (dp DiskPersistor) load(data DataInterface) error {
+	jsonBytes, err := ioutil.ReadFile(dp.filePath)
+	if os.IsPermission(err) {
+		return err
+	}
+
+	if err == nil && len(bytes.TrimSpace(jsonBytes)) > 0 {
+		err = data.JSONUnmarshalV3(jsonBytes)
+		if err != nil {
+			return dp.repair(data, err)
+		}
+		return nil
+	}
+
+	if err == nil && dp.hasBackup() {
+		return dp.repair(data, errors.New("the file is empty"))
+	}
+
 	return dp.write(data)
 }
 
-func (dp DiskPersistor) read(data DataInterface) error {
-	err := dp.makeDirectory()
+// repair keeps a file that could not be parsed for inspection, and replaces
+// it with the version the last save replaced, if that can be parsed.
+func (dp DiskPersistor) repair(data DataInterface, cause error) error {
+	movedTo := dp.filePath + ".corrupt"
+	err := os.Rename(dp.filePath, movedTo)
 	if err != nil {
 		return err
 	}
 
-	jsonBytes, err := ioutil.ReadFile(dp.filePath)
+	restored := false
+	backup, err := ioutil.ReadFile(dp.backupPath())
+	if err == nil && data.JSONUnmarshalV3(backup) == nil {
+		restored = true
+	}
+
+	err = dp.write(data)
 	if err != nil {
 		return err
 	}
 
-	err = data.JSONUnmarshalV3(jsonBytes)
-	return err
+	return &CorruptFileError{
+		Path:     dp.filePath,
+		MovedTo:  movedTo,
+		Restored: restored,
+		Err:      cause,
+	}
 }
 
 func (dp DiskPersistor) write(data DataInterface) error {
-	bytes, err := data.JSONMarshalV3()
+	jsonBytes, err := data.JSONMarshalV3()
 	if err != nil {
 		return err
 	}
 
-	err = ioutil.WriteFile(dp.filePath, bytes, filePermissions)
-	return err
+	tmpFile, err := ioutil.TempFile(filepath.Dir(dp.filePath), filepath.Base(dp.filePath)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(jsonBytes)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tmpFile.Name(), filePermissions)
+	if err != nil {
+		return err
+	}
+
+	// The version being replaced is kept, so that it can be put back should
+	// the file be found cut short. Not every file system can link files.
+	_ = os.Remove(dp.backupPath())
+	_ = os.Link(dp.filePath, dp.backupPath())
+
+	return os.Rename(tmpFile.Name(), dp.filePath)
+}
+
+func (dp DiskPersistor) backupPath() string {
+	return dp.filePath + ".bak"
+}
+
+func (dp DiskPersistor) hasBackup() bool {
+	_, err := os.Stat(dp.backupPath())
+	return err == nil
 }
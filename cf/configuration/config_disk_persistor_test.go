@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sync"
 
 	. "github.com/cloudfoundry/cli/cf/configuration"
 	. "github.com/onsi/ginkgo"
@@ -30,6 +32,9 @@ var _ = Describe("DiskPersistor", func() {
 
 	AfterEach(func() {
 		os.Remove(tmpFile.Name())
+		os.Remove(tmpFile.Name() + ".lock")
+		os.Remove(tmpFile.Name() + ".bak")
+		os.Remove(tmpFile.Name() + ".corrupt")
 	})
 
 	Describe(".Delete", func() {
@@ -54,6 +59,67 @@ var _ = Describe("DiskPersistor", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(string(dataBytes)).To(ContainSubstring(d.Info))
 		})
+
+		It("keeps the version it replaces", func() {
+			err := diskPersistor.Save(&data{Info: "first"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = diskPersistor.Save(&data{Info: "second"})
+			Expect(err).ToNot(HaveOccurred())
+
+			dataBytes, err := ioutil.ReadFile(tmpFile.Name() + ".bak")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(dataBytes)).To(ContainSubstring("first"))
+		})
+
+		It("leaves no temporary files behind", func() {
+			err := diskPersistor.Save(&data{Info: "save test"})
+			Expect(err).ToNot(HaveOccurred())
+
+			tmpFiles, err := filepath.Glob(tmpFile.Name() + ".tmp*")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tmpFiles).To(BeEmpty())
+		})
+	})
+
+	Describe(".Update", func() {
+		It("changes the data as saved", func() {
+			err := ioutil.WriteFile(tmpFile.Name(), []byte(`{"Info":"saved elsewhere"}`), 0600)
+			Expect(err).ToNot(HaveOccurred())
+
+			d := &data{}
+			err = diskPersistor.Update(d, func() {
+				d.Count++
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			dataBytes, err := ioutil.ReadFile(tmpFile.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dataBytes).To(MatchJSON(`{"Info":"saved elsewhere","Count":1}`))
+		})
+
+		It("does not lose updates made at the same time", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer GinkgoRecover()
+
+					d := &data{}
+					err := NewDiskPersistor(tmpFile.Name()).Update(d, func() {
+						d.Count++
+					})
+					Expect(err).ToNot(HaveOccurred())
+				}()
+			}
+			wg.Wait()
+
+			d := &data{}
+			err := diskPersistor.Load(d)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(d.Count).To(Equal(20))
+		})
 	})
 
 	Describe(".Load", func() {
@@ -75,11 +141,62 @@ var _ = Describe("DiskPersistor", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(d.Info).To(Equal("test string"))
 		})
+
+		Context("when the file cannot be parsed", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(tmpFile.Name(), []byte(`{"Info":"cut sh`), 0600)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps the file aside and reports it", func() {
+				d := &data{}
+
+				err := diskPersistor.Load(d)
+				Expect(err).To(BeAssignableToTypeOf(&CorruptFileError{}))
+				Expect(err.(*CorruptFileError).Restored).To(BeFalse())
+				Expect(err.Error()).To(ContainSubstring(tmpFile.Name() + ".corrupt"))
+
+				dataBytes, err := ioutil.ReadFile(tmpFile.Name() + ".corrupt")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(dataBytes)).To(Equal(`{"Info":"cut sh`))
+
+				dataBytes, err = ioutil.ReadFile(tmpFile.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dataBytes).To(MatchJSON(`{"Info":"","Count":0}`))
+			})
+
+			It("restores the previous version when there is one", func() {
+				err := ioutil.WriteFile(tmpFile.Name()+".bak", []byte(`{"Info":"previous"}`), 0600)
+				Expect(err).ToNot(HaveOccurred())
+
+				d := &data{}
+				err = diskPersistor.Load(d)
+				Expect(err).To(BeAssignableToTypeOf(&CorruptFileError{}))
+				Expect(err.(*CorruptFileError).Restored).To(BeTrue())
+				Expect(d.Info).To(Equal("previous"))
+
+				d = &data{}
+				err = diskPersistor.Load(d)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(d.Info).To(Equal("previous"))
+			})
+		})
+
+		It("restores the previous version of an empty file", func() {
+			err := ioutil.WriteFile(tmpFile.Name()+".bak", []byte(`{"Info":"previous"}`), 0600)
+			Expect(err).ToNot(HaveOccurred())
+
+			d := &data{}
+			err = diskPersistor.Load(d)
+			Expect(err).To(BeAssignableToTypeOf(&CorruptFileError{}))
+			Expect(d.Info).To(Equal("previous"))
+		})
 	})
 })
 
 type data struct {
-	Info string
+	Info  string
+	Count int
 }
 
 func (d *data) JSONMarshalV3() ([]byte, error) {
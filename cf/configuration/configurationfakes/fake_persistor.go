@@ -33,6 +33,15 @@ type FakePersistor struct {
 	saveReturns struct {
 		result1 error
 	}
+	UpdateStub        func(configuration.DataInterface, func()) error
+	updateMutex       sync.RWMutex
+	updateArgsForCall []struct {
+		arg1 configuration.DataInterface
+		arg2 func()
+	}
+	updateReturns struct {
+		result1 error
+	}
 }
 
 func (fake *FakePersistor) Delete() {
@@ -138,4 +147,37 @@ func (fake *FakePersistor) SaveReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePersistor) Update(arg1 configuration.DataInterface, arg2 func()) error {
+	fake.updateMutex.Lock()
+	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
+		arg1 configuration.DataInterface
+		arg2 func()
+	}{arg1, arg2})
+	fake.updateMutex.Unlock()
+	if fake.UpdateStub != nil {
+		return fake.UpdateStub(arg1, arg2)
+	} else {
+		return fake.updateReturns.result1
+	}
+}
+
+func (fake *FakePersistor) UpdateCallCount() int {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return len(fake.updateArgsForCall)
+}
+
+func (fake *FakePersistor) UpdateArgsForCall(i int) (configuration.DataInterface, func()) {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return fake.updateArgsForCall[i].arg1, fake.updateArgsForCall[i].arg2
+}
+
+func (fake *FakePersistor) UpdateReturns(result1 error) {
+	fake.UpdateStub = nil
+	fake.updateReturns = struct {
+		result1 error
+	}{result1}
+}
+
 var _ configuration.Persistor = new(FakePersistor)
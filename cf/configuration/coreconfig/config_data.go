@@ -2,10 +2,30 @@ package coreconfig
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/cloudfoundry/cli/cf/models"
 )
 
+// DefaultRequestRetries is how many times a request that fails for a
+// passing reason is retried, unless the config says otherwise.
+const DefaultRequestRetries = 2
+
+// DefaultPluginGracePeriod is how many seconds a plugin that was passed an
+// interrupt or termination signal has to exit before it is killed, unless
+// the config says otherwise.
+const DefaultPluginGracePeriod = 10
+
+// The plugin install policies. Under PluginInstallPolicyAny, any plugin can
+// be installed. Under PluginInstallPolicySigned, only plugins signed by a
+// trusted plugin key can, and under PluginInstallPolicySignedRepo, they must
+// also be installed from a registered plugin repository.
+const (
+	PluginInstallPolicyAny        = "any"
+	PluginInstallPolicySigned     = "signed"
+	PluginInstallPolicySignedRepo = "signed-repo"
+)
+
 type AuthPromptType string
 
 const (
@@ -18,6 +38,30 @@ type AuthPrompt struct {
 	DisplayName string
 }
 
+// TargetProfile is a named API endpoint together with the session and the
+// org and space targeted on it, saved so it can be switched back to.
+type TargetProfile struct {
+	Name                     string
+	Target                   string
+	APIVersion               string
+	AuthorizationEndpoint    string
+	LoggregatorEndPoint      string
+	DopplerEndPoint          string
+	UaaEndpoint              string
+	RoutingAPIEndpoint       string
+	AccessToken              string
+	SSHOAuthClient           string
+	RefreshToken             string
+	OrganizationFields       models.OrganizationFields
+	SpaceFields              models.SpaceFields
+	SSLDisabled              bool
+	CACertFile               string `json:",omitempty"`
+	ClientCertFile           string `json:",omitempty"`
+	ClientKeyFile            string `json:",omitempty"`
+	MinCLIVersion            string
+	MinRecommendedCLIVersion string
+}
+
 type Data struct {
 	ConfigVersion            int
 	Target                   string
@@ -33,13 +77,23 @@ type Data struct {
 	OrganizationFields       models.OrganizationFields
 	SpaceFields              models.SpaceFields
 	SSLDisabled              bool
+	CACertFile               string `json:",omitempty"`
+	ClientCertFile           string `json:",omitempty"`
+	ClientKeyFile            string `json:",omitempty"`
 	AsyncTimeout             uint
 	Trace                    string
 	ColorEnabled             string
 	Locale                   string
 	PluginRepos              []models.PluginRepo
+	PluginKeys               []models.PluginKey `json:",omitempty"`
+	PluginInstallPolicy      string             `json:",omitempty"`
 	MinCLIVersion            string
 	MinRecommendedCLIVersion string
+	CurrentProfile           string          `json:",omitempty"`
+	Profiles                 []TargetProfile `json:",omitempty"`
+	CredentialStore          string          `json:",omitempty"`
+	RequestRetries           *uint           `json:",omitempty"`
+	PluginGracePeriod        *uint           `json:",omitempty"`
 }
 
 func NewData() (data *Data) {
@@ -65,3 +119,113 @@ func (d *Data) JSONUnmarshalV3(input []byte) (err error) {
 
 	return
 }
+
+func (d *Data) targetProfile(name string) TargetProfile {
+	return TargetProfile{
+		Name:                     name,
+		Target:                   d.Target,
+		APIVersion:               d.APIVersion,
+		AuthorizationEndpoint:    d.AuthorizationEndpoint,
+		LoggregatorEndPoint:      d.LoggregatorEndPoint,
+		DopplerEndPoint:          d.DopplerEndPoint,
+		UaaEndpoint:              d.UaaEndpoint,
+		RoutingAPIEndpoint:       d.RoutingAPIEndpoint,
+		AccessToken:              d.AccessToken,
+		SSHOAuthClient:           d.SSHOAuthClient,
+		RefreshToken:             d.RefreshToken,
+		OrganizationFields:       d.OrganizationFields,
+		SpaceFields:              d.SpaceFields,
+		SSLDisabled:              d.SSLDisabled,
+		CACertFile:               d.CACertFile,
+		ClientCertFile:           d.ClientCertFile,
+		ClientKeyFile:            d.ClientKeyFile,
+		MinCLIVersion:            d.MinCLIVersion,
+		MinRecommendedCLIVersion: d.MinRecommendedCLIVersion,
+	}
+}
+
+func (d *Data) setTargetProfile(profile TargetProfile) {
+	d.Target = profile.Target
+	d.APIVersion = profile.APIVersion
+	d.AuthorizationEndpoint = profile.AuthorizationEndpoint
+	d.LoggregatorEndPoint = profile.LoggregatorEndPoint
+	d.DopplerEndPoint = profile.DopplerEndPoint
+	d.UaaEndpoint = profile.UaaEndpoint
+	d.RoutingAPIEndpoint = profile.RoutingAPIEndpoint
+	d.AccessToken = profile.AccessToken
+	d.SSHOAuthClient = profile.SSHOAuthClient
+	d.RefreshToken = profile.RefreshToken
+	d.OrganizationFields = profile.OrganizationFields
+	d.SpaceFields = profile.SpaceFields
+	d.SSLDisabled = profile.SSLDisabled
+	d.CACertFile = profile.CACertFile
+	d.ClientCertFile = profile.ClientCertFile
+	d.ClientKeyFile = profile.ClientKeyFile
+	d.MinCLIVersion = profile.MinCLIVersion
+	d.MinRecommendedCLIVersion = profile.MinRecommendedCLIVersion
+}
+
+func (d *Data) profileIndex(name string) int {
+	for i, profile := range d.Profiles {
+		if profile.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// useProfileTarget targets what the named profile does, if there is such a
+// profile.
+func (d *Data) useProfileTarget(name string) bool {
+	i := d.profileIndex(name)
+	if i == -1 {
+		return false
+	}
+
+	d.setTargetProfile(d.Profiles[i])
+	return true
+}
+
+// syncProfile updates the named profile with the target, unless the target
+// is another API endpoint than the profile's.
+func (d *Data) syncProfile(name string) bool {
+	i := d.profileIndex(name)
+	if i == -1 {
+		return true
+	}
+
+	if d.Profiles[i].Target != d.Target {
+		return false
+	}
+
+	d.Profiles[i] = d.targetProfile(name)
+	return true
+}
+
+// session identifies the API endpoint and user logged in to.
+func (d *Data) session() string {
+	if d.AccessToken == "" {
+		return ""
+	}
+	return d.Target + " " + NewTokenInfo(d.AccessToken).UserGUID
+}
+
+// saveProfile stores the current target as the named profile, replacing a
+// profile of that name.
+func (d *Data) saveProfile(name string) {
+	profile := d.targetProfile(name)
+
+	if i := d.profileIndex(name); i != -1 {
+		d.Profiles[i] = profile
+		return
+	}
+
+	d.Profiles = append(d.Profiles, profile)
+	sort.Sort(profilesByName(d.Profiles))
+}
+
+type profilesByName []TargetProfile
+
+func (p profilesByName) Len() int           { return len(p) }
+func (p profilesByName) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p profilesByName) Less(i, j int) bool { return p[i].Name < p[j].Name }
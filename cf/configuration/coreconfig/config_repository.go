@@ -1,6 +1,7 @@
 package coreconfig
 
 import (
+	"fmt"
 	"strings"
 	"sync"
 
@@ -15,6 +16,10 @@ type ConfigRepository struct {
 	initOnce  *sync.Once
 	persistor configuration.Persistor
 	onError   func(error)
+
+	// sessionProfile is the profile targeted for this run only, in place
+	// of the current target.
+	sessionProfile string
 }
 
 type CCInfo struct {
@@ -31,7 +36,7 @@ func NewRepositoryFromFilepath(filepath string, errorHandler func(error)) Reposi
 	if errorHandler == nil {
 		return nil
 	}
-	return NewRepositoryFromPersistor(configuration.NewDiskPersistor(filepath), errorHandler)
+	return NewRepositoryFromPersistor(newCredentialPersistorFromFilepath(filepath), errorHandler)
 }
 
 func NewRepositoryFromPersistor(persistor configuration.Persistor, errorHandler func(error)) Repository {
@@ -78,12 +83,17 @@ type Reader interface {
 	UserEmail() string
 	IsLoggedIn() bool
 	IsSSLDisabled() bool
+	CACertFile() string
+	ClientCertFile() string
+	ClientKeyFile() string
 	IsMinAPIVersion(semver.Version) bool
 	IsMinCLIVersion(string) bool
 	MinCLIVersion() string
 	MinRecommendedCLIVersion() string
 
 	AsyncTimeout() uint
+	RequestRetries() uint
+	PluginGracePeriod() uint
 	Trace() string
 
 	ColorEnabled() string
@@ -91,6 +101,13 @@ type Reader interface {
 	Locale() string
 
 	PluginRepos() []models.PluginRepo
+	PluginKeys() []models.PluginKey
+	PluginInstallPolicy() string
+
+	CurrentProfile() string
+	Profiles() []TargetProfile
+
+	CredentialStore() string
 }
 
 //go:generate counterfeiter . ReadWriter
@@ -113,12 +130,25 @@ type ReadWriter interface {
 	SetOrganizationFields(models.OrganizationFields)
 	SetSpaceFields(models.SpaceFields)
 	SetSSLDisabled(bool)
+	SetCACertFile(string)
+	SetClientCertificate(certFile string, keyFile string)
 	SetAsyncTimeout(uint)
+	SetRequestRetries(uint)
+	SetPluginGracePeriod(uint)
 	SetTrace(string)
 	SetColorEnabled(string)
 	SetLocale(string)
 	SetPluginRepo(models.PluginRepo)
 	UnSetPluginRepo(int)
+	SetPluginKey(models.PluginKey)
+	UnSetPluginKey(int)
+	SetPluginInstallPolicy(string)
+	SetRefreshedTokens(string, string)
+	SaveProfile(string)
+	UseProfile(string)
+	DeleteProfile(string)
+	SetSessionProfile(string)
+	SetCredentialStore(string)
 }
 
 //go:generate counterfeiter . Repository
@@ -136,6 +166,12 @@ func (c *ConfigRepository) init() {
 		if err != nil {
 			c.onError(err)
 		}
+
+		if c.sessionProfile != "" && !c.data.useProfileTarget(c.sessionProfile) {
+			name := c.sessionProfile
+			c.sessionProfile = ""
+			c.onError(fmt.Errorf("Target profile %s not found", name))
+		}
 	})
 }
 
@@ -147,19 +183,68 @@ func (c *ConfigRepository) read(cb func()) {
 	cb()
 }
 
-func (c *ConfigRepository) write(cb func()) {
+func (c *ConfigRepository) write(change func(*Data)) {
+	c.update(change, change)
+}
+
+// update makes change to the config as this process sees it, and
+// changeSaved to the config as it is saved, which other cf processes may
+// have changed since this one loaded it. Their changes are kept.
+func (c *ConfigRepository) update(change func(*Data), changeSaved func(*Data)) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	c.init()
 
-	cb()
+	change(c.data)
+	c.keepProfile(c.data)
 
-	err := c.persistor.Save(c.data)
+	saved := NewData()
+	err := c.persistor.Update(saved, func() {
+		c.changeSaved(saved, changeSaved)
+	})
 	if err != nil {
 		c.onError(err)
 	}
 }
 
+// keepProfile keeps the current profile up to date with changes to the
+// target, such as a new access token. Targeting another API endpoint
+// leaves the profile instead of changing it.
+func (c *ConfigRepository) keepProfile(data *Data) {
+	if c.sessionProfile != "" {
+		data.syncProfile(c.sessionProfile)
+		return
+	}
+
+	if !data.syncProfile(data.CurrentProfile) {
+		data.CurrentProfile = ""
+	}
+}
+
+// changeSaved makes change to the config as saved. A profile used for this
+// session only stands in for the saved target while the change is made,
+// so that the default target is left alone.
+func (c *ConfigRepository) changeSaved(saved *Data, change func(*Data)) {
+	if c.sessionProfile == "" {
+		change(saved)
+		c.keepProfile(saved)
+		return
+	}
+
+	defaultTarget := saved.targetProfile("")
+	currentProfile := saved.CurrentProfile
+	saved.useProfileTarget(c.sessionProfile)
+
+	change(saved)
+	c.keepProfile(saved)
+
+	// Switching profiles during the session switches the default target.
+	if saved.CurrentProfile != currentProfile && saved.useProfileTarget(saved.CurrentProfile) {
+		return
+	}
+	saved.setTargetProfile(defaultTarget)
+}
+
 // CLOSERS
 
 func (c *ConfigRepository) Close() {
@@ -317,6 +402,27 @@ func (c *ConfigRepository) IsSSLDisabled() (isSSLDisabled bool) {
 	return
 }
 
+func (c *ConfigRepository) CACertFile() (caCertFile string) {
+	c.read(func() {
+		caCertFile = c.data.CACertFile
+	})
+	return
+}
+
+func (c *ConfigRepository) ClientCertFile() (clientCertFile string) {
+	c.read(func() {
+		clientCertFile = c.data.ClientCertFile
+	})
+	return
+}
+
+func (c *ConfigRepository) ClientKeyFile() (clientKeyFile string) {
+	c.read(func() {
+		clientKeyFile = c.data.ClientKeyFile
+	})
+	return
+}
+
 func (c *ConfigRepository) IsMinAPIVersion(requiredVersion semver.Version) bool {
 	var apiVersion string
 	c.read(func() {
@@ -374,6 +480,26 @@ func (c *ConfigRepository) AsyncTimeout() (timeout uint) {
 	return
 }
 
+func (c *ConfigRepository) RequestRetries() (retries uint) {
+	c.read(func() {
+		retries = DefaultRequestRetries
+		if c.data.RequestRetries != nil {
+			retries = *c.data.RequestRetries
+		}
+	})
+	return
+}
+
+func (c *ConfigRepository) PluginGracePeriod() (seconds uint) {
+	c.read(func() {
+		seconds = DefaultPluginGracePeriod
+		if c.data.PluginGracePeriod != nil {
+			seconds = *c.data.PluginGracePeriod
+		}
+	})
+	return
+}
+
 func (c *ConfigRepository) Trace() (trace string) {
 	c.read(func() {
 		trace = c.data.Trace
@@ -402,139 +528,349 @@ func (c *ConfigRepository) PluginRepos() (repos []models.PluginRepo) {
 	return
 }
 
+func (c *ConfigRepository) PluginKeys() (keys []models.PluginKey) {
+	c.read(func() {
+		keys = c.data.PluginKeys
+	})
+	return
+}
+
+func (c *ConfigRepository) PluginInstallPolicy() (policy string) {
+	c.read(func() {
+		policy = c.data.PluginInstallPolicy
+		if policy == "" {
+			policy = PluginInstallPolicyAny
+		}
+	})
+	return
+}
+
+func (c *ConfigRepository) CurrentProfile() (name string) {
+	c.read(func() {
+		name = c.data.CurrentProfile
+		if c.sessionProfile != "" {
+			name = c.sessionProfile
+		}
+	})
+	return
+}
+
+func (c *ConfigRepository) Profiles() (profiles []TargetProfile) {
+	c.read(func() {
+		profiles = c.data.Profiles
+	})
+	return
+}
+
+func (c *ConfigRepository) CredentialStore() (name string) {
+	c.read(func() {
+		name = c.data.CredentialStore
+	})
+	return
+}
+
 // SETTERS
 
 func (c *ConfigRepository) ClearSession() {
-	c.write(func() {
-		c.data.AccessToken = ""
-		c.data.RefreshToken = ""
-		c.data.OrganizationFields = models.OrganizationFields{}
-		c.data.SpaceFields = models.SpaceFields{}
+	c.write(func(data *Data) {
+		data.AccessToken = ""
+		data.RefreshToken = ""
+		data.OrganizationFields = models.OrganizationFields{}
+		data.SpaceFields = models.SpaceFields{}
 	})
 }
 
 func (c *ConfigRepository) SetAPIEndpoint(endpoint string) {
-	c.write(func() {
-		c.data.Target = endpoint
+	c.write(func(data *Data) {
+		data.Target = endpoint
 	})
 }
 
 func (c *ConfigRepository) SetAPIVersion(version string) {
-	c.write(func() {
-		c.data.APIVersion = version
+	c.write(func(data *Data) {
+		data.APIVersion = version
 	})
 }
 
 func (c *ConfigRepository) SetMinCLIVersion(version string) {
-	c.write(func() {
-		c.data.MinCLIVersion = version
+	c.write(func(data *Data) {
+		data.MinCLIVersion = version
 	})
 }
 
 func (c *ConfigRepository) SetMinRecommendedCLIVersion(version string) {
-	c.write(func() {
-		c.data.MinRecommendedCLIVersion = version
+	c.write(func(data *Data) {
+		data.MinRecommendedCLIVersion = version
 	})
 }
 
 func (c *ConfigRepository) SetAuthenticationEndpoint(endpoint string) {
-	c.write(func() {
-		c.data.AuthorizationEndpoint = endpoint
+	c.write(func(data *Data) {
+		data.AuthorizationEndpoint = endpoint
 	})
 }
 
 func (c *ConfigRepository) SetLoggregatorEndpoint(endpoint string) {
-	c.write(func() {
-		c.data.LoggregatorEndPoint = endpoint
+	c.write(func(data *Data) {
+		data.LoggregatorEndPoint = endpoint
 	})
 }
 
 func (c *ConfigRepository) SetDopplerEndpoint(endpoint string) {
-	c.write(func() {
-		c.data.DopplerEndPoint = endpoint
+	c.write(func(data *Data) {
+		data.DopplerEndPoint = endpoint
 	})
 }
 
 func (c *ConfigRepository) SetUaaEndpoint(uaaEndpoint string) {
-	c.write(func() {
-		c.data.UaaEndpoint = uaaEndpoint
+	c.write(func(data *Data) {
+		data.UaaEndpoint = uaaEndpoint
 	})
 }
 
 func (c *ConfigRepository) SetRoutingAPIEndpoint(routingAPIEndpoint string) {
-	c.write(func() {
-		c.data.RoutingAPIEndpoint = routingAPIEndpoint
+	c.write(func(data *Data) {
+		data.RoutingAPIEndpoint = routingAPIEndpoint
 	})
 }
 
 func (c *ConfigRepository) SetAccessToken(token string) {
-	c.write(func() {
-		c.data.AccessToken = token
+	c.write(func(data *Data) {
+		data.AccessToken = token
 	})
 }
 
 func (c *ConfigRepository) SetSSHOAuthClient(clientID string) {
-	c.write(func() {
-		c.data.SSHOAuthClient = clientID
+	c.write(func(data *Data) {
+		data.SSHOAuthClient = clientID
 	})
 }
 
 func (c *ConfigRepository) SetRefreshToken(token string) {
-	c.write(func() {
-		c.data.RefreshToken = token
+	c.write(func(data *Data) {
+		data.RefreshToken = token
 	})
 }
 
 func (c *ConfigRepository) SetOrganizationFields(org models.OrganizationFields) {
-	c.write(func() {
-		c.data.OrganizationFields = org
+	c.write(func(data *Data) {
+		data.OrganizationFields = org
 	})
 }
 
 func (c *ConfigRepository) SetSpaceFields(space models.SpaceFields) {
-	c.write(func() {
-		c.data.SpaceFields = space
+	c.write(func(data *Data) {
+		data.SpaceFields = space
 	})
 }
 
 func (c *ConfigRepository) SetSSLDisabled(disabled bool) {
-	c.write(func() {
-		c.data.SSLDisabled = disabled
+	c.write(func(data *Data) {
+		data.SSLDisabled = disabled
+	})
+}
+
+func (c *ConfigRepository) SetCACertFile(caCertFile string) {
+	c.write(func(data *Data) {
+		data.CACertFile = caCertFile
+	})
+}
+
+func (c *ConfigRepository) SetClientCertificate(certFile string, keyFile string) {
+	c.write(func(data *Data) {
+		data.ClientCertFile = certFile
+		data.ClientKeyFile = keyFile
 	})
 }
 
 func (c *ConfigRepository) SetAsyncTimeout(timeout uint) {
-	c.write(func() {
-		c.data.AsyncTimeout = timeout
+	c.write(func(data *Data) {
+		data.AsyncTimeout = timeout
+	})
+}
+
+func (c *ConfigRepository) SetRequestRetries(retries uint) {
+	c.write(func(data *Data) {
+		data.RequestRetries = &retries
+	})
+}
+
+func (c *ConfigRepository) SetPluginGracePeriod(seconds uint) {
+	c.write(func(data *Data) {
+		data.PluginGracePeriod = &seconds
 	})
 }
 
 func (c *ConfigRepository) SetTrace(value string) {
-	c.write(func() {
-		c.data.Trace = value
+	c.write(func(data *Data) {
+		data.Trace = value
 	})
 }
 
 func (c *ConfigRepository) SetColorEnabled(enabled string) {
-	c.write(func() {
-		c.data.ColorEnabled = enabled
+	c.write(func(data *Data) {
+		data.ColorEnabled = enabled
 	})
 }
 
 func (c *ConfigRepository) SetLocale(locale string) {
-	c.write(func() {
-		c.data.Locale = locale
+	c.write(func(data *Data) {
+		data.Locale = locale
 	})
 }
 
 func (c *ConfigRepository) SetPluginRepo(repo models.PluginRepo) {
-	c.write(func() {
-		c.data.PluginRepos = append(c.data.PluginRepos, repo)
+	c.write(func(data *Data) {
+		data.PluginRepos = append(data.PluginRepos, repo)
 	})
 }
 
+// UnSetPluginRepo removes the plugin repo at index. Other cf processes may
+// have changed the saved list, so it is removed from there by name.
 func (c *ConfigRepository) UnSetPluginRepo(index int) {
-	c.write(func() {
-		c.data.PluginRepos = append(c.data.PluginRepos[:index], c.data.PluginRepos[index+1:]...)
+	var name string
+	removed := false
+	c.update(func(data *Data) {
+		if index < len(data.PluginRepos) {
+			name = data.PluginRepos[index].Name
+			removed = true
+			data.PluginRepos = removePluginRepo(data.PluginRepos, name)
+		}
+	}, func(saved *Data) {
+		if removed {
+			saved.PluginRepos = removePluginRepo(saved.PluginRepos, name)
+		}
+	})
+}
+
+func removePluginRepo(repos []models.PluginRepo, name string) []models.PluginRepo {
+	kept := []models.PluginRepo{}
+	for _, repo := range repos {
+		if repo.Name != name {
+			kept = append(kept, repo)
+		}
+	}
+	return kept
+}
+
+func (c *ConfigRepository) SetPluginKey(key models.PluginKey) {
+	c.write(func(data *Data) {
+		data.PluginKeys = append(data.PluginKeys, key)
+	})
+}
+
+// UnSetPluginKey removes the plugin key at index. Other cf processes may
+// have changed the saved list, so it is removed from there by name.
+func (c *ConfigRepository) UnSetPluginKey(index int) {
+	var name string
+	removed := false
+	c.update(func(data *Data) {
+		if index < len(data.PluginKeys) {
+			name = data.PluginKeys[index].Name
+			removed = true
+			data.PluginKeys = removePluginKey(data.PluginKeys, name)
+		}
+	}, func(saved *Data) {
+		if removed {
+			saved.PluginKeys = removePluginKey(saved.PluginKeys, name)
+		}
+	})
+}
+
+func removePluginKey(keys []models.PluginKey, name string) []models.PluginKey {
+	kept := []models.PluginKey{}
+	for _, key := range keys {
+		if key.Name != name {
+			kept = append(kept, key)
+		}
+	}
+	return kept
+}
+
+func (c *ConfigRepository) SetPluginInstallPolicy(policy string) {
+	c.write(func(data *Data) {
+		data.PluginInstallPolicy = policy
+	})
+}
+
+// SetRefreshedTokens saves tokens refreshed for the current session. When
+// another cf process has logged in elsewhere since, they are kept for this
+// process only, rather than log the other one out.
+func (c *ConfigRepository) SetRefreshedTokens(accessToken string, refreshToken string) {
+	var session string
+	c.update(func(data *Data) {
+		session = data.session()
+		data.AccessToken = accessToken
+		data.RefreshToken = refreshToken
+	}, func(saved *Data) {
+		if saved.session() != session {
+			return
+		}
+		saved.AccessToken = accessToken
+		saved.RefreshToken = refreshToken
+	})
+}
+
+func (c *ConfigRepository) SaveProfile(name string) {
+	c.write(func(data *Data) {
+		data.saveProfile(name)
+		if c.sessionProfile == "" {
+			data.CurrentProfile = name
+		}
 	})
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.sessionProfile != "" {
+		c.sessionProfile = name
+	}
+}
+
+func (c *ConfigRepository) UseProfile(name string) {
+	c.write(func(data *Data) {
+		if data.profileIndex(name) == -1 {
+			return
+		}
+
+		data.CurrentProfile = name
+		if c.sessionProfile == "" {
+			data.useProfileTarget(name)
+		}
+	})
+}
+
+func (c *ConfigRepository) DeleteProfile(name string) {
+	c.write(func(data *Data) {
+		i := data.profileIndex(name)
+		if i == -1 {
+			return
+		}
+
+		data.Profiles = append(data.Profiles[:i], data.Profiles[i+1:]...)
+		if data.CurrentProfile == name {
+			data.CurrentProfile = ""
+		}
+	})
+}
+
+// SetSessionProfile targets the named profile for as long as this config is
+// in use, without changing the default target. It has to be called before
+// the config is first read.
+func (c *ConfigRepository) SetSessionProfile(name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.sessionProfile = name
+}
+
+// SetCredentialStore moves the access and refresh tokens to the named
+// credential store, or back into the config file when name is "".
+func (c *ConfigRepository) SetCredentialStore(name string) {
+	c.write(func(data *Data) {
+		data.CredentialStore = name
+	})
+
+	// Save once more, so that the backup of the config file no longer holds
+	// the tokens either.
+	c.write(func(data *Data) {})
 }
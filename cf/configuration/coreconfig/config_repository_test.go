@@ -34,7 +34,7 @@ var _ = Describe("Configuration Repository", func() {
 		finishSaveCh := make(chan struct{})
 		finishReadCh := make(chan struct{})
 
-		persistor.SaveStub = func(configuration.DataInterface) error {
+		persistor.UpdateStub = func(configuration.DataInterface, func()) error {
 			close(beginSaveCh)
 			<-performSaveCh
 			close(finishSaveCh)
@@ -100,6 +100,13 @@ var _ = Describe("Configuration Repository", func() {
 		config.SetSSLDisabled(false)
 		Expect(config.IsSSLDisabled()).To(BeFalse())
 
+		config.SetCACertFile("/certs/ca.pem")
+		Expect(config.CACertFile()).To(Equal("/certs/ca.pem"))
+
+		config.SetClientCertificate("/certs/client.pem", "/certs/client-key.pem")
+		Expect(config.ClientCertFile()).To(Equal("/certs/client.pem"))
+		Expect(config.ClientKeyFile()).To(Equal("/certs/client-key.pem"))
+
 		config.SetLocale("en_US")
 		Expect(config.Locale()).To(Equal("en_US"))
 
@@ -107,6 +114,15 @@ var _ = Describe("Configuration Repository", func() {
 		Expect(config.PluginRepos()[0].Name).To(Equal("repo"))
 		Expect(config.PluginRepos()[0].URL).To(Equal("nowhere.com"))
 
+		config.SetPluginKey(models.PluginKey{Name: "key1", PublicKey: "a2V5MQ=="})
+		config.SetPluginKey(models.PluginKey{Name: "key2", PublicKey: "a2V5Mg=="})
+		config.UnSetPluginKey(0)
+		Expect(config.PluginKeys()).To(Equal([]models.PluginKey{{Name: "key2", PublicKey: "a2V5Mg=="}}))
+
+		Expect(config.PluginInstallPolicy()).To(Equal(coreconfig.PluginInstallPolicyAny))
+		config.SetPluginInstallPolicy(coreconfig.PluginInstallPolicySigned)
+		Expect(config.PluginInstallPolicy()).To(Equal(coreconfig.PluginInstallPolicySigned))
+
 		s, _ := semver.Make("3.1")
 		Expect(config.IsMinAPIVersion(s)).To(Equal(false))
 
@@ -115,6 +131,14 @@ var _ = Describe("Configuration Repository", func() {
 
 		config.SetMinRecommendedCLIVersion("6.9.0")
 		Expect(config.MinRecommendedCLIVersion()).To(Equal("6.9.0"))
+
+		Expect(config.RequestRetries()).To(Equal(uint(coreconfig.DefaultRequestRetries)))
+		config.SetRequestRetries(0)
+		Expect(config.RequestRetries()).To(Equal(uint(0)))
+
+		Expect(config.PluginGracePeriod()).To(Equal(uint(coreconfig.DefaultPluginGracePeriod)))
+		config.SetPluginGracePeriod(0)
+		Expect(config.PluginGracePeriod()).To(Equal(uint(0)))
 	})
 
 	Describe("HasAPIEndpoint", func() {
@@ -239,6 +263,10 @@ var _ = Describe("Configuration Repository", func() {
 				configPath = filepath.Join(cwd, "..", "..", "..", "fixtures", "config", "outdated-config", ".cf", "config.json")
 			})
 
+			AfterEach(func() {
+				os.Remove(configPath + ".lock")
+			})
+
 			It("returns a new empty config", func() {
 				config = coreconfig.NewRepositoryFromFilepath(configPath, func(err error) {
 					panic(err)
@@ -280,4 +308,222 @@ var _ = Describe("Configuration Repository", func() {
 			Expect(config.IsMinCLIVersion(actualVersion)).To(BeTrue())
 		})
 	})
+
+	Describe("saving", func() {
+		var savedJSON []byte
+
+		// saved is the config as another cf process would find it.
+		saved := func() *coreconfig.Data {
+			data := coreconfig.NewData()
+			Expect(data.JSONUnmarshalV3(savedJSON)).To(Succeed())
+			return data
+		}
+
+		// changeSaved changes the config the way another cf process would.
+		changeSaved := func(change func(*coreconfig.Data)) {
+			data := saved()
+			change(data)
+
+			var err error
+			savedJSON, err = data.JSONMarshalV3()
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			savedJSON = []byte(`{"ConfigVersion": 3}`)
+
+			persistor.LoadStub = func(data configuration.DataInterface) error {
+				return data.JSONUnmarshalV3(savedJSON)
+			}
+			persistor.UpdateStub = func(data configuration.DataInterface, change func()) error {
+				err := data.JSONUnmarshalV3(savedJSON)
+				Expect(err).NotTo(HaveOccurred())
+
+				change()
+
+				savedJSON, err = data.JSONMarshalV3()
+				return err
+			}
+
+			config = coreconfig.NewRepositoryFromPersistor(persistor, func(err error) { panic(err) })
+		})
+
+		It("keeps changes other cf processes saved in the meantime", func() {
+			config.SetAPIEndpoint("https://api.example.com")
+			changeSaved(func(data *coreconfig.Data) {
+				data.Trace = "true"
+			})
+
+			config.SetAsyncTimeout(10)
+
+			Expect(saved().Trace).To(Equal("true"))
+			Expect(saved().Target).To(Equal("https://api.example.com"))
+			Expect(saved().AsyncTimeout).To(Equal(uint(10)))
+			Expect(config.Trace()).To(BeEmpty())
+		})
+
+		It("removes the plugin repo and key by name when other cf processes changed the lists", func() {
+			config.SetPluginRepo(models.PluginRepo{Name: "repo1"})
+			config.SetPluginRepo(models.PluginRepo{Name: "repo2"})
+			config.SetPluginKey(models.PluginKey{Name: "key1"})
+			config.SetPluginKey(models.PluginKey{Name: "key2"})
+			changeSaved(func(data *coreconfig.Data) {
+				data.PluginRepos = data.PluginRepos[1:]
+				data.PluginKeys = data.PluginKeys[1:]
+			})
+
+			config.UnSetPluginRepo(1)
+			config.UnSetPluginKey(1)
+
+			Expect(saved().PluginRepos).To(BeEmpty())
+			Expect(saved().PluginKeys).To(BeEmpty())
+			Expect(config.PluginRepos()).To(Equal([]models.PluginRepo{{Name: "repo1"}}))
+			Expect(config.PluginKeys()).To(Equal([]models.PluginKey{{Name: "key1"}}))
+		})
+
+		Describe("SetRefreshedTokens", func() {
+			BeforeEach(func() {
+				config.SetAPIEndpoint("https://api.example.com")
+				config.SetAccessToken("old-access-token")
+				config.SetRefreshToken("old-refresh-token")
+			})
+
+			It("saves the tokens", func() {
+				config.SetRefreshedTokens("new-access-token", "new-refresh-token")
+
+				Expect(config.AccessToken()).To(Equal("new-access-token"))
+				Expect(saved().AccessToken).To(Equal("new-access-token"))
+				Expect(saved().RefreshToken).To(Equal("new-refresh-token"))
+			})
+
+			It("does not log out another cf process that logged in elsewhere since", func() {
+				changeSaved(func(data *coreconfig.Data) {
+					data.Target = "https://api.other.example.com"
+					data.AccessToken = "other-access-token"
+					data.RefreshToken = "other-refresh-token"
+				})
+
+				config.SetRefreshedTokens("new-access-token", "new-refresh-token")
+
+				Expect(config.AccessToken()).To(Equal("new-access-token"))
+				Expect(saved().AccessToken).To(Equal("other-access-token"))
+				Expect(saved().RefreshToken).To(Equal("other-refresh-token"))
+			})
+
+			It("does not log back in after another cf process logged out", func() {
+				changeSaved(func(data *coreconfig.Data) {
+					data.AccessToken = ""
+					data.RefreshToken = ""
+				})
+
+				config.SetRefreshedTokens("new-access-token", "new-refresh-token")
+
+				Expect(saved().AccessToken).To(BeEmpty())
+			})
+		})
+
+		Describe("target profiles", func() {
+			BeforeEach(func() {
+				config.SetAPIEndpoint("https://api.prod.example.com")
+				config.SetAccessToken("prod-token")
+				config.SaveProfile("prod")
+
+				config.SetAPIEndpoint("https://api.dev.example.com")
+				config.SetAccessToken("dev-token")
+				config.SaveProfile("dev")
+			})
+
+			It("lists the saved profiles by name", func() {
+				profiles := config.Profiles()
+				Expect(profiles).To(HaveLen(2))
+				Expect(profiles[0].Name).To(Equal("dev"))
+				Expect(profiles[0].Target).To(Equal("https://api.dev.example.com"))
+				Expect(profiles[1].Name).To(Equal("prod"))
+				Expect(profiles[1].AccessToken).To(Equal("prod-token"))
+				Expect(config.CurrentProfile()).To(Equal("dev"))
+			})
+
+			It("switches the target to a profile", func() {
+				config.UseProfile("prod")
+
+				Expect(config.CurrentProfile()).To(Equal("prod"))
+				Expect(config.APIEndpoint()).To(Equal("https://api.prod.example.com"))
+				Expect(config.AccessToken()).To(Equal("prod-token"))
+			})
+
+			It("keeps the current profile up to date with the target", func() {
+				config.SetAccessToken("refreshed-dev-token")
+				config.UseProfile("prod")
+				config.UseProfile("dev")
+
+				Expect(config.AccessToken()).To(Equal("refreshed-dev-token"))
+			})
+
+			It("leaves the current profile when another API endpoint is targeted", func() {
+				config.SetAPIEndpoint("https://api.staging.example.com")
+				config.SetAccessToken("staging-token")
+
+				Expect(config.CurrentProfile()).To(BeEmpty())
+				Expect(config.Profiles()[0].Target).To(Equal("https://api.dev.example.com"))
+				Expect(config.Profiles()[0].AccessToken).To(Equal("dev-token"))
+			})
+
+			It("deletes a profile", func() {
+				config.DeleteProfile("dev")
+
+				Expect(config.Profiles()).To(HaveLen(1))
+				Expect(config.CurrentProfile()).To(BeEmpty())
+				Expect(config.APIEndpoint()).To(Equal("https://api.dev.example.com"))
+			})
+
+			Context("when a profile is used for the session", func() {
+				BeforeEach(func() {
+					config = coreconfig.NewRepositoryFromPersistor(persistor, func(err error) { panic(err) })
+					config.SetSessionProfile("prod")
+				})
+
+				It("targets the profile", func() {
+					Expect(config.CurrentProfile()).To(Equal("prod"))
+					Expect(config.APIEndpoint()).To(Equal("https://api.prod.example.com"))
+					Expect(config.AccessToken()).To(Equal("prod-token"))
+				})
+
+				It("saves changes to the profile and leaves the default target alone", func() {
+					config.SetAccessToken("refreshed-prod-token")
+
+					data := saved()
+					Expect(data.Target).To(Equal("https://api.dev.example.com"))
+					Expect(data.AccessToken).To(Equal("dev-token"))
+					Expect(data.CurrentProfile).To(Equal("dev"))
+					Expect(data.Profiles[1].AccessToken).To(Equal("refreshed-prod-token"))
+				})
+
+				It("switches the default target without leaving the profile", func() {
+					changeSaved(func(data *coreconfig.Data) {
+						data.Target = "https://api.staging.example.com"
+						data.AccessToken = "staging-token"
+						data.CurrentProfile = ""
+					})
+
+					config.UseProfile("dev")
+					config.SetAccessToken("refreshed-prod-token")
+
+					Expect(config.APIEndpoint()).To(Equal("https://api.prod.example.com"))
+					Expect(config.AccessToken()).To(Equal("refreshed-prod-token"))
+
+					data := saved()
+					Expect(data.CurrentProfile).To(Equal("dev"))
+					Expect(data.Target).To(Equal("https://api.dev.example.com"))
+					Expect(data.AccessToken).To(Equal("dev-token"))
+				})
+
+				It("reports a profile that does not exist", func() {
+					config = coreconfig.NewRepositoryFromPersistor(persistor, func(err error) { panic(err) })
+					config.SetSessionProfile("staging")
+
+					Expect(func() { config.APIEndpoint() }).To(Panic())
+				})
+			})
+		})
+	})
 })
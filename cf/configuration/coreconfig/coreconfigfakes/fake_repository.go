@@ -130,6 +130,24 @@ type FakeRepository struct {
 	isSSLDisabledReturns     struct {
 		result1 bool
 	}
+	CACertFileStub        func() string
+	cACertFileMutex       sync.RWMutex
+	cACertFileArgsForCall []struct{}
+	cACertFileReturns     struct {
+		result1 string
+	}
+	ClientCertFileStub        func() string
+	clientCertFileMutex       sync.RWMutex
+	clientCertFileArgsForCall []struct{}
+	clientCertFileReturns     struct {
+		result1 string
+	}
+	ClientKeyFileStub        func() string
+	clientKeyFileMutex       sync.RWMutex
+	clientKeyFileArgsForCall []struct{}
+	clientKeyFileReturns     struct {
+		result1 string
+	}
 	IsMinAPIVersionStub        func(semver.Version) bool
 	isMinAPIVersionMutex       sync.RWMutex
 	isMinAPIVersionArgsForCall []struct {
@@ -164,6 +182,18 @@ type FakeRepository struct {
 	asyncTimeoutReturns     struct {
 		result1 uint
 	}
+	RequestRetriesStub        func() uint
+	requestRetriesMutex       sync.RWMutex
+	requestRetriesArgsForCall []struct{}
+	requestRetriesReturns     struct {
+		result1 uint
+	}
+	PluginGracePeriodStub        func() uint
+	pluginGracePeriodMutex       sync.RWMutex
+	pluginGracePeriodArgsForCall []struct{}
+	pluginGracePeriodReturns     struct {
+		result1 uint
+	}
 	TraceStub        func() string
 	traceMutex       sync.RWMutex
 	traceArgsForCall []struct{}
@@ -188,6 +218,36 @@ type FakeRepository struct {
 	pluginReposReturns     struct {
 		result1 []models.PluginRepo
 	}
+	PluginKeysStub        func() []models.PluginKey
+	pluginKeysMutex       sync.RWMutex
+	pluginKeysArgsForCall []struct{}
+	pluginKeysReturns     struct {
+		result1 []models.PluginKey
+	}
+	PluginInstallPolicyStub        func() string
+	pluginInstallPolicyMutex       sync.RWMutex
+	pluginInstallPolicyArgsForCall []struct{}
+	pluginInstallPolicyReturns     struct {
+		result1 string
+	}
+	CurrentProfileStub        func() string
+	currentProfileMutex       sync.RWMutex
+	currentProfileArgsForCall []struct{}
+	currentProfileReturns     struct {
+		result1 string
+	}
+	ProfilesStub        func() []coreconfig.TargetProfile
+	profilesMutex       sync.RWMutex
+	profilesArgsForCall []struct{}
+	profilesReturns     struct {
+		result1 []coreconfig.TargetProfile
+	}
+	CredentialStoreStub        func() string
+	credentialStoreMutex       sync.RWMutex
+	credentialStoreArgsForCall []struct{}
+	credentialStoreReturns     struct {
+		result1 string
+	}
 	ClearSessionStub          func()
 	clearSessionMutex         sync.RWMutex
 	clearSessionArgsForCall   []struct{}
@@ -266,11 +326,32 @@ type FakeRepository struct {
 	setSSLDisabledArgsForCall []struct {
 		arg1 bool
 	}
+	SetCACertFileStub        func(string)
+	setCACertFileMutex       sync.RWMutex
+	setCACertFileArgsForCall []struct {
+		arg1 string
+	}
+	SetClientCertificateStub        func(certFile string, keyFile string)
+	setClientCertificateMutex       sync.RWMutex
+	setClientCertificateArgsForCall []struct {
+		certFile string
+		keyFile  string
+	}
 	SetAsyncTimeoutStub        func(uint)
 	setAsyncTimeoutMutex       sync.RWMutex
 	setAsyncTimeoutArgsForCall []struct {
 		arg1 uint
 	}
+	SetRequestRetriesStub        func(uint)
+	setRequestRetriesMutex       sync.RWMutex
+	setRequestRetriesArgsForCall []struct {
+		arg1 uint
+	}
+	SetPluginGracePeriodStub        func(uint)
+	setPluginGracePeriodMutex       sync.RWMutex
+	setPluginGracePeriodArgsForCall []struct {
+		arg1 uint
+	}
 	SetTraceStub        func(string)
 	setTraceMutex       sync.RWMutex
 	setTraceArgsForCall []struct {
@@ -296,6 +377,52 @@ type FakeRepository struct {
 	unSetPluginRepoArgsForCall []struct {
 		arg1 int
 	}
+	SetPluginKeyStub        func(models.PluginKey)
+	setPluginKeyMutex       sync.RWMutex
+	setPluginKeyArgsForCall []struct {
+		arg1 models.PluginKey
+	}
+	UnSetPluginKeyStub        func(int)
+	unSetPluginKeyMutex       sync.RWMutex
+	unSetPluginKeyArgsForCall []struct {
+		arg1 int
+	}
+	SetPluginInstallPolicyStub        func(string)
+	setPluginInstallPolicyMutex       sync.RWMutex
+	setPluginInstallPolicyArgsForCall []struct {
+		arg1 string
+	}
+	SetRefreshedTokensStub        func(string, string)
+	setRefreshedTokensMutex       sync.RWMutex
+	setRefreshedTokensArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	SaveProfileStub        func(string)
+	saveProfileMutex       sync.RWMutex
+	saveProfileArgsForCall []struct {
+		arg1 string
+	}
+	UseProfileStub        func(string)
+	useProfileMutex       sync.RWMutex
+	useProfileArgsForCall []struct {
+		arg1 string
+	}
+	DeleteProfileStub        func(string)
+	deleteProfileMutex       sync.RWMutex
+	deleteProfileArgsForCall []struct {
+		arg1 string
+	}
+	SetSessionProfileStub        func(string)
+	setSessionProfileMutex       sync.RWMutex
+	setSessionProfileArgsForCall []struct {
+		arg1 string
+	}
+	SetCredentialStoreStub        func(string)
+	setCredentialStoreMutex       sync.RWMutex
+	setCredentialStoreArgsForCall []struct {
+		arg1 string
+	}
 	CloseStub        func()
 	closeMutex       sync.RWMutex
 	closeArgsForCall []struct{}
@@ -781,6 +908,78 @@ func (fake *FakeRepository) IsSSLDisabledReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeRepository) CACertFile() string {
+	fake.cACertFileMutex.Lock()
+	fake.cACertFileArgsForCall = append(fake.cACertFileArgsForCall, struct{}{})
+	fake.cACertFileMutex.Unlock()
+	if fake.CACertFileStub != nil {
+		return fake.CACertFileStub()
+	} else {
+		return fake.cACertFileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) CACertFileCallCount() int {
+	fake.cACertFileMutex.RLock()
+	defer fake.cACertFileMutex.RUnlock()
+	return len(fake.cACertFileArgsForCall)
+}
+
+func (fake *FakeRepository) CACertFileReturns(result1 string) {
+	fake.CACertFileStub = nil
+	fake.cACertFileReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) ClientCertFile() string {
+	fake.clientCertFileMutex.Lock()
+	fake.clientCertFileArgsForCall = append(fake.clientCertFileArgsForCall, struct{}{})
+	fake.clientCertFileMutex.Unlock()
+	if fake.ClientCertFileStub != nil {
+		return fake.ClientCertFileStub()
+	} else {
+		return fake.clientCertFileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ClientCertFileCallCount() int {
+	fake.clientCertFileMutex.RLock()
+	defer fake.clientCertFileMutex.RUnlock()
+	return len(fake.clientCertFileArgsForCall)
+}
+
+func (fake *FakeRepository) ClientCertFileReturns(result1 string) {
+	fake.ClientCertFileStub = nil
+	fake.clientCertFileReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) ClientKeyFile() string {
+	fake.clientKeyFileMutex.Lock()
+	fake.clientKeyFileArgsForCall = append(fake.clientKeyFileArgsForCall, struct{}{})
+	fake.clientKeyFileMutex.Unlock()
+	if fake.ClientKeyFileStub != nil {
+		return fake.ClientKeyFileStub()
+	} else {
+		return fake.clientKeyFileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ClientKeyFileCallCount() int {
+	fake.clientKeyFileMutex.RLock()
+	defer fake.clientKeyFileMutex.RUnlock()
+	return len(fake.clientKeyFileArgsForCall)
+}
+
+func (fake *FakeRepository) ClientKeyFileReturns(result1 string) {
+	fake.ClientKeyFileStub = nil
+	fake.clientKeyFileReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeRepository) IsMinAPIVersion(arg1 semver.Version) bool {
 	fake.isMinAPIVersionMutex.Lock()
 	fake.isMinAPIVersionArgsForCall = append(fake.isMinAPIVersionArgsForCall, struct {
@@ -917,6 +1116,54 @@ func (fake *FakeRepository) AsyncTimeoutReturns(result1 uint) {
 	}{result1}
 }
 
+func (fake *FakeRepository) RequestRetries() uint {
+	fake.requestRetriesMutex.Lock()
+	fake.requestRetriesArgsForCall = append(fake.requestRetriesArgsForCall, struct{}{})
+	fake.requestRetriesMutex.Unlock()
+	if fake.RequestRetriesStub != nil {
+		return fake.RequestRetriesStub()
+	} else {
+		return fake.requestRetriesReturns.result1
+	}
+}
+
+func (fake *FakeRepository) RequestRetriesCallCount() int {
+	fake.requestRetriesMutex.RLock()
+	defer fake.requestRetriesMutex.RUnlock()
+	return len(fake.requestRetriesArgsForCall)
+}
+
+func (fake *FakeRepository) RequestRetriesReturns(result1 uint) {
+	fake.RequestRetriesStub = nil
+	fake.requestRetriesReturns = struct {
+		result1 uint
+	}{result1}
+}
+
+func (fake *FakeRepository) PluginGracePeriod() uint {
+	fake.pluginGracePeriodMutex.Lock()
+	fake.pluginGracePeriodArgsForCall = append(fake.pluginGracePeriodArgsForCall, struct{}{})
+	fake.pluginGracePeriodMutex.Unlock()
+	if fake.PluginGracePeriodStub != nil {
+		return fake.PluginGracePeriodStub()
+	} else {
+		return fake.pluginGracePeriodReturns.result1
+	}
+}
+
+func (fake *FakeRepository) PluginGracePeriodCallCount() int {
+	fake.pluginGracePeriodMutex.RLock()
+	defer fake.pluginGracePeriodMutex.RUnlock()
+	return len(fake.pluginGracePeriodArgsForCall)
+}
+
+func (fake *FakeRepository) PluginGracePeriodReturns(result1 uint) {
+	fake.PluginGracePeriodStub = nil
+	fake.pluginGracePeriodReturns = struct {
+		result1 uint
+	}{result1}
+}
+
 func (fake *FakeRepository) Trace() string {
 	fake.traceMutex.Lock()
 	fake.traceArgsForCall = append(fake.traceArgsForCall, struct{}{})
@@ -1013,6 +1260,126 @@ func (fake *FakeRepository) PluginReposReturns(result1 []models.PluginRepo) {
 	}{result1}
 }
 
+func (fake *FakeRepository) PluginKeys() []models.PluginKey {
+	fake.pluginKeysMutex.Lock()
+	fake.pluginKeysArgsForCall = append(fake.pluginKeysArgsForCall, struct{}{})
+	fake.pluginKeysMutex.Unlock()
+	if fake.PluginKeysStub != nil {
+		return fake.PluginKeysStub()
+	} else {
+		return fake.pluginKeysReturns.result1
+	}
+}
+
+func (fake *FakeRepository) PluginKeysCallCount() int {
+	fake.pluginKeysMutex.RLock()
+	defer fake.pluginKeysMutex.RUnlock()
+	return len(fake.pluginKeysArgsForCall)
+}
+
+func (fake *FakeRepository) PluginKeysReturns(result1 []models.PluginKey) {
+	fake.PluginKeysStub = nil
+	fake.pluginKeysReturns = struct {
+		result1 []models.PluginKey
+	}{result1}
+}
+
+func (fake *FakeRepository) PluginInstallPolicy() string {
+	fake.pluginInstallPolicyMutex.Lock()
+	fake.pluginInstallPolicyArgsForCall = append(fake.pluginInstallPolicyArgsForCall, struct{}{})
+	fake.pluginInstallPolicyMutex.Unlock()
+	if fake.PluginInstallPolicyStub != nil {
+		return fake.PluginInstallPolicyStub()
+	} else {
+		return fake.pluginInstallPolicyReturns.result1
+	}
+}
+
+func (fake *FakeRepository) PluginInstallPolicyCallCount() int {
+	fake.pluginInstallPolicyMutex.RLock()
+	defer fake.pluginInstallPolicyMutex.RUnlock()
+	return len(fake.pluginInstallPolicyArgsForCall)
+}
+
+func (fake *FakeRepository) PluginInstallPolicyReturns(result1 string) {
+	fake.PluginInstallPolicyStub = nil
+	fake.pluginInstallPolicyReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) CurrentProfile() string {
+	fake.currentProfileMutex.Lock()
+	fake.currentProfileArgsForCall = append(fake.currentProfileArgsForCall, struct{}{})
+	fake.currentProfileMutex.Unlock()
+	if fake.CurrentProfileStub != nil {
+		return fake.CurrentProfileStub()
+	} else {
+		return fake.currentProfileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) CurrentProfileCallCount() int {
+	fake.currentProfileMutex.RLock()
+	defer fake.currentProfileMutex.RUnlock()
+	return len(fake.currentProfileArgsForCall)
+}
+
+func (fake *FakeRepository) CurrentProfileReturns(result1 string) {
+	fake.CurrentProfileStub = nil
+	fake.currentProfileReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) Profiles() []coreconfig.TargetProfile {
+	fake.profilesMutex.Lock()
+	fake.profilesArgsForCall = append(fake.profilesArgsForCall, struct{}{})
+	fake.profilesMutex.Unlock()
+	if fake.ProfilesStub != nil {
+		return fake.ProfilesStub()
+	} else {
+		return fake.profilesReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ProfilesCallCount() int {
+	fake.profilesMutex.RLock()
+	defer fake.profilesMutex.RUnlock()
+	return len(fake.profilesArgsForCall)
+}
+
+func (fake *FakeRepository) ProfilesReturns(result1 []coreconfig.TargetProfile) {
+	fake.ProfilesStub = nil
+	fake.profilesReturns = struct {
+		result1 []coreconfig.TargetProfile
+	}{result1}
+}
+
+func (fake *FakeRepository) CredentialStore() string {
+	fake.credentialStoreMutex.Lock()
+	fake.credentialStoreArgsForCall = append(fake.credentialStoreArgsForCall, struct{}{})
+	fake.credentialStoreMutex.Unlock()
+	if fake.CredentialStoreStub != nil {
+		return fake.CredentialStoreStub()
+	} else {
+		return fake.credentialStoreReturns.result1
+	}
+}
+
+func (fake *FakeRepository) CredentialStoreCallCount() int {
+	fake.credentialStoreMutex.RLock()
+	defer fake.credentialStoreMutex.RUnlock()
+	return len(fake.credentialStoreArgsForCall)
+}
+
+func (fake *FakeRepository) CredentialStoreReturns(result1 string) {
+	fake.CredentialStoreStub = nil
+	fake.credentialStoreReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeRepository) ClearSession() {
 	fake.clearSessionMutex.Lock()
 	fake.clearSessionArgsForCall = append(fake.clearSessionArgsForCall, struct{}{})
@@ -1373,6 +1740,53 @@ func (fake *FakeRepository) SetSSLDisabledArgsForCall(i int) bool {
 	return fake.setSSLDisabledArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetCACertFile(arg1 string) {
+	fake.setCACertFileMutex.Lock()
+	fake.setCACertFileArgsForCall = append(fake.setCACertFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setCACertFileMutex.Unlock()
+	if fake.SetCACertFileStub != nil {
+		fake.SetCACertFileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetCACertFileCallCount() int {
+	fake.setCACertFileMutex.RLock()
+	defer fake.setCACertFileMutex.RUnlock()
+	return len(fake.setCACertFileArgsForCall)
+}
+
+func (fake *FakeRepository) SetCACertFileArgsForCall(i int) string {
+	fake.setCACertFileMutex.RLock()
+	defer fake.setCACertFileMutex.RUnlock()
+	return fake.setCACertFileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetClientCertificate(certFile string, keyFile string) {
+	fake.setClientCertificateMutex.Lock()
+	fake.setClientCertificateArgsForCall = append(fake.setClientCertificateArgsForCall, struct {
+		certFile string
+		keyFile  string
+	}{certFile, keyFile})
+	fake.setClientCertificateMutex.Unlock()
+	if fake.SetClientCertificateStub != nil {
+		fake.SetClientCertificateStub(certFile, keyFile)
+	}
+}
+
+func (fake *FakeRepository) SetClientCertificateCallCount() int {
+	fake.setClientCertificateMutex.RLock()
+	defer fake.setClientCertificateMutex.RUnlock()
+	return len(fake.setClientCertificateArgsForCall)
+}
+
+func (fake *FakeRepository) SetClientCertificateArgsForCall(i int) (string, string) {
+	fake.setClientCertificateMutex.RLock()
+	defer fake.setClientCertificateMutex.RUnlock()
+	return fake.setClientCertificateArgsForCall[i].certFile, fake.setClientCertificateArgsForCall[i].keyFile
+}
+
 func (fake *FakeRepository) SetAsyncTimeout(arg1 uint) {
 	fake.setAsyncTimeoutMutex.Lock()
 	fake.setAsyncTimeoutArgsForCall = append(fake.setAsyncTimeoutArgsForCall, struct {
@@ -1396,6 +1810,52 @@ func (fake *FakeRepository) SetAsyncTimeoutArgsForCall(i int) uint {
 	return fake.setAsyncTimeoutArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetRequestRetries(arg1 uint) {
+	fake.setRequestRetriesMutex.Lock()
+	fake.setRequestRetriesArgsForCall = append(fake.setRequestRetriesArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.setRequestRetriesMutex.Unlock()
+	if fake.SetRequestRetriesStub != nil {
+		fake.SetRequestRetriesStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetRequestRetriesCallCount() int {
+	fake.setRequestRetriesMutex.RLock()
+	defer fake.setRequestRetriesMutex.RUnlock()
+	return len(fake.setRequestRetriesArgsForCall)
+}
+
+func (fake *FakeRepository) SetRequestRetriesArgsForCall(i int) uint {
+	fake.setRequestRetriesMutex.RLock()
+	defer fake.setRequestRetriesMutex.RUnlock()
+	return fake.setRequestRetriesArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetPluginGracePeriod(arg1 uint) {
+	fake.setPluginGracePeriodMutex.Lock()
+	fake.setPluginGracePeriodArgsForCall = append(fake.setPluginGracePeriodArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.setPluginGracePeriodMutex.Unlock()
+	if fake.SetPluginGracePeriodStub != nil {
+		fake.SetPluginGracePeriodStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetPluginGracePeriodCallCount() int {
+	fake.setPluginGracePeriodMutex.RLock()
+	defer fake.setPluginGracePeriodMutex.RUnlock()
+	return len(fake.setPluginGracePeriodArgsForCall)
+}
+
+func (fake *FakeRepository) SetPluginGracePeriodArgsForCall(i int) uint {
+	fake.setPluginGracePeriodMutex.RLock()
+	defer fake.setPluginGracePeriodMutex.RUnlock()
+	return fake.setPluginGracePeriodArgsForCall[i].arg1
+}
+
 func (fake *FakeRepository) SetTrace(arg1 string) {
 	fake.setTraceMutex.Lock()
 	fake.setTraceArgsForCall = append(fake.setTraceArgsForCall, struct {
@@ -1511,6 +1971,214 @@ func (fake *FakeRepository) UnSetPluginRepoArgsForCall(i int) int {
 	return fake.unSetPluginRepoArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetPluginKey(arg1 models.PluginKey) {
+	fake.setPluginKeyMutex.Lock()
+	fake.setPluginKeyArgsForCall = append(fake.setPluginKeyArgsForCall, struct {
+		arg1 models.PluginKey
+	}{arg1})
+	fake.setPluginKeyMutex.Unlock()
+	if fake.SetPluginKeyStub != nil {
+		fake.SetPluginKeyStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetPluginKeyCallCount() int {
+	fake.setPluginKeyMutex.RLock()
+	defer fake.setPluginKeyMutex.RUnlock()
+	return len(fake.setPluginKeyArgsForCall)
+}
+
+func (fake *FakeRepository) SetPluginKeyArgsForCall(i int) models.PluginKey {
+	fake.setPluginKeyMutex.RLock()
+	defer fake.setPluginKeyMutex.RUnlock()
+	return fake.setPluginKeyArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) UnSetPluginKey(arg1 int) {
+	fake.unSetPluginKeyMutex.Lock()
+	fake.unSetPluginKeyArgsForCall = append(fake.unSetPluginKeyArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.unSetPluginKeyMutex.Unlock()
+	if fake.UnSetPluginKeyStub != nil {
+		fake.UnSetPluginKeyStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) UnSetPluginKeyCallCount() int {
+	fake.unSetPluginKeyMutex.RLock()
+	defer fake.unSetPluginKeyMutex.RUnlock()
+	return len(fake.unSetPluginKeyArgsForCall)
+}
+
+func (fake *FakeRepository) UnSetPluginKeyArgsForCall(i int) int {
+	fake.unSetPluginKeyMutex.RLock()
+	defer fake.unSetPluginKeyMutex.RUnlock()
+	return fake.unSetPluginKeyArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetPluginInstallPolicy(arg1 string) {
+	fake.setPluginInstallPolicyMutex.Lock()
+	fake.setPluginInstallPolicyArgsForCall = append(fake.setPluginInstallPolicyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setPluginInstallPolicyMutex.Unlock()
+	if fake.SetPluginInstallPolicyStub != nil {
+		fake.SetPluginInstallPolicyStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetPluginInstallPolicyCallCount() int {
+	fake.setPluginInstallPolicyMutex.RLock()
+	defer fake.setPluginInstallPolicyMutex.RUnlock()
+	return len(fake.setPluginInstallPolicyArgsForCall)
+}
+
+func (fake *FakeRepository) SetPluginInstallPolicyArgsForCall(i int) string {
+	fake.setPluginInstallPolicyMutex.RLock()
+	defer fake.setPluginInstallPolicyMutex.RUnlock()
+	return fake.setPluginInstallPolicyArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetRefreshedTokens(arg1 string, arg2 string) {
+	fake.setRefreshedTokensMutex.Lock()
+	fake.setRefreshedTokensArgsForCall = append(fake.setRefreshedTokensArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.setRefreshedTokensMutex.Unlock()
+	if fake.SetRefreshedTokensStub != nil {
+		fake.SetRefreshedTokensStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeRepository) SetRefreshedTokensCallCount() int {
+	fake.setRefreshedTokensMutex.RLock()
+	defer fake.setRefreshedTokensMutex.RUnlock()
+	return len(fake.setRefreshedTokensArgsForCall)
+}
+
+func (fake *FakeRepository) SetRefreshedTokensArgsForCall(i int) (string, string) {
+	fake.setRefreshedTokensMutex.RLock()
+	defer fake.setRefreshedTokensMutex.RUnlock()
+	return fake.setRefreshedTokensArgsForCall[i].arg1, fake.setRefreshedTokensArgsForCall[i].arg2
+}
+
+func (fake *FakeRepository) SaveProfile(arg1 string) {
+	fake.saveProfileMutex.Lock()
+	fake.saveProfileArgsForCall = append(fake.saveProfileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.saveProfileMutex.Unlock()
+	if fake.SaveProfileStub != nil {
+		fake.SaveProfileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SaveProfileCallCount() int {
+	fake.saveProfileMutex.RLock()
+	defer fake.saveProfileMutex.RUnlock()
+	return len(fake.saveProfileArgsForCall)
+}
+
+func (fake *FakeRepository) SaveProfileArgsForCall(i int) string {
+	fake.saveProfileMutex.RLock()
+	defer fake.saveProfileMutex.RUnlock()
+	return fake.saveProfileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) UseProfile(arg1 string) {
+	fake.useProfileMutex.Lock()
+	fake.useProfileArgsForCall = append(fake.useProfileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.useProfileMutex.Unlock()
+	if fake.UseProfileStub != nil {
+		fake.UseProfileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) UseProfileCallCount() int {
+	fake.useProfileMutex.RLock()
+	defer fake.useProfileMutex.RUnlock()
+	return len(fake.useProfileArgsForCall)
+}
+
+func (fake *FakeRepository) UseProfileArgsForCall(i int) string {
+	fake.useProfileMutex.RLock()
+	defer fake.useProfileMutex.RUnlock()
+	return fake.useProfileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) DeleteProfile(arg1 string) {
+	fake.deleteProfileMutex.Lock()
+	fake.deleteProfileArgsForCall = append(fake.deleteProfileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.deleteProfileMutex.Unlock()
+	if fake.DeleteProfileStub != nil {
+		fake.DeleteProfileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) DeleteProfileCallCount() int {
+	fake.deleteProfileMutex.RLock()
+	defer fake.deleteProfileMutex.RUnlock()
+	return len(fake.deleteProfileArgsForCall)
+}
+
+func (fake *FakeRepository) DeleteProfileArgsForCall(i int) string {
+	fake.deleteProfileMutex.RLock()
+	defer fake.deleteProfileMutex.RUnlock()
+	return fake.deleteProfileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetSessionProfile(arg1 string) {
+	fake.setSessionProfileMutex.Lock()
+	fake.setSessionProfileArgsForCall = append(fake.setSessionProfileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setSessionProfileMutex.Unlock()
+	if fake.SetSessionProfileStub != nil {
+		fake.SetSessionProfileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetSessionProfileCallCount() int {
+	fake.setSessionProfileMutex.RLock()
+	defer fake.setSessionProfileMutex.RUnlock()
+	return len(fake.setSessionProfileArgsForCall)
+}
+
+func (fake *FakeRepository) SetSessionProfileArgsForCall(i int) string {
+	fake.setSessionProfileMutex.RLock()
+	defer fake.setSessionProfileMutex.RUnlock()
+	return fake.setSessionProfileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetCredentialStore(arg1 string) {
+	fake.setCredentialStoreMutex.Lock()
+	fake.setCredentialStoreArgsForCall = append(fake.setCredentialStoreArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setCredentialStoreMutex.Unlock()
+	if fake.SetCredentialStoreStub != nil {
+		fake.SetCredentialStoreStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetCredentialStoreCallCount() int {
+	fake.setCredentialStoreMutex.RLock()
+	defer fake.setCredentialStoreMutex.RUnlock()
+	return len(fake.setCredentialStoreArgsForCall)
+}
+
+func (fake *FakeRepository) SetCredentialStoreArgsForCall(i int) string {
+	fake.setCredentialStoreMutex.RLock()
+	defer fake.setCredentialStoreMutex.RUnlock()
+	return fake.setCredentialStoreArgsForCall[i].arg1
+}
+
 func (fake *FakeRepository) Close() {
 	fake.closeMutex.Lock()
 	fake.closeArgsForCall = append(fake.closeArgsForCall, struct{}{})
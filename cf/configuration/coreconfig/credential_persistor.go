@@ -0,0 +1,291 @@
+package coreconfig
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/configuration"
+	"github.com/cloudfoundry/cli/cf/configuration/credentials"
+)
+
+type CredentialStoreFactory func(name string) (credentials.Store, error)
+
+// credentialPersistor keeps the access and refresh tokens of the config in
+// the credential store it names, rather than in the config file.
+type credentialPersistor struct {
+	configuration.Persistor
+	key      string
+	newStore CredentialStoreFactory
+	last     *lastStoredTokens
+}
+
+// storedTokens are the tokens as a credential store was found to keep them.
+type storedTokens struct {
+	store  string
+	secret string
+}
+
+// lastStoredTokens remembers the tokens as this process last read or wrote
+// them, so that saving a change that leaves the tokens alone does not have
+// to go to the credential store, which may run a helper or derive a key.
+type lastStoredTokens struct {
+	tokens storedTokens
+	known  bool
+}
+
+type sessionTokens struct {
+	AccessToken  string `json:",omitempty"`
+	RefreshToken string `json:",omitempty"`
+}
+
+type configTokens struct {
+	sessionTokens
+	Profiles map[string]sessionTokens `json:",omitempty"`
+}
+
+// NewCredentialPersistor saves the config with persistor, keeping its
+// tokens under key in the credential store the config names.
+func NewCredentialPersistor(persistor configuration.Persistor, key string, newStore CredentialStoreFactory) configuration.Persistor {
+	return credentialPersistor{
+		Persistor: persistor,
+		key:       key,
+		newStore:  newStore,
+		last:      &lastStoredTokens{},
+	}
+}
+
+func newCredentialPersistorFromFilepath(path string) configuration.Persistor {
+	dir := filepath.Dir(path)
+	return NewCredentialPersistor(configuration.NewDiskPersistor(path), path, func(name string) (credentials.Store, error) {
+		return credentials.NewStore(name, dir)
+	})
+}
+
+func (cp credentialPersistor) Load(data configuration.DataInterface) error {
+	err := cp.Persistor.Load(data)
+	if err != nil {
+		return err
+	}
+
+	d, ok := data.(*Data)
+	if !ok {
+		return nil
+	}
+
+	stored, err := cp.open(d)
+	cp.remember(stored, err)
+	return err
+}
+
+func (cp credentialPersistor) Save(data configuration.DataInterface) error {
+	d, ok := data.(*Data)
+	if !ok {
+		return cp.Persistor.Save(data)
+	}
+
+	sealed := *d
+	sealed.Profiles = append([]TargetProfile(nil), d.Profiles...)
+
+	stored, ok := cp.lastStored(d.CredentialStore)
+	if !ok {
+		stored = storedTokens{store: d.CredentialStore}
+	}
+
+	stored, err := cp.seal(&sealed, stored)
+	cp.remember(stored, err)
+	if err != nil {
+		return err
+	}
+
+	return cp.Persistor.Save(&sealed)
+}
+
+func (cp credentialPersistor) Update(data configuration.DataInterface, change func()) error {
+	d, ok := data.(*Data)
+	if !ok {
+		return cp.Persistor.Update(data, change)
+	}
+
+	var err error
+	updateErr := cp.Persistor.Update(data, func() {
+		// The config has just been read back from disk without its
+		// tokens. Unless this process does not know them yet, put back the
+		// ones it knows rather than reading them from the store again.
+		stored, ok := cp.lastStored(d.CredentialStore)
+		if ok {
+			err = fillTokens(d, stored.secret)
+		} else {
+			stored, err = cp.open(d)
+		}
+		if err != nil {
+			cp.remember(stored, err)
+			return
+		}
+
+		change()
+		stored, err = cp.seal(d, stored)
+		cp.remember(stored, err)
+	})
+	if updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+// open fills in the tokens kept in the credential store.
+func (cp credentialPersistor) open(d *Data) (storedTokens, error) {
+	stored := storedTokens{store: d.CredentialStore}
+	if stored.store == "" {
+		return stored, nil
+	}
+
+	store, err := cp.newStore(stored.store)
+	if err != nil {
+		return stored, err
+	}
+
+	stored.secret, err = store.Get(cp.key)
+	if err != nil {
+		return stored, err
+	}
+
+	return stored, fillTokens(d, stored.secret)
+}
+
+// fillTokens sets the tokens of the config and its profiles to the ones
+// encoded in secret.
+func fillTokens(d *Data, secret string) error {
+	if secret == "" {
+		return nil
+	}
+
+	var tokens configTokens
+	err := json.Unmarshal([]byte(secret), &tokens)
+	if err != nil {
+		return err
+	}
+
+	d.AccessToken = tokens.AccessToken
+	d.RefreshToken = tokens.RefreshToken
+	for i := range d.Profiles {
+		profileTokens := tokens.Profiles[d.Profiles[i].Name]
+		d.Profiles[i].AccessToken = profileTokens.AccessToken
+		d.Profiles[i].RefreshToken = profileTokens.RefreshToken
+	}
+
+	return nil
+}
+
+// lastStored returns the tokens this process last read from or wrote to the
+// named store, if it knows them.
+func (cp credentialPersistor) lastStored(store string) (storedTokens, bool) {
+	if !cp.last.known || cp.last.tokens.store != store || store == "" {
+		return storedTokens{}, false
+	}
+	return cp.last.tokens, true
+}
+
+func (cp credentialPersistor) remember(stored storedTokens, err error) {
+	cp.last.tokens = stored
+	cp.last.known = err == nil
+}
+
+// seal moves the tokens into the credential store, so that they are not
+// saved in the config file, and erases them from the store they were kept
+// in before. It returns the tokens as the store now keeps them. The store
+// is left alone when the tokens have not changed.
+func (cp credentialPersistor) seal(d *Data, stored storedTokens) (storedTokens, error) {
+	if d.CredentialStore == "" {
+		return storedTokens{}, cp.erase(stored)
+	}
+
+	if d.CredentialStore == stored.store {
+		secret, err := takeTokens(d)
+		if err != nil || secret == stored.secret {
+			return stored, err
+		}
+
+		store, err := cp.newStore(d.CredentialStore)
+		if err != nil {
+			return stored, err
+		}
+
+		sealed := storedTokens{store: d.CredentialStore, secret: secret}
+		if secret == "" {
+			return sealed, store.Erase(cp.key)
+		}
+		return sealed, store.Store(cp.key, secret)
+	}
+
+	store, err := cp.newStore(d.CredentialStore)
+	if err != nil {
+		// Rather than lose the tokens, keep them where they were.
+		d.CredentialStore = stored.store
+		sealed, sealErr := cp.seal(d, stored)
+		if sealErr != nil {
+			return sealed, sealErr
+		}
+		return sealed, err
+	}
+
+	secret, err := takeTokens(d)
+	if err != nil {
+		return stored, err
+	}
+
+	if secret != "" {
+		err = store.Store(cp.key, secret)
+		if err != nil {
+			return stored, err
+		}
+	}
+	return storedTokens{store: d.CredentialStore, secret: secret}, cp.erase(stored)
+}
+
+func (cp credentialPersistor) erase(stored storedTokens) error {
+	if stored.store == "" || stored.secret == "" {
+		return nil
+	}
+
+	store, err := cp.newStore(stored.store)
+	if err != nil {
+		return err
+	}
+	return store.Erase(cp.key)
+}
+
+// takeTokens clears the tokens of the config and its profiles, and returns
+// them encoded, or "" if there are none.
+func takeTokens(d *Data) (string, error) {
+	tokens := configTokens{
+		sessionTokens: sessionTokens{
+			AccessToken:  d.AccessToken,
+			RefreshToken: d.RefreshToken,
+		},
+	}
+	d.AccessToken = ""
+	d.RefreshToken = ""
+
+	for i, profile := range d.Profiles {
+		if profile.AccessToken == "" && profile.RefreshToken == "" {
+			continue
+		}
+
+		if tokens.Profiles == nil {
+			tokens.Profiles = map[string]sessionTokens{}
+		}
+		tokens.Profiles[profile.Name] = sessionTokens{
+			AccessToken:  profile.AccessToken,
+			RefreshToken: profile.RefreshToken,
+		}
+		d.Profiles[i].AccessToken = ""
+		d.Profiles[i].RefreshToken = ""
+	}
+
+	if tokens.AccessToken == "" && tokens.RefreshToken == "" && tokens.Profiles == nil {
+		return "", nil
+	}
+
+	secret, err := json.Marshal(tokens)
+	return string(secret), err
+}
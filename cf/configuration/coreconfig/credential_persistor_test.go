@@ -0,0 +1,182 @@
+package coreconfig_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/configuration"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/configuration/credentials"
+	"github.com/cloudfoundry/cli/cf/configuration/credentials/credentialsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("credential persistor", func() {
+	var (
+		dir        string
+		configPath string
+		secrets    map[string]string
+		store      *credentialsfakes.FakeStore
+		storeErr   error
+		storeOpens int
+		errs       []error
+	)
+
+	newConfig := func() coreconfig.Repository {
+		persistor := coreconfig.NewCredentialPersistor(configuration.NewDiskPersistor(configPath), "my-key", func(name string) (credentials.Store, error) {
+			if name != "my-keyring" {
+				return nil, errors.New("no such store")
+			}
+			storeOpens++
+			return store, storeErr
+		})
+		return coreconfig.NewRepositoryFromPersistor(persistor, func(err error) {
+			errs = append(errs, err)
+		})
+	}
+
+	configFile := func() string {
+		contents, err := ioutil.ReadFile(configPath)
+		Expect(err).NotTo(HaveOccurred())
+		return string(contents)
+	}
+
+	storedTokens := func() map[string]interface{} {
+		tokens := map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(secrets["my-key"]), &tokens)).To(Succeed())
+		return tokens
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "credential-persistor")
+		Expect(err).NotTo(HaveOccurred())
+		configPath = filepath.Join(dir, ".cf", "config.json")
+
+		secrets = map[string]string{}
+		store = new(credentialsfakes.FakeStore)
+		store.GetStub = func(key string) (string, error) {
+			return secrets[key], nil
+		}
+		store.StoreStub = func(key string, secret string) error {
+			secrets[key] = secret
+			return nil
+		}
+		store.EraseStub = func(key string) error {
+			delete(secrets, key)
+			return nil
+		}
+		storeErr = nil
+		storeOpens = 0
+		errs = nil
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("saves the tokens in the config file when no credential store is set", func() {
+		newConfig().SetAccessToken("my-access-token")
+
+		Expect(configFile()).To(ContainSubstring("my-access-token"))
+		Expect(store.StoreCallCount()).To(Equal(0))
+	})
+
+	Context("when a credential store is set", func() {
+		BeforeEach(func() {
+			config := newConfig()
+			config.SetAccessToken("my-access-token")
+			config.SetRefreshToken("my-refresh-token")
+			config.SetAPIEndpoint("https://api.example.com")
+			config.SaveProfile("prod")
+			config.SetCredentialStore("my-keyring")
+		})
+
+		It("keeps the tokens out of the config file and its backup", func() {
+			Expect(configFile()).NotTo(ContainSubstring("my-access-token"))
+			Expect(configFile()).NotTo(ContainSubstring("my-refresh-token"))
+			Expect(configFile()).To(ContainSubstring(`"CredentialStore": "my-keyring"`))
+
+			backup, err := ioutil.ReadFile(configPath + ".bak")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(backup)).NotTo(ContainSubstring("my-access-token"))
+		})
+
+		It("keeps the tokens of the config and its profiles in the store", func() {
+			Expect(storedTokens()).To(Equal(map[string]interface{}{
+				"AccessToken":  "my-access-token",
+				"RefreshToken": "my-refresh-token",
+				"Profiles": map[string]interface{}{
+					"prod": map[string]interface{}{
+						"AccessToken":  "my-access-token",
+						"RefreshToken": "my-refresh-token",
+					},
+				},
+			}))
+		})
+
+		It("reads the tokens back from the store", func() {
+			config := newConfig()
+			Expect(config.AccessToken()).To(Equal("my-access-token"))
+			Expect(config.RefreshToken()).To(Equal("my-refresh-token"))
+			Expect(config.Profiles()[0].AccessToken).To(Equal("my-access-token"))
+			Expect(errs).To(BeEmpty())
+		})
+
+		It("only updates the store when the tokens change", func() {
+			config := newConfig()
+			Expect(config.AccessToken()).To(Equal("my-access-token"))
+			opens := storeOpens
+			getCalls := store.GetCallCount()
+			storeCalls := store.StoreCallCount()
+
+			config.SetAsyncTimeout(5)
+			Expect(storeOpens).To(Equal(opens))
+			Expect(store.GetCallCount()).To(Equal(getCalls))
+			Expect(store.StoreCallCount()).To(Equal(storeCalls))
+
+			config.SetAccessToken("new-access-token")
+			Expect(store.StoreCallCount()).To(Equal(storeCalls + 1))
+			Expect(storedTokens()["AccessToken"]).To(Equal("new-access-token"))
+		})
+
+		It("erases the tokens from the store once there are none", func() {
+			config := newConfig()
+			config.DeleteProfile("prod")
+			config.ClearSession()
+
+			Expect(secrets).NotTo(HaveKey("my-key"))
+		})
+
+		It("moves the tokens back into the config file", func() {
+			newConfig().SetCredentialStore("")
+
+			Expect(configFile()).To(ContainSubstring("my-access-token"))
+			Expect(secrets).NotTo(HaveKey("my-key"))
+			Expect(newConfig().AccessToken()).To(Equal("my-access-token"))
+		})
+
+		It("reports a store that fails", func() {
+			storeErr = errors.New("keyring is locked")
+
+			config := newConfig()
+			Expect(config.AccessToken()).To(BeEmpty())
+			Expect(errs).To(ConsistOf(MatchError("keyring is locked")))
+		})
+	})
+
+	It("keeps the tokens where they were when the store cannot be used", func() {
+		config := newConfig()
+		config.SetAccessToken("my-access-token")
+		config.SetCredentialStore("missing-keyring")
+
+		Expect(errs).NotTo(BeEmpty())
+		Expect(configFile()).To(ContainSubstring("my-access-token"))
+		Expect(configFile()).NotTo(ContainSubstring("missing-keyring"))
+	})
+})
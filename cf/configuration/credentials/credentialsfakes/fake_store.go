@@ -0,0 +1,137 @@
+// This file was generated by counterfeiter
+package credentialsfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/cli/cf/configuration/credentials"
+)
+
+type FakeStore struct {
+	GetStub        func(key string) (string, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		key string
+	}
+	getReturns struct {
+		result1 string
+		result2 error
+	}
+	StoreStub        func(key string, secret string) error
+	storeMutex       sync.RWMutex
+	storeArgsForCall []struct {
+		key    string
+		secret string
+	}
+	storeReturns struct {
+		result1 error
+	}
+	EraseStub        func(key string) error
+	eraseMutex       sync.RWMutex
+	eraseArgsForCall []struct {
+		key string
+	}
+	eraseReturns struct {
+		result1 error
+	}
+}
+
+func (fake *FakeStore) Get(key string) (string, error) {
+	fake.getMutex.Lock()
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		key string
+	}{key})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(key)
+	} else {
+		return fake.getReturns.result1, fake.getReturns.result2
+	}
+}
+
+func (fake *FakeStore) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeStore) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.getArgsForCall[i].key
+}
+
+func (fake *FakeStore) GetReturns(result1 string, result2 error) {
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) Store(key string, secret string) error {
+	fake.storeMutex.Lock()
+	fake.storeArgsForCall = append(fake.storeArgsForCall, struct {
+		key    string
+		secret string
+	}{key, secret})
+	fake.storeMutex.Unlock()
+	if fake.StoreStub != nil {
+		return fake.StoreStub(key, secret)
+	} else {
+		return fake.storeReturns.result1
+	}
+}
+
+func (fake *FakeStore) StoreCallCount() int {
+	fake.storeMutex.RLock()
+	defer fake.storeMutex.RUnlock()
+	return len(fake.storeArgsForCall)
+}
+
+func (fake *FakeStore) StoreArgsForCall(i int) (string, string) {
+	fake.storeMutex.RLock()
+	defer fake.storeMutex.RUnlock()
+	return fake.storeArgsForCall[i].key, fake.storeArgsForCall[i].secret
+}
+
+func (fake *FakeStore) StoreReturns(result1 error) {
+	fake.StoreStub = nil
+	fake.storeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) Erase(key string) error {
+	fake.eraseMutex.Lock()
+	fake.eraseArgsForCall = append(fake.eraseArgsForCall, struct {
+		key string
+	}{key})
+	fake.eraseMutex.Unlock()
+	if fake.EraseStub != nil {
+		return fake.EraseStub(key)
+	} else {
+		return fake.eraseReturns.result1
+	}
+}
+
+func (fake *FakeStore) EraseCallCount() int {
+	fake.eraseMutex.RLock()
+	defer fake.eraseMutex.RUnlock()
+	return len(fake.eraseArgsForCall)
+}
+
+func (fake *FakeStore) EraseArgsForCall(i int) string {
+	fake.eraseMutex.RLock()
+	defer fake.eraseMutex.RUnlock()
+	return fake.eraseArgsForCall[i].key
+}
+
+func (fake *FakeStore) EraseReturns(result1 error) {
+	fake.EraseStub = nil
+	fake.eraseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+var _ credentials.Store = new(FakeStore)
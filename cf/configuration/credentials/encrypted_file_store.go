@@ -0,0 +1,204 @@
+package credentials
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	keyIterations = 100000
+	keyLength     = 32
+	saltLength    = 16
+)
+
+// EncryptedFileStore keeps secrets in a file encrypted with AES-GCM, under
+// a key derived from a passphrase. It does not lock the file; callers
+// sharing it take turns, as cf does through the lock on its config file.
+type EncryptedFileStore struct {
+	path       string
+	passphrase string
+
+	salt []byte
+	key  []byte
+}
+
+type encryptedFile struct {
+	Salt       []byte
+	Nonce      []byte
+	Ciphertext []byte
+}
+
+func NewEncryptedFileStore(path string, passphrase string) *EncryptedFileStore {
+	return &EncryptedFileStore{
+		path:       path,
+		passphrase: passphrase,
+	}
+}
+
+func (s *EncryptedFileStore) Get(key string) (string, error) {
+	secrets, _, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	return secrets[key], nil
+}
+
+func (s *EncryptedFileStore) Store(key string, secret string) error {
+	secrets, salt, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	secrets[key] = secret
+	return s.write(secrets, salt)
+}
+
+func (s *EncryptedFileStore) Erase(key string) error {
+	secrets, salt, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := secrets[key]; !ok {
+		return nil
+	}
+
+	delete(secrets, key)
+	if len(secrets) == 0 {
+		return os.Remove(s.path)
+	}
+	return s.write(secrets, salt)
+}
+
+func (s *EncryptedFileStore) read() (map[string]string, []byte, error) {
+	secrets := map[string]string{}
+
+	contents, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return secrets, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var file encryptedFile
+	err = json.Unmarshal(contents, &file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s could not be read: %s", s.path, err.Error())
+	}
+
+	gcm, err := s.cipher(file.Salt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s could not be decrypted; check that %s is the passphrase it was saved with", s.path, PassphraseEnvVar)
+	}
+
+	err = json.Unmarshal(plaintext, &secrets)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s could not be read: %s", s.path, err.Error())
+	}
+
+	return secrets, file.Salt, nil
+}
+
+func (s *EncryptedFileStore) write(secrets map[string]string, salt []byte) error {
+	if salt == nil {
+		salt = make([]byte, saltLength)
+		_, err := io.ReadFull(rand.Reader, salt)
+		if err != nil {
+			return err
+		}
+	}
+
+	gcm, err := s.cipher(salt)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return err
+	}
+
+	contents, err := json.Marshal(encryptedFile{
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(s.path, contents)
+}
+
+func (s *EncryptedFileStore) cipher(salt []byte) (cipher.AEAD, error) {
+	// Deriving the key is slow on purpose, so it is done once per salt.
+	if s.key == nil || !bytes.Equal(s.salt, salt) {
+		s.key = deriveKey([]byte(s.passphrase), salt)
+		s.salt = salt
+	}
+
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// deriveKey derives a key from a passphrase with PBKDF2 and HMAC-SHA256.
+func deriveKey(passphrase []byte, salt []byte) []byte {
+	return pbkdf2.Key(passphrase, salt, keyIterations, keyLength, sha256.New)
+}
+
+func writeFileAtomically(path string, contents []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(contents)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tmpFile.Name(), 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
@@ -0,0 +1,84 @@
+package credentials_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/configuration/credentials"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EncryptedFileStore", func() {
+	var (
+		dir   string
+		path  string
+		store *credentials.EncryptedFileStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "encrypted-file-store")
+		Expect(err).NotTo(HaveOccurred())
+
+		path = filepath.Join(dir, ".cf", "credentials")
+		store = credentials.NewEncryptedFileStore(path, "my-passphrase")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("has no secrets before any are stored", func() {
+		secret, err := store.Get("my-key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secret).To(BeEmpty())
+	})
+
+	It("keeps secrets by key, encrypted", func() {
+		Expect(store.Store("my-key", "my-secret")).To(Succeed())
+		Expect(store.Store("other-key", "other-secret")).To(Succeed())
+
+		reopened := credentials.NewEncryptedFileStore(path, "my-passphrase")
+		Expect(reopened.Get("my-key")).To(Equal("my-secret"))
+		Expect(reopened.Get("other-key")).To(Equal("other-secret"))
+
+		contents, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).NotTo(ContainSubstring("my-secret"))
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		if os.PathSeparator == '/' {
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		}
+	})
+
+	It("fails to read the secrets with another passphrase", func() {
+		Expect(store.Store("my-key", "my-secret")).To(Succeed())
+
+		_, err := credentials.NewEncryptedFileStore(path, "wrong-passphrase").Get("my-key")
+		Expect(err).To(MatchError(ContainSubstring("could not be decrypted")))
+
+		err = credentials.NewEncryptedFileStore(path, "wrong-passphrase").Store("my-key", "other-secret")
+		Expect(err).To(HaveOccurred())
+		Expect(store.Get("my-key")).To(Equal("my-secret"))
+	})
+
+	It("erases secrets, and the file with the last one", func() {
+		Expect(store.Store("my-key", "my-secret")).To(Succeed())
+		Expect(store.Store("other-key", "other-secret")).To(Succeed())
+
+		Expect(store.Erase("my-key")).To(Succeed())
+		Expect(store.Get("my-key")).To(BeEmpty())
+		Expect(store.Get("other-key")).To(Equal("other-secret"))
+
+		Expect(store.Erase("other-key")).To(Succeed())
+		_, err := os.Stat(path)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+
+		Expect(store.Erase("other-key")).To(Succeed())
+	})
+})
@@ -0,0 +1,86 @@
+package credentials
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// HelperStore keeps secrets with a credential helper, an executable that
+// can put them in an OS keyring or a secrets manager. Like git and docker
+// credential helpers, it is run with the action as its argument, and
+// exchanges JSON on its standard input and output:
+//
+//	get     reads {"Key": KEY} and writes {"Secret": SECRET}, with an empty
+//	        secret if it has none under the key
+//	store   reads {"Key": KEY, "Secret": SECRET}
+//	erase   reads {"Key": KEY}
+//
+// It exits with a non-zero status, and a message on its standard error,
+// when the action fails.
+type HelperStore struct {
+	path string
+}
+
+type helperMessage struct {
+	Key    string `json:",omitempty"`
+	Secret string `json:",omitempty"`
+}
+
+func NewHelperStore(path string) HelperStore {
+	return HelperStore{path: path}
+}
+
+func (h HelperStore) Get(key string) (string, error) {
+	output, err := h.run("get", helperMessage{Key: key})
+	if err != nil {
+		return "", err
+	}
+
+	if len(bytes.TrimSpace(output)) == 0 {
+		return "", nil
+	}
+
+	var response helperMessage
+	err = json.Unmarshal(output, &response)
+	if err != nil {
+		return "", fmt.Errorf("credential helper %s gave an invalid response: %s", h.path, err.Error())
+	}
+	return response.Secret, nil
+}
+
+func (h HelperStore) Store(key string, secret string) error {
+	_, err := h.run("store", helperMessage{Key: key, Secret: secret})
+	return err
+}
+
+func (h HelperStore) Erase(key string) error {
+	_, err := h.run("erase", helperMessage{Key: key})
+	return err
+}
+
+func (h HelperStore) run(action string, request helperMessage) ([]byte, error) {
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(h.path, action)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, fmt.Errorf("credential helper %s failed to %s: %s", h.path, action, message)
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,112 @@
+package credentials_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/cloudfoundry/cli/cf/configuration/credentials"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// helperScript keeps the last request for each action in a file, and
+// answers get with the secret in the get-response file.
+const helperScript = `#!/bin/sh
+dir=$(dirname "$0")
+cat > "$dir/$1-request"
+case "$1" in
+  get) cat "$dir/get-response" 2>/dev/null || true ;;
+esac
+`
+
+var _ = Describe("HelperStore", func() {
+	var (
+		dir   string
+		store credentials.HelperStore
+	)
+
+	BeforeEach(func() {
+		if runtime.GOOS == "windows" {
+			Skip("the test helper is a shell script")
+		}
+
+		var err error
+		dir, err = ioutil.TempDir("", "helper-store")
+		Expect(err).NotTo(HaveOccurred())
+
+		helperPath := filepath.Join(dir, "cf-credential-test")
+		Expect(ioutil.WriteFile(helperPath, []byte(helperScript), 0700)).To(Succeed())
+		store = credentials.NewHelperStore(helperPath)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	request := func(action string) string {
+		contents, err := ioutil.ReadFile(filepath.Join(dir, action+"-request"))
+		Expect(err).NotTo(HaveOccurred())
+		return string(contents)
+	}
+
+	It("gets the secret the helper has under the key", func() {
+		Expect(ioutil.WriteFile(filepath.Join(dir, "get-response"), []byte(`{"Secret":"my-secret"}`), 0600)).To(Succeed())
+
+		Expect(store.Get("my-key")).To(Equal("my-secret"))
+		Expect(request("get")).To(MatchJSON(`{"Key":"my-key"}`))
+	})
+
+	It("gets no secret when the helper has none", func() {
+		Expect(store.Get("my-key")).To(BeEmpty())
+	})
+
+	It("passes secrets to store to the helper", func() {
+		Expect(store.Store("my-key", "my-secret")).To(Succeed())
+		Expect(request("store")).To(MatchJSON(`{"Key":"my-key","Secret":"my-secret"}`))
+	})
+
+	It("asks the helper to erase secrets", func() {
+		Expect(store.Erase("my-key")).To(Succeed())
+		Expect(request("erase")).To(MatchJSON(`{"Key":"my-key"}`))
+	})
+
+	It("fails with the message of a helper that fails", func() {
+		Expect(ioutil.WriteFile(filepath.Join(dir, "get-response"), []byte(`not json`), 0600)).To(Succeed())
+		_, err := store.Get("my-key")
+		Expect(err).To(MatchError(ContainSubstring("invalid response")))
+
+		store = credentials.NewHelperStore("/bin/false")
+		Expect(store.Store("my-key", "my-secret")).To(MatchError(ContainSubstring("failed to store")))
+	})
+})
+
+var _ = Describe("NewStore", func() {
+	var originalPassphrase string
+
+	BeforeEach(func() {
+		originalPassphrase = os.Getenv(credentials.PassphraseEnvVar)
+	})
+
+	AfterEach(func() {
+		os.Setenv(credentials.PassphraseEnvVar, originalPassphrase)
+	})
+
+	It("needs a passphrase for the encrypted store", func() {
+		os.Setenv(credentials.PassphraseEnvVar, "")
+		_, err := credentials.NewStore("encrypted", "some-dir")
+		Expect(err).To(MatchError(ContainSubstring("CF_CREDENTIALS_PASSPHRASE must be set")))
+
+		os.Setenv(credentials.PassphraseEnvVar, "my-passphrase")
+		store, err := credentials.NewStore("encrypted", "some-dir")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(BeAssignableToTypeOf(&credentials.EncryptedFileStore{}))
+	})
+
+	It("fails for a helper that cannot be found", func() {
+		_, err := credentials.NewStore("no-such-keyring", "some-dir")
+		Expect(err).To(MatchError(ContainSubstring("credential helper no-such-keyring could not be found")))
+	})
+})
@@ -0,0 +1,55 @@
+package credentials
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// EncryptedStoreName names the store that keeps secrets in a file
+	// encrypted with the passphrase in PassphraseEnvVar.
+	EncryptedStoreName = "encrypted"
+
+	PassphraseEnvVar = "CF_CREDENTIALS_PASSPHRASE"
+
+	helperPrefix = "cf-credential-"
+)
+
+//go:generate counterfeiter . Store
+
+// Store keeps secrets, such as access and refresh tokens, by key outside
+// the config file.
+type Store interface {
+	// Get returns the secret kept under key, or "" when there is none.
+	Get(key string) (string, error)
+	Store(key string, secret string) error
+	Erase(key string) error
+}
+
+// NewStore returns the store called name, which is either the encrypted
+// store, kept in a file in dir, or a credential helper. A helper is run as
+// cf-credential-NAME from the PATH, or as the executable name when it is a
+// path.
+func NewStore(name string, dir string) (Store, error) {
+	if name == EncryptedStoreName {
+		passphrase := os.Getenv(PassphraseEnvVar)
+		if passphrase == "" {
+			return nil, errors.New(PassphraseEnvVar + " must be set to use the encrypted credential store")
+		}
+		return NewEncryptedFileStore(filepath.Join(dir, "credentials"), passphrase), nil
+	}
+
+	path := name
+	if !strings.ContainsRune(name, filepath.Separator) && !strings.ContainsRune(name, '/') {
+		path = helperPrefix + name
+	}
+
+	path, err := exec.LookPath(path)
+	if err != nil {
+		return nil, errors.New("credential helper " + name + " could not be found: " + err.Error())
+	}
+	return NewHelperStore(path), nil
+}
@@ -57,6 +57,10 @@ var _ = Describe("PluginConfig", func() {
 			}
 		})
 
+		AfterEach(func() {
+			os.Remove(filepath.Join(confighelpers.PluginRepoDir(), ".cf", "plugins", "config.json.lock"))
+		})
+
 		It("returns a list of plugin executables and their location", func() {
 			pluginConfig := NewPluginConfig(func(err error) {
 				if err != nil {
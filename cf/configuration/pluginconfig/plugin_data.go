@@ -14,6 +14,12 @@ type PluginMetadata struct {
 	Location string
 	Version  plugin.VersionType
 	Commands []plugin.Command
+	Hooks    []plugin.Hook `json:",omitempty"`
+
+	// Previous is the plugin as it was before its last update by
+	// update-plugin. Its Location is the old binary, which is kept so the
+	// update can be rolled back.
+	Previous *PluginMetadata `json:",omitempty"`
 }
 
 func NewData() *PluginData {
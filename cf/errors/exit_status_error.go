@@ -0,0 +1,19 @@
+package errors
+
+// ExitStatusError fails a command like any other error, but makes cf exit
+// with Status rather than 1.
+type ExitStatusError struct {
+	Message string
+	Status  int
+}
+
+func NewExitStatusError(message string, status int) *ExitStatusError {
+	return &ExitStatusError{
+		Message: message,
+		Status:  status,
+	}
+}
+
+func (err *ExitStatusError) Error() string {
+	return err.Message
+}
@@ -105,6 +105,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("logout"),
 					presentCommand("passwd"),
 					presentCommand("target"),
+					presentCommand("target-profile"),
 				}, {
 					presentCommand("api"),
 					presentCommand("auth"),
@@ -149,6 +150,9 @@ func newAppPresenter() appPresenter {
 					presentCommand("disable-ssh"),
 					presentCommand("ssh-enabled"),
 					presentCommand("ssh"),
+					presentCommand("scp"),
+					presentCommand("ssh-config"),
+					presentCommand("ssh-known-hosts"),
 				},
 			},
 		}, {
@@ -383,8 +387,14 @@ func newAppPresenter() appPresenter {
 				{
 					presentCommand("plugins"),
 					presentCommand("install-plugin"),
+					presentCommand("update-plugin"),
 					presentCommand("uninstall-plugin"),
 				},
+				{
+					presentCommand("add-plugin-key"),
+					presentCommand("remove-plugin-key"),
+					presentCommand("list-plugin-keys"),
+				},
 			},
 		}, {
 			Name: T("INSTALLED PLUGIN COMMANDS"),
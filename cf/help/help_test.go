@@ -25,6 +25,7 @@ var _ = Describe("Help", func() {
 
 	AfterEach(func() {
 		buffer.Close()
+		os.Remove(filepath.Join("..", "..", "fixtures", "config", "help-plugin-test-config", ".cf", "plugins", "config.json.lock"))
 	})
 
 	It("shows help for all commands", func() {
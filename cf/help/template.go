@@ -17,8 +17,10 @@ func GetHelpTemplate() string {
 {{end}}{{end}}{{end}}
 {{.Title "` + T("ENVIRONMENT VARIABLES:") + `"}}
    CF_COLOR=false                     ` + T("Do not colorize output") + `
+   CF_CREDENTIALS_PASSPHRASE=secret   ` + T("Passphrase of the encrypted credential store, see config") + `
    CF_HOME=path/to/dir/               ` + T("Override path to default config directory") + `
    CF_PLUGIN_HOME=path/to/dir/        ` + T("Override path to default plugin config directory") + `
+   CF_PROFILE=dev                     ` + T("Target profile to use instead of the current target") + `
    CF_STAGING_TIMEOUT=15              ` + T("Max wait time for buildpack staging, in minutes") + `
    CF_STARTUP_TIMEOUT=5               ` + T("Max wait time for app instance startup, in minutes") + `
    CF_TRACE=true                      ` + T("Print API request diagnostics to stdout") + `
@@ -28,5 +30,7 @@ func GetHelpTemplate() string {
 {{.Title "` + T("GLOBAL OPTIONS:") + `"}}
    --help, -h                         ` + T("Show help") + `
    -v                                 ` + T("Print API request diagnostics to stdout") + `
+   --output [table|json|yaml]         ` + T("Output format for listing commands such as apps, services and routes") + `
+   --profile PROFILE                  ` + T("Target profile to use for this command only, see target-profile") + `
 `
 }
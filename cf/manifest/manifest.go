@@ -202,6 +202,10 @@ func mapToAppParams(basePath string, yamlMap generic.Map) (models.AppParams, err
 	appParams.HealthCheckType = stringVal(yamlMap, "health-check-type", &errs)
 	appParams.AppPorts = intSliceVal(yamlMap, "app-ports", &errs)
 
+	if yamlMap.Has("depends-on") {
+		appParams.DependsOn = sliceOrEmptyVal(yamlMap, "depends-on", &errs)
+	}
+
 	if appParams.Path != nil {
 		path := *appParams.Path
 		if filepath.IsAbs(path) {
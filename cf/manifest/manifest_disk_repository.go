@@ -16,7 +16,7 @@ import (
 //go:generate counterfeiter . Repository
 
 type Repository interface {
-	ReadManifest(string) (*Manifest, error)
+	ReadManifest(path string, vars Vars) (*Manifest, error)
 }
 
 type DiskRepository struct{}
@@ -25,7 +25,10 @@ func NewDiskRepository() (repo Repository) {
 	return DiskRepository{}
 }
 
-func (repo DiskRepository) ReadManifest(inputPath string) (*Manifest, error) {
+// ReadManifest reads the manifest at inputPath, or the manifest.yml or
+// manifest.yaml in it when inputPath is a directory, and replaces its
+// ((name)) placeholders with vars.
+func (repo DiskRepository) ReadManifest(inputPath string, vars Vars) (*Manifest, error) {
 	m := NewEmptyManifest()
 	manifestPath, err := repo.manifestPath(inputPath)
 
@@ -40,6 +43,11 @@ func (repo DiskRepository) ReadManifest(inputPath string) (*Manifest, error) {
 		return m, err
 	}
 
+	mapp, err = interpolateVars(mapp, vars)
+	if err != nil {
+		return m, err
+	}
+
 	m.Data = mapp
 
 	return m, nil
@@ -17,7 +17,7 @@ var _ = Describe("ManifestDiskRepository", func() {
 
 	Describe("given a directory containing a file called 'manifest.yml'", func() {
 		It("reads that file", func() {
-			m, err := repo.ReadManifest("../../fixtures/manifests")
+			m, err := repo.ReadManifest("../../fixtures/manifests", nil)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(m.Path).To(Equal(filepath.Clean("../../fixtures/manifests/manifest.yml")))
@@ -31,7 +31,7 @@ var _ = Describe("ManifestDiskRepository", func() {
 
 	Describe("given a directory that doesn't contain a file called 'manifest.y{a}ml'", func() {
 		It("returns an error", func() {
-			m, err := repo.ReadManifest("../../fixtures")
+			m, err := repo.ReadManifest("../../fixtures", nil)
 
 			Expect(err).To(HaveOccurred())
 			Expect(m.Path).To(BeEmpty())
@@ -40,7 +40,7 @@ var _ = Describe("ManifestDiskRepository", func() {
 
 	Describe("given a directory that contains a file called 'manifest.yaml'", func() {
 		It("reads that file", func() {
-			m, err := repo.ReadManifest("../../fixtures/manifests/only_yaml")
+			m, err := repo.ReadManifest("../../fixtures/manifests/only_yaml", nil)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(m.Path).To(Equal(filepath.Clean("../../fixtures/manifests/only_yaml/manifest.yaml")))
@@ -53,7 +53,7 @@ var _ = Describe("ManifestDiskRepository", func() {
 
 	Describe("given a directory contains files called 'manifest.yml' and 'manifest.yaml'", func() {
 		It("reads the file named 'manifest.yml'", func() {
-			m, err := repo.ReadManifest("../../fixtures/manifests/both_yaml_yml")
+			m, err := repo.ReadManifest("../../fixtures/manifests/both_yaml_yml", nil)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(m.Path).To(Equal(filepath.Clean("../../fixtures/manifests/both_yaml_yml/manifest.yml")))
@@ -73,7 +73,7 @@ var _ = Describe("ManifestDiskRepository", func() {
 
 		BeforeEach(func() {
 			inputPath = filepath.Clean("../../fixtures/manifests/different-manifest.yml")
-			m, err = repo.ReadManifest(inputPath)
+			m, err = repo.ReadManifest(inputPath, nil)
 		})
 
 		It("reads the file at that path", func() {
@@ -98,31 +98,31 @@ var _ = Describe("ManifestDiskRepository", func() {
 
 	Describe("given a path to a file that doesn't exist", func() {
 		It("returns an error", func() {
-			_, err := repo.ReadManifest("some/path/that/doesnt/exist/manifest.yml")
+			_, err := repo.ReadManifest("some/path/that/doesnt/exist/manifest.yml", nil)
 			Expect(err).To(HaveOccurred())
 		})
 
 		It("returns empty string for the manifest path", func() {
-			m, _ := repo.ReadManifest("some/path/that/doesnt/exist/manifest.yml")
+			m, _ := repo.ReadManifest("some/path/that/doesnt/exist/manifest.yml", nil)
 			Expect(m.Path).To(Equal(""))
 		})
 	})
 
 	Describe("when the manifest is empty", func() {
 		It("returns an error", func() {
-			_, err := repo.ReadManifest("../../fixtures/manifests/empty-manifest.yml")
+			_, err := repo.ReadManifest("../../fixtures/manifests/empty-manifest.yml", nil)
 			Expect(err).To(HaveOccurred())
 		})
 
 		It("returns the path to the manifest", func() {
 			inputPath := filepath.Clean("../../fixtures/manifests/empty-manifest.yml")
-			m, _ := repo.ReadManifest(inputPath)
+			m, _ := repo.ReadManifest(inputPath, nil)
 			Expect(m.Path).To(Equal(inputPath))
 		})
 	})
 
 	It("converts nested maps to generic maps", func() {
-		m, err := repo.ReadManifest("../../fixtures/manifests/different-manifest.yml")
+		m, err := repo.ReadManifest("../../fixtures/manifests/different-manifest.yml", nil)
 		Expect(err).NotTo(HaveOccurred())
 
 		applications, err := m.Applications()
@@ -133,7 +133,7 @@ var _ = Describe("ManifestDiskRepository", func() {
 	})
 
 	It("merges manifests with their 'inherited' manifests", func() {
-		m, err := repo.ReadManifest("../../fixtures/manifests/inherited-manifest.yml")
+		m, err := repo.ReadManifest("../../fixtures/manifests/inherited-manifest.yml", nil)
 		Expect(err).NotTo(HaveOccurred())
 
 		applications, err := m.Applications()
@@ -156,7 +156,7 @@ var _ = Describe("ManifestDiskRepository", func() {
 	})
 
 	It("supports yml merges", func() {
-		m, err := repo.ReadManifest("../../fixtures/manifests/merge-manifest.yml")
+		m, err := repo.ReadManifest("../../fixtures/manifests/merge-manifest.yml", nil)
 		Expect(err).NotTo(HaveOccurred())
 
 		applications, err := m.Applications()
@@ -174,4 +174,53 @@ var _ = Describe("ManifestDiskRepository", func() {
 		Expect(*applications[2].InstanceCount).To(Equal(3))
 		Expect(*applications[2].Memory).To(Equal(int64(256)))
 	})
+
+	Describe("variable substitution", func() {
+		var vars Vars
+
+		BeforeEach(func() {
+			vars = Vars{}
+			Expect(vars.LoadFile("../../fixtures/manifests/vars.yml")).To(Succeed())
+		})
+
+		It("replaces placeholders with the values from the vars file", func() {
+			m, err := repo.ReadManifest("../../fixtures/manifests/vars-manifest.yml", vars)
+			Expect(err).NotTo(HaveOccurred())
+
+			applications, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*applications[0].Name).To(Equal("my-app"))
+			Expect(*applications[0].Memory).To(Equal(int64(512)))
+			Expect(*applications[0].InstanceCount).To(Equal(3))
+			Expect(*applications[0].Hosts).To(Equal([]string{"my-app-dev"}))
+			Expect(*applications[0].ServicesToBind).To(Equal([]string{"my-db", "my-cache"}))
+			Expect((*applications[0].EnvironmentVars)["STAGE"]).To(Equal("dev"))
+		})
+
+		It("lets assignments override the vars file", func() {
+			Expect(vars.Set("env=prod")).To(Succeed())
+
+			m, err := repo.ReadManifest("../../fixtures/manifests/vars-manifest.yml", vars)
+			Expect(err).NotTo(HaveOccurred())
+
+			applications, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*applications[0].Hosts).To(Equal([]string{"my-app-prod"}))
+		})
+
+		It("lists every variable that has no value", func() {
+			_, err := repo.ReadManifest("../../fixtures/manifests/vars-manifest.yml", Vars{"app_name": "my-app"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected to find variables: env, instances, memory, services"))
+		})
+
+		It("rejects assignments without a name", func() {
+			Expect(vars.Set("=value")).NotTo(Succeed())
+			Expect(vars.Set("no-value")).NotTo(Succeed())
+		})
+
+		It("fails when the vars file does not exist", func() {
+			Expect(vars.LoadFile("some/path/that/doesnt/exist/vars.yml")).NotTo(Succeed())
+		})
+	})
 })
@@ -507,4 +507,26 @@ var _ = Describe("Manifests", func() {
 			Expect(*app[0].ServicesToBind).To(Equal([]string{"service-1", "service-2"}))
 		})
 	})
+
+	Describe("parsing depends-on", func() {
+		It("reads the names of the apps an app depends on", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"depends-on": []interface{}{"app-1", "app-2"},
+			}))
+
+			app, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(*app[0].DependsOn).To(Equal([]string{"app-1", "app-2"}))
+		})
+
+		It("leaves DependsOn nil when the key is omitted", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{}))
+
+			app, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(app[0].DependsOn).To(BeNil())
+		})
+	})
 })
@@ -8,10 +8,11 @@ import (
 )
 
 type FakeRepository struct {
-	ReadManifestStub        func(string) (*manifest.Manifest, error)
+	ReadManifestStub        func(path string, vars manifest.Vars) (*manifest.Manifest, error)
 	readManifestMutex       sync.RWMutex
 	readManifestArgsForCall []struct {
-		arg1 string
+		path string
+		vars manifest.Vars
 	}
 	readManifestReturns struct {
 		result1 *manifest.Manifest
@@ -19,14 +20,15 @@ type FakeRepository struct {
 	}
 }
 
-func (fake *FakeRepository) ReadManifest(arg1 string) (*manifest.Manifest, error) {
+func (fake *FakeRepository) ReadManifest(path string, vars manifest.Vars) (*manifest.Manifest, error) {
 	fake.readManifestMutex.Lock()
 	fake.readManifestArgsForCall = append(fake.readManifestArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		path string
+		vars manifest.Vars
+	}{path, vars})
 	fake.readManifestMutex.Unlock()
 	if fake.ReadManifestStub != nil {
-		return fake.ReadManifestStub(arg1)
+		return fake.ReadManifestStub(path, vars)
 	} else {
 		return fake.readManifestReturns.result1, fake.readManifestReturns.result2
 	}
@@ -38,10 +40,10 @@ func (fake *FakeRepository) ReadManifestCallCount() int {
 	return len(fake.readManifestArgsForCall)
 }
 
-func (fake *FakeRepository) ReadManifestArgsForCall(i int) string {
+func (fake *FakeRepository) ReadManifestArgsForCall(i int) (string, manifest.Vars) {
 	fake.readManifestMutex.RLock()
 	defer fake.readManifestMutex.RUnlock()
-	return fake.readManifestArgsForCall[i].arg1
+	return fake.readManifestArgsForCall[i].path, fake.readManifestArgsForCall[i].vars
 }
 
 func (fake *FakeRepository) ReadManifestReturns(result1 *manifest.Manifest, result2 error) {
@@ -0,0 +1,120 @@
+package manifest
+
+import (
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/generic"
+	"gopkg.in/yaml.v2"
+)
+
+// Vars holds the values that replace ((name)) placeholders in a manifest.
+type Vars map[string]interface{}
+
+// LoadFile adds the top-level keys of the YAML file at path to vars,
+// replacing values that are already set.
+func (vars Vars) LoadFile(path string) error {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	fileVars := make(map[interface{}]interface{})
+	err = yaml.Unmarshal(contents, &fileVars)
+	if err != nil {
+		return errors.New(T("Invalid vars file {{.Path}}: {{.Err}}",
+			map[string]interface{}{"Path": path, "Err": err.Error()}))
+	}
+
+	for key, value := range fileVars {
+		vars[coerceToString(key)] = value
+	}
+
+	return nil
+}
+
+// Set adds a NAME=VALUE assignment, as given to --var. The value is always a
+// string; use a vars file for numbers, lists and maps.
+func (vars Vars) Set(assignment string) error {
+	parts := strings.SplitN(assignment, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return errors.New(T("Invalid variable assignment '{{.Assignment}}'. Expected NAME=VALUE",
+			map[string]interface{}{"Assignment": assignment}))
+	}
+
+	vars[parts[0]] = parts[1]
+	return nil
+}
+
+var varRegex = regexp.MustCompile(`\(\(([\w.-]+)\)\)`)
+
+// interpolateVars replaces the ((name)) placeholders in the values of data.
+// A value that consists of a single placeholder takes the variable's value
+// as is, so a variable can supply a number or a list; placeholders inside a
+// longer string are replaced by the variable's text. Every variable missing
+// from vars is reported in one error.
+func interpolateVars(data generic.Map, vars Vars) (generic.Map, error) {
+	missing := map[string]bool{}
+	output := interpolate(data, vars, missing)
+
+	if len(missing) > 0 {
+		names := []string{}
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, errors.New(T("Expected to find variables: {{.VariableNames}}",
+			map[string]interface{}{"VariableNames": strings.Join(names, ", ")}))
+	}
+
+	return output.(generic.Map), nil
+}
+
+func interpolate(input interface{}, vars Vars, missing map[string]bool) interface{} {
+	switch input := input.(type) {
+	case string:
+		if match := varRegex.FindStringSubmatch(input); match != nil && match[0] == input {
+			value, ok := vars[match[1]]
+			if !ok {
+				missing[match[1]] = true
+				return input
+			}
+			return value
+		}
+
+		return varRegex.ReplaceAllStringFunc(input, func(placeholder string) string {
+			name := varRegex.FindStringSubmatch(placeholder)[1]
+			value, ok := vars[name]
+			if !ok {
+				missing[name] = true
+				return placeholder
+			}
+			return coerceToString(value)
+		})
+	case []interface{}:
+		outputSlice := make([]interface{}, len(input))
+		for index, item := range input {
+			outputSlice[index] = interpolate(item, vars, missing)
+		}
+		return outputSlice
+	case map[interface{}]interface{}:
+		outputMap := make(map[interface{}]interface{})
+		for key, value := range input {
+			outputMap[key] = interpolate(value, vars, missing)
+		}
+		return outputMap
+	case generic.Map:
+		outputMap := generic.NewMap()
+		generic.Each(input, func(key, value interface{}) {
+			outputMap.Set(key, interpolate(value, vars, missing))
+		})
+		return outputMap
+	default:
+		return input
+	}
+}
@@ -86,6 +86,7 @@ const (
 type AppParams struct {
 	BuildpackURL       *string
 	Command            *string
+	DependsOn          *[]string
 	DiskQuota          *int64
 	Domains            *[]string
 	EnvironmentVars    *map[string]interface{}
@@ -124,6 +125,9 @@ func (app *AppParams) Merge(other *AppParams) {
 	if other.Command != nil {
 		app.Command = other.Command
 	}
+	if other.DependsOn != nil {
+		app.DependsOn = other.DependsOn
+	}
 	if other.DiskQuota != nil {
 		app.DiskQuota = other.DiskQuota
 	}
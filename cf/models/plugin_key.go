@@ -0,0 +1,8 @@
+package models
+
+// PluginKey is a public key trusted to sign plugin binaries. PublicKey is
+// the base64 encoding of an ed25519 public key.
+type PluginKey struct {
+	Name      string
+	PublicKey string
+}
@@ -53,6 +53,9 @@ type tokenRefresher interface {
 type Request struct {
 	HTTPReq      *http.Request
 	SeekableBody io.ReadSeeker
+
+	writeBody  func(io.Writer) error
+	progressUI terminal.UI
 }
 
 type Gateway struct {
@@ -60,6 +63,7 @@ type Gateway struct {
 	errHandler      apiErrorHandler
 	PollingEnabled  bool
 	PollingThrottle time.Duration
+	RetryDelay      time.Duration
 	trustedCerts    []tls.Certificate
 	config          coreconfig.Reader
 	warnings        *[]string
@@ -74,6 +78,7 @@ func newGateway(errHandler apiErrorHandler, config coreconfig.Reader, ui termina
 		errHandler:      errHandler,
 		config:          config,
 		PollingThrottle: DefaultPollingThrottle,
+		RetryDelay:      DefaultRetryDelay,
 		warnings:        &[]string{},
 		Clock:           time.Now,
 		ui:              ui,
@@ -238,6 +243,25 @@ func (gateway Gateway) NewRequestForFile(method, fullURL, accessToken string, bo
 	return gateway.newRequest(request, accessToken, progressReader), nil
 }
 
+// NewRequestForStream builds a request whose body is written by writeBody
+// while the request is being sent, so that it never has to be held in memory
+// or on disk. The body is sent chunked, with progress shown as it goes, and
+// writeBody is called again if the request has to be retried with a
+// refreshed token.
+func (gateway Gateway) NewRequestForStream(method, fullURL, accessToken string, writeBody func(io.Writer) error) (*Request, error) {
+	request, err := http.NewRequest(method, fullURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", T("Error building request"), err.Error())
+	}
+
+	request.ContentLength = -1
+
+	req := gateway.newRequest(request, accessToken, nil)
+	req.writeBody = writeBody
+	req.progressUI = gateway.ui
+	return req, nil
+}
+
 func (gateway Gateway) NewRequest(method, path, accessToken string, body io.ReadSeeker) (*Request, error) {
 	request, err := http.NewRequest(method, path, body)
 	if err != nil {
@@ -370,6 +394,9 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response,
 	if request.SeekableBody != nil {
 		httpReq.Body = ioutil.NopCloser(request.SeekableBody)
 	}
+	if request.writeBody != nil {
+		httpReq.Body = streamBody(request)
+	}
 
 	// perform request
 	rawResponse, err := gateway.doRequestAndHandlerError(request)
@@ -388,10 +415,7 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response,
 
 		// reset the auth token and request body
 		httpReq.Header.Set("Authorization", newToken)
-		if request.SeekableBody != nil {
-			_, _ = request.SeekableBody.Seek(0, 0)
-			httpReq.Body = ioutil.NopCloser(request.SeekableBody)
-		}
+		resetBody(request)
 
 		// make the request again
 		rawResponse, err = gateway.doRequestAndHandlerError(request)
@@ -400,43 +424,110 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response,
 	return rawResponse, err
 }
 
-func (gateway Gateway) doRequestAndHandlerError(request *Request) (*http.Response, error) {
-	rawResponse, err := gateway.doRequest(request.HTTPReq)
-	if err != nil {
-		return rawResponse, WrapNetworkErrors(request.HTTPReq.URL.Host, err)
-	}
+// streamBody returns a reader of what the request's writeBody writes.
+// writeBody runs alongside the reader and stops with an error if the reader
+// is closed early.
+func streamBody(request *Request) io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		_ = writer.CloseWithError(request.writeBody(writer))
+	}()
 
-	if rawResponse.StatusCode > 299 {
-		jsonBytes, _ := ioutil.ReadAll(rawResponse.Body)
-		_ = rawResponse.Body.Close()
-		rawResponse.Body = ioutil.NopCloser(bytes.NewBuffer(jsonBytes))
-		err = gateway.errHandler(rawResponse.StatusCode, jsonBytes)
+	if request.progressUI == nil {
+		return reader
 	}
-
-	return rawResponse, err
+	return NewStreamProgressReader(reader, request.progressUI, 5*time.Second)
 }
 
-func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error) {
-	var response *http.Response
-	var err error
+// resetBody rewinds the body of request, so that it can be sent again.
+func resetBody(request *Request) {
+	if request.SeekableBody != nil {
+		_, _ = request.SeekableBody.Seek(0, 0)
+		request.HTTPReq.Body = ioutil.NopCloser(request.SeekableBody)
+	}
+	if request.writeBody != nil {
+		request.HTTPReq.Body = streamBody(request)
+	}
+}
 
+// doRequestAndHandlerError makes the request, retrying it as many times as
+// the config allows when it fails for a passing reason. A request that got
+// no response is always retried, as are idempotent requests the server was
+// briefly unable to handle.
+func (gateway Gateway) doRequestAndHandlerError(request *Request) (*http.Response, error) {
 	if gateway.transport == nil {
-		makeHTTPTransport(&gateway)
+		err := makeHTTPTransport(&gateway)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	httpClient := NewHTTPClient(gateway.transport, NewRequestDumper(gateway.logger))
+	retries := gateway.config.RequestRetries()
 
-	httpClient.DumpRequest(request)
+	for attempt := uint(0); ; attempt++ {
+		if attempt > 0 {
+			resetBody(request)
+		}
 
-	for i := 0; i < 3; i++ {
-		response, err = httpClient.Do(request)
-		if response == nil && err != nil {
-			continue
-		} else {
-			break
+		rawResponse, err := gateway.doRequest(request.HTTPReq)
+		if err != nil {
+			if rawResponse == nil && attempt < retries && isTransientNetworkError(err) {
+				gateway.waitToRetry(request, err.Error(), gateway.retryDelay(attempt, nil), attempt, retries)
+				continue
+			}
+			return rawResponse, WrapNetworkErrors(request.HTTPReq.URL.Host, err)
+		}
+
+		if rawResponse.StatusCode > 299 {
+			jsonBytes, _ := ioutil.ReadAll(rawResponse.Body)
+			_ = rawResponse.Body.Close()
+			rawResponse.Body = ioutil.NopCloser(bytes.NewBuffer(jsonBytes))
+
+			if attempt < retries && retryableStatusCodes[rawResponse.StatusCode] && isIdempotent(request.HTTPReq.Method) {
+				delay := gateway.retryDelay(attempt, rawResponse)
+				if delay <= maxRetryDelay {
+					gateway.waitToRetry(request, rawResponse.Status, delay, attempt, retries)
+					continue
+				}
+			}
+
+			err = gateway.errHandler(rawResponse.StatusCode, jsonBytes)
+		}
+
+		return rawResponse, err
+	}
+}
+
+// retryDelay is how long to wait before retrying after attempt, which is as
+// long as the server asks for in a response, if it does.
+func (gateway Gateway) retryDelay(attempt uint, response *http.Response) time.Duration {
+	if response != nil {
+		if delay, ok := retryAfter(response); ok {
+			return delay
 		}
 	}
+	return retryDelay(gateway.RetryDelay, attempt)
+}
 
+func (gateway Gateway) waitToRetry(request *Request, reason string, delay time.Duration, attempt uint, retries uint) {
+	gateway.logger.Printf("\n%s [%s]\n%s\n", terminal.HeaderColor(T("RETRYING REQUEST:")), time.Now().Format(time.RFC3339), T("{{.Method}} {{.URL}} failed: {{.Reason}}\nRetry {{.Retry}} of {{.Retries}} in {{.Delay}}", map[string]interface{}{
+		"Method":  request.HTTPReq.Method,
+		"URL":     request.HTTPReq.URL.String(),
+		"Reason":  reason,
+		"Retry":   attempt + 1,
+		"Retries": retries,
+		"Delay":   delay,
+	}))
+
+	time.Sleep(delay)
+}
+
+func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error) {
+	httpClient := NewHTTPClient(gateway.transport, NewRequestDumper(gateway.logger))
+
+	httpClient.DumpRequest(request)
+
+	response, err := httpClient.Do(request)
 	if err != nil {
 		return response, err
 	}
@@ -453,15 +544,24 @@ func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error)
 	return response, err
 }
 
-func makeHTTPTransport(gateway *Gateway) {
+func makeHTTPTransport(gateway *Gateway) error {
+	tlsConfig, err := NewTLSConfigFromConfig(gateway.config, gateway.trustedCerts)
+	if err != nil {
+		return err
+	}
+
 	gateway.transport = &http.Transport{
 		Dial:            (&net.Dialer{Timeout: 5 * time.Second}).Dial,
-		TLSClientConfig: NewTLSConfig(gateway.trustedCerts, gateway.config.IsSSLDisabled()),
+		TLSClientConfig: tlsConfig,
 		Proxy:           http.ProxyFromEnvironment,
 	}
+	return nil
 }
 
 func (gateway *Gateway) SetTrustedCerts(certificates []tls.Certificate) {
 	gateway.trustedCerts = certificates
-	makeHTTPTransport(gateway)
+	gateway.transport = nil
+	// When the certificate files cannot be loaded, the transport is made
+	// again, and the error returned, on the next request.
+	_ = makeHTTPTransport(gateway)
 }
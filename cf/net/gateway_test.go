@@ -2,6 +2,7 @@ package net_test
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -49,7 +50,9 @@ var _ = Describe("Gateway", func() {
 
 		ccGateway = NewCloudControllerGateway(config, clock, &testterm.FakeUI{}, new(tracefakes.FakePrinter))
 		ccGateway.PollingThrottle = 3 * time.Millisecond
+		ccGateway.RetryDelay = time.Millisecond
 		uaaGateway = NewUAAGateway(config, &testterm.FakeUI{}, new(tracefakes.FakePrinter))
+		uaaGateway.RetryDelay = time.Millisecond
 	})
 
 	Describe("async timeout", func() {
@@ -97,6 +100,131 @@ var _ = Describe("Gateway", func() {
 			Expect(apiErr).To(HaveOccurred())
 			Expect(client.DoCallCount()).To(Equal(3))
 		})
+
+		It("retries as many times as the config says", func() {
+			config.SetRequestRetries(0)
+			client.DoReturns(nil, errors.New("Connection refused"))
+			request, apiErr := ccGateway.NewRequest("GET", "https://example.com/v2/apps", "BEARER my-access-token", nil)
+			Expect(apiErr).ToNot(HaveOccurred())
+
+			_, apiErr = ccGateway.PerformRequest(request)
+			Expect(apiErr).To(HaveOccurred())
+			Expect(client.DoCallCount()).To(Equal(1))
+		})
+
+		It("does not retry when the server certificate is not trusted", func() {
+			client.DoReturns(nil, &url.Error{Op: "Get", URL: "https://example.com/v2/apps", Err: x509.UnknownAuthorityError{}})
+			request, apiErr := ccGateway.NewRequest("GET", "https://example.com/v2/apps", "BEARER my-access-token", nil)
+			Expect(apiErr).ToNot(HaveOccurred())
+
+			_, apiErr = ccGateway.PerformRequest(request)
+			Expect(apiErr).To(BeAssignableToTypeOf(&errors.InvalidSSLCert{}))
+			Expect(client.DoCallCount()).To(Equal(1))
+		})
+
+		It("does not retry when the certificate error is wrapped", func() {
+			client.DoReturns(nil, &url.Error{Op: "Get", URL: "https://example.com/v2/apps", Err: wrappedError{x509.HostnameError{}}})
+			request, apiErr := ccGateway.NewRequest("GET", "https://example.com/v2/apps", "BEARER my-access-token", nil)
+			Expect(apiErr).ToNot(HaveOccurred())
+
+			_, apiErr = ccGateway.PerformRequest(request)
+			Expect(apiErr).To(HaveOccurred())
+			Expect(client.DoCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("retrying requests the server was briefly unable to handle", func() {
+		var logger *tracefakes.FakePrinter
+
+		BeforeEach(func() {
+			ccServer = ghttp.NewServer()
+			logger = new(tracefakes.FakePrinter)
+			ccGateway = NewCloudControllerGateway(config, clock, &testterm.FakeUI{}, logger)
+			ccGateway.RetryDelay = time.Millisecond
+		})
+
+		AfterEach(func() {
+			ccServer.Close()
+		})
+
+		performRequest := func(method string, body string) error {
+			request, err := ccGateway.NewRequest(method, ccServer.URL()+"/v2/apps", "BEARER my-access-token", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = ccGateway.PerformRequest(request)
+			return err
+		}
+
+		It("retries idempotent requests, with the same body, and logs it", func() {
+			ccServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyBody([]byte("my-body")),
+					ghttp.RespondWith(http.StatusServiceUnavailable, "down for a deploy"),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyBody([]byte("my-body")),
+					ghttp.RespondWith(http.StatusBadGateway, "no route"),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyBody([]byte("my-body")),
+					ghttp.RespondWith(http.StatusOK, "{}"),
+				),
+			)
+
+			Expect(performRequest("PUT", "my-body")).To(Succeed())
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(3))
+
+			var logged []string
+			for i := 0; i < logger.PrintfCallCount(); i++ {
+				format, args := logger.PrintfArgsForCall(i)
+				logged = append(logged, fmt.Sprintf(format, args...))
+			}
+			Expect(strings.Join(logged, "")).To(ContainSubstring("RETRYING REQUEST:"))
+			Expect(strings.Join(logged, "")).To(ContainSubstring("PUT " + ccServer.URL() + "/v2/apps failed: 503 Service Unavailable"))
+			Expect(strings.Join(logged, "")).To(ContainSubstring("Retry 2 of 2"))
+		})
+
+		It("gives up after the retries the config allows", func() {
+			config.SetRequestRetries(1)
+			ccServer.RouteToHandler("GET", "/v2/apps", ghttp.RespondWith(http.StatusBadGateway, `{"code": 10001, "description": "no route"}`))
+
+			err := performRequest("GET", "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.(errors.HTTPError).StatusCode()).To(Equal(http.StatusBadGateway))
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("does not retry requests that are not idempotent", func() {
+			ccServer.RouteToHandler("POST", "/v2/apps", ghttp.RespondWith(http.StatusServiceUnavailable, ""))
+
+			Expect(performRequest("POST", "my-body")).NotTo(Succeed())
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("does not retry other errors", func() {
+			ccServer.RouteToHandler("GET", "/v2/apps", ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+			Expect(performRequest("GET", "")).NotTo(Succeed())
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("waits as long as Retry-After asks for", func() {
+			ccServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusTooManyRequests, "", http.Header{"Retry-After": {"1"}}),
+				ghttp.RespondWith(http.StatusOK, "{}"),
+			)
+
+			start := time.Now()
+			Expect(performRequest("GET", "")).To(Succeed())
+			Expect(time.Since(start)).To(BeNumerically(">=", time.Second))
+		})
+
+		It("does not wait when Retry-After asks for too long", func() {
+			ccServer.RouteToHandler("GET", "/v2/apps", ghttp.RespondWith(http.StatusServiceUnavailable, "", http.Header{"Retry-After": {"3600"}}))
+
+			Expect(performRequest("GET", "")).NotTo(Succeed())
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(1))
+		})
 	})
 
 	Describe("NewRequest", func() {
@@ -584,6 +712,68 @@ var _ = Describe("Gateway", func() {
 
 	})
 
+	Describe("certificate files", func() {
+		var (
+			apiServer *httptest.Server
+			certDir   string
+			request   *Request
+		)
+
+		BeforeEach(func() {
+			var err error
+			certDir, err = ioutil.TempDir("", "gateway-certs")
+			Expect(err).NotTo(HaveOccurred())
+
+			apiServer = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+					fmt.Fprintln(w, `{"client_cert":false}`)
+					return
+				}
+				fmt.Fprintln(w, `{"client_cert":true}`)
+			}))
+			request, _ = ccGateway.NewRequest("GET", apiServer.URL+"/v2/foo", "the-access-token", nil)
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+			os.RemoveAll(certDir)
+		})
+
+		It("trusts the CA certificates in the CA certificate file", func() {
+			caCertFile, _ := testnet.WriteTLSCertFiles(apiServer.TLS.Certificates[0], certDir)
+			config.SetCACertFile(caCertFile)
+
+			_, apiErr := ccGateway.PerformRequest(request)
+			Expect(apiErr).NotTo(HaveOccurred())
+		})
+
+		It("presents the client certificate", func() {
+			apiServer.TLS.ClientAuth = tls.RequireAnyClientCert
+			caCertFile, _ := testnet.WriteTLSCertFiles(apiServer.TLS.Certificates[0], certDir)
+			config.SetCACertFile(caCertFile)
+
+			clientCertDir := certDir + "/client"
+			Expect(os.Mkdir(clientCertDir, 0700)).To(Succeed())
+			clientCertFile, clientKeyFile := testnet.WriteTLSCertFiles(testnet.MakeSelfSignedTLSCert(), clientCertDir)
+			config.SetClientCertificate(clientCertFile, clientKeyFile)
+
+			var response struct {
+				ClientCert bool `json:"client_cert"`
+			}
+			_, apiErr := ccGateway.PerformRequestForJSONResponse(request, &response)
+			Expect(apiErr).NotTo(HaveOccurred())
+			Expect(response.ClientCert).To(BeTrue())
+		})
+
+		It("fails without making the request when a certificate file cannot be loaded", func() {
+			config.SetCACertFile(certDir + "/missing.pem")
+
+			_, apiErr := ccGateway.PerformRequest(request)
+			Expect(apiErr).To(HaveOccurred())
+			Expect(apiErr.Error()).To(ContainSubstring("Error reading CA certificate file"))
+		})
+	})
+
 	Describe("collecting warnings", func() {
 		var (
 			apiServer  *httptest.Server
@@ -689,3 +879,17 @@ func createAuthenticationRepository(apiServer *httptest.Server, authServer *http
 
 	return config, authenticator
 }
+
+// wrappedError wraps an error the way *tls.CertificateVerificationError
+// wraps certificate errors.
+type wrappedError struct {
+	err error
+}
+
+func (e wrappedError) Error() string {
+	return "wrapped: " + e.err.Error()
+}
+
+func (e wrappedError) Unwrap() error {
+	return e.err
+}
@@ -3,6 +3,7 @@ package net
 import (
 	"io"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/cloudfoundry/cli/cf/formatters"
@@ -16,6 +17,9 @@ type ProgressReader struct {
 	quit           chan bool
 	ui             terminal.UI
 	outputInterval time.Duration
+	downloading    bool
+	done           bool
+	closed         chan struct{}
 }
 
 func NewProgressReader(readSeeker io.ReadSeeker, ui terminal.UI, outputInterval time.Duration) *ProgressReader {
@@ -26,6 +30,23 @@ func NewProgressReader(readSeeker io.ReadSeeker, ui terminal.UI, outputInterval
 	}
 }
 
+// StreamProgressReader shows progress like ProgressReader, for a body whose
+// size is not known up front and that cannot be rewound.
+type StreamProgressReader struct {
+	*ProgressReader
+	reader    io.ReadCloser
+	closeOnce sync.Once
+}
+
+// NewStreamProgressReader returns a reader that shows progress until reader
+// is read to the end or closed.
+func NewStreamProgressReader(reader io.ReadCloser, ui terminal.UI, outputInterval time.Duration) *StreamProgressReader {
+	progressReader := NewProgressReader(unseekableReader{reader}, ui, outputInterval)
+	progressReader.SetTotalSize(-1)
+	progressReader.closed = make(chan struct{})
+	return &StreamProgressReader{ProgressReader: progressReader, reader: reader}
+}
+
 func (progressReader *ProgressReader) Read(p []byte) (int, error) {
 	if progressReader.ioReadSeeker == nil {
 		return 0, os.ErrInvalid
@@ -33,7 +54,7 @@ func (progressReader *ProgressReader) Read(p []byte) (int, error) {
 
 	n, err := progressReader.ioReadSeeker.Read(p)
 
-	if progressReader.total > int64(0) {
+	if progressReader.total != int64(0) && !progressReader.done {
 		if n > 0 {
 			if progressReader.quit == nil {
 				progressReader.quit = make(chan bool)
@@ -41,10 +62,15 @@ func (progressReader *ProgressReader) Read(p []byte) (int, error) {
 			}
 
 			progressReader.bytesRead += int64(n)
+		}
 
-			if progressReader.total == progressReader.bytesRead {
-				progressReader.quit <- true
-				return n, err
+		finished := progressReader.total == progressReader.bytesRead ||
+			(progressReader.total < int64(0) && err == io.EOF)
+		if finished && progressReader.quit != nil {
+			progressReader.done = true
+			select {
+			case progressReader.quit <- true:
+			case <-progressReader.closed:
 			}
 		}
 	}
@@ -56,19 +82,37 @@ func (progressReader *ProgressReader) Seek(offset int64, whence int) (int64, err
 	return progressReader.ioReadSeeker.Seek(offset, whence)
 }
 
+// Close stops showing progress for a stream that was not read to the end,
+// and closes the stream.
+func (progressReader *StreamProgressReader) Close() error {
+	progressReader.closeOnce.Do(func() { close(progressReader.closed) })
+	return progressReader.reader.Close()
+}
+
 func (progressReader *ProgressReader) printProgress(quit chan bool) {
 	timer := time.NewTicker(progressReader.outputInterval)
+	defer timer.Stop()
 
 	for {
 		select {
+		case <-progressReader.closed:
+			return
 		case <-quit:
 			//The spaces are there to ensure we overwrite the entire line
 			//before using the terminal printer to output Done Uploading
 			progressReader.ui.PrintCapturingNoOutput("\r                             ")
-			progressReader.ui.Say("\rDone uploading")
+			if progressReader.downloading {
+				progressReader.ui.Say("\rDone downloading")
+			} else {
+				progressReader.ui.Say("\rDone uploading")
+			}
 			return
 		case <-timer.C:
-			progressReader.ui.PrintCapturingNoOutput("\r%s uploaded...", formatters.ByteSize(progressReader.bytesRead))
+			if progressReader.downloading {
+				progressReader.ui.PrintCapturingNoOutput("\r%s downloaded...", formatters.ByteSize(progressReader.bytesRead))
+			} else {
+				progressReader.ui.PrintCapturingNoOutput("\r%s uploaded...", formatters.ByteSize(progressReader.bytesRead))
+			}
 		}
 	}
 }
@@ -76,3 +120,17 @@ func (progressReader *ProgressReader) printProgress(quit chan bool) {
 func (progressReader *ProgressReader) SetTotalSize(size int64) {
 	progressReader.total = size
 }
+
+// SetDownloading makes the progress messages say the bytes are being
+// downloaded rather than uploaded.
+func (progressReader *ProgressReader) SetDownloading() {
+	progressReader.downloading = true
+}
+
+type unseekableReader struct {
+	io.Reader
+}
+
+func (unseekableReader) Seek(offset int64, whence int) (int64, error) {
+	return 0, os.ErrInvalid
+}
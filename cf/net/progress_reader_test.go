@@ -50,6 +50,20 @@ var _ = Describe("ProgressReader", func() {
 		Expect(ui.Outputs).To(ContainSubstrings([]string{"\rDone "}))
 	})
 
+	It("talks about downloading when it is set to", func() {
+		progressReader.SetDownloading()
+		for {
+			time.Sleep(50 * time.Microsecond)
+			_, err := progressReader.Read(b)
+			if err != nil {
+				break
+			}
+		}
+
+		Expect(ui.UncapturedOutput).To(ContainSubstrings([]string{"\r", "downloaded..."}))
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"\rDone downloading"}))
+	})
+
 	It("reads the correct number of bytes", func() {
 		bytesRead := 0
 
@@ -64,4 +78,36 @@ var _ = Describe("ProgressReader", func() {
 
 		Expect(int64(bytesRead)).To(Equal(fileStat.Size()))
 	})
+
+	Describe("StreamProgressReader", func() {
+		var streamReader *StreamProgressReader
+
+		BeforeEach(func() {
+			streamReader = NewStreamProgressReader(testFile, ui, 1*time.Millisecond)
+		})
+
+		It("prints progress until the stream is read to the end", func() {
+			bytesRead := 0
+			for {
+				time.Sleep(50 * time.Microsecond)
+				n, err := streamReader.Read(b)
+				bytesRead += n
+				if err != nil {
+					break
+				}
+			}
+
+			Expect(int64(bytesRead)).To(Equal(fileStat.Size()))
+			Expect(ui.UncapturedOutput).To(ContainSubstrings([]string{"\r", "uploaded..."}))
+			Eventually(func() []string { return ui.Outputs }).Should(ContainSubstrings([]string{"\rDone uploading"}))
+		})
+
+		It("stops printing progress when it is closed early", func() {
+			_, err := streamReader.Read(b)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(streamReader.Close()).To(Succeed())
+			Consistently(func() []string { return ui.Outputs }).ShouldNot(ContainSubstrings([]string{"Done uploading"}))
+		})
+	})
 })
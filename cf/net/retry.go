@@ -0,0 +1,97 @@
+package net
+
+import (
+	"crypto/x509"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultRetryDelay = 1 * time.Second
+	maxRetryDelay     = 30 * time.Second
+)
+
+// retryableStatusCodes are responses of routers, load balancers and APIs
+// that are briefly unavailable, such as during a deploy.
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// isIdempotent tells whether making a request more than once has the same
+// effect as making it once, so that it can be retried after a response.
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// isTransientNetworkError tells whether a request that got no response
+// because of err may succeed when it is made again. Certificate errors do
+// not go away by retrying. They can be wrapped, for instance in a
+// *tls.CertificateVerificationError, so every error err wraps is looked at.
+func isTransientNetworkError(err error) bool {
+	for err != nil {
+		switch typedErr := err.(type) {
+		case x509.UnknownAuthorityError, x509.HostnameError, x509.CertificateInvalidError:
+			return false
+		case *url.Error:
+			err = typedErr.Err
+		case interface {
+			Unwrap() error
+		}:
+			err = typedErr.Unwrap()
+		default:
+			return true
+		}
+	}
+	return true
+}
+
+// retryDelay is how long to wait before retry number attempt: exponentially
+// longer each time, with jitter so that many clients retrying do not do so
+// all at once.
+func retryDelay(base time.Duration, attempt uint) time.Duration {
+	delay := base
+	for i := uint(0); i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	if delay < 2 {
+		return delay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)))
+}
+
+// retryAfter reads the Retry-After header of response, which is either a
+// number of seconds or a date.
+func retryAfter(response *http.Response) (time.Duration, bool) {
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(time.Now())
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
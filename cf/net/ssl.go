@@ -3,6 +3,12 @@ package net
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
 )
 
 func NewTLSConfig(trustedCerts []tls.Certificate, disableSSL bool) (TLSConfig *tls.Config) {
@@ -23,3 +29,44 @@ func NewTLSConfig(trustedCerts []tls.Certificate, disableSSL bool) (TLSConfig *t
 
 	return
 }
+
+// NewTLSConfigWithFiles is NewTLSConfig that also trusts the CA certificates
+// in the PEM file caCertFile, besides the system's, and presents the client
+// certificate in clientCertFile and clientKeyFile. Files that are "" are
+// left out.
+func NewTLSConfigWithFiles(trustedCerts []tls.Certificate, disableSSL bool, caCertFile string, clientCertFile string, clientKeyFile string) (*tls.Config, error) {
+	tlsConfig := NewTLSConfig(trustedCerts, disableSSL)
+
+	if caCertFile != "" {
+		pemCerts, err := ioutil.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", T("Error reading CA certificate file"), err.Error())
+		}
+
+		certPool := tlsConfig.RootCAs
+		if certPool == nil {
+			certPool = systemCertPool()
+		}
+
+		if !certPool.AppendCertsFromPEM(pemCerts) {
+			return nil, errors.New(T("No PEM certificates found in CA certificate file {{.Path}}", map[string]interface{}{"Path": caCertFile}))
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if clientCertFile != "" || clientKeyFile != "" {
+		clientCert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", T("Error loading client certificate"), err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return tlsConfig, nil
+}
+
+// NewTLSConfigFromConfig is NewTLSConfigWithFiles for the SSL settings of
+// the targeted API in config.
+func NewTLSConfigFromConfig(config coreconfig.Reader, trustedCerts []tls.Certificate) (*tls.Config, error) {
+	return NewTLSConfigWithFiles(trustedCerts, config.IsSSLDisabled(), config.CACertFile(), config.ClientCertFile(), config.ClientKeyFile())
+}
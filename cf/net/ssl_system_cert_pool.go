@@ -0,0 +1,16 @@
+//go:build go1.7
+// +build go1.7
+
+package net
+
+import "crypto/x509"
+
+// systemCertPool returns a copy of the system's CA certificates, or an empty
+// pool when they cannot be read.
+func systemCertPool() *x509.CertPool {
+	certPool, err := x509.SystemCertPool()
+	if err != nil {
+		return x509.NewCertPool()
+	}
+	return certPool
+}
@@ -0,0 +1,13 @@
+//go:build !go1.7
+// +build !go1.7
+
+package net
+
+import "crypto/x509"
+
+// systemCertPool returns an empty pool, as the system's CA certificates
+// cannot be copied before Go 1.7. Built with Go 1.6, a CA certificate file
+// is trusted instead of the system's CA certificates, not besides them.
+func systemCertPool() *x509.CertPool {
+	return x509.NewCertPool()
+}
@@ -0,0 +1,60 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/models"
+)
+
+// Application is the schema of each entry printed by `cf apps`.
+type Application struct {
+	Name             string   `json:"name" yaml:"name"`
+	GUID             string   `json:"guid" yaml:"guid"`
+	State            string   `json:"state" yaml:"state"`
+	Instances        int      `json:"instances" yaml:"instances"`
+	RunningInstances int      `json:"running_instances" yaml:"running_instances"`
+	MemoryInMB       int64    `json:"memory_in_mb" yaml:"memory_in_mb"`
+	DiskQuotaInMB    int64    `json:"disk_quota_in_mb" yaml:"disk_quota_in_mb"`
+	URLs             []string `json:"urls" yaml:"urls"`
+}
+
+func NewApplication(app models.Application) Application {
+	urls := []string{}
+	for _, route := range app.Routes {
+		urls = append(urls, route.URL())
+	}
+
+	return Application{
+		Name:             app.Name,
+		GUID:             app.GUID,
+		State:            app.State,
+		Instances:        app.InstanceCount,
+		RunningInstances: app.RunningInstances,
+		MemoryInMB:       app.Memory,
+		DiskQuotaInMB:    app.DiskQuota,
+		URLs:             urls,
+	}
+}
+
+// Event is the schema of each entry printed by `cf events`. Timestamp is in
+// RFC 3339 format, and ActorName is empty when the Cloud Controller only
+// reports the actor's GUID.
+type Event struct {
+	GUID        string `json:"guid" yaml:"guid"`
+	Timestamp   string `json:"timestamp" yaml:"timestamp"`
+	Name        string `json:"name" yaml:"name"`
+	Actor       string `json:"actor" yaml:"actor"`
+	ActorName   string `json:"actor_name" yaml:"actor_name"`
+	Description string `json:"description" yaml:"description"`
+}
+
+func NewEvent(event models.EventFields) Event {
+	return Event{
+		GUID:        event.GUID,
+		Timestamp:   event.Timestamp.Format(time.RFC3339),
+		Name:        event.Name,
+		Actor:       event.Actor,
+		ActorName:   event.ActorName,
+		Description: event.Description,
+	}
+}
@@ -0,0 +1,57 @@
+package presenters
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/models"
+)
+
+// Organization is the schema of each entry printed by `cf orgs`.
+type Organization struct {
+	Name string `json:"name" yaml:"name"`
+	GUID string `json:"guid" yaml:"guid"`
+}
+
+func NewOrganization(org models.Organization) Organization {
+	return Organization{
+		Name: org.Name,
+		GUID: org.GUID,
+	}
+}
+
+// Space is the schema of each entry printed by `cf spaces`.
+type Space struct {
+	Name string `json:"name" yaml:"name"`
+	GUID string `json:"guid" yaml:"guid"`
+}
+
+func NewSpace(space models.Space) Space {
+	return Space{
+		Name: space.Name,
+		GUID: space.GUID,
+	}
+}
+
+// User is the schema of each entry printed by `cf org-users` and
+// `cf space-users`. Roles holds the Cloud Controller names of the user's
+// roles in the org or space, such as "OrgManager" or "SpaceDeveloper".
+type User struct {
+	Username string   `json:"username" yaml:"username"`
+	GUID     string   `json:"guid" yaml:"guid"`
+	IsAdmin  bool     `json:"is_admin" yaml:"is_admin"`
+	Roles    []string `json:"roles" yaml:"roles"`
+}
+
+func NewUser(user models.UserFields, roles []models.Role) User {
+	roleNames := []string{}
+	for _, role := range roles {
+		roleNames = append(roleNames, strings.TrimPrefix(role.ToString(), "Role"))
+	}
+
+	return User{
+		Username: user.Username,
+		GUID:     user.GUID,
+		IsAdmin:  user.IsAdmin,
+		Roles:    roleNames,
+	}
+}
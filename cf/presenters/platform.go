@@ -0,0 +1,172 @@
+package presenters
+
+import "github.com/cloudfoundry/cli/cf/models"
+
+// Buildpack is the schema of each entry printed by `cf buildpacks`.
+// Position, Enabled and Locked are null when the Cloud Controller does not
+// report them.
+type Buildpack struct {
+	Name     string `json:"name" yaml:"name"`
+	GUID     string `json:"guid" yaml:"guid"`
+	Position *int   `json:"position" yaml:"position"`
+	Enabled  *bool  `json:"enabled" yaml:"enabled"`
+	Locked   *bool  `json:"locked" yaml:"locked"`
+	Filename string `json:"filename" yaml:"filename"`
+}
+
+func NewBuildpack(buildpack models.Buildpack) Buildpack {
+	return Buildpack{
+		Name:     buildpack.Name,
+		GUID:     buildpack.GUID,
+		Position: buildpack.Position,
+		Enabled:  buildpack.Enabled,
+		Locked:   buildpack.Locked,
+		Filename: buildpack.Filename,
+	}
+}
+
+// Stack is the schema of each entry printed by `cf stacks`, and of the stack
+// printed by `cf stack`.
+type Stack struct {
+	Name        string `json:"name" yaml:"name"`
+	GUID        string `json:"guid" yaml:"guid"`
+	Description string `json:"description" yaml:"description"`
+}
+
+func NewStack(stack models.Stack) Stack {
+	return Stack{
+		Name:        stack.Name,
+		GUID:        stack.GUID,
+		Description: stack.Description,
+	}
+}
+
+// Quota is the schema of each entry printed by `cf quotas`, and of the quota
+// printed by `cf quota`. Limits of -1 are unlimited.
+type Quota struct {
+	Name               string `json:"name" yaml:"name"`
+	GUID               string `json:"guid" yaml:"guid"`
+	TotalMemoryInMB    int64  `json:"total_memory_in_mb" yaml:"total_memory_in_mb"`
+	InstanceMemoryInMB int64  `json:"instance_memory_in_mb" yaml:"instance_memory_in_mb"`
+	Routes             int    `json:"routes" yaml:"routes"`
+	ServiceInstances   int    `json:"service_instances" yaml:"service_instances"`
+	PaidServicePlans   bool   `json:"paid_service_plans" yaml:"paid_service_plans"`
+	AppInstances       int    `json:"app_instances" yaml:"app_instances"`
+	ReservedRoutePorts int64  `json:"reserved_route_ports" yaml:"reserved_route_ports"`
+}
+
+func NewQuota(quota models.QuotaFields) Quota {
+	reservedRoutePorts, _ := quota.ReservedRoutePorts.Int64()
+
+	return Quota{
+		Name:               quota.Name,
+		GUID:               quota.GUID,
+		TotalMemoryInMB:    quota.MemoryLimit,
+		InstanceMemoryInMB: quota.InstanceMemoryLimit,
+		Routes:             quota.RoutesLimit,
+		ServiceInstances:   quota.ServicesLimit,
+		PaidServicePlans:   quota.NonBasicServicesAllowed,
+		AppInstances:       quota.AppInstanceLimit,
+		ReservedRoutePorts: reservedRoutePorts,
+	}
+}
+
+// NewSpaceQuota builds the record printed by `cf space-quotas` and
+// `cf space-quota`, which share the schema of `cf quotas`.
+func NewSpaceQuota(quota models.SpaceQuota) Quota {
+	reservedRoutePorts, _ := quota.ReservedRoutePortsLimit.Int64()
+
+	return Quota{
+		Name:               quota.Name,
+		GUID:               quota.GUID,
+		TotalMemoryInMB:    quota.MemoryLimit,
+		InstanceMemoryInMB: quota.InstanceMemoryLimit,
+		Routes:             quota.RoutesLimit,
+		ServiceInstances:   quota.ServicesLimit,
+		PaidServicePlans:   quota.NonBasicServicesAllowed,
+		AppInstances:       quota.AppInstanceLimit,
+		ReservedRoutePorts: reservedRoutePorts,
+	}
+}
+
+// FeatureFlag is the schema of each entry printed by `cf feature-flags`, and
+// of the flag printed by `cf feature-flag`.
+type FeatureFlag struct {
+	Name    string `json:"name" yaml:"name"`
+	Enabled bool   `json:"enabled" yaml:"enabled"`
+}
+
+func NewFeatureFlag(flag models.FeatureFlag) FeatureFlag {
+	return FeatureFlag{
+		Name:    flag.Name,
+		Enabled: flag.Enabled,
+	}
+}
+
+// EnvironmentVariable is the schema of each entry printed by
+// `cf running-environment-variable-group` and
+// `cf staging-environment-variable-group`.
+type EnvironmentVariable struct {
+	Name  string `json:"name" yaml:"name"`
+	Value string `json:"value" yaml:"value"`
+}
+
+func NewEnvironmentVariable(envVar models.EnvironmentVariable) EnvironmentVariable {
+	return EnvironmentVariable{
+		Name:  envVar.Name,
+		Value: envVar.Value,
+	}
+}
+
+// RouterGroup is the schema of each entry printed by `cf router-groups`.
+type RouterGroup struct {
+	Name string `json:"name" yaml:"name"`
+	GUID string `json:"guid" yaml:"guid"`
+	Type string `json:"type" yaml:"type"`
+}
+
+func NewRouterGroup(group models.RouterGroup) RouterGroup {
+	return RouterGroup{
+		Name: group.Name,
+		GUID: group.GUID,
+		Type: group.Type,
+	}
+}
+
+// SecurityGroup is the schema of each entry printed by `cf security-groups`,
+// and of the group printed by `cf security-group`. Rules are as the Cloud
+// Controller returns them. Spaces lists the spaces the group is bound to;
+// groups bound only as staging or running defaults have none.
+type SecurityGroup struct {
+	Name   string                   `json:"name" yaml:"name"`
+	GUID   string                   `json:"guid" yaml:"guid"`
+	Rules  []map[string]interface{} `json:"rules" yaml:"rules"`
+	Spaces []SecurityGroupSpace     `json:"spaces" yaml:"spaces"`
+}
+
+type SecurityGroupSpace struct {
+	Name         string `json:"name" yaml:"name"`
+	GUID         string `json:"guid" yaml:"guid"`
+	Organization string `json:"organization" yaml:"organization"`
+}
+
+func NewSecurityGroup(group models.SecurityGroup) SecurityGroup {
+	spaces := []SecurityGroupSpace{}
+	for _, space := range group.Spaces {
+		spaces = append(spaces, SecurityGroupSpace{
+			Name:         space.Name,
+			GUID:         space.GUID,
+			Organization: space.Organization.Name,
+		})
+	}
+
+	rules := []map[string]interface{}{}
+	rules = append(rules, group.Rules...)
+
+	return SecurityGroup{
+		Name:   group.Name,
+		GUID:   group.GUID,
+		Rules:  rules,
+		Spaces: spaces,
+	}
+}
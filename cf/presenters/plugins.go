@@ -0,0 +1,116 @@
+package presenters
+
+import (
+	"fmt"
+
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/models"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// Plugin is the schema of each entry printed by `cf plugins`. Version is
+// empty when the plugin does not report one, and SHA1 is only filled in
+// with --checksum.
+type Plugin struct {
+	Name     string          `json:"name" yaml:"name"`
+	Version  string          `json:"version" yaml:"version"`
+	Location string          `json:"location" yaml:"location"`
+	SHA1     string          `json:"sha1" yaml:"sha1"`
+	Commands []PluginCommand `json:"commands" yaml:"commands"`
+}
+
+type PluginCommand struct {
+	Name     string `json:"name" yaml:"name"`
+	Alias    string `json:"alias" yaml:"alias"`
+	HelpText string `json:"help_text" yaml:"help_text"`
+}
+
+func NewPlugin(name string, metadata pluginconfig.PluginMetadata, sha1 string) Plugin {
+	version := ""
+	if metadata.Version != (plugin.VersionType{}) {
+		version = formatVersion(metadata.Version)
+	}
+
+	commands := []PluginCommand{}
+	for _, command := range metadata.Commands {
+		commands = append(commands, PluginCommand{
+			Name:     command.Name,
+			Alias:    command.Alias,
+			HelpText: command.HelpText,
+		})
+	}
+
+	return Plugin{
+		Name:     name,
+		Version:  version,
+		Location: metadata.Location,
+		SHA1:     sha1,
+		Commands: commands,
+	}
+}
+
+// PluginUpdate is the schema of each entry printed by
+// `cf plugins --outdated`.
+type PluginUpdate struct {
+	Name          string `json:"name" yaml:"name"`
+	Version       string `json:"version" yaml:"version"`
+	LatestVersion string `json:"latest_version" yaml:"latest_version"`
+	Repository    string `json:"repository" yaml:"repository"`
+}
+
+func NewPluginUpdate(name string, installed, latest plugin.VersionType, repoName string) PluginUpdate {
+	return PluginUpdate{
+		Name:          name,
+		Version:       formatVersion(installed),
+		LatestVersion: formatVersion(latest),
+		Repository:    repoName,
+	}
+}
+
+// PluginRepo is the schema of each entry printed by `cf list-plugin-repos`.
+type PluginRepo struct {
+	Name string `json:"name" yaml:"name"`
+	URL  string `json:"url" yaml:"url"`
+}
+
+func NewPluginRepo(repo models.PluginRepo) PluginRepo {
+	return PluginRepo{
+		Name: repo.Name,
+		URL:  repo.URL,
+	}
+}
+
+// RepoPlugin is the schema of each entry printed by `cf repo-plugins`.
+type RepoPlugin struct {
+	Repository  string `json:"repository" yaml:"repository"`
+	Name        string `json:"name" yaml:"name"`
+	Version     string `json:"version" yaml:"version"`
+	Description string `json:"description" yaml:"description"`
+}
+
+func NewRepoPlugin(repoName string, repoPlugin clipr.Plugin) RepoPlugin {
+	return RepoPlugin{
+		Repository:  repoName,
+		Name:        repoPlugin.Name,
+		Version:     repoPlugin.Version,
+		Description: repoPlugin.Description,
+	}
+}
+
+// PluginKey is the schema of each entry printed by `cf list-plugin-keys`.
+type PluginKey struct {
+	Name      string `json:"name" yaml:"name"`
+	PublicKey string `json:"public_key" yaml:"public_key"`
+}
+
+func NewPluginKey(key models.PluginKey) PluginKey {
+	return PluginKey{
+		Name:      key.Name,
+		PublicKey: key.PublicKey,
+	}
+}
+
+func formatVersion(version plugin.VersionType) string {
+	return fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Build)
+}
@@ -0,0 +1,9 @@
+// Package presenters defines the records emitted by listing commands when
+// they are run with the global --output json or --output yaml option.
+//
+// Each type is built from the corresponding cf/models struct rather than
+// from the printed table cells, and its field names are part of the CLI's
+// public interface: fields may be added, but existing fields are not
+// renamed or removed. Sizes are always in megabytes and limits use -1 for
+// "unlimited", mirroring the Cloud Controller API.
+package presenters
@@ -0,0 +1,141 @@
+package presenters_test
+
+import (
+	"encoding/json"
+
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/presenters"
+	"github.com/cloudfoundry/cli/plugin"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Presenters", func() {
+	Describe("NewApplication", func() {
+		It("builds the record from the app summary", func() {
+			app := models.Application{}
+			app.Name = "my-app"
+			app.GUID = "my-app-guid"
+			app.State = "started"
+			app.InstanceCount = 2
+			app.RunningInstances = 1
+			app.Memory = 256
+			app.DiskQuota = 1024
+			app.Routes = []models.RouteSummary{
+				{Host: "my-app", Domain: models.DomainFields{Name: "example.com"}, Path: "/path"},
+			}
+
+			Expect(presenters.NewApplication(app)).To(Equal(presenters.Application{
+				Name:             "my-app",
+				GUID:             "my-app-guid",
+				State:            "started",
+				Instances:        2,
+				RunningInstances: 1,
+				MemoryInMB:       256,
+				DiskQuotaInMB:    1024,
+				URLs:             []string{"my-app.example.com/path"},
+			}))
+		})
+
+		It("encodes an app without routes with an empty list of urls", func() {
+			encoded, err := json.Marshal(presenters.NewApplication(models.Application{}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(encoded)).To(ContainSubstring(`"urls":[]`))
+		})
+	})
+
+	Describe("NewServiceInstance", func() {
+		It("leaves the service empty for user-provided instances", func() {
+			instance := models.ServiceInstance{}
+			instance.Name = "my-ups"
+			instance.ApplicationNames = []string{"app-1"}
+
+			record := presenters.NewServiceInstance(instance)
+			Expect(record.UserProvided).To(BeTrue())
+			Expect(record.Service).To(BeEmpty())
+			Expect(record.BoundApps).To(Equal([]string{"app-1"}))
+		})
+	})
+
+	Describe("NewServiceOffering", func() {
+		It("skips plans without a name", func() {
+			offering := models.ServiceOffering{
+				ServiceOfferingFields: models.ServiceOfferingFields{Label: "mysql"},
+				Plans: []models.ServicePlanFields{
+					{Name: "small", Free: true},
+					{},
+				},
+			}
+
+			record := presenters.NewServiceOffering(offering)
+			Expect(record.Label).To(Equal("mysql"))
+			Expect(record.Plans).To(Equal([]presenters.ServicePlan{{Name: "small", Free: true}}))
+		})
+	})
+
+	Describe("NewUser", func() {
+		It("names the roles the way the Cloud Controller does", func() {
+			user := models.UserFields{Username: "user1", GUID: "user1-guid"}
+
+			record := presenters.NewUser(user, []models.Role{models.RoleOrgManager, models.RoleSpaceDeveloper})
+			Expect(record.Roles).To(Equal([]string{"OrgManager", "SpaceDeveloper"}))
+		})
+	})
+
+	Describe("NewBrokerAccess", func() {
+		It("reports the access of each plan", func() {
+			broker := models.ServiceBroker{
+				Name: "my-broker",
+				Services: []models.ServiceOffering{
+					{
+						ServiceOfferingFields: models.ServiceOfferingFields{Label: "mysql"},
+						Plans: []models.ServicePlanFields{
+							{Name: "public", Public: true},
+							{Name: "limited", OrgNames: []string{"org-1"}},
+							{Name: "private"},
+						},
+					},
+				},
+			}
+
+			record := presenters.NewBrokerAccess(broker)
+			Expect(record.Broker).To(Equal("my-broker"))
+			Expect(record.Services[0].Plans).To(Equal([]presenters.PlanAccess{
+				{Name: "public", Access: "all", Orgs: []string{}},
+				{Name: "limited", Access: "limited", Orgs: []string{"org-1"}},
+				{Name: "private", Access: "none", Orgs: []string{}},
+			}))
+		})
+	})
+
+	Describe("NewPlugin", func() {
+		It("leaves the version empty when the plugin does not report one", func() {
+			metadata := pluginconfig.PluginMetadata{
+				Location: "path/to/plugin",
+				Commands: []plugin.Command{{Name: "cmd", Alias: "c", HelpText: "help"}},
+			}
+
+			record := presenters.NewPlugin("my-plugin", metadata, "")
+			Expect(record.Version).To(BeEmpty())
+			Expect(record.Commands).To(Equal([]presenters.PluginCommand{{Name: "cmd", Alias: "c", HelpText: "help"}}))
+		})
+	})
+
+	Describe("NewQuota", func() {
+		It("reports reserved route ports as a number", func() {
+			quota := models.QuotaFields{
+				Name:                "default",
+				MemoryLimit:         10240,
+				InstanceMemoryLimit: -1,
+				ReservedRoutePorts:  json.Number("4"),
+			}
+
+			record := presenters.NewQuota(quota)
+			Expect(record.TotalMemoryInMB).To(Equal(int64(10240)))
+			Expect(record.InstanceMemoryInMB).To(Equal(int64(-1)))
+			Expect(record.ReservedRoutePorts).To(Equal(int64(4)))
+		})
+	})
+})
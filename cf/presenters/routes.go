@@ -0,0 +1,54 @@
+package presenters
+
+import "github.com/cloudfoundry/cli/cf/models"
+
+// Route is the schema of each entry printed by `cf routes`. Port is 0 for
+// HTTP routes and Type is empty unless the domain belongs to a router
+// group.
+type Route struct {
+	GUID    string   `json:"guid" yaml:"guid"`
+	Space   string   `json:"space" yaml:"space"`
+	Host    string   `json:"host" yaml:"host"`
+	Domain  string   `json:"domain" yaml:"domain"`
+	Port    int      `json:"port" yaml:"port"`
+	Path    string   `json:"path" yaml:"path"`
+	Type    string   `json:"type" yaml:"type"`
+	Apps    []string `json:"apps" yaml:"apps"`
+	Service string   `json:"service" yaml:"service"`
+}
+
+func NewRoute(route models.Route, domain models.DomainFields) Route {
+	apps := []string{}
+	for _, app := range route.Apps {
+		apps = append(apps, app.Name)
+	}
+
+	return Route{
+		GUID:    route.GUID,
+		Space:   route.Space.Name,
+		Host:    route.Host,
+		Domain:  route.Domain.Name,
+		Port:    route.Port,
+		Path:    route.Path,
+		Type:    domain.RouterGroupType,
+		Apps:    apps,
+		Service: route.ServiceInstance.Name,
+	}
+}
+
+// Domain is the schema of each entry printed by `cf domains`.
+type Domain struct {
+	Name   string `json:"name" yaml:"name"`
+	GUID   string `json:"guid" yaml:"guid"`
+	Shared bool   `json:"shared" yaml:"shared"`
+	Type   string `json:"type" yaml:"type"`
+}
+
+func NewDomain(domain models.DomainFields) Domain {
+	return Domain{
+		Name:   domain.Name,
+		GUID:   domain.GUID,
+		Shared: domain.Shared,
+		Type:   domain.RouterGroupType,
+	}
+}
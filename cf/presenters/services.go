@@ -0,0 +1,173 @@
+package presenters
+
+import "github.com/cloudfoundry/cli/cf/models"
+
+// ServiceInstance is the schema of each entry printed by `cf services`.
+// Service is empty for user-provided service instances.
+type ServiceInstance struct {
+	Name          string        `json:"name" yaml:"name"`
+	GUID          string        `json:"guid" yaml:"guid"`
+	Service       string        `json:"service" yaml:"service"`
+	Plan          string        `json:"plan" yaml:"plan"`
+	BoundApps     []string      `json:"bound_apps" yaml:"bound_apps"`
+	LastOperation LastOperation `json:"last_operation" yaml:"last_operation"`
+	UserProvided  bool          `json:"user_provided" yaml:"user_provided"`
+}
+
+type LastOperation struct {
+	Type  string `json:"type" yaml:"type"`
+	State string `json:"state" yaml:"state"`
+}
+
+func NewServiceInstance(instance models.ServiceInstance) ServiceInstance {
+	boundApps := []string{}
+	boundApps = append(boundApps, instance.ApplicationNames...)
+
+	return ServiceInstance{
+		Name:      instance.Name,
+		GUID:      instance.GUID,
+		Service:   instance.ServiceOffering.Label,
+		Plan:      instance.ServicePlan.Name,
+		BoundApps: boundApps,
+		LastOperation: LastOperation{
+			Type:  instance.LastOperation.Type,
+			State: instance.LastOperation.State,
+		},
+		UserProvided: instance.IsUserProvided(),
+	}
+}
+
+// ServiceOffering is the schema of each entry printed by `cf marketplace`.
+type ServiceOffering struct {
+	Label       string        `json:"label" yaml:"label"`
+	GUID        string        `json:"guid" yaml:"guid"`
+	Description string        `json:"description" yaml:"description"`
+	Plans       []ServicePlan `json:"plans" yaml:"plans"`
+}
+
+type ServicePlan struct {
+	Name        string `json:"name" yaml:"name"`
+	GUID        string `json:"guid" yaml:"guid"`
+	Description string `json:"description" yaml:"description"`
+	Free        bool   `json:"free" yaml:"free"`
+}
+
+func NewServiceOffering(offering models.ServiceOffering) ServiceOffering {
+	plans := []ServicePlan{}
+	for _, plan := range offering.Plans {
+		if plan.Name == "" {
+			continue
+		}
+		plans = append(plans, ServicePlan{
+			Name:        plan.Name,
+			GUID:        plan.GUID,
+			Description: plan.Description,
+			Free:        plan.Free,
+		})
+	}
+
+	return ServiceOffering{
+		Label:       offering.Label,
+		GUID:        offering.GUID,
+		Description: offering.Description,
+		Plans:       plans,
+	}
+}
+
+// ServiceBroker is the schema of each entry printed by `cf service-brokers`.
+type ServiceBroker struct {
+	Name string `json:"name" yaml:"name"`
+	GUID string `json:"guid" yaml:"guid"`
+	URL  string `json:"url" yaml:"url"`
+}
+
+func NewServiceBroker(broker models.ServiceBroker) ServiceBroker {
+	return ServiceBroker{
+		Name: broker.Name,
+		GUID: broker.GUID,
+		URL:  broker.URL,
+	}
+}
+
+// ServiceKey is the schema of each entry printed by `cf service-keys`.
+// Credentials are left out; use `cf service-key` to see them.
+type ServiceKey struct {
+	Name string `json:"name" yaml:"name"`
+	GUID string `json:"guid" yaml:"guid"`
+}
+
+func NewServiceKey(key models.ServiceKey) ServiceKey {
+	return ServiceKey{
+		Name: key.Fields.Name,
+		GUID: key.Fields.GUID,
+	}
+}
+
+// ServiceAuthToken is the schema of each entry printed by
+// `cf service-auth-tokens`. The token itself is left out.
+type ServiceAuthToken struct {
+	Label    string `json:"label" yaml:"label"`
+	Provider string `json:"provider" yaml:"provider"`
+	GUID     string `json:"guid" yaml:"guid"`
+}
+
+func NewServiceAuthToken(token models.ServiceAuthTokenFields) ServiceAuthToken {
+	return ServiceAuthToken{
+		Label:    token.Label,
+		Provider: token.Provider,
+		GUID:     token.GUID,
+	}
+}
+
+// BrokerAccess is the schema of each entry printed by `cf service-access`.
+// Access is "all", "limited" or "none", and Orgs lists the orgs a limited
+// plan is available to.
+type BrokerAccess struct {
+	Broker   string          `json:"broker" yaml:"broker"`
+	Services []ServiceAccess `json:"services" yaml:"services"`
+}
+
+type ServiceAccess struct {
+	Label string       `json:"label" yaml:"label"`
+	Plans []PlanAccess `json:"plans" yaml:"plans"`
+}
+
+type PlanAccess struct {
+	Name   string   `json:"name" yaml:"name"`
+	Access string   `json:"access" yaml:"access"`
+	Orgs   []string `json:"orgs" yaml:"orgs"`
+}
+
+func NewBrokerAccess(broker models.ServiceBroker) BrokerAccess {
+	services := []ServiceAccess{}
+	for _, service := range broker.Services {
+		plans := []PlanAccess{}
+		for _, plan := range service.Plans {
+			orgs := []string{}
+			orgs = append(orgs, plan.OrgNames...)
+
+			access := "none"
+			if plan.Public {
+				access = "all"
+			} else if len(orgs) > 0 {
+				access = "limited"
+			}
+
+			plans = append(plans, PlanAccess{
+				Name:   plan.Name,
+				Access: access,
+				Orgs:   orgs,
+			})
+		}
+
+		services = append(services, ServiceAccess{
+			Label: service.Label,
+			Plans: plans,
+		})
+	}
+
+	return BrokerAccess{
+		Broker:   broker.Name,
+		Services: services,
+	}
+}
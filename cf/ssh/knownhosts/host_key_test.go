@@ -0,0 +1,49 @@
+package knownhosts_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LoadOrCreateHostKey", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "host-key")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("creates a key the first time and reads it after that", func() {
+		path := filepath.Join(dir, ".cf", "ssh_proxy_host_key")
+
+		created, err := knownhosts.LoadOrCreateHostKey(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+		loaded, err := knownhosts.LoadOrCreateHostKey(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.PublicKey().Marshal()).To(Equal(created.PublicKey().Marshal()))
+	})
+
+	It("returns an error when the key cannot be parsed", func() {
+		path := filepath.Join(dir, "ssh_proxy_host_key")
+		Expect(ioutil.WriteFile(path, []byte("garbage"), 0600)).To(Succeed())
+
+		_, err := knownhosts.LoadOrCreateHostKey(path)
+		Expect(err).To(HaveOccurred())
+	})
+})
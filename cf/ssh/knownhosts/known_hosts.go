@@ -0,0 +1,221 @@
+package knownhosts
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Entry is the trusted host key of the SSH proxy of an API endpoint.
+type Entry struct {
+	APIEndpoint string
+	SSHEndpoint string
+	Key         ssh.PublicKey
+}
+
+//go:generate counterfeiter . KnownHosts
+
+// KnownHosts records the host key of each SSH proxy the first time cf
+// connects to it, so that a changed key is noticed on later connections.
+type KnownHosts interface {
+	Lookup(apiEndpoint string, sshEndpoint string) (ssh.PublicKey, error)
+	Add(apiEndpoint string, sshEndpoint string, key ssh.PublicKey) error
+	Remove(apiEndpoint string, sshEndpoint string) (int, error)
+	List() ([]Entry, error)
+}
+
+// NewKnownHosts stores the keys in a known_hosts style file at path. Each
+// line holds the API endpoint, the SSH endpoint, and the key in the format
+// of an OpenSSH authorized_keys line.
+func NewKnownHosts(path string) KnownHosts {
+	return &knownHostsFile{
+		path:  path,
+		mutex: new(sync.Mutex),
+	}
+}
+
+type knownHostsFile struct {
+	path  string
+	mutex *sync.Mutex
+}
+
+// Lookup returns the trusted key of sshEndpoint for apiEndpoint, or nil when
+// there is none.
+func (k *knownHostsFile) Lookup(apiEndpoint string, sshEndpoint string) (ssh.PublicKey, error) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	entries, err := k.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.APIEndpoint == apiEndpoint && entry.SSHEndpoint == sshEndpoint {
+			return entry.Key, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Add trusts key for sshEndpoint of apiEndpoint, replacing the key trusted
+// before.
+func (k *knownHostsFile) Add(apiEndpoint string, sshEndpoint string, key ssh.PublicKey) error {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	entries, err := k.load()
+	if err != nil {
+		return err
+	}
+
+	updated := []Entry{}
+	for _, entry := range entries {
+		if entry.APIEndpoint != apiEndpoint || entry.SSHEndpoint != sshEndpoint {
+			updated = append(updated, entry)
+		}
+	}
+	updated = append(updated, Entry{APIEndpoint: apiEndpoint, SSHEndpoint: sshEndpoint, Key: key})
+
+	return k.save(updated)
+}
+
+// Remove forgets the key of sshEndpoint for apiEndpoint, or every key of
+// apiEndpoint when sshEndpoint is empty. It returns how many keys were
+// forgotten.
+func (k *knownHostsFile) Remove(apiEndpoint string, sshEndpoint string) (int, error) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	entries, err := k.load()
+	if err != nil {
+		return 0, err
+	}
+
+	kept := []Entry{}
+	for _, entry := range entries {
+		if entry.APIEndpoint == apiEndpoint && (sshEndpoint == "" || entry.SSHEndpoint == sshEndpoint) {
+			continue
+		}
+		kept = append(kept, entry)
+	}
+
+	removed := len(entries) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+
+	return removed, k.save(kept)
+}
+
+// List returns every trusted key, sorted by API and SSH endpoint.
+func (k *knownHostsFile) List() ([]Entry, error) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	entries, err := k.load()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Sort(byEndpoint(entries))
+	return entries, nil
+}
+
+// load reads the entries in the file. A missing file has no entries, and
+// lines that cannot be parsed are skipped.
+func (k *knownHostsFile) load() ([]Entry, error) {
+	entries := []Entry{}
+
+	contents, err := ioutil.ReadFile(k.path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(fields[2]))
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, Entry{APIEndpoint: fields[0], SSHEndpoint: fields[1], Key: key})
+	}
+
+	return entries, scanner.Err()
+}
+
+func (k *knownHostsFile) save(entries []Entry) error {
+	err := os.MkdirAll(filepath.Dir(k.path), 0700)
+	if err != nil {
+		return err
+	}
+
+	buffer := &bytes.Buffer{}
+	for _, entry := range entries {
+		fmt.Fprintf(buffer, "%s %s %s", entry.APIEndpoint, entry.SSHEndpoint, ssh.MarshalAuthorizedKey(entry.Key))
+	}
+
+	// The keys are written next to the file and renamed over it, so that a
+	// crash or another cf ssh saving at the same time cannot leave it cut
+	// short.
+	tmpFile, err := ioutil.TempFile(filepath.Dir(k.path), filepath.Base(k.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(buffer.Bytes())
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), k.path)
+}
+
+// Fingerprint is the SHA1 fingerprint of key, in the format the Cloud
+// Controller advertises the SSH proxy's fingerprint in.
+func Fingerprint(key ssh.PublicKey) string {
+	sum := sha1.Sum(key.Marshal())
+	return strings.Replace(fmt.Sprintf("% x", sum), " ", ":", -1)
+}
+
+type byEndpoint []Entry
+
+func (e byEndpoint) Len() int      { return len(e) }
+func (e byEndpoint) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
+func (e byEndpoint) Less(i, j int) bool {
+	if e[i].APIEndpoint != e[j].APIEndpoint {
+		return e[i].APIEndpoint < e[j].APIEndpoint
+	}
+	return e[i].SSHEndpoint < e[j].SSHEndpoint
+}
@@ -0,0 +1,168 @@
+package knownhosts_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KnownHosts", func() {
+	var (
+		dir        string
+		path       string
+		knownHosts knownhosts.KnownHosts
+
+		key, otherKey ssh.PublicKey
+	)
+
+	newKey := func() ssh.PublicKey {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+		Expect(err).NotTo(HaveOccurred())
+		return publicKey
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "known-hosts")
+		Expect(err).NotTo(HaveOccurred())
+
+		path = filepath.Join(dir, ".cf", "known_hosts")
+		knownHosts = knownhosts.NewKnownHosts(path)
+
+		key = newKey()
+		otherKey = newKey()
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("has no keys when the file does not exist", func() {
+		found, err := knownHosts.Lookup("https://api.example.com", "ssh.example.com:2222")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeNil())
+
+		entries, err := knownHosts.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("remembers added keys per API endpoint", func() {
+		Expect(knownHosts.Add("https://api.example.com", "ssh.example.com:2222", key)).To(Succeed())
+		Expect(knownHosts.Add("https://api.other.com", "ssh.example.com:2222", otherKey)).To(Succeed())
+
+		found, err := knownhosts.NewKnownHosts(path).Lookup("https://api.example.com", "ssh.example.com:2222")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.Marshal()).To(Equal(key.Marshal()))
+
+		found, err = knownHosts.Lookup("https://api.other.com", "ssh.example.com:2222")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.Marshal()).To(Equal(otherKey.Marshal()))
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	It("replaces the key of an endpoint when it is added again", func() {
+		Expect(knownHosts.Add("https://api.example.com", "ssh.example.com:2222", key)).To(Succeed())
+		Expect(knownHosts.Add("https://api.example.com", "ssh.example.com:2222", otherKey)).To(Succeed())
+
+		entries, err := knownHosts.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Key.Marshal()).To(Equal(otherKey.Marshal()))
+	})
+
+	It("replaces the file rather than writing over it", func() {
+		Expect(knownHosts.Add("https://api.example.com", "ssh.example.com:2222", key)).To(Succeed())
+		before, err := os.Open(path)
+		Expect(err).NotTo(HaveOccurred())
+		defer before.Close()
+
+		Expect(knownHosts.Add("https://api.other.com", "ssh.example.com:2222", otherKey)).To(Succeed())
+
+		contents, err := ioutil.ReadAll(before)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).NotTo(ContainSubstring("https://api.other.com"))
+
+		files, err := ioutil.ReadDir(filepath.Dir(path))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+	})
+
+	It("lists the keys sorted by endpoint", func() {
+		Expect(knownHosts.Add("https://b.example.com", "ssh.b.example.com:2222", key)).To(Succeed())
+		Expect(knownHosts.Add("https://a.example.com", "ssh.a.example.com:2222", otherKey)).To(Succeed())
+
+		entries, err := knownHosts.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].APIEndpoint).To(Equal("https://a.example.com"))
+		Expect(entries[0].SSHEndpoint).To(Equal("ssh.a.example.com:2222"))
+		Expect(entries[1].APIEndpoint).To(Equal("https://b.example.com"))
+	})
+
+	Describe("Remove", func() {
+		BeforeEach(func() {
+			Expect(knownHosts.Add("https://api.example.com", "ssh.example.com:2222", key)).To(Succeed())
+			Expect(knownHosts.Add("https://api.example.com", "ssh2.example.com:2222", key)).To(Succeed())
+			Expect(knownHosts.Add("https://api.other.com", "ssh.example.com:2222", otherKey)).To(Succeed())
+		})
+
+		It("forgets the key of one SSH endpoint", func() {
+			removed, err := knownHosts.Remove("https://api.example.com", "ssh.example.com:2222")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(Equal(1))
+
+			entries, err := knownHosts.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+		})
+
+		It("forgets every key of the API endpoint when no SSH endpoint is given", func() {
+			removed, err := knownHosts.Remove("https://api.example.com", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(Equal(2))
+
+			entries, err := knownHosts.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].APIEndpoint).To(Equal("https://api.other.com"))
+		})
+
+		It("returns zero when nothing matches", func() {
+			removed, err := knownHosts.Remove("https://api.unknown.com", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(Equal(0))
+		})
+	})
+
+	It("skips comments and lines it cannot parse", func() {
+		Expect(knownHosts.Add("https://api.example.com", "ssh.example.com:2222", key)).To(Succeed())
+
+		contents, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		contents = append([]byte("# trusted keys\ngarbage\n"), contents...)
+		Expect(ioutil.WriteFile(path, contents, 0600)).To(Succeed())
+
+		entries, err := knownHosts.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("formats fingerprints like the Cloud Controller", func() {
+		Expect(knownhosts.Fingerprint(key)).To(MatchRegexp(`^([0-9a-f]{2}:){19}[0-9a-f]{2}$`))
+	})
+})
@@ -0,0 +1,13 @@
+package knownhosts_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestKnownHosts(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "KnownHosts Suite")
+}
@@ -0,0 +1,178 @@
+// This file was generated by counterfeiter
+package knownhostsfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
+	"golang.org/x/crypto/ssh"
+)
+
+type FakeKnownHosts struct {
+	LookupStub        func(apiEndpoint string, sshEndpoint string) (ssh.PublicKey, error)
+	lookupMutex       sync.RWMutex
+	lookupArgsForCall []struct {
+		apiEndpoint string
+		sshEndpoint string
+	}
+	lookupReturns struct {
+		result1 ssh.PublicKey
+		result2 error
+	}
+	AddStub        func(apiEndpoint string, sshEndpoint string, key ssh.PublicKey) error
+	addMutex       sync.RWMutex
+	addArgsForCall []struct {
+		apiEndpoint string
+		sshEndpoint string
+		key         ssh.PublicKey
+	}
+	addReturns struct {
+		result1 error
+	}
+	RemoveStub        func(apiEndpoint string, sshEndpoint string) (int, error)
+	removeMutex       sync.RWMutex
+	removeArgsForCall []struct {
+		apiEndpoint string
+		sshEndpoint string
+	}
+	removeReturns struct {
+		result1 int
+		result2 error
+	}
+	ListStub        func() ([]knownhosts.Entry, error)
+	listMutex       sync.RWMutex
+	listArgsForCall []struct{}
+	listReturns     struct {
+		result1 []knownhosts.Entry
+		result2 error
+	}
+}
+
+func (fake *FakeKnownHosts) Lookup(apiEndpoint string, sshEndpoint string) (ssh.PublicKey, error) {
+	fake.lookupMutex.Lock()
+	fake.lookupArgsForCall = append(fake.lookupArgsForCall, struct {
+		apiEndpoint string
+		sshEndpoint string
+	}{apiEndpoint, sshEndpoint})
+	fake.lookupMutex.Unlock()
+	if fake.LookupStub != nil {
+		return fake.LookupStub(apiEndpoint, sshEndpoint)
+	} else {
+		return fake.lookupReturns.result1, fake.lookupReturns.result2
+	}
+}
+
+func (fake *FakeKnownHosts) LookupCallCount() int {
+	fake.lookupMutex.RLock()
+	defer fake.lookupMutex.RUnlock()
+	return len(fake.lookupArgsForCall)
+}
+
+func (fake *FakeKnownHosts) LookupArgsForCall(i int) (string, string) {
+	fake.lookupMutex.RLock()
+	defer fake.lookupMutex.RUnlock()
+	return fake.lookupArgsForCall[i].apiEndpoint, fake.lookupArgsForCall[i].sshEndpoint
+}
+
+func (fake *FakeKnownHosts) LookupReturns(result1 ssh.PublicKey, result2 error) {
+	fake.LookupStub = nil
+	fake.lookupReturns = struct {
+		result1 ssh.PublicKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKnownHosts) Add(apiEndpoint string, sshEndpoint string, key ssh.PublicKey) error {
+	fake.addMutex.Lock()
+	fake.addArgsForCall = append(fake.addArgsForCall, struct {
+		apiEndpoint string
+		sshEndpoint string
+		key         ssh.PublicKey
+	}{apiEndpoint, sshEndpoint, key})
+	fake.addMutex.Unlock()
+	if fake.AddStub != nil {
+		return fake.AddStub(apiEndpoint, sshEndpoint, key)
+	} else {
+		return fake.addReturns.result1
+	}
+}
+
+func (fake *FakeKnownHosts) AddCallCount() int {
+	fake.addMutex.RLock()
+	defer fake.addMutex.RUnlock()
+	return len(fake.addArgsForCall)
+}
+
+func (fake *FakeKnownHosts) AddArgsForCall(i int) (string, string, ssh.PublicKey) {
+	fake.addMutex.RLock()
+	defer fake.addMutex.RUnlock()
+	return fake.addArgsForCall[i].apiEndpoint, fake.addArgsForCall[i].sshEndpoint, fake.addArgsForCall[i].key
+}
+
+func (fake *FakeKnownHosts) AddReturns(result1 error) {
+	fake.AddStub = nil
+	fake.addReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeKnownHosts) Remove(apiEndpoint string, sshEndpoint string) (int, error) {
+	fake.removeMutex.Lock()
+	fake.removeArgsForCall = append(fake.removeArgsForCall, struct {
+		apiEndpoint string
+		sshEndpoint string
+	}{apiEndpoint, sshEndpoint})
+	fake.removeMutex.Unlock()
+	if fake.RemoveStub != nil {
+		return fake.RemoveStub(apiEndpoint, sshEndpoint)
+	} else {
+		return fake.removeReturns.result1, fake.removeReturns.result2
+	}
+}
+
+func (fake *FakeKnownHosts) RemoveCallCount() int {
+	fake.removeMutex.RLock()
+	defer fake.removeMutex.RUnlock()
+	return len(fake.removeArgsForCall)
+}
+
+func (fake *FakeKnownHosts) RemoveArgsForCall(i int) (string, string) {
+	fake.removeMutex.RLock()
+	defer fake.removeMutex.RUnlock()
+	return fake.removeArgsForCall[i].apiEndpoint, fake.removeArgsForCall[i].sshEndpoint
+}
+
+func (fake *FakeKnownHosts) RemoveReturns(result1 int, result2 error) {
+	fake.RemoveStub = nil
+	fake.removeReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKnownHosts) List() ([]knownhosts.Entry, error) {
+	fake.listMutex.Lock()
+	fake.listArgsForCall = append(fake.listArgsForCall, struct{}{})
+	fake.listMutex.Unlock()
+	if fake.ListStub != nil {
+		return fake.ListStub()
+	} else {
+		return fake.listReturns.result1, fake.listReturns.result2
+	}
+}
+
+func (fake *FakeKnownHosts) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *FakeKnownHosts) ListReturns(result1 []knownhosts.Entry, result2 error) {
+	fake.ListStub = nil
+	fake.listReturns = struct {
+		result1 []knownhosts.Entry
+		result2 error
+	}{result1, result2}
+}
+
+var _ knownhosts.KnownHosts = new(FakeKnownHosts)
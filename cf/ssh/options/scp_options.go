@@ -0,0 +1,96 @@
+package options
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry/cli/flags"
+)
+
+// SCPOptions describe a copy between the local machine and an app
+// container. Exactly one of the two paths given to `cf scp` is in the
+// container, written as APP[/INDEX]:PATH.
+type SCPOptions struct {
+	SSHOptions
+	RemotePath string
+	LocalPath  string
+	Download   bool
+	Recursive  bool
+}
+
+func NewSCPOptions(fc flags.FlagContext) (*SCPOptions, error) {
+	scpOptions := &SCPOptions{}
+	scpOptions.SkipHostValidation = fc.Bool("k")
+	scpOptions.Recursive = fc.Bool("r")
+
+	source, target := fc.Args()[0], fc.Args()[1]
+
+	sourceRemote, sourceIsRemote := parseRemoteSpec(source)
+	targetRemote, targetIsRemote := parseRemoteSpec(target)
+
+	switch {
+	case sourceIsRemote && !targetIsRemote:
+		scpOptions.setRemote(sourceRemote)
+		scpOptions.LocalPath = target
+		scpOptions.Download = true
+	case targetIsRemote && !sourceIsRemote:
+		scpOptions.setRemote(targetRemote)
+		scpOptions.LocalPath = source
+	default:
+		return scpOptions, errors.New("Exactly one of SOURCE and TARGET must be in an app, written as APP[/INDEX]:PATH")
+	}
+
+	return scpOptions, nil
+}
+
+type remoteSpec struct {
+	appName string
+	index   uint
+	path    string
+}
+
+func (o *SCPOptions) setRemote(spec remoteSpec) {
+	o.AppName = spec.appName
+	o.Index = spec.index
+	o.RemotePath = spec.path
+}
+
+// parseRemoteSpec splits an argument of the form APP[/INDEX]:PATH. Anything
+// else, such as a Windows path with a drive letter, is a local path.
+func parseRemoteSpec(arg string) (remoteSpec, bool) {
+	spec := remoteSpec{}
+
+	colon := strings.Index(arg, ":")
+	if colon <= 0 || isWindowsDrive(arg) {
+		return spec, false
+	}
+
+	target := arg[:colon]
+	if strings.Contains(target, `\`) {
+		return spec, false
+	}
+
+	spec.appName = target
+	if slash := strings.Index(target, "/"); slash >= 0 {
+		index, err := strconv.ParseUint(target[slash+1:], 10, 32)
+		if slash == 0 || err != nil {
+			return spec, false
+		}
+		spec.appName = target[:slash]
+		spec.index = uint(index)
+	}
+
+	spec.path = arg[colon+1:]
+	if spec.path == "" {
+		spec.path = "."
+	}
+
+	return spec, true
+}
+
+func isWindowsDrive(arg string) bool {
+	return len(arg) >= 2 && arg[1] == ':' &&
+		(len(arg) == 2 || arg[2] == '\\' || arg[2] == '/') &&
+		strings.ContainsAny(arg[:1], "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+}
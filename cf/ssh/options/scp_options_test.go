@@ -0,0 +1,109 @@
+package options_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/ssh/options"
+	"github.com/cloudfoundry/cli/flags"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SCPOptions", func() {
+	var (
+		opts       *options.SCPOptions
+		args       []string
+		parseError error
+		fc         flags.FlagContext
+	)
+
+	BeforeEach(func() {
+		fc = flags.New()
+		fc.NewBoolFlag("r", "", "")
+		fc.NewBoolFlag("skip-host-validation", "k", "")
+
+		args = []string{}
+	})
+
+	JustBeforeEach(func() {
+		err := fc.Parse(args...)
+		Expect(err).NotTo(HaveOccurred())
+
+		opts, parseError = options.NewSCPOptions(fc)
+	})
+
+	Context("when the source is in the app", func() {
+		BeforeEach(func() {
+			args = append(args, "-r", "-k", "app-1/3:/home/vcap/logs", "local-dir")
+		})
+
+		It("downloads", func() {
+			Expect(parseError).NotTo(HaveOccurred())
+			Expect(opts.AppName).To(Equal("app-1"))
+			Expect(opts.Index).To(Equal(uint(3)))
+			Expect(opts.RemotePath).To(Equal("/home/vcap/logs"))
+			Expect(opts.LocalPath).To(Equal("local-dir"))
+			Expect(opts.Download).To(BeTrue())
+			Expect(opts.Recursive).To(BeTrue())
+			Expect(opts.SkipHostValidation).To(BeTrue())
+		})
+	})
+
+	Context("when the target is in the app", func() {
+		BeforeEach(func() {
+			args = append(args, "local-file", "app-1:")
+		})
+
+		It("uploads to the home directory of the first instance", func() {
+			Expect(parseError).NotTo(HaveOccurred())
+			Expect(opts.AppName).To(Equal("app-1"))
+			Expect(opts.Index).To(Equal(uint(0)))
+			Expect(opts.RemotePath).To(Equal("."))
+			Expect(opts.LocalPath).To(Equal("local-file"))
+			Expect(opts.Download).To(BeFalse())
+			Expect(opts.Recursive).To(BeFalse())
+		})
+	})
+
+	Context("when the local path has a Windows drive letter", func() {
+		BeforeEach(func() {
+			args = append(args, `C:\dumps`, "app-1:heap.hprof")
+		})
+
+		It("treats it as local", func() {
+			Expect(parseError).NotTo(HaveOccurred())
+			Expect(opts.LocalPath).To(Equal(`C:\dumps`))
+			Expect(opts.RemotePath).To(Equal("heap.hprof"))
+		})
+	})
+
+	Context("when the local path has a colon after a directory", func() {
+		BeforeEach(func() {
+			args = append(args, "app-1:file", "./dir/a:b")
+		})
+
+		It("treats it as local", func() {
+			Expect(parseError).NotTo(HaveOccurred())
+			Expect(opts.LocalPath).To(Equal("./dir/a:b"))
+		})
+	})
+
+	Context("when both paths are in an app", func() {
+		BeforeEach(func() {
+			args = append(args, "app-1:file", "app-2:file")
+		})
+
+		It("returns an error", func() {
+			Expect(parseError).To(MatchError(ContainSubstring("Exactly one of SOURCE and TARGET")))
+		})
+	})
+
+	Context("when neither path is in an app", func() {
+		BeforeEach(func() {
+			args = append(args, "here", "there")
+		})
+
+		It("returns an error", func() {
+			Expect(parseError).To(HaveOccurred())
+		})
+	})
+})
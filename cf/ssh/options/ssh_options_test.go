@@ -20,6 +20,8 @@ var _ = Describe("SSHOptions", func() {
 		BeforeEach(func() {
 			fc = flags.New()
 			fc.NewStringSliceFlag("L", "", "")
+			fc.NewStringSliceFlag("R", "", "")
+			fc.NewStringSliceFlag("D", "", "")
 			fc.NewStringSliceFlag("command", "c", "")
 			fc.NewIntFlag("app-instance-index", "i", "")
 			fc.NewBoolFlag("skip-host-validation", "k", "")
@@ -27,6 +29,7 @@ var _ = Describe("SSHOptions", func() {
 			fc.NewBoolFlag("request-pseudo-tty", "t", "")
 			fc.NewBoolFlag("force-pseudo-tty", "tt", "")
 			fc.NewBoolFlag("disable-pseudo-tty", "T", "")
+			fc.NewBoolFlag("all-instances", "", "")
 
 			args = []string{}
 			parseError = nil
@@ -281,6 +284,83 @@ var _ = Describe("SSHOptions", func() {
 			})
 		})
 
+		Context("when remote port forwarding is requested", func() {
+			BeforeEach(func() {
+				args = append(args, "app-name")
+			})
+
+			Context("without an explicit bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-R", "9229:localhost:9229")
+				})
+
+				It("sets the remote forward spec", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.RemoteForwardSpecs).To(ConsistOf(options.ForwardSpec{ListenAddress: "localhost:9229", ConnectAddress: "localhost:9229"}))
+					Expect(opts.ForwardSpecs).To(BeEmpty())
+				})
+			})
+
+			Context("with * as the bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-R", "*:8080:mock-service:80")
+				})
+
+				It("sets the remote forward spec", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.RemoteForwardSpecs).To(ConsistOf(options.ForwardSpec{ListenAddress: ":8080", ConnectAddress: "mock-service:80"}))
+				})
+			})
+
+			Context("with too few parts", func() {
+				BeforeEach(func() {
+					args = append(args, "-R", "8080:mock-service")
+				})
+
+				It("returns an error", func() {
+					Expect(parseError).To(MatchError(`Unable to parse remote forwarding argument: "8080:mock-service"`))
+				})
+			})
+		})
+
+		Context("when dynamic port forwarding is requested", func() {
+			BeforeEach(func() {
+				args = append(args, "app-name")
+			})
+
+			Context("with only a port", func() {
+				BeforeEach(func() {
+					args = append(args, "-D", "1080")
+				})
+
+				It("listens on localhost", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.DynamicForwardAddresses).To(ConsistOf("localhost:1080"))
+				})
+			})
+
+			Context("with a bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-D", "[::1]:1080", "-D", "*:1081")
+				})
+
+				It("listens on the bind addresses", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.DynamicForwardAddresses).To(ConsistOf("[::1]:1080", ":1081"))
+				})
+			})
+
+			Context("with too many parts", func() {
+				BeforeEach(func() {
+					args = append(args, "-D", "localhost:1080:remote")
+				})
+
+				It("returns an error", func() {
+					Expect(parseError).To(MatchError(`Unable to parse dynamic forwarding argument: "localhost:1080:remote"`))
+				})
+			})
+		})
+
 		Context("when -N is specified", func() {
 			BeforeEach(func() {
 				args = append(args, "app-name", "-N")
@@ -292,6 +372,40 @@ var _ = Describe("SSHOptions", func() {
 				Expect(opts.AppName).To(Equal("app-name"))
 			})
 		})
+
+		Context("when --all-instances is specified", func() {
+			Context("with a command", func() {
+				BeforeEach(func() {
+					args = append(args, "app-name", "--all-instances", "-c", "uptime")
+				})
+
+				It("runs the command on all instances", func() {
+					Expect(parseError).ToNot(HaveOccurred())
+					Expect(opts.AllInstances).To(BeTrue())
+					Expect(opts.Command).To(Equal([]string{"uptime"}))
+				})
+			})
+
+			Context("without a command", func() {
+				BeforeEach(func() {
+					args = append(args, "app-name", "--all-instances")
+				})
+
+				It("returns an error", func() {
+					Expect(parseError).To(MatchError("--all-instances requires a command to run (-c)"))
+				})
+			})
+
+			Context("with an instance index", func() {
+				BeforeEach(func() {
+					args = append(args, "app-name", "--all-instances", "-c", "uptime", "-i", "1")
+				})
+
+				It("returns an error", func() {
+					Expect(parseError).To(MatchError(ContainSubstring("--all-instances cannot be combined with -i")))
+				})
+			})
+		})
 	})
 
 })
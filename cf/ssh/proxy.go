@@ -0,0 +1,128 @@
+package sshCmd
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Proxy serves SSH to a local client, such as ssh running cf ssh-proxy as
+// its ProxyCommand, on in and out. It presents hostKey, accepts the client
+// without authentication, and relays every channel the client opens to the
+// connected app instance. It returns when the client disconnects.
+func (c *secureShell) Proxy(in io.Reader, out io.WriteCloser, hostKey ssh.Signer) error {
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(hostKey)
+
+	serverConn, channels, requests, err := ssh.NewServerConn(&pipeConn{Reader: in, WriteCloser: out}, config)
+	if err != nil {
+		return err
+	}
+	defer serverConn.Close()
+
+	// Remote port forwarding would need the app instance to open channels
+	// back to the client, which cf ssh -R does instead.
+	go ssh.DiscardRequests(requests)
+
+	go func() {
+		for newChannel := range channels {
+			go c.relayChannel(newChannel)
+		}
+	}()
+
+	go func() {
+		_ = c.secureClient.Wait()
+		_ = serverConn.Close()
+	}()
+
+	keepaliveStopCh := make(chan struct{})
+	defer close(keepaliveStopCh)
+
+	go keepalive(c.secureClient.Conn(), time.NewTicker(c.keepAliveInterval), keepaliveStopCh)
+
+	err = serverConn.Wait()
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
+
+// relayChannel opens the same kind of channel on the app instance and
+// copies data and requests between the two until the app instance closes
+// its end.
+func (c *secureShell) relayChannel(newChannel ssh.NewChannel) {
+	target, targetRequests, err := c.secureClient.Conn().OpenChannel(newChannel.ChannelType(), newChannel.ExtraData())
+	if err != nil {
+		if openErr, ok := err.(*ssh.OpenChannelError); ok {
+			_ = newChannel.Reject(openErr.Reason, openErr.Message)
+		} else {
+			_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		}
+		return
+	}
+	defer target.Close()
+
+	source, sourceRequests, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	defer source.Close()
+
+	go relayRequests(target, sourceRequests)
+
+	targetRequestsDone := make(chan struct{})
+	go func() {
+		relayRequests(source, targetRequests)
+		close(targetRequestsDone)
+	}()
+
+	go func() {
+		_, _ = io.Copy(target, source)
+		_ = target.CloseWrite()
+	}()
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go copyAndDone(wg, source, target)
+	go copyAndDone(wg, source.Stderr(), target.Stderr())
+	wg.Wait()
+
+	_ = source.CloseWrite()
+
+	// The exit status arrives as a request, so wait for the app instance
+	// to close the channel before closing the client's.
+	<-targetRequestsDone
+}
+
+func relayRequests(to ssh.Channel, requests <-chan *ssh.Request) {
+	for request := range requests {
+		ok, err := to.SendRequest(request.Type, request.WantReply, request.Payload)
+		if err != nil {
+			ok = false
+		}
+		if request.WantReply {
+			_ = request.Reply(ok, nil)
+		}
+	}
+}
+
+// pipeConn makes a reader and writer, such as standard input and output,
+// usable as the connection of an SSH server.
+type pipeConn struct {
+	io.Reader
+	io.WriteCloser
+}
+
+func (p *pipeConn) LocalAddr() net.Addr                { return pipeAddr{} }
+func (p *pipeConn) RemoteAddr() net.Addr               { return pipeAddr{} }
+func (p *pipeConn) SetDeadline(t time.Time) error      { return nil }
+func (p *pipeConn) SetReadDeadline(t time.Time) error  { return nil }
+func (p *pipeConn) SetWriteDeadline(t time.Time) error { return nil }
+
+type pipeAddr struct{}
+
+func (pipeAddr) Network() string { return "pipe" }
+func (pipeAddr) String() string  { return "pipe" }
@@ -0,0 +1,166 @@
+package sshCmd_test
+
+import (
+	"bytes"
+	"net"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/ssh"
+	"github.com/cloudfoundry/cli/cf/ssh/options"
+	"github.com/cloudfoundry/cli/cf/ssh/sshfakes"
+	"github.com/cloudfoundry/cli/cf/ssh/terminal/terminalhelperfakes"
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecureShell proxy", func() {
+	var (
+		fakeSecureDialer *sshfakes.FakeSecureDialer
+		fakeSecureClient *sshfakes.FakeSecureClient
+		targetClient     *ssh.Client
+		proxyErrCh       chan error
+		client           *ssh.Client
+	)
+
+	// serveTarget plays the SSH proxy of the app instance: it runs exec
+	// requests by echoing the command and exiting with status 3.
+	serveTarget := func(conn net.Conn) {
+		config := &ssh.ServerConfig{NoClientAuth: true}
+		config.AddHostKey(TestHostKey)
+
+		_, channels, requests, err := ssh.NewServerConn(conn, config)
+		if err != nil {
+			return
+		}
+		go ssh.DiscardRequests(requests)
+
+		for newChannel := range channels {
+			if newChannel.ChannelType() != "session" {
+				newChannel.Reject(ssh.UnknownChannelType, "only sessions")
+				continue
+			}
+
+			channel, channelRequests, err := newChannel.Accept()
+			if err != nil {
+				continue
+			}
+
+			go func() {
+				for request := range channelRequests {
+					if request.Type != "exec" {
+						request.Reply(false, nil)
+						continue
+					}
+					request.Reply(true, nil)
+
+					channel.Write([]byte("ran: " + string(request.Payload[4:])))
+					channel.Stderr().Write([]byte("warning"))
+					channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{3}))
+					channel.Close()
+				}
+			}()
+		}
+	}
+
+	// connPair connects two ends over loopback: unlike net.Pipe, TCP lets
+	// both ends send their version at the same time.
+	connPair := func() (net.Conn, net.Conn) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		defer listener.Close()
+
+		accepted := make(chan net.Conn, 1)
+		go func() {
+			conn, _ := listener.Accept()
+			accepted <- conn
+		}()
+
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		return conn, <-accepted
+	}
+
+	BeforeEach(func() {
+		targetConn, targetServerConn := connPair()
+		go serveTarget(targetServerConn)
+
+		conn, channels, requests, err := ssh.NewClientConn(targetConn, "ssh.example.com:2222", &ssh.ClientConfig{User: "cf:app-guid/0"})
+		Expect(err).NotTo(HaveOccurred())
+		targetClient = ssh.NewClient(conn, channels, requests)
+
+		fakeSecureDialer = new(sshfakes.FakeSecureDialer)
+		fakeSecureClient = new(sshfakes.FakeSecureClient)
+		fakeSecureDialer.DialReturns(fakeSecureClient, nil)
+		fakeSecureClient.ConnReturns(targetClient)
+		fakeSecureClient.WaitStub = targetClient.Wait
+
+		app := models.Application{}
+		app.State = "STARTED"
+		app.Diego = true
+
+		secureShell := sshCmd.NewSecureShell(
+			fakeSecureDialer,
+			&terminalhelperfakes.FakeTerminalHelper{},
+			new(sshfakes.FakeListenerFactory),
+			30*time.Second,
+			app,
+			"",
+			"",
+			"",
+			"",
+			nil,
+		)
+		Expect(secureShell.Connect(&options.SSHOptions{AppName: "app-1", SkipHostValidation: true})).To(Succeed())
+
+		proxyConn, clientConn := connPair()
+		proxyErrCh = make(chan error, 1)
+		go func() {
+			proxyErrCh <- secureShell.Proxy(proxyConn, proxyConn, TestPrivateKey)
+		}()
+
+		clientConfig := &ssh.ClientConfig{
+			User: "cf:app-guid/0",
+			HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+				defer GinkgoRecover()
+				Expect(key.Marshal()).To(Equal(TestPrivateKey.PublicKey().Marshal()))
+				return nil
+			},
+		}
+		conn, channels, requests, err = ssh.NewClientConn(clientConn, "cf-app-1", clientConfig)
+		Expect(err).NotTo(HaveOccurred())
+		client = ssh.NewClient(conn, channels, requests)
+	})
+
+	AfterEach(func() {
+		targetClient.Close()
+	})
+
+	It("relays sessions to the app instance", func() {
+		session, err := client.NewSession()
+		Expect(err).NotTo(HaveOccurred())
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		session.Stdout = stdout
+		session.Stderr = stderr
+
+		err = session.Run("uptime")
+		Expect(err).To(BeAssignableToTypeOf(&ssh.ExitError{}))
+		Expect(err.(*ssh.ExitError).ExitStatus()).To(Equal(3))
+		Expect(stdout.String()).To(Equal("ran: uptime"))
+		Expect(stderr.String()).To(Equal("warning"))
+	})
+
+	It("passes on the reason the app instance rejects a channel", func() {
+		_, err := client.Dial("tcp", "localhost:8080")
+		Expect(err).To(MatchError(ContainSubstring("only sessions")))
+	})
+
+	It("returns when the client disconnects", func() {
+		Expect(client.Close()).To(Succeed())
+		Eventually(proxyErrCh).Should(Receive(BeNil()))
+	})
+})
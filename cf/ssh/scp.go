@@ -0,0 +1,203 @@
+package sshCmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/sftp"
+)
+
+// CopyProgress is given the reader of each file Download and Upload copy,
+// along with the file's name and size, and returns the reader to copy
+// from. It lets the caller report progress.
+type CopyProgress func(name string, size int64, file io.ReadSeeker) io.Reader
+
+// Download copies remotePath in the app container to localPath over the
+// SFTP subsystem. Directories are only copied when recursive is set.
+func (c *secureShell) Download(remotePath string, localPath string, recursive bool, progress CopyProgress) error {
+	client, session, err := c.openSFTP()
+	if err != nil {
+		return err
+	}
+	defer closeSFTP(client, session)
+
+	info, err := client.Stat(remotePath)
+	if err != nil {
+		return fmt.Errorf("%s: %s", remotePath, err.Error())
+	}
+
+	if info.IsDir() && !recursive {
+		return fmt.Errorf("%s is a directory; use -r to copy directories", remotePath)
+	}
+
+	if localInfo, statErr := os.Stat(localPath); statErr == nil && localInfo.IsDir() {
+		localPath = filepath.Join(localPath, path.Base(remotePath))
+	}
+
+	if !info.IsDir() {
+		return downloadFile(client, remotePath, localPath, info, progress)
+	}
+
+	walker := client.Walk(remotePath)
+	for walker.Step() {
+		if walker.Err() != nil {
+			return walker.Err()
+		}
+
+		rel := walker.Path()[len(path.Clean(remotePath)):]
+		target := filepath.Join(localPath, filepath.FromSlash(rel))
+
+		if walker.Stat().IsDir() {
+			err = os.MkdirAll(target, walker.Stat().Mode().Perm()|0700)
+		} else {
+			err = downloadFile(client, walker.Path(), target, walker.Stat(), progress)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Upload copies localPath to remotePath in the app container over the SFTP
+// subsystem. Directories are only copied when recursive is set.
+func (c *secureShell) Upload(localPath string, remotePath string, recursive bool, progress CopyProgress) error {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() && !recursive {
+		return fmt.Errorf("%s is a directory; use -r to copy directories", localPath)
+	}
+
+	client, session, err := c.openSFTP()
+	if err != nil {
+		return err
+	}
+	defer closeSFTP(client, session)
+
+	if remoteInfo, statErr := client.Stat(remotePath); statErr == nil && remoteInfo.IsDir() {
+		remotePath = path.Join(remotePath, filepath.Base(localPath))
+	}
+
+	if !info.IsDir() {
+		return uploadFile(client, localPath, remotePath, info, progress)
+	}
+
+	root := filepath.Clean(localPath)
+	return filepath.Walk(root, func(fullPath string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, fullPath)
+		if err != nil {
+			return err
+		}
+		target := path.Join(remotePath, filepath.ToSlash(rel))
+
+		if !fileInfo.IsDir() {
+			return uploadFile(client, fullPath, target, fileInfo, progress)
+		}
+
+		if existing, statErr := client.Stat(target); statErr == nil && existing.IsDir() {
+			return nil
+		}
+		err = client.Mkdir(target)
+		if err != nil {
+			return fmt.Errorf("%s: %s", target, err.Error())
+		}
+		return client.Chmod(target, fileInfo.Mode().Perm())
+	})
+}
+
+// openSFTP starts an SFTP client on a new session of the connected client.
+func (c *secureShell) openSFTP() (*sftp.Client, SecureSession, error) {
+	session, err := c.secureClient.NewSession()
+	if err != nil {
+		return nil, nil, fmt.Errorf("SSH session allocation failed: %s", err.Error())
+	}
+
+	inPipe, err := session.StdinPipe()
+	if err != nil {
+		_ = session.Close()
+		return nil, nil, err
+	}
+
+	outPipe, err := session.StdoutPipe()
+	if err != nil {
+		_ = session.Close()
+		return nil, nil, err
+	}
+
+	err = session.RequestSubsystem("sftp")
+	if err != nil {
+		_ = session.Close()
+		return nil, nil, fmt.Errorf("SFTP subsystem request failed: %s", err.Error())
+	}
+
+	client, err := sftp.NewClientPipe(outPipe, inPipe)
+	if err != nil {
+		_ = session.Close()
+		return nil, nil, err
+	}
+
+	return client, session, nil
+}
+
+// closeSFTP closes the session first: the client only finishes closing once
+// the session's output ends.
+func closeSFTP(client *sftp.Client, session SecureSession) {
+	_ = session.Close()
+	_ = client.Close()
+}
+
+func downloadFile(client *sftp.Client, remotePath string, localPath string, info os.FileInfo, progress CopyProgress) error {
+	remoteFile, err := client.Open(remotePath)
+	if err != nil {
+		return fmt.Errorf("%s: %s", remotePath, err.Error())
+	}
+	defer remoteFile.Close()
+
+	localFile, err := os.OpenFile(localPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer localFile.Close()
+
+	_, err = io.Copy(localFile, withProgress(progress, remotePath, info.Size(), remoteFile))
+	return err
+}
+
+func uploadFile(client *sftp.Client, localPath string, remotePath string, info os.FileInfo, progress CopyProgress) error {
+	localFile, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer localFile.Close()
+
+	remoteFile, err := client.Create(remotePath)
+	if err != nil {
+		return fmt.Errorf("%s: %s", remotePath, err.Error())
+	}
+	defer remoteFile.Close()
+
+	_, err = io.Copy(remoteFile, withProgress(progress, localPath, info.Size(), localFile))
+	if err != nil {
+		return err
+	}
+
+	return client.Chmod(remotePath, info.Mode().Perm())
+}
+
+func withProgress(progress CopyProgress, name string, size int64, file io.ReadSeeker) io.Reader {
+	if progress == nil {
+		return file
+	}
+	return progress(name, size, file)
+}
@@ -0,0 +1,208 @@
+package sshCmd_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/ssh"
+	"github.com/cloudfoundry/cli/cf/ssh/options"
+	"github.com/cloudfoundry/cli/cf/ssh/sshfakes"
+	"github.com/cloudfoundry/cli/cf/ssh/terminal/terminalhelperfakes"
+	"github.com/pkg/sftp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type sftpServerConn struct {
+	io.Reader
+	io.WriteCloser
+}
+
+var _ = Describe("SecureShell file copies", func() {
+	var (
+		fakeSecureDialer  *sshfakes.FakeSecureDialer
+		fakeSecureClient  *sshfakes.FakeSecureClient
+		fakeSecureSession *sshfakes.FakeSecureSession
+
+		secureShell sshCmd.SecureShell
+
+		localDir  string
+		remoteDir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		localDir, err = ioutil.TempDir("", "scp-local")
+		Expect(err).NotTo(HaveOccurred())
+		remoteDir, err = ioutil.TempDir("", "scp-remote")
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeSecureDialer = new(sshfakes.FakeSecureDialer)
+		fakeSecureClient = new(sshfakes.FakeSecureClient)
+		fakeSecureSession = new(sshfakes.FakeSecureSession)
+
+		fakeSecureDialer.DialReturns(fakeSecureClient, nil)
+		fakeSecureClient.NewSessionReturns(fakeSecureSession, nil)
+
+		// Serve the local file system over SFTP on the session's pipes.
+		serverIn, clientOut := io.Pipe()
+		clientIn, serverOut := io.Pipe()
+		fakeSecureSession.StdinPipeReturns(clientOut, nil)
+		fakeSecureSession.StdoutPipeReturns(clientIn, nil)
+		fakeSecureSession.RequestSubsystemStub = func(string) error {
+			server, serverErr := sftp.NewServer(sftpServerConn{serverIn, serverOut})
+			if serverErr != nil {
+				return serverErr
+			}
+			go server.Serve()
+			return nil
+		}
+		fakeSecureSession.CloseStub = func() error {
+			_ = clientOut.Close()
+			_ = serverOut.Close()
+			return nil
+		}
+
+		app := models.Application{}
+		app.State = "STARTED"
+		app.Diego = true
+
+		secureShell = sshCmd.NewSecureShell(
+			fakeSecureDialer,
+			&terminalhelperfakes.FakeTerminalHelper{},
+			new(sshfakes.FakeListenerFactory),
+			30*time.Second,
+			app,
+			"",
+			"",
+			"",
+			"",
+			nil,
+		)
+
+		err = secureShell.Connect(&options.SSHOptions{AppName: "app-1", SkipHostValidation: true})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(localDir)
+		os.RemoveAll(remoteDir)
+	})
+
+	writeFile := func(path string, contents string) {
+		Expect(os.MkdirAll(filepath.Dir(path), 0700)).To(Succeed())
+		Expect(ioutil.WriteFile(path, []byte(contents), 0640)).To(Succeed())
+	}
+
+	readFile := func(path string) string {
+		contents, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		return string(contents)
+	}
+
+	Describe("Download", func() {
+		It("copies a file over the sftp subsystem", func() {
+			writeFile(filepath.Join(remoteDir, "heap.hprof"), "heap")
+
+			err := secureShell.Download(filepath.ToSlash(filepath.Join(remoteDir, "heap.hprof")), localDir, false, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSecureSession.RequestSubsystemArgsForCall(0)).To(Equal("sftp"))
+			Expect(readFile(filepath.Join(localDir, "heap.hprof"))).To(Equal("heap"))
+			Expect(fakeSecureSession.CloseCallCount()).To(BeNumerically(">=", 1))
+		})
+
+		It("copies directories when recursive", func() {
+			writeFile(filepath.Join(remoteDir, "logs", "a.log"), "a")
+			writeFile(filepath.Join(remoteDir, "logs", "nested", "b.log"), "b")
+
+			err := secureShell.Download(filepath.ToSlash(filepath.Join(remoteDir, "logs")), filepath.Join(localDir, "copy"), true, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(readFile(filepath.Join(localDir, "copy", "a.log"))).To(Equal("a"))
+			Expect(readFile(filepath.Join(localDir, "copy", "nested", "b.log"))).To(Equal("b"))
+		})
+
+		It("refuses to copy a directory unless recursive", func() {
+			Expect(os.Mkdir(filepath.Join(remoteDir, "logs"), 0700)).To(Succeed())
+
+			err := secureShell.Download(filepath.ToSlash(filepath.Join(remoteDir, "logs")), localDir, false, nil)
+			Expect(err).To(MatchError(ContainSubstring("use -r to copy directories")))
+		})
+
+		It("passes each file through the progress function", func() {
+			writeFile(filepath.Join(remoteDir, "heap.hprof"), "heap")
+
+			var names []string
+			var sizes []int64
+			progress := func(name string, size int64, file io.ReadSeeker) io.Reader {
+				names = append(names, name)
+				sizes = append(sizes, size)
+				return file
+			}
+
+			err := secureShell.Download(filepath.ToSlash(filepath.Join(remoteDir, "heap.hprof")), localDir, false, progress)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(Equal([]string{filepath.ToSlash(filepath.Join(remoteDir, "heap.hprof"))}))
+			Expect(sizes).To(Equal([]int64{4}))
+		})
+
+		It("returns an error when the subsystem cannot be started", func() {
+			fakeSecureSession.RequestSubsystemStub = nil
+			fakeSecureSession.RequestSubsystemReturns(io.ErrUnexpectedEOF)
+
+			err := secureShell.Download("file", localDir, false, nil)
+			Expect(err).To(MatchError(ContainSubstring("SFTP subsystem request failed")))
+		})
+	})
+
+	Describe("Upload", func() {
+		It("copies a file into a remote directory", func() {
+			writeFile(filepath.Join(localDir, "config.yml"), "config")
+
+			err := secureShell.Upload(filepath.Join(localDir, "config.yml"), filepath.ToSlash(remoteDir), false, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(readFile(filepath.Join(remoteDir, "config.yml"))).To(Equal("config"))
+			info, err := os.Stat(filepath.Join(remoteDir, "config.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0640)))
+		})
+
+		It("copies directories when recursive", func() {
+			writeFile(filepath.Join(localDir, "config", "a.yml"), "a")
+			writeFile(filepath.Join(localDir, "config", "nested", "b.yml"), "b")
+
+			err := secureShell.Upload(filepath.Join(localDir, "config"), filepath.ToSlash(filepath.Join(remoteDir, "copy")), true, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(readFile(filepath.Join(remoteDir, "copy", "a.yml"))).To(Equal("a"))
+			Expect(readFile(filepath.Join(remoteDir, "copy", "nested", "b.yml"))).To(Equal("b"))
+		})
+
+		It("refuses to copy a directory unless recursive", func() {
+			err := secureShell.Upload(localDir, filepath.ToSlash(remoteDir), false, nil)
+			Expect(err).To(MatchError(ContainSubstring("use -r to copy directories")))
+			Expect(fakeSecureClient.NewSessionCallCount()).To(Equal(0))
+		})
+
+		It("passes each file through the progress function", func() {
+			writeFile(filepath.Join(localDir, "config.yml"), "config")
+
+			var read bytes.Buffer
+			progress := func(name string, size int64, file io.ReadSeeker) io.Reader {
+				return io.TeeReader(file, &read)
+			}
+
+			err := secureShell.Upload(filepath.Join(localDir, "config.yml"), filepath.ToSlash(remoteDir), false, progress)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read.String()).To(Equal("config"))
+		})
+	})
+})
@@ -0,0 +1,146 @@
+package sshCmd
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+)
+
+// The parts of SOCKS5 (RFC 1928) the dynamic port forward uses: no
+// authentication and the CONNECT command.
+const (
+	socksVersion5 = 0x05
+
+	socksAuthNone         = 0x00
+	socksAuthNoAcceptable = 0xff
+
+	socksCommandConnect = 0x01
+
+	socksAddressIPv4   = 0x01
+	socksAddressDomain = 0x03
+	socksAddressIPv6   = 0x04
+
+	socksReplySucceeded           = 0x00
+	socksReplyHostUnreachable     = 0x04
+	socksReplyCommandNotSupported = 0x07
+	socksReplyAddressNotSupported = 0x08
+)
+
+type socksError struct {
+	reply   byte
+	message string
+}
+
+func (e *socksError) Error() string {
+	return e.message
+}
+
+func (c *secureShell) handleSOCKSConnection(conn net.Conn) {
+	defer conn.Close()
+
+	targetAddr, err := socksHandshake(conn)
+	if err != nil {
+		if socksErr, ok := err.(*socksError); ok {
+			_ = writeSOCKSReply(conn, socksErr.reply)
+		}
+		return
+	}
+
+	target, err := c.secureClient.Dial("tcp", targetAddr)
+	if err != nil {
+		fmt.Printf("connect to %s failed: %s\n", targetAddr, err.Error())
+		_ = writeSOCKSReply(conn, socksReplyHostUnreachable)
+		return
+	}
+	defer target.Close()
+
+	err = writeSOCKSReply(conn, socksReplySucceeded)
+	if err != nil {
+		return
+	}
+
+	pipeConnections(conn, target)
+}
+
+// socksHandshake negotiates the method and reads the CONNECT request,
+// returning the address the client wants to reach.
+func socksHandshake(conn io.ReadWriter) (string, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return "", err
+	}
+	if header[0] != socksVersion5 {
+		return "", fmt.Errorf("unsupported SOCKS version %d", header[0])
+	}
+
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return "", err
+	}
+
+	method := byte(socksAuthNoAcceptable)
+	for _, m := range methods {
+		if m == socksAuthNone {
+			method = socksAuthNone
+		}
+	}
+	if _, err := conn.Write([]byte{socksVersion5, method}); err != nil {
+		return "", err
+	}
+	if method == socksAuthNoAcceptable {
+		return "", errors.New("SOCKS client does not support connecting without authentication")
+	}
+
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return "", err
+	}
+	if request[0] != socksVersion5 {
+		return "", fmt.Errorf("unsupported SOCKS version %d", request[0])
+	}
+	if request[1] != socksCommandConnect {
+		return "", &socksError{reply: socksReplyCommandNotSupported, message: fmt.Sprintf("unsupported SOCKS command %d", request[1])}
+	}
+
+	var host string
+	switch request[3] {
+	case socksAddressIPv4, socksAddressIPv6:
+		ip := make([]byte, net.IPv4len)
+		if request[3] == socksAddressIPv6 {
+			ip = make([]byte, net.IPv6len)
+		}
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return "", err
+		}
+		host = net.IP(ip).String()
+	case socksAddressDomain:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return "", err
+		}
+		domain := make([]byte, length[0])
+		if _, err := io.ReadFull(conn, domain); err != nil {
+			return "", err
+		}
+		host = string(domain)
+	default:
+		return "", &socksError{reply: socksReplyAddressNotSupported, message: fmt.Sprintf("unsupported SOCKS address type %d", request[3])}
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), nil
+}
+
+// writeSOCKSReply answers a CONNECT request. The bound address is left
+// empty because the connection is made from the app container.
+func writeSOCKSReply(w io.Writer, reply byte) error {
+	_, err := w.Write([]byte{socksVersion5, reply, 0x00, socksAddressIPv4, 0, 0, 0, 0, 0, 0})
+	return err
+}
@@ -1,8 +1,8 @@
 package sshCmd
 
 import (
+	"bytes"
 	"crypto/md5"
-	"crypto/sha1"
 	"errors"
 	"fmt"
 	"io"
@@ -18,6 +18,7 @@ import (
 	"golang.org/x/crypto/ssh"
 
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
 	"github.com/cloudfoundry/cli/cf/ssh/options"
 	"github.com/cloudfoundry/cli/cf/ssh/sigwinch"
 	"github.com/cloudfoundry/cli/cf/ssh/terminal"
@@ -34,7 +35,13 @@ const (
 type SecureShell interface {
 	Connect(opts *options.SSHOptions) error
 	InteractiveSession() error
+	ExecuteCommand(stdout io.Writer, stderr io.Writer) error
 	LocalPortForward() error
+	RemotePortForward() error
+	DynamicPortForward() error
+	Download(remotePath string, localPath string, recursive bool, progress CopyProgress) error
+	Upload(localPath string, remotePath string, recursive bool, progress CopyProgress) error
+	Proxy(in io.Reader, out io.WriteCloser, hostKey ssh.Signer) error
 	Wait() error
 	Close() error
 }
@@ -51,6 +58,7 @@ type SecureClient interface {
 	NewSession() (SecureSession, error)
 	Conn() ssh.Conn
 	Dial(network, address string) (net.Conn, error)
+	Listen(network, address string) (net.Listener, error)
 	Wait() error
 	Close() error
 }
@@ -71,6 +79,7 @@ type SecureSession interface {
 	StderrPipe() (io.Reader, error)
 	Start(command string) error
 	Shell() error
+	RequestSubsystem(subsystem string) error
 	Wait() error
 	Close() error
 }
@@ -84,10 +93,12 @@ type secureShell struct {
 	sshEndpointFingerprint string
 	sshEndpoint            string
 	token                  string
+	apiEndpoint            string
+	knownHosts             knownhosts.KnownHosts
 	secureClient           SecureClient
 	opts                   *options.SSHOptions
 
-	localListeners []net.Listener
+	listeners []net.Listener
 }
 
 func NewSecureShell(
@@ -99,6 +110,8 @@ func NewSecureShell(
 	sshEndpointFingerprint string,
 	sshEndpoint string,
 	token string,
+	apiEndpoint string,
+	knownHosts knownhosts.KnownHosts,
 ) SecureShell {
 	return &secureShell{
 		secureDialer:      secureDialer,
@@ -109,7 +122,9 @@ func NewSecureShell(
 		sshEndpointFingerprint: sshEndpointFingerprint,
 		sshEndpoint:            sshEndpoint,
 		token:                  token,
-		localListeners:         []net.Listener{},
+		apiEndpoint:            apiEndpoint,
+		knownHosts:             knownHosts,
+		listeners:              []net.Listener{},
 	}
 }
 
@@ -124,7 +139,7 @@ func (c *secureShell) Connect(opts *options.SSHOptions) error {
 		Auth: []ssh.AuthMethod{
 			ssh.Password(c.token),
 		},
-		HostKeyCallback: fingerprintCallback(opts, c.sshEndpointFingerprint),
+		HostKeyCallback: c.fingerprintCallback(opts),
 	}
 
 	secureClient, err := c.secureDialer.Dial("tcp", c.sshEndpoint, clientConfig)
@@ -138,7 +153,7 @@ func (c *secureShell) Connect(opts *options.SSHOptions) error {
 }
 
 func (c *secureShell) Close() error {
-	for _, listener := range c.localListeners {
+	for _, listener := range c.listeners {
 		_ = listener.Close()
 	}
 	return c.secureClient.Close()
@@ -150,15 +165,54 @@ func (c *secureShell) LocalPortForward() error {
 		if err != nil {
 			return err
 		}
-		c.localListeners = append(c.localListeners, listener)
+		c.listeners = append(c.listeners, listener)
+
+		connectAddress := forwardSpec.ConnectAddress
+		go c.forwardAcceptLoop(listener, func(conn net.Conn) {
+			c.handleForwardConnection(conn, c.secureClient.Dial, connectAddress)
+		})
+	}
+
+	return nil
+}
+
+// RemotePortForward asks the app container to listen on each remote forward
+// address and connects what it accepts to the local machine.
+func (c *secureShell) RemotePortForward() error {
+	for _, forwardSpec := range c.opts.RemoteForwardSpecs {
+		listener, err := c.secureClient.Listen("tcp", forwardSpec.ListenAddress)
+		if err != nil {
+			return err
+		}
+		c.listeners = append(c.listeners, listener)
 
-		go c.localForwardAcceptLoop(listener, forwardSpec.ConnectAddress)
+		connectAddress := forwardSpec.ConnectAddress
+		go c.forwardAcceptLoop(listener, func(conn net.Conn) {
+			c.handleForwardConnection(conn, net.Dial, connectAddress)
+		})
 	}
 
 	return nil
 }
 
-func (c *secureShell) localForwardAcceptLoop(listener net.Listener, addr string) {
+// DynamicPortForward starts a SOCKS5 proxy on each dynamic forward address.
+// The proxy connects to the addresses its clients ask for from the app
+// container.
+func (c *secureShell) DynamicPortForward() error {
+	for _, address := range c.opts.DynamicForwardAddresses {
+		listener, err := c.listenerFactory.Listen("tcp", address)
+		if err != nil {
+			return err
+		}
+		c.listeners = append(c.listeners, listener)
+
+		go c.forwardAcceptLoop(listener, c.handleSOCKSConnection)
+	}
+
+	return nil
+}
+
+func (c *secureShell) forwardAcceptLoop(listener net.Listener, handle func(net.Conn)) {
 	defer listener.Close()
 
 	for {
@@ -171,20 +225,26 @@ func (c *secureShell) localForwardAcceptLoop(listener net.Listener, addr string)
 			return
 		}
 
-		go c.handleForwardConnection(conn, addr)
+		go handle(conn)
 	}
 }
 
-func (c *secureShell) handleForwardConnection(conn net.Conn, targetAddr string) {
+type dialFunc func(network, address string) (net.Conn, error)
+
+func (c *secureShell) handleForwardConnection(conn net.Conn, dial dialFunc, targetAddr string) {
 	defer conn.Close()
 
-	target, err := c.secureClient.Dial("tcp", targetAddr)
+	target, err := dial("tcp", targetAddr)
 	if err != nil {
 		fmt.Printf("connect to %s failed: %s\n", targetAddr, err.Error())
 		return
 	}
 	defer target.Close()
 
+	pipeConnections(conn, target)
+}
+
+func pipeConnections(conn net.Conn, target net.Conn) {
 	wg := &sync.WaitGroup{}
 	wg.Add(2)
 
@@ -310,6 +370,46 @@ func (c *secureShell) InteractiveSession() error {
 	return result
 }
 
+// ExecuteCommand runs the command in the options without a terminal or any
+// input, copying its output to stdout and stderr.
+func (c *secureShell) ExecuteCommand(stdout io.Writer, stderr io.Writer) error {
+	session, err := c.secureClient.NewSession()
+	if err != nil {
+		return fmt.Errorf("SSH session allocation failed: %s", err.Error())
+	}
+	defer session.Close()
+
+	outPipe, err := session.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	errPipe, err := session.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	err = session.Start(strings.Join(c.opts.Command, " "))
+	if err != nil {
+		return err
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+
+	go copyAndDone(wg, stdout, outPipe)
+	go copyAndDone(wg, stderr, errPipe)
+
+	keepaliveStopCh := make(chan struct{})
+	defer close(keepaliveStopCh)
+
+	go keepalive(c.secureClient.Conn(), time.NewTicker(c.keepAliveInterval), keepaliveStopCh)
+
+	result := session.Wait()
+	wg.Wait()
+	return result
+}
+
 func (c *secureShell) Wait() error {
 	keepaliveStopCh := make(chan struct{})
 	defer close(keepaliveStopCh)
@@ -336,38 +436,98 @@ func md5Fingerprint(key ssh.PublicKey) string {
 	return strings.Replace(fmt.Sprintf("% x", sum), " ", ":", -1)
 }
 
-func sha1Fingerprint(key ssh.PublicKey) string {
-	sum := sha1.Sum(key.Marshal())
-	return strings.Replace(fmt.Sprintf("% x", sum), " ", ":", -1)
-}
-
 type hostKeyCallback func(hostname string, remote net.Addr, key ssh.PublicKey) error
 
-func fingerprintCallback(opts *options.SSHOptions, expectedFingerprint string) hostKeyCallback {
+func (c *secureShell) fingerprintCallback(opts *options.SSHOptions) hostKeyCallback {
 	if opts.SkipHostValidation {
 		return nil
 	}
 
 	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
-		switch len(expectedFingerprint) {
-		case sha1FingerprintLength:
-			fingerprint := sha1Fingerprint(key)
-			if fingerprint != expectedFingerprint {
-				return fmt.Errorf("Host key verification failed.\n\nThe fingerprint of the received key was %q.", fingerprint)
-			}
-		case md5FingerprintLength:
-			fingerprint := md5Fingerprint(key)
-			if fingerprint != expectedFingerprint {
-				return fmt.Errorf("Host key verification failed.\n\nThe fingerprint of the received key was %q.", fingerprint)
+		advertised, err := verifyAdvertisedFingerprint(c.sshEndpointFingerprint, key)
+		if err != nil {
+			return err
+		}
+
+		if c.knownHosts == nil {
+			if !advertised {
+				return fmt.Errorf("Unable to verify identity of host.\n\nThe fingerprint of the received key was %q.", md5Fingerprint(key))
 			}
-		case 0:
-			fingerprint := md5Fingerprint(key)
-			return fmt.Errorf("Unable to verify identity of host.\n\nThe fingerprint of the received key was %q.", fingerprint)
-		default:
-			return errors.New("Unsupported host key fingerprint format")
+			return nil
+		}
+
+		return c.verifyKnownHost(key, advertised)
+	}
+}
+
+// verifyAdvertisedFingerprint compares the key with the fingerprint the
+// Cloud Controller advertises, and reports whether it advertises one.
+func verifyAdvertisedFingerprint(expectedFingerprint string, key ssh.PublicKey) (bool, error) {
+	switch len(expectedFingerprint) {
+	case sha1FingerprintLength:
+		fingerprint := knownhosts.Fingerprint(key)
+		if fingerprint != expectedFingerprint {
+			return false, fmt.Errorf("Host key verification failed.\n\nThe fingerprint of the received key was %q.", fingerprint)
 		}
+	case md5FingerprintLength:
+		fingerprint := md5Fingerprint(key)
+		if fingerprint != expectedFingerprint {
+			return false, fmt.Errorf("Host key verification failed.\n\nThe fingerprint of the received key was %q.", fingerprint)
+		}
+	case 0:
+		return false, nil
+	default:
+		return false, errors.New("Unsupported host key fingerprint format")
+	}
+	return true, nil
+}
+
+// verifyKnownHost trusts the key of an SSH proxy the first time it is seen,
+// and refuses a different key after that unless the Cloud Controller
+// advertises the new one.
+func (c *secureShell) verifyKnownHost(key ssh.PublicKey, advertised bool) error {
+	trusted, err := c.knownHosts.Lookup(c.apiEndpoint, c.sshEndpoint)
+	if err != nil {
+		return fmt.Errorf("Unable to read known hosts: %s", err.Error())
+	}
+
+	switch {
+	case trusted != nil && bytes.Equal(trusted.Marshal(), key.Marshal()):
 		return nil
+	case trusted != nil && !advertised:
+		return fmt.Errorf("Host key verification failed.\n\n%s\nIf the key was changed on purpose, run 'cf ssh-known-hosts remove %s' and try again.",
+			c.hostKeyChangedWarning(trusted, key), c.sshEndpoint)
+	case trusted != nil:
+		c.warn("%s\nThe Cloud Controller advertises the new key, so it replaces the trusted one.\n", c.hostKeyChangedWarning(trusted, key))
+	case !advertised:
+		c.warn("Warning: Permanently added the host key of %s (fingerprint %q) to the list of known hosts.\n", c.sshEndpoint, knownhosts.Fingerprint(key))
 	}
+
+	err = c.knownHosts.Add(c.apiEndpoint, c.sshEndpoint, key)
+	if err != nil {
+		c.warn("Warning: Unable to save the host key of %s: %s\n", c.sshEndpoint, err.Error())
+	}
+
+	return nil
+}
+
+func (c *secureShell) hostKeyChangedWarning(trusted ssh.PublicKey, key ssh.PublicKey) string {
+	return fmt.Sprintf(`@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@
+@    WARNING: REMOTE HOST IDENTIFICATION HAS CHANGED!     @
+@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@
+The host key of %s for %s is not the one trusted before.
+Someone could be eavesdropping on the connection, or the key was replaced.
+The fingerprint of the trusted key is %q.
+The fingerprint of the received key was %q.
+`, c.sshEndpoint, c.apiEndpoint, knownhosts.Fingerprint(trusted), knownhosts.Fingerprint(key))
+}
+
+func (c *secureShell) warn(format string, args ...interface{}) {
+	_, _, stderr := c.terminalHelper.StdStreams()
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+	fmt.Fprintf(stderr, format, args...)
 }
 
 func (c *secureShell) shouldAllocateTerminal(opts *options.SSHOptions, stdinIsTerminal bool) bool {
@@ -469,6 +629,9 @@ func (sc *secureClient) Wait() error    { return sc.client.Wait() }
 func (sc *secureClient) Dial(n, addr string) (net.Conn, error) {
 	return sc.client.Dial(n, addr)
 }
+func (sc *secureClient) Listen(n, addr string) (net.Listener, error) {
+	return sc.client.Listen(n, addr)
+}
 func (sc *secureClient) NewSession() (SecureSession, error) {
 	return sc.client.NewSession()
 }
@@ -7,11 +7,15 @@
 package sshCmd_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"os"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -24,6 +28,8 @@ import (
 	"github.com/cloudfoundry-incubator/diego-ssh/test_helpers/fake_ssh"
 	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/ssh"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts"
+	"github.com/cloudfoundry/cli/cf/ssh/knownhosts/knownhostsfakes"
 	"github.com/cloudfoundry/cli/cf/ssh/options"
 	"github.com/cloudfoundry/cli/cf/ssh/sshfakes"
 	"github.com/cloudfoundry/cli/cf/ssh/terminal/terminalhelperfakes"
@@ -56,6 +62,8 @@ var _ = Describe("SSH", func() {
 		sshEndpointFingerprint string
 		sshEndpoint            string
 		token                  string
+		apiEndpoint            string
+		knownHosts             knownhosts.KnownHosts
 	)
 
 	BeforeEach(func() {
@@ -71,6 +79,8 @@ var _ = Describe("SSH", func() {
 		sshEndpoint = ""
 		sshEndpointFingerprint = ""
 		token = ""
+		apiEndpoint = ""
+		knownHosts = nil
 
 		fakeConnection = &fake_ssh.FakeConn{}
 		fakeSecureClient = new(sshfakes.FakeSecureClient)
@@ -111,6 +121,8 @@ var _ = Describe("SSH", func() {
 			sshEndpointFingerprint,
 			sshEndpoint,
 			token,
+			apiEndpoint,
+			knownHosts,
 		)
 	})
 
@@ -275,6 +287,104 @@ var _ = Describe("SSH", func() {
 					Eventually(err).Should(MatchError(MatchRegexp("Unsupported host key fingerprint format")))
 				})
 			})
+
+			Context("when known hosts are recorded", func() {
+				var (
+					fakeKnownHosts *knownhostsfakes.FakeKnownHosts
+					stderr         *bytes.Buffer
+				)
+
+				BeforeEach(func() {
+					apiEndpoint = "https://api.example.com"
+					fakeKnownHosts = new(knownhostsfakes.FakeKnownHosts)
+					knownHosts = fakeKnownHosts
+
+					stderr = &bytes.Buffer{}
+					fakeTerminalHelper.StdStreamsReturns(ioutil.NopCloser(&bytes.Buffer{}), &bytes.Buffer{}, stderr)
+					terminalHelper = fakeTerminalHelper
+				})
+
+				Context("when the host has not been seen before", func() {
+					It("trusts the key and warns when the Cloud Controller does not advertise a fingerprint", func() {
+						err := callback("", addr, TestHostKey.PublicKey())
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeKnownHosts.LookupCallCount()).To(Equal(1))
+						api, endpoint := fakeKnownHosts.LookupArgsForCall(0)
+						Expect(api).To(Equal("https://api.example.com"))
+						Expect(endpoint).To(Equal("ssh.example.com:22"))
+
+						Expect(fakeKnownHosts.AddCallCount()).To(Equal(1))
+						_, _, key := fakeKnownHosts.AddArgsForCall(0)
+						Expect(key.Marshal()).To(Equal(TestHostKey.PublicKey().Marshal()))
+						Expect(stderr.String()).To(ContainSubstring("Permanently added the host key of ssh.example.com:22"))
+					})
+
+					Context("when the key matches the advertised fingerprint", func() {
+						BeforeEach(func() {
+							sshEndpointFingerprint = knownhosts.Fingerprint(TestHostKey.PublicKey())
+						})
+
+						It("trusts the key quietly", func() {
+							err := callback("", addr, TestHostKey.PublicKey())
+							Expect(err).NotTo(HaveOccurred())
+							Expect(fakeKnownHosts.AddCallCount()).To(Equal(1))
+							Expect(stderr.String()).To(BeEmpty())
+						})
+					})
+				})
+
+				Context("when the trusted key matches", func() {
+					BeforeEach(func() {
+						fakeKnownHosts.LookupReturns(TestHostKey.PublicKey(), nil)
+					})
+
+					It("accepts the key", func() {
+						err := callback("", addr, TestHostKey.PublicKey())
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeKnownHosts.AddCallCount()).To(Equal(0))
+						Expect(stderr.String()).To(BeEmpty())
+					})
+				})
+
+				Context("when the trusted key is different", func() {
+					BeforeEach(func() {
+						fakeKnownHosts.LookupReturns(TestPrivateKey.PublicKey(), nil)
+					})
+
+					It("refuses the key", func() {
+						err := callback("", addr, TestHostKey.PublicKey())
+						Expect(err).To(MatchError(ContainSubstring("Host key verification failed.")))
+						Expect(err).To(MatchError(ContainSubstring("REMOTE HOST IDENTIFICATION HAS CHANGED")))
+						Expect(err).To(MatchError(ContainSubstring("cf ssh-known-hosts remove ssh.example.com:22")))
+						Expect(fakeKnownHosts.AddCallCount()).To(Equal(0))
+					})
+
+					Context("when the Cloud Controller advertises the new key", func() {
+						BeforeEach(func() {
+							sshEndpointFingerprint = knownhosts.Fingerprint(TestHostKey.PublicKey())
+						})
+
+						It("warns and trusts the new key", func() {
+							err := callback("", addr, TestHostKey.PublicKey())
+							Expect(err).NotTo(HaveOccurred())
+							Expect(stderr.String()).To(ContainSubstring("REMOTE HOST IDENTIFICATION HAS CHANGED"))
+							Expect(fakeKnownHosts.AddCallCount()).To(Equal(1))
+						})
+					})
+				})
+
+				Context("when looking up the trusted key fails", func() {
+					BeforeEach(func() {
+						fakeKnownHosts.LookupReturns(nil, errors.New("permission denied"))
+					})
+
+					It("returns an error", func() {
+						err := callback("", addr, TestHostKey.PublicKey())
+						Expect(err).To(MatchError("Unable to read known hosts: permission denied"))
+					})
+				})
+			})
 		})
 
 		Context("when the skip host validation flag is set", func() {
@@ -1123,6 +1233,323 @@ var _ = Describe("SSH", func() {
 		})
 	})
 
+	Describe("RemotePortForward", func() {
+		var (
+			opts               *options.SSHOptions
+			remoteForwardError error
+
+			echoListener   net.Listener
+			remoteListener net.Listener
+		)
+
+		BeforeEach(func() {
+			var err error
+			echoListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+
+			go func() {
+				for {
+					conn, acceptErr := echoListener.Accept()
+					if acceptErr != nil {
+						return
+					}
+					go func() {
+						io.Copy(conn, conn)
+						conn.Close()
+					}()
+				}
+			}()
+
+			// The app container's listener is simulated with a local one.
+			remoteListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			fakeSecureClient.ListenReturns(remoteListener, nil)
+
+			opts = &options.SSHOptions{
+				AppName: "app-1",
+				RemoteForwardSpecs: []options.ForwardSpec{{
+					ListenAddress:  "localhost:9229",
+					ConnectAddress: echoListener.Addr().String(),
+				}},
+			}
+
+			currentApp.State = "STARTED"
+			currentApp.Diego = true
+		})
+
+		JustBeforeEach(func() {
+			connectErr := secureShell.Connect(opts)
+			Expect(connectErr).NotTo(HaveOccurred())
+
+			remoteForwardError = secureShell.RemotePortForward()
+		})
+
+		AfterEach(func() {
+			secureShell.Close()
+			echoListener.Close()
+		})
+
+		It("listens on the remote address through the secure client", func() {
+			Expect(remoteForwardError).NotTo(HaveOccurred())
+			Expect(fakeSecureClient.ListenCallCount()).To(Equal(1))
+
+			network, addr := fakeSecureClient.ListenArgsForCall(0)
+			Expect(network).To(Equal("tcp"))
+			Expect(addr).To(Equal("localhost:9229"))
+		})
+
+		It("connects remote connections to the local connect address", func() {
+			conn, err := net.Dial("tcp", remoteListener.Addr().String())
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			_, err = conn.Write([]byte("hello"))
+			Expect(err).NotTo(HaveOccurred())
+
+			response := make([]byte, 5)
+			_, err = io.ReadFull(conn, response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(response)).To(Equal("hello"))
+		})
+
+		It("closes the remote listener when the shell is closed", func() {
+			Expect(secureShell.Close()).To(Succeed())
+
+			_, err := remoteListener.Accept()
+			Expect(err).To(HaveOccurred())
+		})
+
+		Context("when the remote listen fails", func() {
+			BeforeEach(func() {
+				fakeSecureClient.ListenReturns(nil, errors.New("tcpip-forward request denied"))
+			})
+
+			It("returns the error", func() {
+				Expect(remoteForwardError).To(MatchError("tcpip-forward request denied"))
+			})
+		})
+	})
+
+	Describe("DynamicPortForward", func() {
+		var (
+			opts                *options.SSHOptions
+			dynamicForwardError error
+
+			echoListener  net.Listener
+			proxyListener net.Listener
+		)
+
+		BeforeEach(func() {
+			var err error
+			echoListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+
+			go func() {
+				for {
+					conn, acceptErr := echoListener.Accept()
+					if acceptErr != nil {
+						return
+					}
+					go func() {
+						io.Copy(conn, conn)
+						conn.Close()
+					}()
+				}
+			}()
+
+			proxyListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			fakeListenerFactory.ListenReturns(proxyListener, nil)
+
+			fakeSecureClient.DialStub = net.Dial
+
+			opts = &options.SSHOptions{
+				AppName:                 "app-1",
+				DynamicForwardAddresses: []string{"localhost:1080"},
+			}
+
+			currentApp.State = "STARTED"
+			currentApp.Diego = true
+		})
+
+		JustBeforeEach(func() {
+			connectErr := secureShell.Connect(opts)
+			Expect(connectErr).NotTo(HaveOccurred())
+
+			dynamicForwardError = secureShell.DynamicPortForward()
+		})
+
+		AfterEach(func() {
+			secureShell.Close()
+			echoListener.Close()
+		})
+
+		socksConnect := func(request []byte) (net.Conn, []byte) {
+			conn, err := net.Dial("tcp", proxyListener.Addr().String())
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = conn.Write([]byte{0x05, 0x01, 0x00})
+			Expect(err).NotTo(HaveOccurred())
+
+			method := make([]byte, 2)
+			_, err = io.ReadFull(conn, method)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(method).To(Equal([]byte{0x05, 0x00}))
+
+			_, err = conn.Write(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			reply := make([]byte, 10)
+			_, err = io.ReadFull(conn, reply)
+			Expect(err).NotTo(HaveOccurred())
+
+			return conn, reply
+		}
+
+		It("listens on the dynamic forward address", func() {
+			Expect(dynamicForwardError).NotTo(HaveOccurred())
+
+			network, addr := fakeListenerFactory.ListenArgsForCall(0)
+			Expect(network).To(Equal("tcp"))
+			Expect(addr).To(Equal("localhost:1080"))
+		})
+
+		It("connects to the requested host name from the app container", func() {
+			_, port, err := net.SplitHostPort(echoListener.Addr().String())
+			Expect(err).NotTo(HaveOccurred())
+			portNumber, err := strconv.Atoi(port)
+			Expect(err).NotTo(HaveOccurred())
+
+			request := []byte{0x05, 0x01, 0x00, 0x03, byte(len("localhost"))}
+			request = append(request, []byte("localhost")...)
+			request = append(request, byte(portNumber>>8), byte(portNumber))
+
+			conn, reply := socksConnect(request)
+			defer conn.Close()
+			Expect(reply[1]).To(Equal(byte(0x00)))
+
+			Expect(fakeSecureClient.DialCallCount()).To(Equal(1))
+			_, addr := fakeSecureClient.DialArgsForCall(0)
+			Expect(addr).To(Equal("localhost:" + port))
+
+			_, err = conn.Write([]byte("hello"))
+			Expect(err).NotTo(HaveOccurred())
+
+			response := make([]byte, 5)
+			_, err = io.ReadFull(conn, response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(response)).To(Equal("hello"))
+		})
+
+		It("connects to a requested IPv4 address", func() {
+			request := []byte{0x05, 0x01, 0x00, 0x01, 127, 0, 0, 1, 0x1f, 0x90}
+
+			conn, _ := socksConnect(request)
+			defer conn.Close()
+
+			Expect(fakeSecureClient.DialCallCount()).To(Equal(1))
+			_, addr := fakeSecureClient.DialArgsForCall(0)
+			Expect(addr).To(Equal("127.0.0.1:8080"))
+		})
+
+		It("refuses commands other than CONNECT", func() {
+			request := []byte{0x05, 0x02, 0x00, 0x01, 127, 0, 0, 1, 0x1f, 0x90}
+
+			conn, reply := socksConnect(request)
+			defer conn.Close()
+
+			Expect(reply[1]).To(Equal(byte(0x07)))
+			Expect(fakeSecureClient.DialCallCount()).To(Equal(0))
+		})
+
+		It("reports hosts the app container cannot reach", func() {
+			fakeSecureClient.DialStub = nil
+			fakeSecureClient.DialReturns(nil, errors.New("connection refused"))
+
+			request := []byte{0x05, 0x01, 0x00, 0x01, 10, 0, 0, 1, 0x1f, 0x90}
+
+			conn, reply := socksConnect(request)
+			defer conn.Close()
+
+			Expect(reply[1]).To(Equal(byte(0x04)))
+		})
+
+		Context("when listening fails", func() {
+			BeforeEach(func() {
+				fakeListenerFactory.ListenReturns(nil, errors.New("address in use"))
+			})
+
+			It("returns the error", func() {
+				Expect(dynamicForwardError).To(MatchError("address in use"))
+			})
+		})
+	})
+
+	Describe("ExecuteCommand", func() {
+		var (
+			opts           *options.SSHOptions
+			stdout, stderr *bytes.Buffer
+			executeErr     error
+		)
+
+		BeforeEach(func() {
+			opts = &options.SSHOptions{
+				AppName: "app-1",
+				Command: []string{"cat", "/etc/hostname"},
+			}
+
+			currentApp.State = "STARTED"
+			currentApp.Diego = true
+
+			stdout = &bytes.Buffer{}
+			stderr = &bytes.Buffer{}
+
+			fakeSecureSession.StdoutPipeReturns(strings.NewReader("out"), nil)
+			fakeSecureSession.StderrPipeReturns(strings.NewReader("err"), nil)
+		})
+
+		JustBeforeEach(func() {
+			connectErr := secureShell.Connect(opts)
+			Expect(connectErr).NotTo(HaveOccurred())
+
+			executeErr = secureShell.ExecuteCommand(stdout, stderr)
+		})
+
+		It("runs the command in a new session without a terminal", func() {
+			Expect(executeErr).NotTo(HaveOccurred())
+			Expect(fakeSecureSession.StartCallCount()).To(Equal(1))
+			Expect(fakeSecureSession.StartArgsForCall(0)).To(Equal("cat /etc/hostname"))
+			Expect(fakeSecureSession.RequestPtyCallCount()).To(Equal(0))
+			Expect(fakeSecureSession.CloseCallCount()).To(Equal(1))
+		})
+
+		It("copies the session output to the writers", func() {
+			Expect(stdout.String()).To(Equal("out"))
+			Expect(stderr.String()).To(Equal("err"))
+		})
+
+		Context("when the command fails", func() {
+			BeforeEach(func() {
+				fakeSecureSession.WaitReturns(errors.New("exit status 3"))
+			})
+
+			It("returns the result from wait", func() {
+				Expect(executeErr).To(MatchError("exit status 3"))
+			})
+		})
+
+		Context("when the command cannot be started", func() {
+			BeforeEach(func() {
+				fakeSecureSession.StartReturns(errors.New("start failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(executeErr).To(MatchError("start failed"))
+				Expect(fakeSecureSession.WaitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("Wait", func() {
 		var opts *options.SSHOptions
 		var waitErr error
@@ -67,6 +67,14 @@ type FakeSecureSession struct {
 	shellReturns     struct {
 		result1 error
 	}
+	RequestSubsystemStub        func(subsystem string) error
+	requestSubsystemMutex       sync.RWMutex
+	requestSubsystemArgsForCall []struct {
+		subsystem string
+	}
+	requestSubsystemReturns struct {
+		result1 error
+	}
 	WaitStub        func() error
 	waitMutex       sync.RWMutex
 	waitArgsForCall []struct{}
@@ -117,12 +125,17 @@ func (fake *FakeSecureSession) RequestPtyReturns(result1 error) {
 }
 
 func (fake *FakeSecureSession) SendRequest(name string, wantReply bool, payload []byte) (bool, error) {
+	var payloadCopy []byte
+	if payload != nil {
+		payloadCopy = make([]byte, len(payload))
+		copy(payloadCopy, payload)
+	}
 	fake.sendRequestMutex.Lock()
 	fake.sendRequestArgsForCall = append(fake.sendRequestArgsForCall, struct {
 		name      string
 		wantReply bool
 		payload   []byte
-	}{name, wantReply, payload})
+	}{name, wantReply, payloadCopy})
 	fake.sendRequestMutex.Unlock()
 	if fake.SendRequestStub != nil {
 		return fake.SendRequestStub(name, wantReply, payload)
@@ -282,6 +295,38 @@ func (fake *FakeSecureSession) ShellReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSecureSession) RequestSubsystem(subsystem string) error {
+	fake.requestSubsystemMutex.Lock()
+	fake.requestSubsystemArgsForCall = append(fake.requestSubsystemArgsForCall, struct {
+		subsystem string
+	}{subsystem})
+	fake.requestSubsystemMutex.Unlock()
+	if fake.RequestSubsystemStub != nil {
+		return fake.RequestSubsystemStub(subsystem)
+	} else {
+		return fake.requestSubsystemReturns.result1
+	}
+}
+
+func (fake *FakeSecureSession) RequestSubsystemCallCount() int {
+	fake.requestSubsystemMutex.RLock()
+	defer fake.requestSubsystemMutex.RUnlock()
+	return len(fake.requestSubsystemArgsForCall)
+}
+
+func (fake *FakeSecureSession) RequestSubsystemArgsForCall(i int) string {
+	fake.requestSubsystemMutex.RLock()
+	defer fake.requestSubsystemMutex.RUnlock()
+	return fake.requestSubsystemArgsForCall[i].subsystem
+}
+
+func (fake *FakeSecureSession) RequestSubsystemReturns(result1 error) {
+	fake.RequestSubsystemStub = nil
+	fake.requestSubsystemReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSecureSession) Wait() error {
 	fake.waitMutex.Lock()
 	fake.waitArgsForCall = append(fake.waitArgsForCall, struct{}{})
@@ -2,10 +2,12 @@
 package sshfakes
 
 import (
+	"io"
 	"sync"
 
 	"github.com/cloudfoundry/cli/cf/ssh"
 	"github.com/cloudfoundry/cli/cf/ssh/options"
+	"golang.org/x/crypto/ssh"
 )
 
 type FakeSecureShell struct {
@@ -23,12 +25,65 @@ type FakeSecureShell struct {
 	interactiveSessionReturns     struct {
 		result1 error
 	}
+	ExecuteCommandStub        func(stdout io.Writer, stderr io.Writer) error
+	executeCommandMutex       sync.RWMutex
+	executeCommandArgsForCall []struct {
+		stdout io.Writer
+		stderr io.Writer
+	}
+	executeCommandReturns struct {
+		result1 error
+	}
 	LocalPortForwardStub        func() error
 	localPortForwardMutex       sync.RWMutex
 	localPortForwardArgsForCall []struct{}
 	localPortForwardReturns     struct {
 		result1 error
 	}
+	RemotePortForwardStub        func() error
+	remotePortForwardMutex       sync.RWMutex
+	remotePortForwardArgsForCall []struct{}
+	remotePortForwardReturns     struct {
+		result1 error
+	}
+	DynamicPortForwardStub        func() error
+	dynamicPortForwardMutex       sync.RWMutex
+	dynamicPortForwardArgsForCall []struct{}
+	dynamicPortForwardReturns     struct {
+		result1 error
+	}
+	DownloadStub        func(remotePath string, localPath string, recursive bool, progress sshCmd.CopyProgress) error
+	downloadMutex       sync.RWMutex
+	downloadArgsForCall []struct {
+		remotePath string
+		localPath  string
+		recursive  bool
+		progress   sshCmd.CopyProgress
+	}
+	downloadReturns struct {
+		result1 error
+	}
+	UploadStub        func(localPath string, remotePath string, recursive bool, progress sshCmd.CopyProgress) error
+	uploadMutex       sync.RWMutex
+	uploadArgsForCall []struct {
+		localPath  string
+		remotePath string
+		recursive  bool
+		progress   sshCmd.CopyProgress
+	}
+	uploadReturns struct {
+		result1 error
+	}
+	ProxyStub        func(in io.Reader, out io.WriteCloser, hostKey ssh.Signer) error
+	proxyMutex       sync.RWMutex
+	proxyArgsForCall []struct {
+		in      io.Reader
+		out     io.WriteCloser
+		hostKey ssh.Signer
+	}
+	proxyReturns struct {
+		result1 error
+	}
 	WaitStub        func() error
 	waitMutex       sync.RWMutex
 	waitArgsForCall []struct{}
@@ -99,6 +154,39 @@ func (fake *FakeSecureShell) InteractiveSessionReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSecureShell) ExecuteCommand(stdout io.Writer, stderr io.Writer) error {
+	fake.executeCommandMutex.Lock()
+	fake.executeCommandArgsForCall = append(fake.executeCommandArgsForCall, struct {
+		stdout io.Writer
+		stderr io.Writer
+	}{stdout, stderr})
+	fake.executeCommandMutex.Unlock()
+	if fake.ExecuteCommandStub != nil {
+		return fake.ExecuteCommandStub(stdout, stderr)
+	} else {
+		return fake.executeCommandReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) ExecuteCommandCallCount() int {
+	fake.executeCommandMutex.RLock()
+	defer fake.executeCommandMutex.RUnlock()
+	return len(fake.executeCommandArgsForCall)
+}
+
+func (fake *FakeSecureShell) ExecuteCommandArgsForCall(i int) (io.Writer, io.Writer) {
+	fake.executeCommandMutex.RLock()
+	defer fake.executeCommandMutex.RUnlock()
+	return fake.executeCommandArgsForCall[i].stdout, fake.executeCommandArgsForCall[i].stderr
+}
+
+func (fake *FakeSecureShell) ExecuteCommandReturns(result1 error) {
+	fake.ExecuteCommandStub = nil
+	fake.executeCommandReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSecureShell) LocalPortForward() error {
 	fake.localPortForwardMutex.Lock()
 	fake.localPortForwardArgsForCall = append(fake.localPortForwardArgsForCall, struct{}{})
@@ -123,6 +211,158 @@ func (fake *FakeSecureShell) LocalPortForwardReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSecureShell) RemotePortForward() error {
+	fake.remotePortForwardMutex.Lock()
+	fake.remotePortForwardArgsForCall = append(fake.remotePortForwardArgsForCall, struct{}{})
+	fake.remotePortForwardMutex.Unlock()
+	if fake.RemotePortForwardStub != nil {
+		return fake.RemotePortForwardStub()
+	} else {
+		return fake.remotePortForwardReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) RemotePortForwardCallCount() int {
+	fake.remotePortForwardMutex.RLock()
+	defer fake.remotePortForwardMutex.RUnlock()
+	return len(fake.remotePortForwardArgsForCall)
+}
+
+func (fake *FakeSecureShell) RemotePortForwardReturns(result1 error) {
+	fake.RemotePortForwardStub = nil
+	fake.remotePortForwardReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecureShell) DynamicPortForward() error {
+	fake.dynamicPortForwardMutex.Lock()
+	fake.dynamicPortForwardArgsForCall = append(fake.dynamicPortForwardArgsForCall, struct{}{})
+	fake.dynamicPortForwardMutex.Unlock()
+	if fake.DynamicPortForwardStub != nil {
+		return fake.DynamicPortForwardStub()
+	} else {
+		return fake.dynamicPortForwardReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) DynamicPortForwardCallCount() int {
+	fake.dynamicPortForwardMutex.RLock()
+	defer fake.dynamicPortForwardMutex.RUnlock()
+	return len(fake.dynamicPortForwardArgsForCall)
+}
+
+func (fake *FakeSecureShell) DynamicPortForwardReturns(result1 error) {
+	fake.DynamicPortForwardStub = nil
+	fake.dynamicPortForwardReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecureShell) Download(remotePath string, localPath string, recursive bool, progress sshCmd.CopyProgress) error {
+	fake.downloadMutex.Lock()
+	fake.downloadArgsForCall = append(fake.downloadArgsForCall, struct {
+		remotePath string
+		localPath  string
+		recursive  bool
+		progress   sshCmd.CopyProgress
+	}{remotePath, localPath, recursive, progress})
+	fake.downloadMutex.Unlock()
+	if fake.DownloadStub != nil {
+		return fake.DownloadStub(remotePath, localPath, recursive, progress)
+	} else {
+		return fake.downloadReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) DownloadCallCount() int {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	return len(fake.downloadArgsForCall)
+}
+
+func (fake *FakeSecureShell) DownloadArgsForCall(i int) (string, string, bool, sshCmd.CopyProgress) {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	return fake.downloadArgsForCall[i].remotePath, fake.downloadArgsForCall[i].localPath, fake.downloadArgsForCall[i].recursive, fake.downloadArgsForCall[i].progress
+}
+
+func (fake *FakeSecureShell) DownloadReturns(result1 error) {
+	fake.DownloadStub = nil
+	fake.downloadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecureShell) Upload(localPath string, remotePath string, recursive bool, progress sshCmd.CopyProgress) error {
+	fake.uploadMutex.Lock()
+	fake.uploadArgsForCall = append(fake.uploadArgsForCall, struct {
+		localPath  string
+		remotePath string
+		recursive  bool
+		progress   sshCmd.CopyProgress
+	}{localPath, remotePath, recursive, progress})
+	fake.uploadMutex.Unlock()
+	if fake.UploadStub != nil {
+		return fake.UploadStub(localPath, remotePath, recursive, progress)
+	} else {
+		return fake.uploadReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) UploadCallCount() int {
+	fake.uploadMutex.RLock()
+	defer fake.uploadMutex.RUnlock()
+	return len(fake.uploadArgsForCall)
+}
+
+func (fake *FakeSecureShell) UploadArgsForCall(i int) (string, string, bool, sshCmd.CopyProgress) {
+	fake.uploadMutex.RLock()
+	defer fake.uploadMutex.RUnlock()
+	return fake.uploadArgsForCall[i].localPath, fake.uploadArgsForCall[i].remotePath, fake.uploadArgsForCall[i].recursive, fake.uploadArgsForCall[i].progress
+}
+
+func (fake *FakeSecureShell) UploadReturns(result1 error) {
+	fake.UploadStub = nil
+	fake.uploadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecureShell) Proxy(in io.Reader, out io.WriteCloser, hostKey ssh.Signer) error {
+	fake.proxyMutex.Lock()
+	fake.proxyArgsForCall = append(fake.proxyArgsForCall, struct {
+		in      io.Reader
+		out     io.WriteCloser
+		hostKey ssh.Signer
+	}{in, out, hostKey})
+	fake.proxyMutex.Unlock()
+	if fake.ProxyStub != nil {
+		return fake.ProxyStub(in, out, hostKey)
+	} else {
+		return fake.proxyReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) ProxyCallCount() int {
+	fake.proxyMutex.RLock()
+	defer fake.proxyMutex.RUnlock()
+	return len(fake.proxyArgsForCall)
+}
+
+func (fake *FakeSecureShell) ProxyArgsForCall(i int) (io.Reader, io.WriteCloser, ssh.Signer) {
+	fake.proxyMutex.RLock()
+	defer fake.proxyMutex.RUnlock()
+	return fake.proxyArgsForCall[i].in, fake.proxyArgsForCall[i].out, fake.proxyArgsForCall[i].hostKey
+}
+
+func (fake *FakeSecureShell) ProxyReturns(result1 error) {
+	fake.ProxyStub = nil
+	fake.proxyReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSecureShell) Wait() error {
 	fake.waitMutex.Lock()
 	fake.waitArgsForCall = append(fake.waitArgsForCall, struct{}{})
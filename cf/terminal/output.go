@@ -0,0 +1,67 @@
+package terminal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"gopkg.in/yaml.v2"
+)
+
+// OutputFormat selects how listing commands render their results. The
+// default, TableOutput, is the column-aligned text meant for humans. The
+// other formats emit the command's documented schema (see package
+// presenters) and are meant for scripts.
+type OutputFormat string
+
+const (
+	TableOutput OutputFormat = "table"
+	JSONOutput  OutputFormat = "json"
+	YAMLOutput  OutputFormat = "yaml"
+)
+
+// ParseOutputFormat converts the value given to the global --output
+// option into an OutputFormat. The empty string selects TableOutput.
+func ParseOutputFormat(value string) (OutputFormat, error) {
+	switch OutputFormat(strings.ToLower(value)) {
+	case "", TableOutput:
+		return TableOutput, nil
+	case JSONOutput:
+		return JSONOutput, nil
+	case YAMLOutput:
+		return YAMLOutput, nil
+	}
+
+	return TableOutput, errors.New(T("Invalid output format '{{.Format}}'. Valid formats are: table, json, yaml",
+		map[string]interface{}{"Format": value}))
+}
+
+// IsStructured is true for the machine-readable formats.
+func (f OutputFormat) IsStructured() bool {
+	return f == JSONOutput || f == YAMLOutput
+}
+
+// WriteStructured encodes v to w in the given machine-readable format.
+func WriteStructured(w io.Writer, format OutputFormat, v interface{}) error {
+	switch format {
+	case JSONOutput:
+		encoded, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", encoded)
+		return err
+	case YAMLOutput:
+		encoded, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(encoded)
+		return err
+	}
+
+	return fmt.Errorf("output format %q is not a structured format", format)
+}
@@ -0,0 +1,92 @@
+package terminal_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/trace/tracefakes"
+	io_helpers "github.com/cloudfoundry/cli/testhelpers/io"
+
+	. "github.com/cloudfoundry/cli/cf/terminal"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OutputFormat", func() {
+	type record struct {
+		Name  string   `json:"name" yaml:"name"`
+		Items []string `json:"items" yaml:"items"`
+	}
+
+	Describe("ParseOutputFormat", func() {
+		It("defaults to table output", func() {
+			format, err := ParseOutputFormat("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format).To(Equal(TableOutput))
+			Expect(format.IsStructured()).To(BeFalse())
+		})
+
+		It("accepts json and yaml regardless of case", func() {
+			format, err := ParseOutputFormat("JSON")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format).To(Equal(JSONOutput))
+			Expect(format.IsStructured()).To(BeTrue())
+
+			format, err = ParseOutputFormat("yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format).To(Equal(YAMLOutput))
+			Expect(format.IsStructured()).To(BeTrue())
+		})
+
+		It("rejects unknown formats", func() {
+			_, err := ParseOutputFormat("xml")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Invalid output format 'xml'"))
+		})
+	})
+
+	Describe("WriteStructured", func() {
+		var buffer *bytes.Buffer
+
+		BeforeEach(func() {
+			buffer = &bytes.Buffer{}
+		})
+
+		It("writes indented json", func() {
+			err := WriteStructured(buffer, JSONOutput, []record{{Name: "a", Items: []string{"x"}}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(Equal("[\n  {\n    \"name\": \"a\",\n    \"items\": [\n      \"x\"\n    ]\n  }\n]\n"))
+		})
+
+		It("writes yaml", func() {
+			err := WriteStructured(buffer, YAMLOutput, []record{{Name: "a", Items: []string{"x"}}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(Equal("- name: a\n  items:\n  - x\n"))
+		})
+
+		It("refuses to write table output", func() {
+			err := WriteStructured(buffer, TableOutput, []record{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("UI.PrintStructured", func() {
+		It("writes to stdout even when the printer is disabled", func() {
+			printer := NewTeePrinter(os.Stdout)
+			printer.DisableTerminalOutput(true)
+
+			io_helpers.SimulateStdin("", func(reader io.Reader) {
+				output := io_helpers.CaptureOutput(func() {
+					ui := NewUI(reader, os.Stdout, printer, new(tracefakes.FakePrinter))
+					ui.SetOutputFormat(JSONOutput)
+					ui.Say("Getting things...")
+					Expect(ui.PrintStructured(record{Name: "a"})).To(Succeed())
+				})
+
+				Expect(strings.Join(output, "")).To(Equal(`{  "name": "a",  "items": null}`))
+			})
+		})
+	})
+})
@@ -0,0 +1,71 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+type prefixedUI struct {
+	UI
+	prefix string
+	lock   *sync.Mutex
+}
+
+// NewPrefixedUI returns a UI that prints through ui, starting every line
+// with prefix. UIs that share lock can be used from several goroutines at
+// once without their lines getting mixed up.
+func NewPrefixedUI(ui UI, prefix string, lock *sync.Mutex) UI {
+	return &prefixedUI{
+		UI:     ui,
+		prefix: prefix,
+		lock:   lock,
+	}
+}
+
+func (ui *prefixedUI) Say(message string, args ...interface{}) {
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+
+	ui.lock.Lock()
+	defer ui.lock.Unlock()
+
+	for _, line := range strings.Split(message, "\n") {
+		ui.UI.Say("%s", ui.prefix+line)
+	}
+}
+
+func (ui *prefixedUI) Warn(message string, args ...interface{}) {
+	ui.Say(WarningColor(fmt.Sprintf(message, args...)))
+}
+
+func (ui *prefixedUI) Ok() {
+	ui.Say(SuccessColor(T("OK")))
+}
+
+func (ui *prefixedUI) Failed(message string, args ...interface{}) {
+	ui.Say(FailureColor(T("FAILED")))
+	ui.Say(message, args...)
+	ui.UI.PanicQuietly()
+}
+
+func (ui *prefixedUI) PrintPaginator(rows []string, err error) {
+	if err != nil {
+		ui.Failed(err.Error())
+		return
+	}
+
+	for _, row := range rows {
+		ui.Say(row)
+	}
+}
+
+func (ui *prefixedUI) Table(headers []string) *UITable {
+	return &UITable{
+		UI:    ui,
+		Table: NewTable(headers),
+	}
+}
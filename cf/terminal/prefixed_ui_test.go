@@ -0,0 +1,63 @@
+package terminal_test
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cloudfoundry/cli/cf/terminal/terminalfakes"
+
+	. "github.com/cloudfoundry/cli/cf/terminal"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrefixedUI", func() {
+	var (
+		fakeUI *terminalfakes.FakeUI
+		lines  []string
+		ui     UI
+	)
+
+	BeforeEach(func() {
+		lines = []string{}
+		fakeUI = new(terminalfakes.FakeUI)
+		fakeUI.SayStub = func(message string, args ...interface{}) {
+			lines = append(lines, fmt.Sprintf(message, args...))
+		}
+
+		ui = NewPrefixedUI(fakeUI, "my-app | ", new(sync.Mutex))
+	})
+
+	It("prefixes every line it says", func() {
+		ui.Say("Uploading %s...\nDone", "my-app")
+
+		Expect(lines).To(Equal([]string{"my-app | Uploading my-app...", "my-app | Done"}))
+	})
+
+	It("does not treat a message without arguments as a format", func() {
+		ui.Say("100%")
+
+		Expect(lines).To(Equal([]string{"my-app | 100%"}))
+	})
+
+	It("prefixes the lines of tables", func() {
+		table := ui.Table([]string{"name", "state"})
+		table.Add("web", "running")
+		table.Print()
+
+		Expect(lines).To(HaveLen(2))
+		for _, line := range lines {
+			Expect(line).To(HavePrefix("my-app | "))
+		}
+	})
+
+	It("prints FAILED with the message and panics quietly", func() {
+		fakeUI.PanicQuietlyStub = func() {
+			panic(QuietPanic)
+		}
+
+		Expect(func() { ui.Failed("it broke") }).To(Panic())
+		Expect(lines[0]).To(ContainSubstring("FAILED"))
+		Expect(lines[1]).To(Equal("my-app | it broke"))
+	})
+})
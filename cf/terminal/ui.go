@@ -3,6 +3,7 @@ package terminal
 import (
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
 	. "github.com/cloudfoundry/cli/cf/i18n"
@@ -43,6 +44,13 @@ type UI interface {
 	Table(headers []string) *UITable
 	NotifyUpdateIfNeeded(coreconfig.Reader)
 
+	// OutputFormat reports the format selected with the global --output
+	// option. Commands that support structured output check it and call
+	// PrintStructured instead of printing a table.
+	OutputFormat() OutputFormat
+	SetOutputFormat(OutputFormat)
+	PrintStructured(v interface{}) error
+
 	Writer() io.Writer
 }
 
@@ -53,18 +61,28 @@ type Printer interface {
 }
 
 type terminalUI struct {
-	stdin   io.Reader
-	stdout  io.Writer
-	printer Printer
-	logger  trace.Printer
+	stdin        io.Reader
+	stdout       io.Writer
+	stderr       io.Writer
+	printer      Printer
+	logger       trace.Printer
+	outputFormat OutputFormat
 }
 
 func NewUI(r io.Reader, w io.Writer, printer Printer, logger trace.Printer) UI {
+	return NewUIWithErrorWriter(r, w, os.Stderr, printer, logger)
+}
+
+// NewUIWithErrorWriter is NewUI with the writer that warnings and failures
+// go to while structured output is requested.
+func NewUIWithErrorWriter(r io.Reader, w io.Writer, errW io.Writer, printer Printer, logger trace.Printer) UI {
 	return &terminalUI{
-		stdin:   r,
-		stdout:  w,
-		printer: printer,
-		logger:  logger,
+		stdin:        r,
+		stdout:       w,
+		stderr:       errW,
+		printer:      printer,
+		logger:       logger,
+		outputFormat: TableOutput,
 	}
 }
 
@@ -101,6 +119,11 @@ func (ui *terminalUI) Say(message string, args ...interface{}) {
 
 func (ui *terminalUI) Warn(message string, args ...interface{}) {
 	message = fmt.Sprintf(message, args...)
+	if ui.outputFormat.IsStructured() {
+		// Like failures, warnings go to stderr to keep stdout parseable.
+		ui.sayToStderr(message)
+		return
+	}
 	ui.Say(WarningColor(message))
 	return
 }
@@ -168,7 +191,12 @@ func (ui *terminalUI) Failed(message string, args ...interface{}) {
 	ui.logger.Print(failed)
 	ui.logger.Print(message)
 
-	if !ui.logger.WritesToConsole() {
+	if ui.outputFormat.IsStructured() {
+		// The printer is silenced while structured output is requested,
+		// so failures go to stderr to keep stdout parseable.
+		ui.sayToStderr(failed)
+		ui.sayToStderr(message)
+	} else if !ui.logger.WritesToConsole() {
 		ui.Say(FailureColor(failed))
 		ui.Say(message)
 	}
@@ -176,6 +204,15 @@ func (ui *terminalUI) Failed(message string, args ...interface{}) {
 	ui.PanicQuietly()
 }
 
+// sayToStderr writes a line to the error writer. A tee printer still gets
+// the line, so that plugins capturing the output of a command see it.
+func (ui *terminalUI) sayToStderr(message string) {
+	if tee, ok := ui.printer.(*TeePrinter); ok {
+		tee.saveOutputToBucket(message + "\n")
+	}
+	fmt.Fprintln(ui.stderr, message)
+}
+
 func (ui *terminalUI) PanicQuietly() {
 	panic(QuietPanic)
 }
@@ -183,6 +220,10 @@ func (ui *terminalUI) PanicQuietly() {
 func (ui *terminalUI) ShowConfiguration(config coreconfig.Reader) {
 	table := ui.Table([]string{"", ""})
 
+	if config.CurrentProfile() != "" {
+		table.Add(T("Profile:"), EntityNameColor(config.CurrentProfile()))
+	}
+
 	if config.HasAPIEndpoint() {
 		table.Add(
 			T("API endpoint:"),
@@ -293,6 +334,21 @@ func (u *UITable) Print() {
 	}
 }
 
+func (ui *terminalUI) OutputFormat() OutputFormat {
+	return ui.outputFormat
+}
+
+func (ui *terminalUI) SetOutputFormat(format OutputFormat) {
+	ui.outputFormat = format
+}
+
+// PrintStructured writes v to stdout in the current output format. It
+// bypasses the printer, which is disabled for structured output so that
+// progress messages do not end up in the document.
+func (ui *terminalUI) PrintStructured(v interface{}) error {
+	return WriteStructured(ui.stdout, ui.outputFormat, v)
+}
+
 func (ui *terminalUI) NotifyUpdateIfNeeded(config coreconfig.Reader) {
 	if !config.IsMinCLIVersion(cf.Version) {
 		ui.Say("")
@@ -1,6 +1,8 @@
 package terminal_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"io"
 	"os"
 	"strings"
@@ -303,6 +305,20 @@ var _ = Describe("UI", func() {
 					Expect(output).To(ContainSubstrings([]string{"Space:", "my-space"}))
 				})
 			})
+
+			It("does not mention a target profile when none is current", func() {
+				Expect(output).ToNot(ContainSubstrings([]string{"Profile:"}))
+			})
+
+			Context("when a target profile is current", func() {
+				BeforeEach(func() {
+					config.SaveProfile("prod")
+				})
+
+				It("tells the user which profile is targeted", func() {
+					Expect(output).To(ContainSubstrings([]string{"Profile:", "prod"}))
+				})
+			})
 		})
 
 		It("prompts the user to target an org and space when no org or space is targeted", func() {
@@ -401,6 +417,51 @@ var _ = Describe("UI", func() {
 		})
 	})
 
+	Describe("structured output", func() {
+		var (
+			stdout  *bytes.Buffer
+			stderr  *bytes.Buffer
+			printer *TeePrinter
+			ui      UI
+		)
+
+		BeforeEach(func() {
+			stdout = &bytes.Buffer{}
+			stderr = &bytes.Buffer{}
+			printer = NewTeePrinter(stdout)
+			printer.DisableTerminalOutput(true)
+			ui = NewUIWithErrorWriter(os.Stdin, stdout, stderr, printer, fakeLogger)
+			ui.SetOutputFormat(JSONOutput)
+		})
+
+		It("keeps warnings out of the JSON written to stdout", func() {
+			ui.Warn("careful with %s", "that")
+			Expect(ui.PrintStructured(map[string]string{"name": "my-app"})).To(Succeed())
+
+			var document map[string]string
+			Expect(json.Unmarshal(stdout.Bytes(), &document)).To(Succeed())
+			Expect(document).To(Equal(map[string]string{"name": "my-app"}))
+			Expect(stderr.String()).To(Equal("careful with that\n"))
+		})
+
+		It("writes failures to the error writer", func() {
+			assert.DoesPanic(QuietPanic, func() {
+				ui.Failed("uh oh")
+			})
+
+			Expect(stdout.String()).To(BeEmpty())
+			Expect(stderr.String()).To(ContainSubstring("FAILED\nuh oh\n"))
+		})
+
+		It("still passes warnings on to the output bucket of the printer", func() {
+			bucket := &bytes.Buffer{}
+			printer.SetOutputBucket(bucket)
+
+			ui.Warn("careful")
+			Expect(bucket.String()).To(Equal("careful\n"))
+		})
+	})
+
 	Describe("NotifyUpdateIfNeeded", func() {
 
 		var (
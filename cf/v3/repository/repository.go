@@ -32,8 +32,7 @@ func NewRepository(config coreconfig.ReadWriter, client client.Client) Repositor
 func (r *repository) handleUpdatedTokens() {
 	if r.client.TokensUpdated() {
 		accessToken, refreshToken := r.client.GetUpdatedTokens()
-		r.config.SetAccessToken(accessToken)
-		r.config.SetRefreshToken(refreshToken)
+		r.config.SetRefreshedTokens(accessToken, refreshToken)
 	}
 }
 
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+type CommandHooks struct {
+}
+
+func (c *CommandHooks) Run(cliConnection plugin.CliConnection, args []string) {
+	context, ok := plugin.ParseCommandHookArgs(args)
+	if !ok {
+		return
+	}
+
+	switch context.Hook {
+	case plugin.PreCommandHook:
+		fmt.Println("pre", context.Command, strings.Join(context.Args, " "))
+		for _, arg := range context.Args {
+			switch arg {
+			case "protected-app":
+				cliConnection.VetoCommand("protected-app is protected")
+			case "broken-hook":
+				os.Exit(3)
+			}
+		}
+	case plugin.PostCommandHook:
+		fmt.Println("post", context.Command, context.ExitStatus, context.Error, strings.Join(context.Args, " "))
+	}
+}
+
+func (c *CommandHooks) GetMetadata() plugin.PluginMetadata {
+	return plugin.PluginMetadata{
+		Name: "CommandHooks",
+		Hooks: []plugin.Hook{
+			{Command: "delete", Pre: true, Post: true},
+			{Command: "target", Post: true},
+		},
+	}
+}
+
+func main() {
+	plugin.Start(new(CommandHooks))
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	netrpc "net/rpc"
 	"os"
 	"runtime"
 	"strings"
@@ -10,6 +12,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/confighelpers"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	cferrors "github.com/cloudfoundry/cli/cf/errors"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/net"
 	"github.com/cloudfoundry/cli/cf/panicprinter"
@@ -23,6 +26,9 @@ import (
 
 var cmdRegistry = commandregistry.Commands
 
+// exitStatus is the status cf exits with after a failure.
+var exitStatus = 1
+
 func main() {
 	traceEnv := os.Getenv("CF_TRACE")
 	traceLogger := trace.NewLogger(Writer, false, traceEnv, "")
@@ -43,6 +49,16 @@ func main() {
 
 	newArgs, isVerbose := handleVerbose(os.Args)
 	os.Args = newArgs
+
+	//handles `cf --output FORMAT --profile PROFILE COMMAND ...`, in either order
+	//rearrange args to `cf COMMAND ... --output FORMAT` so the command sees the option
+	//the profile is passed on in the environment, so plugins and the cf
+	//commands they run target it too
+	leadingArgs, profile := handleLeadingGlobalOptions(os.Args[1:])
+	os.Args = append([]string{os.Args[0]}, leadingArgs...)
+	if profile != "" {
+		os.Setenv("CF_PROFILE", profile)
+	}
 	traceLogger = trace.NewLogger(Writer, isVerbose, traceEnv, "")
 
 	errFunc := func(err error) {
@@ -62,6 +78,12 @@ func main() {
 
 	traceConfigVal := config.Trace()
 
+	if profile := os.Getenv("CF_PROFILE"); profile != "" && !hasTargetProfile(config, profile) {
+		fmt.Fprintln(Writer, terminal.FailureColor(T("FAILED")))
+		fmt.Fprintln(Writer, T("Target profile {{.Name}} not found", map[string]interface{}{"Name": profile}))
+		os.Exit(1)
+	}
+
 	traceLogger = trace.NewLogger(Writer, isVerbose, traceEnv, traceConfigVal)
 
 	deps := commandregistry.NewDependency(Writer, traceLogger)
@@ -96,6 +118,20 @@ func main() {
 		flagContext.SkipFlagParsing(meta.SkipFlagParsing)
 
 		cmdArgs := os.Args[2:]
+		if meta.OutputFormats {
+			var outputFormat terminal.OutputFormat
+			cmdArgs, outputFormat, err = handleOutputFormat(cmdArgs)
+			if err != nil {
+				deps.UI.Failed(err.Error())
+			}
+
+			deps.UI.SetOutputFormat(outputFormat)
+			deps.TeePrinter.DisableTerminalOutput(outputFormat.IsStructured())
+		} else if _, ok := meta.Flags["output"]; !ok && !meta.SkipFlagParsing && hasOutputFormat(cmdArgs) {
+			deps.UI.Failed(T("The {{.Command}} command does not support --output. It is supported by listing commands such as apps, services and routes.",
+				map[string]interface{}{"Command": meta.Name}))
+		}
+
 		err = flagContext.Parse(cmdArgs...)
 		if err != nil {
 			usage := cmdRegistry.CommandUsage(cmdName)
@@ -115,8 +151,19 @@ func main() {
 			}
 		}
 
-		err = cmd.Execute(flagContext)
+		hooks, hookService := commandHooks(deps, meta)
+		if hookService != nil {
+			err = rpc.RunPreCommandHooks(hookService, hooks, meta.Name, cmdArgs)
+			if err != nil {
+				deps.UI.Failed(err.Error())
+			}
+		}
+
+		err = executeCommand(cmd, flagContext, deps.UI, hookService, hooks, meta.Name, cmdArgs)
 		if err != nil {
+			if statusErr, ok := err.(*cferrors.ExitStatusError); ok {
+				exitStatus = statusErr.Status
+			}
 			ui := terminal.NewUI(os.Stdin, Writer, terminal.NewTeePrinter(Writer), traceLogger)
 			ui.Failed(err.Error())
 		}
@@ -127,7 +174,7 @@ func main() {
 	}
 
 	//non core command, try plugin command
-	rpcService, err := rpc.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, rpc.NewCommandRunner(), deps.Logger, Writer)
+	rpcService, err := rpc.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, rpc.NewCommandRunner(), deps.Logger, Writer, netrpc.DefaultServer)
 	if err != nil {
 		deps.UI.Say(T("Error initializing RPC service: ") + err.Error())
 		os.Exit(1)
@@ -138,12 +185,66 @@ func main() {
 	})
 	pluginList := pluginConfig.Plugins()
 
-	ran := rpc.RunMethodIfExists(rpcService, os.Args[1:], pluginList)
+	ran, err := rpc.RunMethodIfExists(rpcService, os.Args[1:], pluginList)
 	if !ran {
 		deps.UI.Say("'" + os.Args[1] + T("' is not a registered command. See 'cf help'"))
 		os.Exit(1)
 	}
+	if err != nil {
+		if statusErr, ok := err.(*cferrors.ExitStatusError); ok {
+			os.Exit(statusErr.Status)
+		}
+		deps.UI.Failed(err.Error())
+	}
+
+}
 
+// commandHooks returns the hooks installed plugins registered for the core
+// command, and the RPC service to run them with, which is nil when there are
+// none.
+func commandHooks(deps commandregistry.Dependency, meta commandregistry.CommandMetadata) ([]rpc.CommandHook, *rpc.CliRpcService) {
+	pluginConfig := pluginconfig.NewPluginConfig(func(err error) {
+		deps.UI.Failed(fmt.Sprintf("Error read/writing plugin config: %s, ", err.Error()))
+	})
+
+	hooks := rpc.FindCommandHooks(pluginConfig.Plugins(), meta)
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+
+	// The hooks get an RPC server of their own, so that the core command can
+	// still register services on net/rpc's default one.
+	rpcService, err := rpc.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, rpc.NewCommandRunner(), deps.Logger, Writer, netrpc.NewServer())
+	if err != nil {
+		deps.UI.Failed(T("Error initializing RPC service: ") + err.Error())
+	}
+	return hooks, rpcService
+}
+
+// executeCommand executes cmd and then runs its post command hooks, which
+// also run when cmd fails with ui.Failed.
+func executeCommand(cmd commandregistry.Command, flagContext flags.FlagContext, ui terminal.UI, hookService *rpc.CliRpcService, hooks []rpc.CommandHook, cmdName string, cmdArgs []string) (err error) {
+	if hookService != nil {
+		defer func() {
+			recovered := recover()
+			if recovered == terminal.QuietPanic {
+				err = errors.New(T("FAILED"))
+			} else if recovered != nil {
+				err = fmt.Errorf("%v", recovered)
+			}
+
+			hookErr := rpc.RunPostCommandHooks(hookService, hooks, cmdName, cmdArgs, err)
+			if hookErr != nil {
+				ui.Warn(hookErr.Error())
+			}
+
+			if recovered != nil {
+				panic(recovered)
+			}
+		}()
+	}
+
+	return cmd.Execute(flagContext)
 }
 
 func handlePanics(printer terminal.Printer, logger trace.Printer) {
@@ -156,7 +257,7 @@ func handlePanics(printer terminal.Printer, logger trace.Printer) {
 	panicprinter.DisplayCrashDialog(err, commandArgs, stackTrace)
 
 	if err != nil {
-		os.Exit(1)
+		os.Exit(exitStatus)
 	}
 }
 
@@ -212,3 +313,71 @@ func handleVerbose(args []string) ([]string, bool) {
 
 	return args, verbose
 }
+
+func handleLeadingGlobalOptions(args []string) ([]string, string) {
+	var profile string
+	outputArgs := []string{}
+
+	i := 0
+	for i < len(args) {
+		switch {
+		case args[i] == "--output" && i+1 < len(args):
+			outputArgs = append(outputArgs, args[i:i+2]...)
+			i += 2
+		case strings.HasPrefix(args[i], "--output="):
+			outputArgs = append(outputArgs, args[i])
+			i++
+		case args[i] == "--profile" && i+1 < len(args):
+			profile = args[i+1]
+			i += 2
+		case strings.HasPrefix(args[i], "--profile="):
+			profile = strings.TrimPrefix(args[i], "--profile=")
+			i++
+		default:
+			return append(append([]string{}, args[i:]...), outputArgs...), profile
+		}
+	}
+
+	return append(append([]string{}, args[i:]...), outputArgs...), profile
+}
+
+func hasTargetProfile(config coreconfig.Reader, name string) bool {
+	for _, profile := range config.Profiles() {
+		if profile.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasOutputFormat(args []string) bool {
+	for _, arg := range args {
+		if arg == "--output" || strings.HasPrefix(arg, "--output=") {
+			return true
+		}
+	}
+	return false
+}
+
+func handleOutputFormat(args []string) ([]string, terminal.OutputFormat, error) {
+	var value string
+	remaining := []string{}
+
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--output":
+			if i == len(args)-1 {
+				return nil, terminal.TableOutput, errors.New(T("No value provided for flag: --output"))
+			}
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--output="):
+			value = strings.TrimPrefix(args[i], "--output=")
+		default:
+			remaining = append(remaining, args[i])
+		}
+	}
+
+	format, err := terminal.ParseOutputFormat(value)
+	return remaining, format, err
+}
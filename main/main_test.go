@@ -2,6 +2,7 @@ package main_test
 
 import (
 	"bufio"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -45,6 +46,8 @@ var _ = Describe("main", func() {
 	})
 
 	AfterEach(func() {
+		os.Remove(filepath.Join(os.Getenv("CF_PLUGIN_HOME"), ".cf", "plugins", "config.json.lock"))
+
 		err := os.Setenv("CF_PLUGIN_HOME", old_PLUGINS_HOME)
 		Expect(err).NotTo(HaveOccurred())
 	})
@@ -114,6 +117,59 @@ var _ = Describe("main", func() {
 		})
 	})
 
+	Describe("Targets a profile for one command with --profile", func() {
+		var (
+			cfHome     string
+			old_CFHOME string
+		)
+
+		BeforeEach(func() {
+			var err error
+			cfHome, err = ioutil.TempDir("", "cf-home")
+			Expect(err).NotTo(HaveOccurred())
+
+			old_CFHOME = os.Getenv("CF_HOME")
+			os.Setenv("CF_HOME", cfHome)
+		})
+
+		AfterEach(func() {
+			os.Setenv("CF_HOME", old_CFHOME)
+			os.RemoveAll(cfHome)
+		})
+
+		It("fails when the profile has not been saved", func() {
+			output := Cf("--profile", "prod", "target").Wait(5 * time.Second)
+			Eventually(output.Out).Should(Say("Target profile prod not found"))
+			Expect(output.ExitCode()).To(Equal(1))
+		})
+
+		It("accepts --profile=PROFILE", func() {
+			output := Cf("--profile=prod", "target").Wait(5 * time.Second)
+			Eventually(output.Out).Should(Say("Target profile prod not found"))
+		})
+
+		Context("when the profile has been saved", func() {
+			BeforeEach(func() {
+				err := os.MkdirAll(filepath.Join(cfHome, ".cf"), 0700)
+				Expect(err).NotTo(HaveOccurred())
+				err = ioutil.WriteFile(filepath.Join(cfHome, ".cf", "config.json"), []byte(`{"ConfigVersion": 3, "Profiles": [{"Name": "prod"}]}`), 0600)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("accepts --output before --profile", func() {
+				result := Cf("--output", "xml", "--profile", "prod", "apps")
+				Eventually(result.Out).Should(Say("Invalid output format 'xml'"))
+				Eventually(result).Should(Exit(1))
+			})
+
+			It("accepts --output after --profile", func() {
+				result := Cf("--profile", "prod", "--output=xml", "apps")
+				Eventually(result.Out).Should(Say("Invalid output format 'xml'"))
+				Eventually(result).Should(Exit(1))
+			})
+		})
+	})
+
 	Describe("Shows debug information with -b or --build", func() {
 		It("prints the golang version if '--build' flag is provided", func() {
 			output := Cf("--build").Wait(1 * time.Second)
@@ -155,6 +211,43 @@ var _ = Describe("main", func() {
 		})
 	})
 
+	Describe("Selects the output format with --output", func() {
+		It("rejects unknown output formats", func() {
+			result := Cf("apps", "--output", "xml")
+			Eventually(result.Out).Should(Say("Invalid output format 'xml'"))
+			Eventually(result).Should(Exit(1))
+		})
+
+		It("accepts the option before the command name", func() {
+			result := Cf("--output", "xml", "apps")
+			Eventually(result.Out).Should(Say("Invalid output format 'xml'"))
+			Eventually(result).Should(Exit(1))
+		})
+
+		It("does not take other options starting with --output for it", func() {
+			result := Cf("--outputs", "apps")
+			Eventually(result.Out).Should(Say("'--outputs' is not a registered command"))
+			Eventually(result).Should(Exit(1))
+		})
+
+		It("writes failures to stderr when structured output is requested", func() {
+			dir, err := os.Getwd()
+			Expect(err).ToNot(HaveOccurred())
+			fullDir := filepath.Join(dir, "..", "fixtures") //set home to a config w/o targeted api
+			result := CfWith_CF_HOME(fullDir, "apps", "--output=json")
+
+			Eventually(result.Err).Should(Say("No API endpoint set."))
+			Eventually(result).Should(Exit(1))
+			Expect(result.Out.Contents()).To(BeEmpty())
+		})
+
+		It("is not accepted by commands without structured output", func() {
+			result := Cf("app", "my-app", "--output", "json")
+			Eventually(result.Out).Should(Say("The app command does not support --output"))
+			Eventually(result).Should(Exit(1))
+		})
+	})
+
 	Describe("exit codes", func() {
 		It("exits non-zero when an unknown command is invoked", func() {
 			result := Cf("some-command-that-should-never-actually-be-a-real-thing-i-can-use")
@@ -381,3 +381,166 @@ func (c *cliConnection) GetService(serviceInstance string) (plugin_models.GetSer
 
 	return result, err
 }
+
+func (c *cliConnection) GetRoutes() ([]plugin_models.GetRoutes_Model, error) {
+	var result []plugin_models.GetRoutes_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetRoutes", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetDomains() ([]plugin_models.GetDomains_Model, error) {
+	var result []plugin_models.GetDomains_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetDomains", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetServiceKeys(serviceInstance string) ([]plugin_models.GetServiceKeys_Model, error) {
+	var result []plugin_models.GetServiceKeys_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetServiceKeys", serviceInstance, &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetServiceKey(serviceInstance string, keyName string) (plugin_models.GetServiceKey_Model, error) {
+	var result plugin_models.GetServiceKey_Model
+
+	cmdArgs := []string{serviceInstance, keyName}
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetServiceKey", cmdArgs, &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetBuildpacks() ([]plugin_models.GetBuildpacks_Model, error) {
+	var result []plugin_models.GetBuildpacks_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetBuildpacks", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetStacks() ([]plugin_models.GetStacks_Model, error) {
+	var result []plugin_models.GetStacks_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetStacks", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetSecurityGroups() ([]plugin_models.GetSecurityGroups_Model, error) {
+	var result []plugin_models.GetSecurityGroups_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetSecurityGroups", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetQuotas() ([]plugin_models.GetQuotas_Model, error) {
+	var result []plugin_models.GetQuotas_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetQuotas", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetSpaceQuotas() ([]plugin_models.GetSpaceQuotas_Model, error) {
+	var result []plugin_models.GetSpaceQuotas_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetSpaceQuotas", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetAppEvents(appName string) ([]plugin_models.GetAppEvents_Model, error) {
+	var result []plugin_models.GetAppEvents_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetAppEvents", appName, &result)
+	})
+
+	return result, err
+}
+
+// TailLogs streams the logs of the app until stop is closed. Both channels
+// are closed when the stream ends; an error that ends it is sent on the
+// error channel first. Only one app can have its logs tailed at a time.
+func (c *cliConnection) TailLogs(appName string, stop <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error) {
+	messages := make(chan plugin_models.LogMessage)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(messages)
+
+		err := c.withClientDo(func(client *rpc.Client) error {
+			var started bool
+			err := client.Call("CliRpcCmd.StartLogStream", appName, &started)
+			if err != nil {
+				return err
+			}
+			defer client.Call("CliRpcCmd.StopLogStream", "", &started)
+
+			for {
+				select {
+				case <-stop:
+					return nil
+				default:
+				}
+
+				var batch plugin_models.LogBatch
+				err = client.Call("CliRpcCmd.ReadLogStream", "", &batch)
+				if err != nil {
+					return err
+				}
+
+				for _, message := range batch.Messages {
+					select {
+					case messages <- message:
+					case <-stop:
+						return nil
+					}
+				}
+
+				if batch.Ended {
+					return nil
+				}
+			}
+		})
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	return messages, errs
+}
+
+func (c *cliConnection) VetoCommand(message string) error {
+	var success bool
+
+	return c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.VetoCommand", message, &success)
+	})
+}
@@ -0,0 +1,64 @@
+package plugin
+
+import "strconv"
+
+// The first argument Run is passed when the plugin is run for one of its
+// hooks.
+const (
+	PreCommandHook  = "CLI-MESSAGE-PRE-COMMAND"
+	PostCommandHook = "CLI-MESSAGE-POST-COMMAND"
+)
+
+// CommandHookContext is the core command a hook is run for.
+type CommandHookContext struct {
+	// Hook is PreCommandHook or PostCommandHook.
+	Hook    string
+	Command string
+	Args    []string
+
+	// ExitStatus and Error are the result of the command, for post command
+	// hooks. Error is "" when the command succeeded.
+	ExitStatus int
+	Error      string
+}
+
+// CommandHookArgs returns the args Run is passed for the hook in context.
+// They are:
+//
+//	CLI-MESSAGE-PRE-COMMAND COMMAND [ARGS...]
+//	CLI-MESSAGE-POST-COMMAND COMMAND EXIT_STATUS ERROR [ARGS...]
+func CommandHookArgs(context CommandHookContext) []string {
+	args := []string{context.Hook, context.Command}
+	if context.Hook == PostCommandHook {
+		args = append(args, strconv.Itoa(context.ExitStatus), context.Error)
+	}
+	return append(args, context.Args...)
+}
+
+// ParseCommandHookArgs parses the args Run is passed when the plugin is run
+// for one of its hooks. ok is false when args are not for a hook.
+//
+// A pre command hook can stop the command from running with
+// CliConnection.VetoCommand, or by failing.
+func ParseCommandHookArgs(args []string) (context CommandHookContext, ok bool) {
+	if len(args) < 2 {
+		return CommandHookContext{}, false
+	}
+
+	switch args[0] {
+	case PreCommandHook:
+		return CommandHookContext{Hook: args[0], Command: args[1], Args: args[2:]}, true
+	case PostCommandHook:
+		if len(args) < 4 {
+			return CommandHookContext{}, false
+		}
+
+		exitStatus, err := strconv.Atoi(args[2])
+		if err != nil {
+			return CommandHookContext{}, false
+		}
+		return CommandHookContext{Hook: args[0], Command: args[1], ExitStatus: exitStatus, Error: args[3], Args: args[4:]}, true
+	}
+
+	return CommandHookContext{}, false
+}
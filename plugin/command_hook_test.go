@@ -0,0 +1,54 @@
+package plugin_test
+
+import (
+	"github.com/cloudfoundry/cli/plugin"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Command hooks", func() {
+	Describe("CommandHookArgs", func() {
+		It("passes the command and its args to pre command hooks", func() {
+			args := plugin.CommandHookArgs(plugin.CommandHookContext{
+				Hook:    plugin.PreCommandHook,
+				Command: "delete",
+				Args:    []string{"my-app", "-f"},
+			})
+			Expect(args).To(Equal([]string{"CLI-MESSAGE-PRE-COMMAND", "delete", "my-app", "-f"}))
+		})
+
+		It("also passes the exit status and error to post command hooks", func() {
+			args := plugin.CommandHookArgs(plugin.CommandHookContext{
+				Hook:       plugin.PostCommandHook,
+				Command:    "delete",
+				Args:       []string{"my-app"},
+				ExitStatus: 1,
+				Error:      "App my-app not found",
+			})
+			Expect(args).To(Equal([]string{"CLI-MESSAGE-POST-COMMAND", "delete", "1", "App my-app not found", "my-app"}))
+		})
+	})
+
+	Describe("ParseCommandHookArgs", func() {
+		It("parses the args CommandHookArgs returns", func() {
+			contexts := []plugin.CommandHookContext{
+				{Hook: plugin.PreCommandHook, Command: "push", Args: []string{"my-app"}},
+				{Hook: plugin.PostCommandHook, Command: "push", Args: []string{}, ExitStatus: 2, Error: "failed"},
+			}
+
+			for _, context := range contexts {
+				parsed, ok := plugin.ParseCommandHookArgs(plugin.CommandHookArgs(context))
+				Expect(ok).To(BeTrue())
+				Expect(parsed).To(Equal(context))
+			}
+		})
+
+		It("returns false when the args are not for a hook", func() {
+			_, ok := plugin.ParseCommandHookArgs([]string{"my-command", "arg"})
+			Expect(ok).To(BeFalse())
+
+			_, ok = plugin.ParseCommandHookArgs([]string{"CLI-MESSAGE-POST-COMMAND", "delete", "not-a-status", ""})
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
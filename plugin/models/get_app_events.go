@@ -0,0 +1,12 @@
+package plugin_models
+
+import "time"
+
+type GetAppEvents_Model struct {
+	Guid        string
+	Name        string
+	Timestamp   time.Time
+	Description string
+	Actor       string
+	ActorName   string
+}
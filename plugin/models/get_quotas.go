@@ -0,0 +1,12 @@
+package plugin_models
+
+type GetQuotas_Model struct {
+	Guid                    string
+	Name                    string
+	MemoryLimit             int64
+	InstanceMemoryLimit     int64
+	RoutesLimit             int
+	ServicesLimit           int
+	NonBasicServicesAllowed bool
+	AppInstanceLimit        int
+}
@@ -0,0 +1,8 @@
+package plugin_models
+
+type GetServiceKey_Model struct {
+	Guid                string
+	Name                string
+	ServiceInstanceGuid string
+	Credentials         map[string]interface{}
+}
@@ -0,0 +1,7 @@
+package plugin_models
+
+type GetServiceKeys_Model struct {
+	Guid                string
+	Name                string
+	ServiceInstanceGuid string
+}
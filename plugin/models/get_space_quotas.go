@@ -0,0 +1,13 @@
+package plugin_models
+
+type GetSpaceQuotas_Model struct {
+	Guid                    string
+	Name                    string
+	OrgGuid                 string
+	MemoryLimit             int64
+	InstanceMemoryLimit     int64
+	RoutesLimit             int
+	ServicesLimit           int
+	NonBasicServicesAllowed bool
+	AppInstanceLimit        int
+}
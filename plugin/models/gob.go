@@ -0,0 +1,10 @@
+package plugin_models
+
+import "encoding/gob"
+
+func init() {
+	// Service key credentials and security group rules are decoded from
+	// JSON into interface values, which gob only sends as registered types.
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
@@ -0,0 +1,18 @@
+package plugin_models
+
+import "time"
+
+type LogMessage struct {
+	Message        string
+	IsError        bool
+	Timestamp      time.Time
+	SourceName     string
+	SourceInstance string
+}
+
+// LogBatch is the log messages read from a log stream at once. Ended is
+// true when the stream has no more messages.
+type LogBatch struct {
+	Messages []LogMessage
+	Ended    bool
+}
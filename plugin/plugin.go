@@ -42,6 +42,18 @@ type CliConnection interface {
 	GetService(string) (plugin_models.GetService_Model, error)
 	GetOrg(string) (plugin_models.GetOrg_Model, error)
 	GetSpace(string) (plugin_models.GetSpace_Model, error)
+	GetRoutes() ([]plugin_models.GetRoutes_Model, error)
+	GetDomains() ([]plugin_models.GetDomains_Model, error)
+	GetServiceKeys(string) ([]plugin_models.GetServiceKeys_Model, error)
+	GetServiceKey(string, string) (plugin_models.GetServiceKey_Model, error)
+	GetBuildpacks() ([]plugin_models.GetBuildpacks_Model, error)
+	GetStacks() ([]plugin_models.GetStacks_Model, error)
+	GetSecurityGroups() ([]plugin_models.GetSecurityGroups_Model, error)
+	GetQuotas() ([]plugin_models.GetQuotas_Model, error)
+	GetSpaceQuotas() ([]plugin_models.GetSpaceQuotas_Model, error)
+	GetAppEvents(string) ([]plugin_models.GetAppEvents_Model, error)
+	TailLogs(string, <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error)
+	VetoCommand(string) error
 }
 
 type VersionType struct {
@@ -55,6 +67,16 @@ type PluginMetadata struct {
 	Version       VersionType
 	MinCliVersion VersionType
 	Commands      []Command
+	Hooks         []Hook
+}
+
+// Hook registers the plugin to be run before, after, or both, the core
+// command Command, which can be a command name or alias. See
+// ParseCommandHookArgs for how the plugin is run.
+type Hook struct {
+	Command string
+	Pre     bool
+	Post    bool
 }
 
 type Usage struct {
@@ -5,7 +5,7 @@ import (
 	"sync"
 
 	"github.com/cloudfoundry/cli/plugin"
-	"github.com/cloudfoundry/cli/plugin/models"
+	plugin_models "github.com/cloudfoundry/cli/plugin/models"
 )
 
 type FakeCliConnection struct {
@@ -216,6 +216,101 @@ type FakeCliConnection struct {
 		result1 plugin_models.GetSpace_Model
 		result2 error
 	}
+	GetRoutesStub        func() ([]plugin_models.GetRoutes_Model, error)
+	getRoutesMutex       sync.RWMutex
+	getRoutesArgsForCall []struct{}
+	getRoutesReturns     struct {
+		result1 []plugin_models.GetRoutes_Model
+		result2 error
+	}
+	GetDomainsStub        func() ([]plugin_models.GetDomains_Model, error)
+	getDomainsMutex       sync.RWMutex
+	getDomainsArgsForCall []struct{}
+	getDomainsReturns     struct {
+		result1 []plugin_models.GetDomains_Model
+		result2 error
+	}
+	GetServiceKeysStub        func(string) ([]plugin_models.GetServiceKeys_Model, error)
+	getServiceKeysMutex       sync.RWMutex
+	getServiceKeysArgsForCall []struct {
+		arg1 string
+	}
+	getServiceKeysReturns struct {
+		result1 []plugin_models.GetServiceKeys_Model
+		result2 error
+	}
+	GetServiceKeyStub        func(string, string) (plugin_models.GetServiceKey_Model, error)
+	getServiceKeyMutex       sync.RWMutex
+	getServiceKeyArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getServiceKeyReturns struct {
+		result1 plugin_models.GetServiceKey_Model
+		result2 error
+	}
+	GetBuildpacksStub        func() ([]plugin_models.GetBuildpacks_Model, error)
+	getBuildpacksMutex       sync.RWMutex
+	getBuildpacksArgsForCall []struct{}
+	getBuildpacksReturns     struct {
+		result1 []plugin_models.GetBuildpacks_Model
+		result2 error
+	}
+	GetStacksStub        func() ([]plugin_models.GetStacks_Model, error)
+	getStacksMutex       sync.RWMutex
+	getStacksArgsForCall []struct{}
+	getStacksReturns     struct {
+		result1 []plugin_models.GetStacks_Model
+		result2 error
+	}
+	GetSecurityGroupsStub        func() ([]plugin_models.GetSecurityGroups_Model, error)
+	getSecurityGroupsMutex       sync.RWMutex
+	getSecurityGroupsArgsForCall []struct{}
+	getSecurityGroupsReturns     struct {
+		result1 []plugin_models.GetSecurityGroups_Model
+		result2 error
+	}
+	GetQuotasStub        func() ([]plugin_models.GetQuotas_Model, error)
+	getQuotasMutex       sync.RWMutex
+	getQuotasArgsForCall []struct{}
+	getQuotasReturns     struct {
+		result1 []plugin_models.GetQuotas_Model
+		result2 error
+	}
+	GetSpaceQuotasStub        func() ([]plugin_models.GetSpaceQuotas_Model, error)
+	getSpaceQuotasMutex       sync.RWMutex
+	getSpaceQuotasArgsForCall []struct{}
+	getSpaceQuotasReturns     struct {
+		result1 []plugin_models.GetSpaceQuotas_Model
+		result2 error
+	}
+	GetAppEventsStub        func(string) ([]plugin_models.GetAppEvents_Model, error)
+	getAppEventsMutex       sync.RWMutex
+	getAppEventsArgsForCall []struct {
+		arg1 string
+	}
+	getAppEventsReturns struct {
+		result1 []plugin_models.GetAppEvents_Model
+		result2 error
+	}
+	TailLogsStub        func(string, <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error)
+	tailLogsMutex       sync.RWMutex
+	tailLogsArgsForCall []struct {
+		arg1 string
+		arg2 <-chan struct{}
+	}
+	tailLogsReturns struct {
+		result1 <-chan plugin_models.LogMessage
+		result2 <-chan error
+	}
+	VetoCommandStub        func(string) error
+	vetoCommandMutex       sync.RWMutex
+	vetoCommandArgsForCall []struct {
+		arg1 string
+	}
+	vetoCommandReturns struct {
+		result1 error
+	}
 }
 
 func (fake *FakeCliConnection) CliCommandWithoutTerminalOutput(args ...string) ([]string, error) {
@@ -959,4 +1054,345 @@ func (fake *FakeCliConnection) GetSpaceReturns(result1 plugin_models.GetSpace_Mo
 	}{result1, result2}
 }
 
+func (fake *FakeCliConnection) GetRoutes() ([]plugin_models.GetRoutes_Model, error) {
+	fake.getRoutesMutex.Lock()
+	fake.getRoutesArgsForCall = append(fake.getRoutesArgsForCall, struct{}{})
+	fake.getRoutesMutex.Unlock()
+	if fake.GetRoutesStub != nil {
+		return fake.GetRoutesStub()
+	} else {
+		return fake.getRoutesReturns.result1, fake.getRoutesReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetRoutesCallCount() int {
+	fake.getRoutesMutex.RLock()
+	defer fake.getRoutesMutex.RUnlock()
+	return len(fake.getRoutesArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetRoutesReturns(result1 []plugin_models.GetRoutes_Model, result2 error) {
+	fake.GetRoutesStub = nil
+	fake.getRoutesReturns = struct {
+		result1 []plugin_models.GetRoutes_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetDomains() ([]plugin_models.GetDomains_Model, error) {
+	fake.getDomainsMutex.Lock()
+	fake.getDomainsArgsForCall = append(fake.getDomainsArgsForCall, struct{}{})
+	fake.getDomainsMutex.Unlock()
+	if fake.GetDomainsStub != nil {
+		return fake.GetDomainsStub()
+	} else {
+		return fake.getDomainsReturns.result1, fake.getDomainsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetDomainsCallCount() int {
+	fake.getDomainsMutex.RLock()
+	defer fake.getDomainsMutex.RUnlock()
+	return len(fake.getDomainsArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetDomainsReturns(result1 []plugin_models.GetDomains_Model, result2 error) {
+	fake.GetDomainsStub = nil
+	fake.getDomainsReturns = struct {
+		result1 []plugin_models.GetDomains_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetServiceKeys(arg1 string) ([]plugin_models.GetServiceKeys_Model, error) {
+	fake.getServiceKeysMutex.Lock()
+	fake.getServiceKeysArgsForCall = append(fake.getServiceKeysArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.getServiceKeysMutex.Unlock()
+	if fake.GetServiceKeysStub != nil {
+		return fake.GetServiceKeysStub(arg1)
+	} else {
+		return fake.getServiceKeysReturns.result1, fake.getServiceKeysReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetServiceKeysCallCount() int {
+	fake.getServiceKeysMutex.RLock()
+	defer fake.getServiceKeysMutex.RUnlock()
+	return len(fake.getServiceKeysArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetServiceKeysArgsForCall(i int) string {
+	fake.getServiceKeysMutex.RLock()
+	defer fake.getServiceKeysMutex.RUnlock()
+	return fake.getServiceKeysArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnection) GetServiceKeysReturns(result1 []plugin_models.GetServiceKeys_Model, result2 error) {
+	fake.GetServiceKeysStub = nil
+	fake.getServiceKeysReturns = struct {
+		result1 []plugin_models.GetServiceKeys_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetServiceKey(arg1 string, arg2 string) (plugin_models.GetServiceKey_Model, error) {
+	fake.getServiceKeyMutex.Lock()
+	fake.getServiceKeyArgsForCall = append(fake.getServiceKeyArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.getServiceKeyMutex.Unlock()
+	if fake.GetServiceKeyStub != nil {
+		return fake.GetServiceKeyStub(arg1, arg2)
+	} else {
+		return fake.getServiceKeyReturns.result1, fake.getServiceKeyReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetServiceKeyCallCount() int {
+	fake.getServiceKeyMutex.RLock()
+	defer fake.getServiceKeyMutex.RUnlock()
+	return len(fake.getServiceKeyArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetServiceKeyArgsForCall(i int) (string, string) {
+	fake.getServiceKeyMutex.RLock()
+	defer fake.getServiceKeyMutex.RUnlock()
+	return fake.getServiceKeyArgsForCall[i].arg1, fake.getServiceKeyArgsForCall[i].arg2
+}
+
+func (fake *FakeCliConnection) GetServiceKeyReturns(result1 plugin_models.GetServiceKey_Model, result2 error) {
+	fake.GetServiceKeyStub = nil
+	fake.getServiceKeyReturns = struct {
+		result1 plugin_models.GetServiceKey_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetBuildpacks() ([]plugin_models.GetBuildpacks_Model, error) {
+	fake.getBuildpacksMutex.Lock()
+	fake.getBuildpacksArgsForCall = append(fake.getBuildpacksArgsForCall, struct{}{})
+	fake.getBuildpacksMutex.Unlock()
+	if fake.GetBuildpacksStub != nil {
+		return fake.GetBuildpacksStub()
+	} else {
+		return fake.getBuildpacksReturns.result1, fake.getBuildpacksReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetBuildpacksCallCount() int {
+	fake.getBuildpacksMutex.RLock()
+	defer fake.getBuildpacksMutex.RUnlock()
+	return len(fake.getBuildpacksArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetBuildpacksReturns(result1 []plugin_models.GetBuildpacks_Model, result2 error) {
+	fake.GetBuildpacksStub = nil
+	fake.getBuildpacksReturns = struct {
+		result1 []plugin_models.GetBuildpacks_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetStacks() ([]plugin_models.GetStacks_Model, error) {
+	fake.getStacksMutex.Lock()
+	fake.getStacksArgsForCall = append(fake.getStacksArgsForCall, struct{}{})
+	fake.getStacksMutex.Unlock()
+	if fake.GetStacksStub != nil {
+		return fake.GetStacksStub()
+	} else {
+		return fake.getStacksReturns.result1, fake.getStacksReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetStacksCallCount() int {
+	fake.getStacksMutex.RLock()
+	defer fake.getStacksMutex.RUnlock()
+	return len(fake.getStacksArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetStacksReturns(result1 []plugin_models.GetStacks_Model, result2 error) {
+	fake.GetStacksStub = nil
+	fake.getStacksReturns = struct {
+		result1 []plugin_models.GetStacks_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetSecurityGroups() ([]plugin_models.GetSecurityGroups_Model, error) {
+	fake.getSecurityGroupsMutex.Lock()
+	fake.getSecurityGroupsArgsForCall = append(fake.getSecurityGroupsArgsForCall, struct{}{})
+	fake.getSecurityGroupsMutex.Unlock()
+	if fake.GetSecurityGroupsStub != nil {
+		return fake.GetSecurityGroupsStub()
+	} else {
+		return fake.getSecurityGroupsReturns.result1, fake.getSecurityGroupsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetSecurityGroupsCallCount() int {
+	fake.getSecurityGroupsMutex.RLock()
+	defer fake.getSecurityGroupsMutex.RUnlock()
+	return len(fake.getSecurityGroupsArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetSecurityGroupsReturns(result1 []plugin_models.GetSecurityGroups_Model, result2 error) {
+	fake.GetSecurityGroupsStub = nil
+	fake.getSecurityGroupsReturns = struct {
+		result1 []plugin_models.GetSecurityGroups_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetQuotas() ([]plugin_models.GetQuotas_Model, error) {
+	fake.getQuotasMutex.Lock()
+	fake.getQuotasArgsForCall = append(fake.getQuotasArgsForCall, struct{}{})
+	fake.getQuotasMutex.Unlock()
+	if fake.GetQuotasStub != nil {
+		return fake.GetQuotasStub()
+	} else {
+		return fake.getQuotasReturns.result1, fake.getQuotasReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetQuotasCallCount() int {
+	fake.getQuotasMutex.RLock()
+	defer fake.getQuotasMutex.RUnlock()
+	return len(fake.getQuotasArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetQuotasReturns(result1 []plugin_models.GetQuotas_Model, result2 error) {
+	fake.GetQuotasStub = nil
+	fake.getQuotasReturns = struct {
+		result1 []plugin_models.GetQuotas_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetSpaceQuotas() ([]plugin_models.GetSpaceQuotas_Model, error) {
+	fake.getSpaceQuotasMutex.Lock()
+	fake.getSpaceQuotasArgsForCall = append(fake.getSpaceQuotasArgsForCall, struct{}{})
+	fake.getSpaceQuotasMutex.Unlock()
+	if fake.GetSpaceQuotasStub != nil {
+		return fake.GetSpaceQuotasStub()
+	} else {
+		return fake.getSpaceQuotasReturns.result1, fake.getSpaceQuotasReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetSpaceQuotasCallCount() int {
+	fake.getSpaceQuotasMutex.RLock()
+	defer fake.getSpaceQuotasMutex.RUnlock()
+	return len(fake.getSpaceQuotasArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetSpaceQuotasReturns(result1 []plugin_models.GetSpaceQuotas_Model, result2 error) {
+	fake.GetSpaceQuotasStub = nil
+	fake.getSpaceQuotasReturns = struct {
+		result1 []plugin_models.GetSpaceQuotas_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetAppEvents(arg1 string) ([]plugin_models.GetAppEvents_Model, error) {
+	fake.getAppEventsMutex.Lock()
+	fake.getAppEventsArgsForCall = append(fake.getAppEventsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.getAppEventsMutex.Unlock()
+	if fake.GetAppEventsStub != nil {
+		return fake.GetAppEventsStub(arg1)
+	} else {
+		return fake.getAppEventsReturns.result1, fake.getAppEventsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetAppEventsCallCount() int {
+	fake.getAppEventsMutex.RLock()
+	defer fake.getAppEventsMutex.RUnlock()
+	return len(fake.getAppEventsArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetAppEventsArgsForCall(i int) string {
+	fake.getAppEventsMutex.RLock()
+	defer fake.getAppEventsMutex.RUnlock()
+	return fake.getAppEventsArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnection) GetAppEventsReturns(result1 []plugin_models.GetAppEvents_Model, result2 error) {
+	fake.GetAppEventsStub = nil
+	fake.getAppEventsReturns = struct {
+		result1 []plugin_models.GetAppEvents_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) TailLogs(arg1 string, arg2 <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error) {
+	fake.tailLogsMutex.Lock()
+	fake.tailLogsArgsForCall = append(fake.tailLogsArgsForCall, struct {
+		arg1 string
+		arg2 <-chan struct{}
+	}{arg1, arg2})
+	fake.tailLogsMutex.Unlock()
+	if fake.TailLogsStub != nil {
+		return fake.TailLogsStub(arg1, arg2)
+	} else {
+		return fake.tailLogsReturns.result1, fake.tailLogsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) TailLogsCallCount() int {
+	fake.tailLogsMutex.RLock()
+	defer fake.tailLogsMutex.RUnlock()
+	return len(fake.tailLogsArgsForCall)
+}
+
+func (fake *FakeCliConnection) TailLogsArgsForCall(i int) (string, <-chan struct{}) {
+	fake.tailLogsMutex.RLock()
+	defer fake.tailLogsMutex.RUnlock()
+	return fake.tailLogsArgsForCall[i].arg1, fake.tailLogsArgsForCall[i].arg2
+}
+
+func (fake *FakeCliConnection) TailLogsReturns(result1 <-chan plugin_models.LogMessage, result2 <-chan error) {
+	fake.TailLogsStub = nil
+	fake.tailLogsReturns = struct {
+		result1 <-chan plugin_models.LogMessage
+		result2 <-chan error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) VetoCommand(arg1 string) error {
+	fake.vetoCommandMutex.Lock()
+	fake.vetoCommandArgsForCall = append(fake.vetoCommandArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.vetoCommandMutex.Unlock()
+	if fake.VetoCommandStub != nil {
+		return fake.VetoCommandStub(arg1)
+	} else {
+		return fake.vetoCommandReturns.result1
+	}
+}
+
+func (fake *FakeCliConnection) VetoCommandCallCount() int {
+	fake.vetoCommandMutex.RLock()
+	defer fake.vetoCommandMutex.RUnlock()
+	return len(fake.vetoCommandArgsForCall)
+}
+
+func (fake *FakeCliConnection) VetoCommandArgsForCall(i int) string {
+	fake.vetoCommandMutex.RLock()
+	defer fake.vetoCommandMutex.RUnlock()
+	return fake.vetoCommandArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnection) VetoCommandReturns(result1 error) {
+	fake.VetoCommandStub = nil
+	fake.vetoCommandReturns = struct {
+		result1 error
+	}{result1}
+}
+
 var _ plugin.CliConnection = new(FakeCliConnection)
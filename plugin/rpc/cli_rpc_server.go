@@ -1,13 +1,16 @@
 package rpc
 
 import (
+	"errors"
 	"os"
+	"sync"
 
 	"github.com/blang/semver"
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/plugin"
 	"github.com/cloudfoundry/cli/plugin/models"
@@ -26,6 +29,7 @@ import (
 type CliRpcService struct {
 	listener net.Listener
 	stopCh   chan struct{}
+	server   *rpc.Server
 	Pinged   bool
 	RpcCmd   *CliRpcCmd
 }
@@ -40,6 +44,12 @@ type CliRpcCmd struct {
 	outputBucket         *bytes.Buffer
 	logger               trace.Printer
 	stdout               io.Writer
+
+	logStreamMutex sync.Mutex
+	logStream      *logStream
+
+	vetoMutex   sync.Mutex
+	vetoMessage string
 }
 
 //go:generate counterfeiter . TerminalOutputSwitch
@@ -62,8 +72,10 @@ func NewRpcService(
 	newCmdRunner CommandRunner,
 	logger trace.Printer,
 	w io.Writer,
+	rpcServer *rpc.Server,
 ) (*CliRpcService, error) {
 	rpcService := &CliRpcService{
+		server: rpcServer,
 		RpcCmd: &CliRpcCmd{
 			PluginMetadata:       &plugin.PluginMetadata{},
 			outputCapture:        outputCapture,
@@ -77,7 +89,7 @@ func NewRpcService(
 		},
 	}
 
-	err := rpc.Register(rpcService.RpcCmd)
+	err := rpcServer.Register(rpcService.RpcCmd)
 	if err != nil {
 		return nil, err
 	}
@@ -95,27 +107,29 @@ func (cli *CliRpcService) Port() string {
 }
 
 func (cli *CliRpcService) Start() error {
-	var err error
-
-	cli.stopCh = make(chan struct{})
-
-	cli.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
 		return err
 	}
 
+	// The service can be started again once stopped, so the goroutine
+	// keeps to the listener and stop channel of this start.
+	stopCh := make(chan struct{})
+	cli.listener = listener
+	cli.stopCh = stopCh
+
 	go func() {
 		for {
-			conn, err := cli.listener.Accept()
+			conn, err := listener.Accept()
 			if err != nil {
 				select {
-				case <-cli.stopCh:
+				case <-stopCh:
 					return
 				default:
 					fmt.Println(err)
 				}
 			} else {
-				go rpc.ServeConn(conn)
+				go cli.server.ServeConn(conn)
 			}
 		}
 	}()
@@ -150,6 +164,27 @@ func (cmd *CliRpcCmd) SetPluginMetadata(pluginMetadata plugin.PluginMetadata, re
 	return nil
 }
 
+// VetoCommand stops the core command a pre command hook is run for from
+// running, with message as the reason.
+func (cmd *CliRpcCmd) VetoCommand(message string, retVal *bool) error {
+	cmd.vetoMutex.Lock()
+	defer cmd.vetoMutex.Unlock()
+
+	cmd.vetoMessage = message
+	*retVal = true
+	return nil
+}
+
+// takeVeto returns the message of the last veto, if any, and forgets it.
+func (cmd *CliRpcCmd) takeVeto() string {
+	cmd.vetoMutex.Lock()
+	defer cmd.vetoMutex.Unlock()
+
+	message := cmd.vetoMessage
+	cmd.vetoMessage = ""
+	return message
+}
+
 func (cmd *CliRpcCmd) DisableTerminalOutput(disable bool, retVal *bool) error {
 	cmd.terminalOutputSwitch.DisableTerminalOutput(disable)
 	*retVal = true
@@ -475,3 +510,265 @@ func (cmd *CliRpcCmd) GetService(serviceInstance string, retVal *plugin_models.G
 
 	return cmd.newCmdRunner.Command([]string{"service", serviceInstance}, deps, true)
 }
+
+func (cmd *CliRpcCmd) GetRoutes(_ string, retVal *[]plugin_models.GetRoutes_Model) error {
+	routes := []plugin_models.GetRoutes_Model{}
+
+	err := cmd.repoLocator.GetRouteRepository().ListRoutes(func(route models.Route) bool {
+		model := plugin_models.GetRoutes_Model{
+			Guid: route.GUID,
+			Host: route.Host,
+			Domain: plugin_models.GetRoutes_Domain{
+				Guid: route.Domain.GUID,
+				Name: route.Domain.Name,
+			},
+			Path: route.Path,
+			Port: route.Port,
+			Space: plugin_models.GetRoutes_Space{
+				Guid: route.Space.GUID,
+				Name: route.Space.Name,
+			},
+			ServiceInstance: plugin_models.GetRoutes_ServiceInstance{
+				Guid: route.ServiceInstance.GUID,
+				Name: route.ServiceInstance.Name,
+			},
+		}
+		for _, app := range route.Apps {
+			model.Apps = append(model.Apps, plugin_models.GetRoutes_App{
+				Guid: app.GUID,
+				Name: app.Name,
+			})
+		}
+
+		routes = append(routes, model)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	*retVal = routes
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetDomains(_ string, retVal *[]plugin_models.GetDomains_Model) error {
+	if !cmd.cliConfig.HasOrganization() {
+		return errors.New("No org targeted")
+	}
+
+	domains := []plugin_models.GetDomains_Model{}
+
+	err := cmd.repoLocator.GetDomainRepository().ListDomainsForOrg(cmd.cliConfig.OrganizationFields().GUID, func(domain models.DomainFields) bool {
+		domains = append(domains, plugin_models.GetDomains_Model{
+			Guid:                   domain.GUID,
+			Name:                   domain.Name,
+			OwningOrganizationGuid: domain.OwningOrganizationGUID,
+			RouterGroupType:        domain.RouterGroupType,
+			Shared:                 domain.Shared,
+		})
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	*retVal = domains
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetServiceKeys(serviceInstance string, retVal *[]plugin_models.GetServiceKeys_Model) error {
+	instance, err := cmd.repoLocator.GetServiceRepository().FindInstanceByName(serviceInstance)
+	if err != nil {
+		return err
+	}
+
+	serviceKeys, err := cmd.repoLocator.GetServiceKeyRepository().ListServiceKeys(instance.GUID)
+	if err != nil {
+		return err
+	}
+
+	keys := []plugin_models.GetServiceKeys_Model{}
+	for _, serviceKey := range serviceKeys {
+		keys = append(keys, plugin_models.GetServiceKeys_Model{
+			Guid:                serviceKey.Fields.GUID,
+			Name:                serviceKey.Fields.Name,
+			ServiceInstanceGuid: instance.GUID,
+		})
+	}
+
+	*retVal = keys
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetServiceKey(args []string, retVal *plugin_models.GetServiceKey_Model) error {
+	if len(args) != 2 {
+		return errors.New("GetServiceKey requires a service instance name and a service key name")
+	}
+
+	instance, err := cmd.repoLocator.GetServiceRepository().FindInstanceByName(args[0])
+	if err != nil {
+		return err
+	}
+
+	serviceKey, err := cmd.repoLocator.GetServiceKeyRepository().GetServiceKey(instance.GUID, args[1])
+	if err != nil {
+		return err
+	}
+	if serviceKey.Fields.GUID == "" {
+		return fmt.Errorf("Service key %s not found", args[1])
+	}
+
+	retVal.Guid = serviceKey.Fields.GUID
+	retVal.Name = serviceKey.Fields.Name
+	retVal.ServiceInstanceGuid = instance.GUID
+	retVal.Credentials = serviceKey.Credentials
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetBuildpacks(_ string, retVal *[]plugin_models.GetBuildpacks_Model) error {
+	buildpacks := []plugin_models.GetBuildpacks_Model{}
+
+	err := cmd.repoLocator.GetBuildpackRepository().ListBuildpacks(func(buildpack models.Buildpack) bool {
+		model := plugin_models.GetBuildpacks_Model{
+			Guid:     buildpack.GUID,
+			Name:     buildpack.Name,
+			Filename: buildpack.Filename,
+		}
+		if buildpack.Position != nil {
+			model.Position = *buildpack.Position
+		}
+		if buildpack.Enabled != nil {
+			model.Enabled = *buildpack.Enabled
+		}
+		if buildpack.Locked != nil {
+			model.Locked = *buildpack.Locked
+		}
+
+		buildpacks = append(buildpacks, model)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	*retVal = buildpacks
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetStacks(_ string, retVal *[]plugin_models.GetStacks_Model) error {
+	stacks, err := cmd.repoLocator.GetStackRepository().FindAll()
+	if err != nil {
+		return err
+	}
+
+	*retVal = []plugin_models.GetStacks_Model{}
+	for _, stack := range stacks {
+		*retVal = append(*retVal, plugin_models.GetStacks_Model{
+			Guid:        stack.GUID,
+			Name:        stack.Name,
+			Description: stack.Description,
+		})
+	}
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetSecurityGroups(_ string, retVal *[]plugin_models.GetSecurityGroups_Model) error {
+	securityGroups, err := cmd.repoLocator.GetSecurityGroupRepository().FindAll()
+	if err != nil {
+		return err
+	}
+
+	*retVal = []plugin_models.GetSecurityGroups_Model{}
+	for _, securityGroup := range securityGroups {
+		model := plugin_models.GetSecurityGroups_Model{
+			Guid:  securityGroup.GUID,
+			Name:  securityGroup.Name,
+			Rules: securityGroup.Rules,
+		}
+		for _, space := range securityGroup.Spaces {
+			model.Spaces = append(model.Spaces, plugin_models.GetSecurityGroups_Space{
+				Guid:    space.GUID,
+				Name:    space.Name,
+				OrgGuid: space.Organization.GUID,
+				OrgName: space.Organization.Name,
+			})
+		}
+
+		*retVal = append(*retVal, model)
+	}
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetQuotas(_ string, retVal *[]plugin_models.GetQuotas_Model) error {
+	quotas, err := cmd.repoLocator.GetQuotaRepository().FindAll()
+	if err != nil {
+		return err
+	}
+
+	*retVal = []plugin_models.GetQuotas_Model{}
+	for _, quota := range quotas {
+		*retVal = append(*retVal, plugin_models.GetQuotas_Model{
+			Guid:                    quota.GUID,
+			Name:                    quota.Name,
+			MemoryLimit:             quota.MemoryLimit,
+			InstanceMemoryLimit:     quota.InstanceMemoryLimit,
+			RoutesLimit:             quota.RoutesLimit,
+			ServicesLimit:           quota.ServicesLimit,
+			NonBasicServicesAllowed: quota.NonBasicServicesAllowed,
+			AppInstanceLimit:        quota.AppInstanceLimit,
+		})
+	}
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetSpaceQuotas(_ string, retVal *[]plugin_models.GetSpaceQuotas_Model) error {
+	if !cmd.cliConfig.HasOrganization() {
+		return errors.New("No org targeted")
+	}
+
+	quotas, err := cmd.repoLocator.GetSpaceQuotaRepository().FindByOrg(cmd.cliConfig.OrganizationFields().GUID)
+	if err != nil {
+		return err
+	}
+
+	*retVal = []plugin_models.GetSpaceQuotas_Model{}
+	for _, quota := range quotas {
+		*retVal = append(*retVal, plugin_models.GetSpaceQuotas_Model{
+			Guid:                    quota.GUID,
+			Name:                    quota.Name,
+			OrgGuid:                 quota.OrgGUID,
+			MemoryLimit:             quota.MemoryLimit,
+			InstanceMemoryLimit:     quota.InstanceMemoryLimit,
+			RoutesLimit:             quota.RoutesLimit,
+			ServicesLimit:           quota.ServicesLimit,
+			NonBasicServicesAllowed: quota.NonBasicServicesAllowed,
+			AppInstanceLimit:        quota.AppInstanceLimit,
+		})
+	}
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetAppEvents(appName string, retVal *[]plugin_models.GetAppEvents_Model) error {
+	app, err := cmd.repoLocator.GetApplicationRepository().Read(appName)
+	if err != nil {
+		return err
+	}
+
+	events, err := cmd.repoLocator.GetAppEventsRepository().RecentEvents(app.GUID, 50)
+	if err != nil {
+		return err
+	}
+
+	*retVal = []plugin_models.GetAppEvents_Model{}
+	for _, event := range events {
+		*retVal = append(*retVal, plugin_models.GetAppEvents_Model{
+			Guid:        event.GUID,
+			Name:        event.Name,
+			Timestamp:   event.Timestamp,
+			Description: event.Description,
+			Actor:       event.Actor,
+			ActorName:   event.ActorName,
+		})
+	}
+	return nil
+}
@@ -9,7 +9,16 @@ import (
 
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/api"
+	"github.com/cloudfoundry/cli/cf/api/apifakes"
+	"github.com/cloudfoundry/cli/cf/api/appevents/appeventsfakes"
+	"github.com/cloudfoundry/cli/cf/api/applications/applicationsfakes"
 	"github.com/cloudfoundry/cli/cf/api/authentication/authenticationfakes"
+	"github.com/cloudfoundry/cli/cf/api/logs"
+	"github.com/cloudfoundry/cli/cf/api/logs/logsfakes"
+	"github.com/cloudfoundry/cli/cf/api/quotas/quotasfakes"
+	"github.com/cloudfoundry/cli/cf/api/securitygroups/securitygroupsfakes"
+	"github.com/cloudfoundry/cli/cf/api/spacequotas/spacequotasfakes"
+	"github.com/cloudfoundry/cli/cf/api/stacks/stacksfakes"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/terminal"
@@ -20,6 +29,8 @@ import (
 	. "github.com/cloudfoundry/cli/plugin/rpc/fakecommand"
 	"github.com/cloudfoundry/cli/plugin/rpc/rpcfakes"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/gogo/protobuf/proto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -46,19 +57,30 @@ var _ = Describe("Server", func() {
 
 	Describe(".NewRpcService", func() {
 		BeforeEach(func() {
-			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
+			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
 		It("returns an err of another Rpc process is already registered", func() {
-			_, err := NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
+			_, err := NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("can be created alongside another Rpc process on an RPC server of its own", func() {
+			isolatedService, err := NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil, rpc.NewServer())
+			Expect(err).ToNot(HaveOccurred())
+
+			err = isolatedService.Start()
+			Expect(err).ToNot(HaveOccurred())
+			defer isolatedService.Stop()
+
+			pingCli(isolatedService.Port())
+		})
 	})
 
 	Describe(".Stop", func() {
 		BeforeEach(func() {
-			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
+			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 			Expect(err).ToNot(HaveOccurred())
 
 			err := rpcService.Start()
@@ -80,7 +102,7 @@ var _ = Describe("Server", func() {
 
 	Describe(".Start", func() {
 		BeforeEach(func() {
-			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
+			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 			Expect(err).ToNot(HaveOccurred())
 
 			err := rpcService.Start()
@@ -104,7 +126,7 @@ var _ = Describe("Server", func() {
 
 	Describe(".IsMinCliVersion()", func() {
 		BeforeEach(func() {
-			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
+			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 			Expect(err).ToNot(HaveOccurred())
 
 			err := rpcService.Start()
@@ -170,7 +192,7 @@ var _ = Describe("Server", func() {
 		)
 
 		BeforeEach(func() {
-			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
+			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 			Expect(err).ToNot(HaveOccurred())
 
 			err := rpcService.Start()
@@ -207,11 +229,41 @@ var _ = Describe("Server", func() {
 		})
 	})
 
+	Describe(".VetoCommand", func() {
+		BeforeEach(func() {
+			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
+			Expect(err).ToNot(HaveOccurred())
+
+			err := rpcService.Start()
+			Expect(err).ToNot(HaveOccurred())
+
+			pingCli(rpcService.Port())
+
+			client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			rpcService.Stop()
+
+			//give time for server to stop
+			time.Sleep(50 * time.Millisecond)
+		})
+
+		It("accepts the veto", func() {
+			var success bool
+			err = client.Call("CliRpcCmd.VetoCommand", "not today", &success)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(success).To(BeTrue())
+		})
+	})
+
 	Describe(".GetOutputAndReset", func() {
 		Context("success", func() {
 			BeforeEach(func() {
 				outputCapture := terminal.NewTeePrinter(os.Stdout)
-				rpcService, err = NewRpcService(outputCapture, nil, nil, api.RepositoryLocator{}, cmdRunner.NewCommandRunner(), nil, nil)
+				rpcService, err = NewRpcService(outputCapture, nil, nil, api.RepositoryLocator{}, cmdRunner.NewCommandRunner(), nil, nil, rpc.DefaultServer)
 				Expect(err).ToNot(HaveOccurred())
 
 				err := rpcService.Start()
@@ -252,7 +304,7 @@ var _ = Describe("Server", func() {
 
 		BeforeEach(func() {
 			terminalOutputSwitch = new(rpcfakes.FakeTerminalOutputSwitch)
-			rpcService, err = NewRpcService(nil, terminalOutputSwitch, nil, api.RepositoryLocator{}, nil, nil, nil)
+			rpcService, err = NewRpcService(nil, terminalOutputSwitch, nil, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 			Expect(err).ToNot(HaveOccurred())
 
 			err := rpcService.Start()
@@ -284,7 +336,7 @@ var _ = Describe("Server", func() {
 			terminalOutputSwitch := terminal.NewTeePrinter(os.Stdout)
 
 			runner = new(rpcfakes.FakeCommandRunner)
-			rpcService, err = NewRpcService(outputCapture, terminalOutputSwitch, nil, api.RepositoryLocator{}, runner, nil, nil)
+			rpcService, err = NewRpcService(outputCapture, terminalOutputSwitch, nil, api.RepositoryLocator{}, runner, nil, nil, rpc.DefaultServer)
 			Expect(err).ToNot(HaveOccurred())
 
 			err := rpcService.Start()
@@ -430,7 +482,7 @@ var _ = Describe("Server", func() {
 				outputCapture := terminal.NewTeePrinter(os.Stdout)
 				runner = new(rpcfakes.FakeCommandRunner)
 
-				rpcService, err = NewRpcService(outputCapture, nil, nil, api.RepositoryLocator{}, runner, nil, nil)
+				rpcService, err = NewRpcService(outputCapture, nil, nil, api.RepositoryLocator{}, runner, nil, nil, rpc.DefaultServer)
 				Expect(err).ToNot(HaveOccurred())
 
 				err := rpcService.Start()
@@ -493,7 +545,7 @@ var _ = Describe("Server", func() {
 						},
 					})
 
-					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil)
+					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 					err := rpcService.Start()
 					Expect(err).ToNot(HaveOccurred())
 
@@ -520,7 +572,7 @@ var _ = Describe("Server", func() {
 						Name: "space-name",
 					})
 
-					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil)
+					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 					err := rpcService.Start()
 					Expect(err).ToNot(HaveOccurred())
 
@@ -542,7 +594,7 @@ var _ = Describe("Server", func() {
 
 			Context(".Username, .UserGuid, .UserEmail", func() {
 				BeforeEach(func() {
-					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil)
+					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 					err := rpcService.Start()
 					Expect(err).ToNot(HaveOccurred())
 
@@ -570,7 +622,7 @@ var _ = Describe("Server", func() {
 
 			Context(".IsSSLDisabled", func() {
 				BeforeEach(func() {
-					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil)
+					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 					err := rpcService.Start()
 					Expect(err).ToNot(HaveOccurred())
 
@@ -591,7 +643,7 @@ var _ = Describe("Server", func() {
 
 			Context(".IsLoggedIn", func() {
 				BeforeEach(func() {
-					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil)
+					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 					err := rpcService.Start()
 					Expect(err).ToNot(HaveOccurred())
 
@@ -612,7 +664,7 @@ var _ = Describe("Server", func() {
 
 			Context(".HasOrganization and .HasSpace ", func() {
 				BeforeEach(func() {
-					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil)
+					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 					err := rpcService.Start()
 					Expect(err).ToNot(HaveOccurred())
 
@@ -636,7 +688,7 @@ var _ = Describe("Server", func() {
 
 			Context(".LoggregatorEndpoint and .DopplerEndpoint ", func() {
 				BeforeEach(func() {
-					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil)
+					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 					err := rpcService.Start()
 					Expect(err).ToNot(HaveOccurred())
 
@@ -663,7 +715,7 @@ var _ = Describe("Server", func() {
 
 			Context(".ApiEndpoint, .ApiVersion and .HasAPIEndpoint", func() {
 				BeforeEach(func() {
-					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil)
+					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
 					err := rpcService.Start()
 					Expect(err).ToNot(HaveOccurred())
 
@@ -702,7 +754,7 @@ var _ = Describe("Server", func() {
 					locator := api.RepositoryLocator{}
 					locator = locator.SetAuthenticationRepository(authRepo)
 
-					rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil)
+					rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil, rpc.DefaultServer)
 					err := rpcService.Start()
 					Expect(err).ToNot(HaveOccurred())
 
@@ -749,7 +801,7 @@ var _ = Describe("Server", func() {
 		Context("fail", func() {
 			BeforeEach(func() {
 				outputCapture := terminal.NewTeePrinter(os.Stdout)
-				rpcService, err = NewRpcService(outputCapture, nil, nil, api.RepositoryLocator{}, cmdRunner.NewCommandRunner(), nil, nil)
+				rpcService, err = NewRpcService(outputCapture, nil, nil, api.RepositoryLocator{}, cmdRunner.NewCommandRunner(), nil, nil, rpc.DefaultServer)
 				Expect(err).ToNot(HaveOccurred())
 
 				err := rpcService.Start()
@@ -790,6 +842,423 @@ var _ = Describe("Server", func() {
 			})
 		})
 	})
+
+	Describe("resource API", func() {
+		var (
+			config  coreconfig.Repository
+			locator api.RepositoryLocator
+		)
+
+		BeforeEach(func() {
+			config = testconfig.NewRepositoryWithDefaults()
+			locator = api.RepositoryLocator{}
+		})
+
+		JustBeforeEach(func() {
+			rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil, rpc.DefaultServer)
+			Expect(err).ToNot(HaveOccurred())
+
+			err := rpcService.Start()
+			Expect(err).ToNot(HaveOccurred())
+
+			pingCli(rpcService.Port())
+
+			client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			rpcService.Stop()
+
+			//give time for server to stop
+			time.Sleep(50 * time.Millisecond)
+		})
+
+		Context(".GetRoutes", func() {
+			BeforeEach(func() {
+				routeRepo := new(apifakes.FakeRouteRepository)
+				routeRepo.ListRoutesStub = func(cb func(models.Route) bool) error {
+					cb(models.Route{
+						GUID:   "route-guid",
+						Host:   "my-host",
+						Domain: models.DomainFields{GUID: "domain-guid", Name: "example.com"},
+						Path:   "/path",
+						Space:  models.SpaceFields{GUID: "space-guid", Name: "my-space"},
+						Apps:   []models.ApplicationFields{{GUID: "app-guid", Name: "my-app"}},
+					})
+					return nil
+				}
+				locator = locator.SetRouteRepository(routeRepo)
+			})
+
+			It("returns the routes of the current space", func() {
+				var routes []plugin_models.GetRoutes_Model
+				err = client.Call("CliRpcCmd.GetRoutes", "", &routes)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(routes).To(HaveLen(1))
+				Expect(routes[0].Guid).To(Equal("route-guid"))
+				Expect(routes[0].Host).To(Equal("my-host"))
+				Expect(routes[0].Domain.Name).To(Equal("example.com"))
+				Expect(routes[0].Path).To(Equal("/path"))
+				Expect(routes[0].Space.Name).To(Equal("my-space"))
+				Expect(routes[0].Apps).To(Equal([]plugin_models.GetRoutes_App{{Guid: "app-guid", Name: "my-app"}}))
+			})
+		})
+
+		Context(".GetDomains", func() {
+			var domainRepo *apifakes.FakeDomainRepository
+
+			BeforeEach(func() {
+				domainRepo = new(apifakes.FakeDomainRepository)
+				domainRepo.ListDomainsForOrgStub = func(orgGUID string, cb func(models.DomainFields) bool) error {
+					cb(models.DomainFields{GUID: "shared-guid", Name: "shared.example.com", Shared: true})
+					cb(models.DomainFields{GUID: "private-guid", Name: "private.example.com", OwningOrganizationGUID: orgGUID})
+					return nil
+				}
+				locator = locator.SetDomainRepository(domainRepo)
+			})
+
+			It("returns the domains of the current org", func() {
+				var domains []plugin_models.GetDomains_Model
+				err = client.Call("CliRpcCmd.GetDomains", "", &domains)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(domainRepo.ListDomainsForOrgCallCount()).To(Equal(1))
+				orgGUID, _ := domainRepo.ListDomainsForOrgArgsForCall(0)
+				Expect(orgGUID).To(Equal(config.OrganizationFields().GUID))
+
+				Expect(domains).To(Equal([]plugin_models.GetDomains_Model{
+					{Guid: "shared-guid", Name: "shared.example.com", Shared: true},
+					{Guid: "private-guid", Name: "private.example.com", OwningOrganizationGuid: orgGUID},
+				}))
+			})
+
+			Context("when no org is targeted", func() {
+				BeforeEach(func() {
+					config.SetOrganizationFields(models.OrganizationFields{})
+				})
+
+				It("returns an error", func() {
+					var domains []plugin_models.GetDomains_Model
+					err = client.Call("CliRpcCmd.GetDomains", "", &domains)
+					Expect(err).To(MatchError("No org targeted"))
+				})
+			})
+		})
+
+		Context(".GetServiceKeys and .GetServiceKey", func() {
+			var serviceKeyRepo *apifakes.FakeServiceKeyRepository
+
+			BeforeEach(func() {
+				serviceRepo := new(apifakes.FakeServiceRepository)
+				serviceRepo.FindInstanceByNameReturns(models.ServiceInstance{
+					ServiceInstanceFields: models.ServiceInstanceFields{GUID: "instance-guid", Name: "my-service"},
+				}, nil)
+				locator = locator.SetServiceRepository(serviceRepo)
+
+				serviceKeyRepo = new(apifakes.FakeServiceKeyRepository)
+				serviceKeyRepo.ListServiceKeysReturns([]models.ServiceKey{
+					{Fields: models.ServiceKeyFields{GUID: "key-guid", Name: "my-key"}},
+				}, nil)
+				serviceKeyRepo.GetServiceKeyReturns(models.ServiceKey{
+					Fields: models.ServiceKeyFields{GUID: "key-guid", Name: "my-key"},
+					Credentials: map[string]interface{}{
+						"username": "admin",
+						"port":     float64(5432),
+						"hosts":    []interface{}{"a", "b"},
+					},
+				}, nil)
+				locator = locator.SetServiceKeyRepository(serviceKeyRepo)
+			})
+
+			It("returns the keys of the service instance", func() {
+				var keys []plugin_models.GetServiceKeys_Model
+				err = client.Call("CliRpcCmd.GetServiceKeys", "my-service", &keys)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(serviceKeyRepo.ListServiceKeysArgsForCall(0)).To(Equal("instance-guid"))
+				Expect(keys).To(Equal([]plugin_models.GetServiceKeys_Model{
+					{Guid: "key-guid", Name: "my-key", ServiceInstanceGuid: "instance-guid"},
+				}))
+			})
+
+			It("returns the key with its credentials", func() {
+				var key plugin_models.GetServiceKey_Model
+				err = client.Call("CliRpcCmd.GetServiceKey", []string{"my-service", "my-key"}, &key)
+				Expect(err).ToNot(HaveOccurred())
+
+				instanceGUID, keyName := serviceKeyRepo.GetServiceKeyArgsForCall(0)
+				Expect(instanceGUID).To(Equal("instance-guid"))
+				Expect(keyName).To(Equal("my-key"))
+
+				Expect(key.Guid).To(Equal("key-guid"))
+				Expect(key.Credentials).To(Equal(map[string]interface{}{
+					"username": "admin",
+					"port":     float64(5432),
+					"hosts":    []interface{}{"a", "b"},
+				}))
+			})
+
+			It("returns an error when the key does not exist", func() {
+				serviceKeyRepo.GetServiceKeyReturns(models.ServiceKey{}, nil)
+
+				var key plugin_models.GetServiceKey_Model
+				err = client.Call("CliRpcCmd.GetServiceKey", []string{"my-service", "missing-key"}, &key)
+				Expect(err).To(MatchError("Service key missing-key not found"))
+			})
+		})
+
+		Context(".GetBuildpacks", func() {
+			BeforeEach(func() {
+				position := 1
+				enabled := true
+				buildpackRepo := new(apifakes.FakeBuildpackRepository)
+				buildpackRepo.ListBuildpacksStub = func(cb func(models.Buildpack) bool) error {
+					cb(models.Buildpack{GUID: "buildpack-guid", Name: "go_buildpack", Position: &position, Enabled: &enabled, Filename: "go.zip"})
+					return nil
+				}
+				locator = locator.SetBuildpackRepository(buildpackRepo)
+			})
+
+			It("returns the buildpacks", func() {
+				var buildpacks []plugin_models.GetBuildpacks_Model
+				err = client.Call("CliRpcCmd.GetBuildpacks", "", &buildpacks)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(buildpacks).To(Equal([]plugin_models.GetBuildpacks_Model{
+					{Guid: "buildpack-guid", Name: "go_buildpack", Position: 1, Enabled: true, Filename: "go.zip"},
+				}))
+			})
+		})
+
+		Context(".GetStacks", func() {
+			BeforeEach(func() {
+				stackRepo := new(stacksfakes.FakeStackRepository)
+				stackRepo.FindAllReturns([]models.Stack{{GUID: "stack-guid", Name: "cflinuxfs2", Description: "Cloud Foundry Linux"}}, nil)
+				locator = locator.SetStackRepository(stackRepo)
+			})
+
+			It("returns the stacks", func() {
+				var stacks []plugin_models.GetStacks_Model
+				err = client.Call("CliRpcCmd.GetStacks", "", &stacks)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(stacks).To(Equal([]plugin_models.GetStacks_Model{
+					{Guid: "stack-guid", Name: "cflinuxfs2", Description: "Cloud Foundry Linux"},
+				}))
+			})
+		})
+
+		Context(".GetSecurityGroups", func() {
+			BeforeEach(func() {
+				securityGroupRepo := new(securitygroupsfakes.FakeSecurityGroupRepo)
+				securityGroupRepo.FindAllReturns([]models.SecurityGroup{
+					{
+						SecurityGroupFields: models.SecurityGroupFields{
+							GUID:  "group-guid",
+							Name:  "my-group",
+							Rules: []map[string]interface{}{{"protocol": "tcp", "destination": "10.0.0.0/8"}},
+						},
+						Spaces: []models.Space{
+							{
+								SpaceFields:  models.SpaceFields{GUID: "space-guid", Name: "my-space"},
+								Organization: models.OrganizationFields{GUID: "org-guid", Name: "my-org"},
+							},
+						},
+					},
+				}, nil)
+				locator = locator.SetSecurityGroupRepository(securityGroupRepo)
+			})
+
+			It("returns the security groups with their rules and spaces", func() {
+				var groups []plugin_models.GetSecurityGroups_Model
+				err = client.Call("CliRpcCmd.GetSecurityGroups", "", &groups)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(groups).To(Equal([]plugin_models.GetSecurityGroups_Model{
+					{
+						Guid:   "group-guid",
+						Name:   "my-group",
+						Rules:  []map[string]interface{}{{"protocol": "tcp", "destination": "10.0.0.0/8"}},
+						Spaces: []plugin_models.GetSecurityGroups_Space{{Guid: "space-guid", Name: "my-space", OrgGuid: "org-guid", OrgName: "my-org"}},
+					},
+				}))
+			})
+		})
+
+		Context(".GetQuotas and .GetSpaceQuotas", func() {
+			var spaceQuotaRepo *spacequotasfakes.FakeSpaceQuotaRepository
+
+			BeforeEach(func() {
+				quotaRepo := new(quotasfakes.FakeQuotaRepository)
+				quotaRepo.FindAllReturns([]models.QuotaFields{{GUID: "quota-guid", Name: "default", MemoryLimit: 1024, RoutesLimit: 10}}, nil)
+				locator = locator.SetQuotaRepository(quotaRepo)
+
+				spaceQuotaRepo = new(spacequotasfakes.FakeSpaceQuotaRepository)
+				spaceQuotaRepo.FindByOrgReturns([]models.SpaceQuota{{GUID: "space-quota-guid", Name: "small", OrgGUID: "org-guid", MemoryLimit: 512}}, nil)
+				locator = locator.SetSpaceQuotaRepository(spaceQuotaRepo)
+			})
+
+			It("returns the org quotas", func() {
+				var quotas []plugin_models.GetQuotas_Model
+				err = client.Call("CliRpcCmd.GetQuotas", "", &quotas)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(quotas).To(Equal([]plugin_models.GetQuotas_Model{
+					{Guid: "quota-guid", Name: "default", MemoryLimit: 1024, RoutesLimit: 10},
+				}))
+			})
+
+			It("returns the space quotas of the current org", func() {
+				var quotas []plugin_models.GetSpaceQuotas_Model
+				err = client.Call("CliRpcCmd.GetSpaceQuotas", "", &quotas)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(spaceQuotaRepo.FindByOrgArgsForCall(0)).To(Equal(config.OrganizationFields().GUID))
+				Expect(quotas).To(Equal([]plugin_models.GetSpaceQuotas_Model{
+					{Guid: "space-quota-guid", Name: "small", OrgGuid: "org-guid", MemoryLimit: 512},
+				}))
+			})
+		})
+
+		Context(".GetAppEvents", func() {
+			var appEventsRepo *appeventsfakes.FakeRepository
+
+			BeforeEach(func() {
+				appRepo := new(applicationsfakes.FakeRepository)
+				appRepo.ReadReturns(models.Application{ApplicationFields: models.ApplicationFields{GUID: "app-guid", Name: "my-app"}}, nil)
+				locator = locator.SetApplicationRepository(appRepo)
+
+				appEventsRepo = new(appeventsfakes.FakeRepository)
+				appEventsRepo.RecentEventsReturns([]models.EventFields{
+					{GUID: "event-guid", Name: "audit.app.update", Timestamp: time.Unix(1000, 0).UTC(), Description: "instances: 2", ActorName: "admin"},
+				}, nil)
+				locator = locator.SetAppEventsRepository(appEventsRepo)
+			})
+
+			It("returns the recent events of the app", func() {
+				var events []plugin_models.GetAppEvents_Model
+				err = client.Call("CliRpcCmd.GetAppEvents", "my-app", &events)
+				Expect(err).ToNot(HaveOccurred())
+
+				appGUID, _ := appEventsRepo.RecentEventsArgsForCall(0)
+				Expect(appGUID).To(Equal("app-guid"))
+				Expect(events).To(Equal([]plugin_models.GetAppEvents_Model{
+					{Guid: "event-guid", Name: "audit.app.update", Timestamp: time.Unix(1000, 0).UTC(), Description: "instances: 2", ActorName: "admin"},
+				}))
+			})
+		})
+
+		Context("log streams", func() {
+			var (
+				logsRepo *logsfakes.FakeRepository
+				logChan  chan<- logs.Loggable
+				errChan  chan<- error
+				tailing  chan struct{}
+			)
+
+			BeforeEach(func() {
+				appRepo := new(applicationsfakes.FakeRepository)
+				appRepo.ReadReturns(models.Application{ApplicationFields: models.ApplicationFields{GUID: "app-guid", Name: "my-app"}}, nil)
+				locator = locator.SetApplicationRepository(appRepo)
+
+				tailing = make(chan struct{})
+				logsRepo = new(logsfakes.FakeRepository)
+				logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), c chan<- logs.Loggable, e chan<- error) {
+					logChan = c
+					errChan = e
+					select {
+					case <-tailing:
+					default:
+						close(tailing)
+					}
+				}
+				locator = locator.SetLogsRepository(logsRepo)
+			})
+
+			startLogStream := func() {
+				var started bool
+				err = client.Call("CliRpcCmd.StartLogStream", "my-app", &started)
+				Expect(err).ToNot(HaveOccurred())
+				Eventually(tailing).Should(BeClosed())
+				appGUID, _, _, _ := logsRepo.TailLogsForArgsForCall(0)
+				Expect(appGUID).To(Equal("app-guid"))
+			}
+
+			It("returns the messages tailed for the app", func() {
+				startLogStream()
+
+				go func() {
+					defer GinkgoRecover()
+					logChan <- logs.NewNoaaLogMessage(&events.LogMessage{
+						Message:        []byte("hello"),
+						MessageType:    events.LogMessage_OUT.Enum(),
+						Timestamp:      proto.Int64(time.Unix(1000, 0).UnixNano()),
+						SourceType:     proto.String("APP"),
+						SourceInstance: proto.String("0"),
+					})
+				}()
+
+				var batch plugin_models.LogBatch
+				err = client.Call("CliRpcCmd.ReadLogStream", "", &batch)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(batch.Ended).To(BeFalse())
+				Expect(batch.Messages).To(HaveLen(1))
+				Expect(batch.Messages[0].Message).To(Equal("hello"))
+				Expect(batch.Messages[0].IsError).To(BeFalse())
+				Expect(batch.Messages[0].SourceName).To(Equal("APP"))
+				Expect(batch.Messages[0].SourceInstance).To(Equal("0"))
+				Expect(batch.Messages[0].Timestamp.Equal(time.Unix(1000, 0))).To(BeTrue())
+			})
+
+			It("returns the error that ends the stream", func() {
+				startLogStream()
+
+				go func() {
+					errChan <- errors.New("doppler went away")
+				}()
+
+				var batch plugin_models.LogBatch
+				err = client.Call("CliRpcCmd.ReadLogStream", "", &batch)
+				Expect(err).To(MatchError("doppler went away"))
+
+				err = client.Call("CliRpcCmd.ReadLogStream", "", &batch)
+				Expect(err).To(MatchError("Logs are not being tailed"))
+			})
+
+			It("reports when the stream has ended", func() {
+				startLogStream()
+				close(logChan)
+
+				var batch plugin_models.LogBatch
+				err = client.Call("CliRpcCmd.ReadLogStream", "", &batch)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(batch.Ended).To(BeTrue())
+			})
+
+			It("tails the logs of one app at a time", func() {
+				startLogStream()
+
+				var started bool
+				err = client.Call("CliRpcCmd.StartLogStream", "my-app", &started)
+				Expect(err).To(MatchError("Logs are already being tailed"))
+			})
+
+			It("closes the logs repository when the stream is stopped", func() {
+				startLogStream()
+
+				var stopped bool
+				err = client.Call("CliRpcCmd.StopLogStream", "", &stopped)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(logsRepo.CloseCallCount()).To(Equal(1))
+
+				var started bool
+				err = client.Call("CliRpcCmd.StartLogStream", "my-app", &started)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
 })
 
 func pingCli(port string) {
@@ -0,0 +1,135 @@
+package rpc
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// CommandHook is a hook an installed plugin registered for a core command.
+type CommandHook struct {
+	PluginName string
+	Location   string
+	plugin.Hook
+}
+
+// FindCommandHooks returns the hooks the plugins in pluginList registered
+// for the core command, sorted by plugin name.
+func FindCommandHooks(pluginList map[string]pluginconfig.PluginMetadata, command commandregistry.CommandMetadata) []CommandHook {
+	pluginNames := []string{}
+	for pluginName := range pluginList {
+		pluginNames = append(pluginNames, pluginName)
+	}
+	sort.Strings(pluginNames)
+
+	hooks := []CommandHook{}
+	for _, pluginName := range pluginNames {
+		metadata := pluginList[pluginName]
+		for _, hook := range metadata.Hooks {
+			if hook.Command == "" || (hook.Command != command.Name && hook.Command != command.ShortName) {
+				continue
+			}
+
+			hooks = append(hooks, CommandHook{
+				PluginName: pluginName,
+				Location:   metadata.Location,
+				Hook:       hook,
+			})
+		}
+	}
+	return hooks
+}
+
+// RunPreCommandHooks runs the pre command hooks in hooks for the core
+// command, in order. It stops at the first hook that vetoes the command or
+// fails, and returns an error saying why the command must not run.
+func RunPreCommandHooks(rpcService *CliRpcService, hooks []CommandHook, command string, args []string) error {
+	hookArgs := plugin.CommandHookArgs(plugin.CommandHookContext{
+		Hook:    plugin.PreCommandHook,
+		Command: command,
+		Args:    args,
+	})
+
+	started := false
+	for _, hook := range hooks {
+		if !hook.Pre {
+			continue
+		}
+
+		if !started {
+			err := rpcService.Start()
+			if err != nil {
+				return err
+			}
+			defer rpcService.Stop()
+			started = true
+		}
+
+		rpcService.RpcCmd.takeVeto()
+		err := runStartedPluginBinary(rpcService, hook.Location, hookArgs)
+		if message := rpcService.RpcCmd.takeVeto(); message != "" {
+			return fmt.Errorf("Plugin %s stopped %s from running: %s", hook.PluginName, command, message)
+		}
+		if err != nil {
+			return fmt.Errorf("Plugin %s stopped %s from running, as its pre command hook failed: %s", hook.PluginName, command, hookErrorMessage(err))
+		}
+	}
+	return nil
+}
+
+// RunPostCommandHooks runs the post command hooks in hooks for the core
+// command, which failed with cmdErr, or succeeded if it is nil. Hooks that
+// fail do not stop the others; an error listing them is returned.
+func RunPostCommandHooks(rpcService *CliRpcService, hooks []CommandHook, command string, args []string, cmdErr error) error {
+	context := plugin.CommandHookContext{
+		Hook:    plugin.PostCommandHook,
+		Command: command,
+		Args:    args,
+	}
+	if cmdErr != nil {
+		context.ExitStatus = 1
+		if statusErr, ok := cmdErr.(*errors.ExitStatusError); ok {
+			context.ExitStatus = statusErr.Status
+		}
+		context.Error = cmdErr.Error()
+	}
+	hookArgs := plugin.CommandHookArgs(context)
+
+	started := false
+	failures := ""
+	for _, hook := range hooks {
+		if !hook.Post {
+			continue
+		}
+
+		if !started {
+			err := rpcService.Start()
+			if err != nil {
+				return err
+			}
+			defer rpcService.Stop()
+			started = true
+		}
+
+		err := runStartedPluginBinary(rpcService, hook.Location, hookArgs)
+		if err != nil {
+			failures += fmt.Sprintf("\nPlugin %s: %s", hook.PluginName, hookErrorMessage(err))
+		}
+	}
+
+	if failures != "" {
+		return fmt.Errorf("Post command hooks failed for %s:%s", command, failures)
+	}
+	return nil
+}
+
+func hookErrorMessage(err error) string {
+	if statusErr, ok := err.(*errors.ExitStatusError); ok {
+		return fmt.Sprintf("exit status %d", statusErr.Status)
+	}
+	return err.Error()
+}
@@ -0,0 +1,167 @@
+package rpc_test
+
+import (
+	"net/rpc"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/api"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
+	"github.com/cloudfoundry/cli/plugin"
+	. "github.com/cloudfoundry/cli/plugin/rpc"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	. "github.com/cloudfoundry/cli/testhelpers/io"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Command hooks", func() {
+	Describe("FindCommandHooks", func() {
+		It("returns the hooks registered for the command's name or short name, sorted by plugin", func() {
+			pluginList := map[string]pluginconfig.PluginMetadata{
+				"plugin-b": {
+					Location: "/plugins/b",
+					Hooks: []plugin.Hook{
+						{Command: "d", Pre: true},
+						{Command: "push", Post: true},
+					},
+				},
+				"plugin-a": {
+					Location: "/plugins/a",
+					Hooks: []plugin.Hook{
+						{Command: "delete", Post: true},
+					},
+				},
+				"plugin-c": {
+					Location: "/plugins/c",
+				},
+			}
+
+			hooks := FindCommandHooks(pluginList, commandregistry.CommandMetadata{Name: "delete", ShortName: "d"})
+			Expect(hooks).To(Equal([]CommandHook{
+				{PluginName: "plugin-a", Location: "/plugins/a", Hook: plugin.Hook{Command: "delete", Post: true}},
+				{PluginName: "plugin-b", Location: "/plugins/b", Hook: plugin.Hook{Command: "d", Pre: true}},
+			}))
+		})
+
+		It("returns no hooks when no plugin registered any for the command", func() {
+			pluginList := map[string]pluginconfig.PluginMetadata{
+				"plugin-a": {
+					Hooks: []plugin.Hook{{Command: "push", Pre: true}},
+				},
+			}
+
+			Expect(FindCommandHooks(pluginList, commandregistry.CommandMetadata{Name: "delete"})).To(BeEmpty())
+		})
+	})
+
+	Describe("running hooks", func() {
+		var (
+			hookService *CliRpcService
+			hooks       []CommandHook
+		)
+
+		BeforeEach(func() {
+			var err error
+			hookService, err = NewRpcService(nil, nil, testconfig.NewRepositoryWithDefaults(), api.RepositoryLocator{}, nil, nil, nil, rpc.NewServer())
+			Expect(err).NotTo(HaveOccurred())
+
+			hooks = []CommandHook{
+				{
+					PluginName: "CommandHooks",
+					Location:   filepath.Join("..", "..", "fixtures", "plugins", "command_hooks.exe"),
+					Hook:       plugin.Hook{Command: "delete", Pre: true, Post: true},
+				},
+			}
+		})
+
+		Describe("RunPreCommandHooks", func() {
+			It("runs the plugin with the command and its args", func() {
+				var err error
+				output := CaptureOutput(func() {
+					err = RunPreCommandHooks(hookService, hooks, "delete", []string{"my-app", "-f"})
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(ContainElement("pre delete my-app -f"))
+			})
+
+			It("runs every hook, one after another", func() {
+				hooks = append(hooks, hooks[0], hooks[0])
+
+				var err error
+				output := CaptureOutput(func() {
+					err = RunPreCommandHooks(hookService, hooks, "delete", []string{"my-app"})
+					Expect(RunPostCommandHooks(hookService, hooks, "delete", []string{"my-app"}, nil)).To(Succeed())
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				preCount := 0
+				for _, line := range output {
+					Expect(line).NotTo(ContainSubstring("use of closed network connection"))
+					if line == "pre delete my-app" {
+						preCount++
+					}
+				}
+				Expect(preCount).To(Equal(3))
+			})
+
+			It("returns an error when the plugin vetoes the command", func() {
+				var err error
+				CaptureOutput(func() {
+					err = RunPreCommandHooks(hookService, hooks, "delete", []string{"protected-app"})
+				})
+				Expect(err).To(MatchError("Plugin CommandHooks stopped delete from running: protected-app is protected"))
+
+				CaptureOutput(func() {
+					err = RunPreCommandHooks(hookService, hooks, "delete", []string{"my-app"})
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error when the hook fails", func() {
+				var err error
+				CaptureOutput(func() {
+					err = RunPreCommandHooks(hookService, hooks, "delete", []string{"broken-hook"})
+				})
+				Expect(err).To(MatchError("Plugin CommandHooks stopped delete from running, as its pre command hook failed: exit status 3"))
+			})
+
+			It("does not run post command hooks", func() {
+				hooks[0].Pre = false
+
+				output := CaptureOutput(func() {
+					Expect(RunPreCommandHooks(hookService, hooks, "delete", []string{"protected-app"})).To(Succeed())
+				})
+				Expect(output).NotTo(ContainElement(HavePrefix("pre")))
+			})
+		})
+
+		Describe("RunPostCommandHooks", func() {
+			It("runs the plugin with the command, its args and its result", func() {
+				var err error
+				output := CaptureOutput(func() {
+					err = RunPostCommandHooks(hookService, hooks, "delete", []string{"my-app"}, nil)
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(ContainElement("post delete 0  my-app"))
+			})
+
+			It("passes on the exit status and error of a command that failed", func() {
+				output := CaptureOutput(func() {
+					err := RunPostCommandHooks(hookService, hooks, "delete", []string{"my-app"}, errors.NewExitStatusError("App not found", 4))
+					Expect(err).NotTo(HaveOccurred())
+				})
+				Expect(output).To(ContainElement("post delete 4 App not found my-app"))
+			})
+
+			It("returns an error when a hook fails", func() {
+				hooks[0].Location = filepath.Join("..", "..", "fixtures", "plugins", "does-not-exist.exe")
+
+				err := RunPostCommandHooks(hookService, hooks, "delete", []string{"my-app"}, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("Post command hooks failed for delete:\nPlugin CommandHooks: "))
+			})
+		})
+	})
+})
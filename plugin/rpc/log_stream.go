@@ -0,0 +1,140 @@
+package rpc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/api/logs"
+	"github.com/cloudfoundry/cli/plugin/models"
+)
+
+const (
+	// logStreamWait is how long ReadLogStream waits for a message, so that
+	// a plugin reading an idle stream gets a chance to stop it.
+	logStreamWait = time.Second
+
+	maxLogBatch = 100
+)
+
+// logStream relays the logs of an app, tailed with the logs repository, to
+// a plugin that reads them in batches. There is at most one at a time, as
+// the CLI has a single connection to doppler.
+type logStream struct {
+	logsRepo logs.Repository
+	messages chan logs.Loggable
+	errs     chan error
+}
+
+func (cmd *CliRpcCmd) StartLogStream(appName string, retVal *bool) error {
+	cmd.logStreamMutex.Lock()
+	defer cmd.logStreamMutex.Unlock()
+
+	if cmd.logStream != nil {
+		return errors.New("Logs are already being tailed")
+	}
+
+	app, err := cmd.repoLocator.GetApplicationRepository().Read(appName)
+	if err != nil {
+		return err
+	}
+
+	stream := &logStream{
+		logsRepo: cmd.repoLocator.GetLogsRepository(),
+		messages: make(chan logs.Loggable),
+		errs:     make(chan error),
+	}
+	go stream.logsRepo.TailLogsFor(app.GUID, func() {}, stream.messages, stream.errs)
+
+	cmd.logStream = stream
+	*retVal = true
+	return nil
+}
+
+func (cmd *CliRpcCmd) ReadLogStream(_ string, retVal *plugin_models.LogBatch) error {
+	cmd.logStreamMutex.Lock()
+	stream := cmd.logStream
+	cmd.logStreamMutex.Unlock()
+
+	if stream == nil {
+		return errors.New("Logs are not being tailed")
+	}
+
+	select {
+	case msg, ok := <-stream.messages:
+		if !ok {
+			cmd.endLogStream(stream)
+			retVal.Ended = true
+			return nil
+		}
+		retVal.Messages = append(retVal.Messages, newLogMessage(msg))
+	case err := <-stream.errs:
+		cmd.endLogStream(stream)
+		if err != nil {
+			return err
+		}
+		retVal.Ended = true
+		return nil
+	case <-time.After(logStreamWait):
+		return nil
+	}
+
+	for len(retVal.Messages) < maxLogBatch {
+		select {
+		case msg, ok := <-stream.messages:
+			if !ok {
+				return nil
+			}
+			retVal.Messages = append(retVal.Messages, newLogMessage(msg))
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (cmd *CliRpcCmd) StopLogStream(_ string, retVal *bool) error {
+	cmd.logStreamMutex.Lock()
+	stream := cmd.logStream
+	cmd.logStreamMutex.Unlock()
+
+	if stream != nil {
+		stream.logsRepo.Close()
+		cmd.endLogStream(stream)
+
+		// The repository flushes what it has buffered, or reports an error,
+		// when it is closed, which must not block it forever.
+		go func() {
+			for {
+				select {
+				case _, ok := <-stream.messages:
+					if !ok {
+						return
+					}
+				case <-stream.errs:
+				}
+			}
+		}()
+	}
+
+	*retVal = true
+	return nil
+}
+
+func (cmd *CliRpcCmd) endLogStream(stream *logStream) {
+	cmd.logStreamMutex.Lock()
+	defer cmd.logStreamMutex.Unlock()
+
+	if cmd.logStream == stream {
+		cmd.logStream = nil
+	}
+}
+
+func newLogMessage(msg logs.Loggable) plugin_models.LogMessage {
+	return plugin_models.LogMessage{
+		Message:        msg.ToSimpleLog(),
+		IsError:        msg.IsError(),
+		Timestamp:      msg.GetTimestamp(),
+		SourceName:     msg.GetSourceName(),
+		SourceInstance: msg.GetSourceInstance(),
+	}
+}
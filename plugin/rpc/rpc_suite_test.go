@@ -1,7 +1,10 @@
 package rpc_test
 
 import (
+	"path/filepath"
+
 	"github.com/cloudfoundry/cli/plugin/rpc"
+	"github.com/cloudfoundry/cli/testhelpers/pluginbuilder"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -14,3 +17,7 @@ func TestRpc(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Rpc Suite")
 }
+
+var _ = BeforeSuite(func() {
+	pluginbuilder.BuildTestBinary(filepath.Join("..", "..", "fixtures", "plugins"), "command_hooks")
+})
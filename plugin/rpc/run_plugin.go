@@ -3,38 +3,113 @@ package rpc
 import (
 	"os"
 	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 )
 
-func RunMethodIfExists(rpcService *CliRpcService, args []string, pluginList map[string]pluginconfig.PluginMetadata) bool {
+// RunMethodIfExists runs the plugin command args[0], if a plugin has it. The
+// plugin shares the standard input, output and error of cf, is passed the
+// termination signals cf gets, and is killed if it has not exited within the
+// plugin grace period of the config after them or an interrupt. A plugin
+// that fails makes RunMethodIfExists return an ExitStatusError with its
+// exit status.
+func RunMethodIfExists(rpcService *CliRpcService, args []string, pluginList map[string]pluginconfig.PluginMetadata) (bool, error) {
 	for _, metadata := range pluginList {
 		for _, command := range metadata.Commands {
 			if command.Name == args[0] || command.Alias == args[0] {
 				args[0] = command.Name
 
-				rpcService.Start()
-				defer rpcService.Stop()
+				return true, runPluginBinary(rpcService, metadata.Location, args)
+			}
+		}
+	}
+	return false, nil
+}
+
+func runPluginBinary(rpcService *CliRpcService, location string, args []string) error {
+	rpcService.Start()
+	defer rpcService.Stop()
+
+	return runStartedPluginBinary(rpcService, location, args)
+}
+
+// runStartedPluginBinary is runPluginBinary for an rpcService that is
+// already started.
+func runStartedPluginBinary(rpcService *CliRpcService, location string, args []string) error {
+	pluginArgs := append([]string{rpcService.Port()}, args...)
+
+	cmd := exec.Command(location, pluginArgs...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
 
-				pluginArgs := append([]string{rpcService.Port()}, args...)
+	gracePeriod := time.Duration(rpcService.RpcCmd.cliConfig.PluginGracePeriod()) * time.Second
+	return RunPlugin(cmd, signals, gracePeriod)
+}
+
+// RunPlugin runs the plugin command, passing on the signals cf gets, and
+// kills the plugin if it has not exited gracePeriod after the first of them.
+// Interrupts are not passed on: the plugin is in cf's process group, so the
+// terminal already sent it the interrupt for Ctrl-C, and a second one would
+// make it look like Ctrl-C was pressed twice.
+func RunPlugin(cmd *exec.Cmd, signals <-chan os.Signal, gracePeriod time.Duration) error {
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
 
-				cmd := exec.Command(metadata.Location, pluginArgs...)
-				cmd.Stdout = os.Stdout
-				cmd.Stdin = os.Stdin
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
 
-				defer stopPlugin(cmd)
-				err := cmd.Run()
+	var killTimer <-chan time.Time
+	for {
+		select {
+		case err = <-exited:
+			return pluginExitError(err)
+		case sig := <-signals:
+			if sig != os.Interrupt {
+				err = cmd.Process.Signal(sig)
 				if err != nil {
-					os.Exit(1)
+					// Signals other than kill cannot be sent on Windows.
+					_ = cmd.Process.Kill()
+					continue
 				}
-				return true
 			}
+
+			if killTimer == nil {
+				killTimer = time.After(gracePeriod)
+			}
+		case <-killTimer:
+			_ = cmd.Process.Kill()
 		}
 	}
-	return false
 }
 
-func stopPlugin(plugin *exec.Cmd) {
-	plugin.Process.Kill()
-	plugin.Wait()
+func pluginExitError(err error) error {
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return err
+	}
+
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok {
+		return errors.NewExitStatusError("", 1)
+	}
+
+	// Like shells, report a plugin killed by a signal as exiting with 128
+	// plus the signal number.
+	if status.Signaled() {
+		return errors.NewExitStatusError("", 128+int(status.Signal()))
+	}
+	return errors.NewExitStatusError("", status.ExitStatus())
 }
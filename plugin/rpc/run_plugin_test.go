@@ -0,0 +1,102 @@
+package rpc_test
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/plugin/rpc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunPlugin", func() {
+	var (
+		signals chan os.Signal
+		runErr  chan error
+	)
+
+	BeforeEach(func() {
+		if runtime.GOOS == "windows" {
+			Skip("plugins are run from sh scripts")
+		}
+
+		signals = make(chan os.Signal, 1)
+		runErr = make(chan error, 1)
+	})
+
+	// runScript runs script as a plugin, and returns once it has printed
+	// "ready".
+	runScript := func(script string, gracePeriod time.Duration) {
+		reader, writer := io.Pipe()
+		cmd := exec.Command("sh", "-c", script)
+		cmd.Stdout = writer
+
+		go func() {
+			runErr <- RunPlugin(cmd, signals, gracePeriod)
+			writer.Close()
+		}()
+
+		line, err := bufio.NewReader(reader).ReadString('\n')
+		Expect(err).NotTo(HaveOccurred())
+		Expect(line).To(Equal("ready\n"))
+		go io.Copy(ioutil.Discard, reader)
+	}
+
+	It("succeeds when the plugin exits with status 0", func() {
+		runScript("echo ready", time.Second)
+		Eventually(runErr).Should(Receive(BeNil()))
+	})
+
+	It("returns the exit status of a plugin that fails", func() {
+		runScript("echo ready; exit 3", time.Second)
+
+		var err error
+		Eventually(runErr).Should(Receive(&err))
+		Expect(err).To(Equal(errors.NewExitStatusError("", 3)))
+	})
+
+	It("returns an error when the plugin cannot be run", func() {
+		cmd := exec.Command("/does/not/exist")
+
+		err := RunPlugin(cmd, signals, time.Second)
+		Expect(err).To(HaveOccurred())
+		Expect(err).NotTo(BeAssignableToTypeOf(&errors.ExitStatusError{}))
+	})
+
+	It("passes signals on to the plugin", func() {
+		runScript("trap 'exit 7' TERM; echo ready; while true; do sleep 0.05; done", time.Minute)
+
+		signals <- syscall.SIGTERM
+
+		var err error
+		Eventually(runErr, 5*time.Second).Should(Receive(&err))
+		Expect(err).To(Equal(errors.NewExitStatusError("", 7)))
+	})
+
+	It("does not pass on interrupts, which the terminal already sent the plugin", func() {
+		runScript("trap 'exit 7' INT; echo ready; while true; do sleep 0.05; done", 300*time.Millisecond)
+
+		signals <- os.Interrupt
+
+		var err error
+		Eventually(runErr, 5*time.Second).Should(Receive(&err))
+		Expect(err).To(Equal(errors.NewExitStatusError("", 128+int(syscall.SIGKILL))))
+	})
+
+	It("kills the plugin when it has not exited within the grace period of a signal", func() {
+		runScript("trap '' TERM; echo ready; while true; do sleep 0.05; done", 100*time.Millisecond)
+
+		signals <- syscall.SIGTERM
+
+		var err error
+		Eventually(runErr, 5*time.Second).Should(Receive(&err))
+		Expect(err).To(Equal(errors.NewExitStatusError("", 128+int(syscall.SIGKILL))))
+	})
+})
@@ -11,5 +11,7 @@ import (
 
 func NewTestCloudControllerGateway(configRepo coreconfig.Reader) net.Gateway {
 	fakeLogger := new(tracefakes.FakePrinter)
-	return net.NewCloudControllerGateway(configRepo, time.Now, &testterm.FakeUI{}, fakeLogger)
+	gateway := net.NewCloudControllerGateway(configRepo, time.Now, &testterm.FakeUI{}, fakeLogger)
+	gateway.RetryDelay = time.Millisecond
+	return gateway
 }
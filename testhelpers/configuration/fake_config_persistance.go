@@ -36,6 +36,11 @@ func (fp *FakePersistor) Exists() bool {
 	return true
 }
 
+func (fp *FakePersistor) Update(data configuration.DataInterface, change func()) error {
+	change()
+	return fp.Save(data)
+}
+
 func (fp *FakePersistor) Save(data configuration.DataInterface) (err error) {
 	fp.SaveArgs.Data = data.(*coreconfig.Data)
 	err = fp.SaveReturns.Err
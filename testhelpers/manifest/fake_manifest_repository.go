@@ -7,6 +7,7 @@ import (
 type FakeManifestRepository struct {
 	ReadManifestArgs struct {
 		Path string
+		Vars manifest.Vars
 	}
 	ReadManifestReturns struct {
 		Manifest *manifest.Manifest
@@ -14,8 +15,9 @@ type FakeManifestRepository struct {
 	}
 }
 
-func (repo *FakeManifestRepository) ReadManifest(inputPath string) (m *manifest.Manifest, err error) {
+func (repo *FakeManifestRepository) ReadManifest(inputPath string, vars manifest.Vars) (m *manifest.Manifest, err error) {
 	repo.ReadManifestArgs.Path = inputPath
+	repo.ReadManifestArgs.Vars = vars
 	if repo.ReadManifestReturns.Manifest != nil {
 		m = repo.ReadManifestReturns.Manifest
 	} else {
@@ -8,8 +8,10 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"io/ioutil"
 	"math/big"
 	"net"
+	"path/filepath"
 	"time"
 )
 
@@ -29,6 +31,32 @@ func MakeUnauthorizedTLSCert() tls.Certificate {
 	return generateCert([]string{"127.0.0.1", "::1"}, time.Date(2020, time.December, 1, 0, 0, 0, 0, time.UTC), false)
 }
 
+// WriteTLSCertFiles writes the certificate and the RSA private key of cert
+// to PEM files in dir, and returns their paths.
+func WriteTLSCertFiles(cert tls.Certificate, dir string) (certFile string, keyFile string) {
+	certOut := new(bytes.Buffer)
+	for _, derBytes := range cert.Certificate {
+		pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+	}
+
+	keyOut := new(bytes.Buffer)
+	pem.Encode(keyOut, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(cert.PrivateKey.(*rsa.PrivateKey))})
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+
+	err := ioutil.WriteFile(certFile, certOut.Bytes(), 0600)
+	if err != nil {
+		panic(err)
+	}
+	err = ioutil.WriteFile(keyFile, keyOut.Bytes(), 0600)
+	if err != nil {
+		panic(err)
+	}
+
+	return certFile, keyFile
+}
+
 func generateCert(hosts []string, notAfter time.Time, isAuthorizedToSign bool) tls.Certificate {
 	priv, err := rsa.GenerateKey(rand.Reader, 1024)
 	if err != nil {
@@ -5,7 +5,7 @@ import (
 	"sync"
 
 	"github.com/cloudfoundry/cli/plugin"
-	"github.com/cloudfoundry/cli/plugin/models"
+	plugin_models "github.com/cloudfoundry/cli/plugin/models"
 	"github.com/cloudfoundry/cli/testhelpers/rpcserver"
 )
 
@@ -280,6 +280,132 @@ type FakeHandlers struct {
 	getServiceReturns struct {
 		result1 error
 	}
+	GetRoutesStub        func(args string, retVal *[]plugin_models.GetRoutes_Model) error
+	getRoutesMutex       sync.RWMutex
+	getRoutesArgsForCall []struct {
+		args   string
+		retVal *[]plugin_models.GetRoutes_Model
+	}
+	getRoutesReturns struct {
+		result1 error
+	}
+	GetDomainsStub        func(args string, retVal *[]plugin_models.GetDomains_Model) error
+	getDomainsMutex       sync.RWMutex
+	getDomainsArgsForCall []struct {
+		args   string
+		retVal *[]plugin_models.GetDomains_Model
+	}
+	getDomainsReturns struct {
+		result1 error
+	}
+	GetServiceKeysStub        func(serviceInstance string, retVal *[]plugin_models.GetServiceKeys_Model) error
+	getServiceKeysMutex       sync.RWMutex
+	getServiceKeysArgsForCall []struct {
+		serviceInstance string
+		retVal          *[]plugin_models.GetServiceKeys_Model
+	}
+	getServiceKeysReturns struct {
+		result1 error
+	}
+	GetServiceKeyStub        func(args []string, retVal *plugin_models.GetServiceKey_Model) error
+	getServiceKeyMutex       sync.RWMutex
+	getServiceKeyArgsForCall []struct {
+		args   []string
+		retVal *plugin_models.GetServiceKey_Model
+	}
+	getServiceKeyReturns struct {
+		result1 error
+	}
+	GetBuildpacksStub        func(args string, retVal *[]plugin_models.GetBuildpacks_Model) error
+	getBuildpacksMutex       sync.RWMutex
+	getBuildpacksArgsForCall []struct {
+		args   string
+		retVal *[]plugin_models.GetBuildpacks_Model
+	}
+	getBuildpacksReturns struct {
+		result1 error
+	}
+	GetStacksStub        func(args string, retVal *[]plugin_models.GetStacks_Model) error
+	getStacksMutex       sync.RWMutex
+	getStacksArgsForCall []struct {
+		args   string
+		retVal *[]plugin_models.GetStacks_Model
+	}
+	getStacksReturns struct {
+		result1 error
+	}
+	GetSecurityGroupsStub        func(args string, retVal *[]plugin_models.GetSecurityGroups_Model) error
+	getSecurityGroupsMutex       sync.RWMutex
+	getSecurityGroupsArgsForCall []struct {
+		args   string
+		retVal *[]plugin_models.GetSecurityGroups_Model
+	}
+	getSecurityGroupsReturns struct {
+		result1 error
+	}
+	GetQuotasStub        func(args string, retVal *[]plugin_models.GetQuotas_Model) error
+	getQuotasMutex       sync.RWMutex
+	getQuotasArgsForCall []struct {
+		args   string
+		retVal *[]plugin_models.GetQuotas_Model
+	}
+	getQuotasReturns struct {
+		result1 error
+	}
+	GetSpaceQuotasStub        func(args string, retVal *[]plugin_models.GetSpaceQuotas_Model) error
+	getSpaceQuotasMutex       sync.RWMutex
+	getSpaceQuotasArgsForCall []struct {
+		args   string
+		retVal *[]plugin_models.GetSpaceQuotas_Model
+	}
+	getSpaceQuotasReturns struct {
+		result1 error
+	}
+	GetAppEventsStub        func(appName string, retVal *[]plugin_models.GetAppEvents_Model) error
+	getAppEventsMutex       sync.RWMutex
+	getAppEventsArgsForCall []struct {
+		appName string
+		retVal  *[]plugin_models.GetAppEvents_Model
+	}
+	getAppEventsReturns struct {
+		result1 error
+	}
+	StartLogStreamStub        func(appName string, retVal *bool) error
+	startLogStreamMutex       sync.RWMutex
+	startLogStreamArgsForCall []struct {
+		appName string
+		retVal  *bool
+	}
+	startLogStreamReturns struct {
+		result1 error
+	}
+	ReadLogStreamStub        func(args string, retVal *plugin_models.LogBatch) error
+	readLogStreamMutex       sync.RWMutex
+	readLogStreamArgsForCall []struct {
+		args   string
+		retVal *plugin_models.LogBatch
+	}
+	readLogStreamReturns struct {
+		result1 error
+	}
+	VetoCommandStub        func(message string, retVal *bool) error
+	vetoCommandMutex       sync.RWMutex
+	vetoCommandArgsForCall []struct {
+		message string
+		retVal  *bool
+	}
+	vetoCommandReturns struct {
+		result1 error
+	}
+	StopLogStreamStub        func(args string, retVal *bool) error
+	stopLogStreamMutex       sync.RWMutex
+	stopLogStreamArgsForCall []struct {
+		args   string
+		retVal *bool
+	}
+	stopLogStreamReturns struct {
+		result1 error
+	}
 }
 
 func (fake *FakeHandlers) IsMinCliVersion(args string, retVal *bool) error {
@@ -382,11 +508,16 @@ func (fake *FakeHandlers) DisableTerminalOutputReturns(result1 error) {
 }
 
 func (fake *FakeHandlers) CallCoreCommand(args []string, retVal *bool) error {
+	var argsCopy []string
+	if args != nil {
+		argsCopy = make([]string, len(args))
+		copy(argsCopy, args)
+	}
 	fake.callCoreCommandMutex.Lock()
 	fake.callCoreCommandArgsForCall = append(fake.callCoreCommandArgsForCall, struct {
 		args   []string
 		retVal *bool
-	}{args, retVal})
+	}{argsCopy, retVal})
 	fake.callCoreCommandMutex.Unlock()
 	if fake.CallCoreCommandStub != nil {
 		return fake.CallCoreCommandStub(args, retVal)
@@ -1108,11 +1239,16 @@ func (fake *FakeHandlers) GetServicesReturns(result1 error) {
 }
 
 func (fake *FakeHandlers) GetOrgUsers(args []string, retVal *[]plugin_models.GetOrgUsers_Model) error {
+	var argsCopy []string
+	if args != nil {
+		argsCopy = make([]string, len(args))
+		copy(argsCopy, args)
+	}
 	fake.getOrgUsersMutex.Lock()
 	fake.getOrgUsersArgsForCall = append(fake.getOrgUsersArgsForCall, struct {
 		args   []string
 		retVal *[]plugin_models.GetOrgUsers_Model
-	}{args, retVal})
+	}{argsCopy, retVal})
 	fake.getOrgUsersMutex.Unlock()
 	if fake.GetOrgUsersStub != nil {
 		return fake.GetOrgUsersStub(args, retVal)
@@ -1141,11 +1277,16 @@ func (fake *FakeHandlers) GetOrgUsersReturns(result1 error) {
 }
 
 func (fake *FakeHandlers) GetSpaceUsers(args []string, retVal *[]plugin_models.GetSpaceUsers_Model) error {
+	var argsCopy []string
+	if args != nil {
+		argsCopy = make([]string, len(args))
+		copy(argsCopy, args)
+	}
 	fake.getSpaceUsersMutex.Lock()
 	fake.getSpaceUsersArgsForCall = append(fake.getSpaceUsersArgsForCall, struct {
 		args   []string
 		retVal *[]plugin_models.GetSpaceUsers_Model
-	}{args, retVal})
+	}{argsCopy, retVal})
 	fake.getSpaceUsersMutex.Unlock()
 	if fake.GetSpaceUsersStub != nil {
 		return fake.GetSpaceUsersStub(args, retVal)
@@ -1272,4 +1413,471 @@ func (fake *FakeHandlers) GetServiceReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeHandlers) GetRoutes(args string, retVal *[]plugin_models.GetRoutes_Model) error {
+	fake.getRoutesMutex.Lock()
+	fake.getRoutesArgsForCall = append(fake.getRoutesArgsForCall, struct {
+		args   string
+		retVal *[]plugin_models.GetRoutes_Model
+	}{args, retVal})
+	fake.getRoutesMutex.Unlock()
+	if fake.GetRoutesStub != nil {
+		return fake.GetRoutesStub(args, retVal)
+	} else {
+		return fake.getRoutesReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetRoutesCallCount() int {
+	fake.getRoutesMutex.RLock()
+	defer fake.getRoutesMutex.RUnlock()
+	return len(fake.getRoutesArgsForCall)
+}
+
+func (fake *FakeHandlers) GetRoutesArgsForCall(i int) (string, *[]plugin_models.GetRoutes_Model) {
+	fake.getRoutesMutex.RLock()
+	defer fake.getRoutesMutex.RUnlock()
+	return fake.getRoutesArgsForCall[i].args, fake.getRoutesArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetRoutesReturns(result1 error) {
+	fake.GetRoutesStub = nil
+	fake.getRoutesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetDomains(args string, retVal *[]plugin_models.GetDomains_Model) error {
+	fake.getDomainsMutex.Lock()
+	fake.getDomainsArgsForCall = append(fake.getDomainsArgsForCall, struct {
+		args   string
+		retVal *[]plugin_models.GetDomains_Model
+	}{args, retVal})
+	fake.getDomainsMutex.Unlock()
+	if fake.GetDomainsStub != nil {
+		return fake.GetDomainsStub(args, retVal)
+	} else {
+		return fake.getDomainsReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetDomainsCallCount() int {
+	fake.getDomainsMutex.RLock()
+	defer fake.getDomainsMutex.RUnlock()
+	return len(fake.getDomainsArgsForCall)
+}
+
+func (fake *FakeHandlers) GetDomainsArgsForCall(i int) (string, *[]plugin_models.GetDomains_Model) {
+	fake.getDomainsMutex.RLock()
+	defer fake.getDomainsMutex.RUnlock()
+	return fake.getDomainsArgsForCall[i].args, fake.getDomainsArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetDomainsReturns(result1 error) {
+	fake.GetDomainsStub = nil
+	fake.getDomainsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetServiceKeys(serviceInstance string, retVal *[]plugin_models.GetServiceKeys_Model) error {
+	fake.getServiceKeysMutex.Lock()
+	fake.getServiceKeysArgsForCall = append(fake.getServiceKeysArgsForCall, struct {
+		serviceInstance string
+		retVal          *[]plugin_models.GetServiceKeys_Model
+	}{serviceInstance, retVal})
+	fake.getServiceKeysMutex.Unlock()
+	if fake.GetServiceKeysStub != nil {
+		return fake.GetServiceKeysStub(serviceInstance, retVal)
+	} else {
+		return fake.getServiceKeysReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetServiceKeysCallCount() int {
+	fake.getServiceKeysMutex.RLock()
+	defer fake.getServiceKeysMutex.RUnlock()
+	return len(fake.getServiceKeysArgsForCall)
+}
+
+func (fake *FakeHandlers) GetServiceKeysArgsForCall(i int) (string, *[]plugin_models.GetServiceKeys_Model) {
+	fake.getServiceKeysMutex.RLock()
+	defer fake.getServiceKeysMutex.RUnlock()
+	return fake.getServiceKeysArgsForCall[i].serviceInstance, fake.getServiceKeysArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetServiceKeysReturns(result1 error) {
+	fake.GetServiceKeysStub = nil
+	fake.getServiceKeysReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetServiceKey(args []string, retVal *plugin_models.GetServiceKey_Model) error {
+	var argsCopy []string
+	if args != nil {
+		argsCopy = make([]string, len(args))
+		copy(argsCopy, args)
+	}
+	fake.getServiceKeyMutex.Lock()
+	fake.getServiceKeyArgsForCall = append(fake.getServiceKeyArgsForCall, struct {
+		args   []string
+		retVal *plugin_models.GetServiceKey_Model
+	}{argsCopy, retVal})
+	fake.getServiceKeyMutex.Unlock()
+	if fake.GetServiceKeyStub != nil {
+		return fake.GetServiceKeyStub(args, retVal)
+	} else {
+		return fake.getServiceKeyReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetServiceKeyCallCount() int {
+	fake.getServiceKeyMutex.RLock()
+	defer fake.getServiceKeyMutex.RUnlock()
+	return len(fake.getServiceKeyArgsForCall)
+}
+
+func (fake *FakeHandlers) GetServiceKeyArgsForCall(i int) ([]string, *plugin_models.GetServiceKey_Model) {
+	fake.getServiceKeyMutex.RLock()
+	defer fake.getServiceKeyMutex.RUnlock()
+	return fake.getServiceKeyArgsForCall[i].args, fake.getServiceKeyArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetServiceKeyReturns(result1 error) {
+	fake.GetServiceKeyStub = nil
+	fake.getServiceKeyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetBuildpacks(args string, retVal *[]plugin_models.GetBuildpacks_Model) error {
+	fake.getBuildpacksMutex.Lock()
+	fake.getBuildpacksArgsForCall = append(fake.getBuildpacksArgsForCall, struct {
+		args   string
+		retVal *[]plugin_models.GetBuildpacks_Model
+	}{args, retVal})
+	fake.getBuildpacksMutex.Unlock()
+	if fake.GetBuildpacksStub != nil {
+		return fake.GetBuildpacksStub(args, retVal)
+	} else {
+		return fake.getBuildpacksReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetBuildpacksCallCount() int {
+	fake.getBuildpacksMutex.RLock()
+	defer fake.getBuildpacksMutex.RUnlock()
+	return len(fake.getBuildpacksArgsForCall)
+}
+
+func (fake *FakeHandlers) GetBuildpacksArgsForCall(i int) (string, *[]plugin_models.GetBuildpacks_Model) {
+	fake.getBuildpacksMutex.RLock()
+	defer fake.getBuildpacksMutex.RUnlock()
+	return fake.getBuildpacksArgsForCall[i].args, fake.getBuildpacksArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetBuildpacksReturns(result1 error) {
+	fake.GetBuildpacksStub = nil
+	fake.getBuildpacksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetStacks(args string, retVal *[]plugin_models.GetStacks_Model) error {
+	fake.getStacksMutex.Lock()
+	fake.getStacksArgsForCall = append(fake.getStacksArgsForCall, struct {
+		args   string
+		retVal *[]plugin_models.GetStacks_Model
+	}{args, retVal})
+	fake.getStacksMutex.Unlock()
+	if fake.GetStacksStub != nil {
+		return fake.GetStacksStub(args, retVal)
+	} else {
+		return fake.getStacksReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetStacksCallCount() int {
+	fake.getStacksMutex.RLock()
+	defer fake.getStacksMutex.RUnlock()
+	return len(fake.getStacksArgsForCall)
+}
+
+func (fake *FakeHandlers) GetStacksArgsForCall(i int) (string, *[]plugin_models.GetStacks_Model) {
+	fake.getStacksMutex.RLock()
+	defer fake.getStacksMutex.RUnlock()
+	return fake.getStacksArgsForCall[i].args, fake.getStacksArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetStacksReturns(result1 error) {
+	fake.GetStacksStub = nil
+	fake.getStacksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetSecurityGroups(args string, retVal *[]plugin_models.GetSecurityGroups_Model) error {
+	fake.getSecurityGroupsMutex.Lock()
+	fake.getSecurityGroupsArgsForCall = append(fake.getSecurityGroupsArgsForCall, struct {
+		args   string
+		retVal *[]plugin_models.GetSecurityGroups_Model
+	}{args, retVal})
+	fake.getSecurityGroupsMutex.Unlock()
+	if fake.GetSecurityGroupsStub != nil {
+		return fake.GetSecurityGroupsStub(args, retVal)
+	} else {
+		return fake.getSecurityGroupsReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetSecurityGroupsCallCount() int {
+	fake.getSecurityGroupsMutex.RLock()
+	defer fake.getSecurityGroupsMutex.RUnlock()
+	return len(fake.getSecurityGroupsArgsForCall)
+}
+
+func (fake *FakeHandlers) GetSecurityGroupsArgsForCall(i int) (string, *[]plugin_models.GetSecurityGroups_Model) {
+	fake.getSecurityGroupsMutex.RLock()
+	defer fake.getSecurityGroupsMutex.RUnlock()
+	return fake.getSecurityGroupsArgsForCall[i].args, fake.getSecurityGroupsArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetSecurityGroupsReturns(result1 error) {
+	fake.GetSecurityGroupsStub = nil
+	fake.getSecurityGroupsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetQuotas(args string, retVal *[]plugin_models.GetQuotas_Model) error {
+	fake.getQuotasMutex.Lock()
+	fake.getQuotasArgsForCall = append(fake.getQuotasArgsForCall, struct {
+		args   string
+		retVal *[]plugin_models.GetQuotas_Model
+	}{args, retVal})
+	fake.getQuotasMutex.Unlock()
+	if fake.GetQuotasStub != nil {
+		return fake.GetQuotasStub(args, retVal)
+	} else {
+		return fake.getQuotasReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetQuotasCallCount() int {
+	fake.getQuotasMutex.RLock()
+	defer fake.getQuotasMutex.RUnlock()
+	return len(fake.getQuotasArgsForCall)
+}
+
+func (fake *FakeHandlers) GetQuotasArgsForCall(i int) (string, *[]plugin_models.GetQuotas_Model) {
+	fake.getQuotasMutex.RLock()
+	defer fake.getQuotasMutex.RUnlock()
+	return fake.getQuotasArgsForCall[i].args, fake.getQuotasArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetQuotasReturns(result1 error) {
+	fake.GetQuotasStub = nil
+	fake.getQuotasReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetSpaceQuotas(args string, retVal *[]plugin_models.GetSpaceQuotas_Model) error {
+	fake.getSpaceQuotasMutex.Lock()
+	fake.getSpaceQuotasArgsForCall = append(fake.getSpaceQuotasArgsForCall, struct {
+		args   string
+		retVal *[]plugin_models.GetSpaceQuotas_Model
+	}{args, retVal})
+	fake.getSpaceQuotasMutex.Unlock()
+	if fake.GetSpaceQuotasStub != nil {
+		return fake.GetSpaceQuotasStub(args, retVal)
+	} else {
+		return fake.getSpaceQuotasReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetSpaceQuotasCallCount() int {
+	fake.getSpaceQuotasMutex.RLock()
+	defer fake.getSpaceQuotasMutex.RUnlock()
+	return len(fake.getSpaceQuotasArgsForCall)
+}
+
+func (fake *FakeHandlers) GetSpaceQuotasArgsForCall(i int) (string, *[]plugin_models.GetSpaceQuotas_Model) {
+	fake.getSpaceQuotasMutex.RLock()
+	defer fake.getSpaceQuotasMutex.RUnlock()
+	return fake.getSpaceQuotasArgsForCall[i].args, fake.getSpaceQuotasArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetSpaceQuotasReturns(result1 error) {
+	fake.GetSpaceQuotasStub = nil
+	fake.getSpaceQuotasReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetAppEvents(appName string, retVal *[]plugin_models.GetAppEvents_Model) error {
+	fake.getAppEventsMutex.Lock()
+	fake.getAppEventsArgsForCall = append(fake.getAppEventsArgsForCall, struct {
+		appName string
+		retVal  *[]plugin_models.GetAppEvents_Model
+	}{appName, retVal})
+	fake.getAppEventsMutex.Unlock()
+	if fake.GetAppEventsStub != nil {
+		return fake.GetAppEventsStub(appName, retVal)
+	} else {
+		return fake.getAppEventsReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetAppEventsCallCount() int {
+	fake.getAppEventsMutex.RLock()
+	defer fake.getAppEventsMutex.RUnlock()
+	return len(fake.getAppEventsArgsForCall)
+}
+
+func (fake *FakeHandlers) GetAppEventsArgsForCall(i int) (string, *[]plugin_models.GetAppEvents_Model) {
+	fake.getAppEventsMutex.RLock()
+	defer fake.getAppEventsMutex.RUnlock()
+	return fake.getAppEventsArgsForCall[i].appName, fake.getAppEventsArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetAppEventsReturns(result1 error) {
+	fake.GetAppEventsStub = nil
+	fake.getAppEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) StartLogStream(appName string, retVal *bool) error {
+	fake.startLogStreamMutex.Lock()
+	fake.startLogStreamArgsForCall = append(fake.startLogStreamArgsForCall, struct {
+		appName string
+		retVal  *bool
+	}{appName, retVal})
+	fake.startLogStreamMutex.Unlock()
+	if fake.StartLogStreamStub != nil {
+		return fake.StartLogStreamStub(appName, retVal)
+	} else {
+		return fake.startLogStreamReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) StartLogStreamCallCount() int {
+	fake.startLogStreamMutex.RLock()
+	defer fake.startLogStreamMutex.RUnlock()
+	return len(fake.startLogStreamArgsForCall)
+}
+
+func (fake *FakeHandlers) StartLogStreamArgsForCall(i int) (string, *bool) {
+	fake.startLogStreamMutex.RLock()
+	defer fake.startLogStreamMutex.RUnlock()
+	return fake.startLogStreamArgsForCall[i].appName, fake.startLogStreamArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) StartLogStreamReturns(result1 error) {
+	fake.StartLogStreamStub = nil
+	fake.startLogStreamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) ReadLogStream(args string, retVal *plugin_models.LogBatch) error {
+	fake.readLogStreamMutex.Lock()
+	fake.readLogStreamArgsForCall = append(fake.readLogStreamArgsForCall, struct {
+		args   string
+		retVal *plugin_models.LogBatch
+	}{args, retVal})
+	fake.readLogStreamMutex.Unlock()
+	if fake.ReadLogStreamStub != nil {
+		return fake.ReadLogStreamStub(args, retVal)
+	} else {
+		return fake.readLogStreamReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) ReadLogStreamCallCount() int {
+	fake.readLogStreamMutex.RLock()
+	defer fake.readLogStreamMutex.RUnlock()
+	return len(fake.readLogStreamArgsForCall)
+}
+
+func (fake *FakeHandlers) ReadLogStreamArgsForCall(i int) (string, *plugin_models.LogBatch) {
+	fake.readLogStreamMutex.RLock()
+	defer fake.readLogStreamMutex.RUnlock()
+	return fake.readLogStreamArgsForCall[i].args, fake.readLogStreamArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) ReadLogStreamReturns(result1 error) {
+	fake.ReadLogStreamStub = nil
+	fake.readLogStreamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) VetoCommand(message string, retVal *bool) error {
+	fake.vetoCommandMutex.Lock()
+	fake.vetoCommandArgsForCall = append(fake.vetoCommandArgsForCall, struct {
+		message string
+		retVal  *bool
+	}{message, retVal})
+	fake.vetoCommandMutex.Unlock()
+	if fake.VetoCommandStub != nil {
+		return fake.VetoCommandStub(message, retVal)
+	} else {
+		return fake.vetoCommandReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) VetoCommandCallCount() int {
+	fake.vetoCommandMutex.RLock()
+	defer fake.vetoCommandMutex.RUnlock()
+	return len(fake.vetoCommandArgsForCall)
+}
+
+func (fake *FakeHandlers) VetoCommandArgsForCall(i int) (string, *bool) {
+	fake.vetoCommandMutex.RLock()
+	defer fake.vetoCommandMutex.RUnlock()
+	return fake.vetoCommandArgsForCall[i].message, fake.vetoCommandArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) VetoCommandReturns(result1 error) {
+	fake.VetoCommandStub = nil
+	fake.vetoCommandReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) StopLogStream(args string, retVal *bool) error {
+	fake.stopLogStreamMutex.Lock()
+	fake.stopLogStreamArgsForCall = append(fake.stopLogStreamArgsForCall, struct {
+		args   string
+		retVal *bool
+	}{args, retVal})
+	fake.stopLogStreamMutex.Unlock()
+	if fake.StopLogStreamStub != nil {
+		return fake.StopLogStreamStub(args, retVal)
+	} else {
+		return fake.stopLogStreamReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) StopLogStreamCallCount() int {
+	fake.stopLogStreamMutex.RLock()
+	defer fake.stopLogStreamMutex.RUnlock()
+	return len(fake.stopLogStreamArgsForCall)
+}
+
+func (fake *FakeHandlers) StopLogStreamArgsForCall(i int) (string, *bool) {
+	fake.stopLogStreamMutex.RLock()
+	defer fake.stopLogStreamMutex.RUnlock()
+	return fake.stopLogStreamArgsForCall[i].args, fake.stopLogStreamArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) StopLogStreamReturns(result1 error) {
+	fake.StopLogStreamStub = nil
+	fake.stopLogStreamReturns = struct {
+		result1 error
+	}{result1}
+}
+
 var _ rpcserver.Handlers = new(FakeHandlers)
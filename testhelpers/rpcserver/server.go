@@ -46,6 +46,20 @@ type Handlers interface {
 	GetOrg(orgName string, retVal *plugin_models.GetOrg_Model) error
 	GetSpace(spaceName string, retVal *plugin_models.GetSpace_Model) error
 	GetService(serviceInstance string, retVal *plugin_models.GetService_Model) error
+	GetRoutes(args string, retVal *[]plugin_models.GetRoutes_Model) error
+	GetDomains(args string, retVal *[]plugin_models.GetDomains_Model) error
+	GetServiceKeys(serviceInstance string, retVal *[]plugin_models.GetServiceKeys_Model) error
+	GetServiceKey(args []string, retVal *plugin_models.GetServiceKey_Model) error
+	GetBuildpacks(args string, retVal *[]plugin_models.GetBuildpacks_Model) error
+	GetStacks(args string, retVal *[]plugin_models.GetStacks_Model) error
+	GetSecurityGroups(args string, retVal *[]plugin_models.GetSecurityGroups_Model) error
+	GetQuotas(args string, retVal *[]plugin_models.GetQuotas_Model) error
+	GetSpaceQuotas(args string, retVal *[]plugin_models.GetSpaceQuotas_Model) error
+	GetAppEvents(appName string, retVal *[]plugin_models.GetAppEvents_Model) error
+	StartLogStream(appName string, retVal *bool) error
+	ReadLogStream(args string, retVal *plugin_models.LogBatch) error
+	VetoCommand(message string, retVal *bool) error
+	StopLogStream(args string, retVal *bool) error
 }
 
 type TestServer struct {
@@ -1,6 +1,7 @@
 package terminal
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"strings"
@@ -27,6 +28,7 @@ type FakeUI struct {
 	FailedWithUsageCommandName string
 	PanickedQuietly            bool
 	ShowConfigurationCalled    bool
+	Format                     term.OutputFormat
 
 	sayMutex sync.Mutex
 }
@@ -169,3 +171,28 @@ func (ui *FakeUI) NotifyUpdateIfNeeded(config coreconfig.Reader) {
 		ui.Say("Cloud Foundry API version {{.APIVer}} requires CLI version " + config.MinCLIVersion() + "  You are currently on version {{.CLIVer}}. To upgrade your CLI, please visit: https://github.com/cloudfoundry/cli#downloads")
 	}
 }
+
+func (ui *FakeUI) OutputFormat() term.OutputFormat {
+	if ui.Format == "" {
+		return term.TableOutput
+	}
+	return ui.Format
+}
+
+func (ui *FakeUI) SetOutputFormat(format term.OutputFormat) {
+	ui.Format = format
+}
+
+func (ui *FakeUI) PrintStructured(v interface{}) error {
+	buffer := &bytes.Buffer{}
+	err := term.WriteStructured(buffer, ui.OutputFormat(), v)
+	if err != nil {
+		return err
+	}
+
+	ui.sayMutex.Lock()
+	defer ui.sayMutex.Unlock()
+
+	ui.Outputs = append(ui.Outputs, strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")...)
+	return nil
+}